@@ -71,7 +71,7 @@ var runCmd = &cobra.Command{
 			return docker.NewResolver(resolverOpts)
 		}
 
-		srv, err := blobserve.NewServer(cfg.BlobServe, resolverProvider)
+		srv, err := blobserve.NewServer(cfg.BlobServe, resolverProvider, prometheus.WrapRegistererWithPrefix("blobserve_", reg))
 		if err != nil {
 			log.WithError(err).Fatal("cannot create blob server")
 		}
@@ -97,6 +97,23 @@ var runCmd = &cobra.Command{
 			}()
 			log.WithField("addr", cfg.PrometheusAddr).Info("started Prometheus metrics server")
 		}
+		if cfg.AdminAddr != "" {
+			adminSrv := &http.Server{
+				Addr:              cfg.AdminAddr,
+				Handler:           srv.AdminHandler(),
+				ReadHeaderTimeout: 5 * time.Second,
+				ReadTimeout:       30 * time.Second,
+				WriteTimeout:      30 * time.Second,
+				IdleTimeout:       2 * time.Minute,
+			}
+			go func() {
+				err := adminSrv.ListenAndServe()
+				if err != nil {
+					log.WithError(err).Error("admin server failed")
+				}
+			}()
+			log.WithField("addr", cfg.AdminAddr).Info("started admin server")
+		}
 
 		if cfg.ReadinessProbeAddr != "" {
 			// use the first layer as source for the tests
@@ -0,0 +1,161 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package blobserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/gorilla/mux"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// warmupTimeout is the time the download of a blob requested by a warmup may take
+const warmupTimeout = 15 * time.Minute
+
+// WarmupRequest asks blobserve to download the blobs of refs before they're requested by users
+type WarmupRequest struct {
+	Refs []string `json:"refs"`
+	// Pin prevents the blobs of the refs from being evicted until they're unpinned
+	Pin bool `json:"pin,omitempty"`
+}
+
+// UnpinRequest allows the blobs of refs to be evicted again
+type UnpinRequest struct {
+	Refs []string `json:"refs"`
+}
+
+// RefsResponse lists the normalised refs a request applied to
+type RefsResponse struct {
+	Refs []string `json:"refs"`
+}
+
+// InventoryResponse lists the blobs which are currently in the blobspace
+type InventoryResponse struct {
+	Blobs     []InventoryBlob `json:"blobs"`
+	TotalSize int64           `json:"totalSizeBytes"`
+}
+
+// AdminHandler serves the admin API which warms up refs at runtime and lists the blobspace inventory.
+// The admin API is unauthenticated and must only be served on an internal address.
+func (reg *Server) AdminHandler() http.Handler {
+	r := mux.NewRouter()
+	r.Path("/warmup").Methods(http.MethodPost).HandlerFunc(reg.handleWarmup)
+	r.Path("/unpin").Methods(http.MethodPost).HandlerFunc(reg.handleUnpin)
+	r.Path("/inventory").Methods(http.MethodGet).HandlerFunc(reg.handleInventory)
+	return r
+}
+
+func (reg *Server) handleWarmup(w http.ResponseWriter, req *http.Request) {
+	var body WarmupRequest
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("cannot decode request: %v", err), http.StatusBadRequest)
+		return
+	}
+	refs, err := reg.parseRefs(body.Refs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, ref := range refs {
+		if body.Pin {
+			reg.refstore.Pin(ref)
+		}
+
+		// The warmup must outlive this request, hence we do not use the request context.
+		go func(ref string) {
+			ctx, cancel := context.WithTimeout(context.Background(), warmupTimeout)
+			defer cancel()
+
+			// refs are re-resolved, such that moved tags are warmed up too
+			log.WithField("ref", ref).WithField("pin", body.Pin).Info("warming up blob")
+			err := reg.refstore.Refresh(ctx, ref)
+			if err != nil {
+				log.WithError(err).WithField("ref", ref).Warn("cannot warm up blob")
+			}
+		}(ref)
+	}
+
+	writeJSON(w, http.StatusAccepted, RefsResponse{Refs: refs})
+}
+
+func (reg *Server) handleUnpin(w http.ResponseWriter, req *http.Request) {
+	var body UnpinRequest
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("cannot decode request: %v", err), http.StatusBadRequest)
+		return
+	}
+	refs, err := reg.parseRefs(body.Refs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, ref := range refs {
+		reg.refstore.Unpin(ref)
+	}
+
+	writeJSON(w, http.StatusOK, RefsResponse{Refs: refs})
+}
+
+func (reg *Server) handleInventory(w http.ResponseWriter, req *http.Request) {
+	blobs, err := reg.refstore.Inventory()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("cannot list blobs: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	res := InventoryResponse{Blobs: blobs}
+	for _, blob := range blobs {
+		res.TotalSize += blob.Size
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// parseRefs normalises refs and ensures they can be served by this server
+func (reg *Server) parseRefs(refs []string) ([]string, error) {
+	if len(refs) == 0 {
+		return nil, xerrors.Errorf("no refs given")
+	}
+
+	res := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		pref, err := reference.ParseNamed(ref)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot parse ref %q: %w", ref, err)
+		}
+
+		_, hasTag := pref.(reference.Tagged)
+		_, hasDigest := pref.(reference.Digested)
+		if !hasTag && !hasDigest {
+			return nil, xerrors.Errorf("cannot parse ref %q: tag or digest is missing", ref)
+		}
+
+		if _, ok := reg.Config.Repos[pref.Name()]; !ok && !reg.Config.AllowAnyRepo {
+			return nil, xerrors.Errorf("forbidden repo: %q", pref.Name())
+		}
+
+		res = append(res, pref.String())
+	}
+	return res, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		log.WithError(err).Warn("cannot write response")
+	}
+}
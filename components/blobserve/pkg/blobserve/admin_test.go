@@ -0,0 +1,181 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package blobserve
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/containerd/containerd/remotes"
+	"github.com/google/go-cmp/cmp"
+
+	blobserve_config "github.com/gitpod-io/gitpod/blobserve/pkg/config"
+)
+
+func newTestAdminServer(content map[string]blobstate) *Server {
+	ff := &fakeFetcher{}
+	store := &refstore{
+		Resolver:  func() remotes.Resolver { return ff },
+		blobspace: &inMemoryBlobspace{Content: content},
+		refcache:  make(map[string]*refstate),
+		pinned:    make(map[string]struct{}),
+		close:     make(chan struct{}),
+		once:      &sync.Once{},
+		requests:  make(chan downloadRequest),
+	}
+	go store.serveRequests()
+
+	return &Server{
+		Config: blobserve_config.BlobServe{
+			Repos: map[string]blobserve_config.Repo{
+				"gitpod.io/ide": {},
+			},
+		},
+		refstore: store,
+	}
+}
+
+func TestAdminWarmup(t *testing.T) {
+	type Expectation struct {
+		Status int
+		Refs   []string
+		Pinned []string
+	}
+	tests := []struct {
+		Name        string
+		Body        string
+		Expectation Expectation
+	}{
+		{
+			Name:        "invalid body",
+			Body:        "{",
+			Expectation: Expectation{Status: http.StatusBadRequest},
+		},
+		{
+			Name:        "no refs",
+			Body:        `{"refs":[]}`,
+			Expectation: Expectation{Status: http.StatusBadRequest},
+		},
+		{
+			Name:        "missing tag",
+			Body:        `{"refs":["gitpod.io/ide"]}`,
+			Expectation: Expectation{Status: http.StatusBadRequest},
+		},
+		{
+			Name:        "forbidden repo",
+			Body:        `{"refs":["gitpod.io/ide:latest","gitpod.io/other:latest"]}`,
+			Expectation: Expectation{Status: http.StatusBadRequest},
+		},
+		{
+			Name: "warmup",
+			Body: `{"refs":["gitpod.io/ide:latest"]}`,
+			Expectation: Expectation{
+				Status: http.StatusAccepted,
+				Refs:   []string{"gitpod.io/ide:latest"},
+			},
+		},
+		{
+			Name: "warmup and pin",
+			Body: `{"refs":["gitpod.io/ide:latest","gitpod.io/ide:commit-1234"],"pin":true}`,
+			Expectation: Expectation{
+				Status: http.StatusAccepted,
+				Refs:   []string{"gitpod.io/ide:latest", "gitpod.io/ide:commit-1234"},
+				Pinned: []string{"gitpod.io/ide:commit-1234", "gitpod.io/ide:latest"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// we don't close the refstore because the warmup continues in the background
+			srv := newTestAdminServer(nil)
+
+			rec := httptest.NewRecorder()
+			srv.AdminHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/warmup", strings.NewReader(tt.Body)))
+
+			act := Expectation{Status: rec.Code}
+			if rec.Code == http.StatusAccepted {
+				var resp RefsResponse
+				err := json.NewDecoder(rec.Body).Decode(&resp)
+				if err != nil {
+					t.Fatal(err)
+				}
+				act.Refs = resp.Refs
+			}
+			act.Pinned = pinnedRefs(srv.refstore)
+
+			if diff := cmp.Diff(tt.Expectation, act); diff != "" {
+				t.Errorf("warmup mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAdminUnpin(t *testing.T) {
+	srv := newTestAdminServer(nil)
+	defer srv.refstore.Close()
+	srv.refstore.Pin("gitpod.io/ide:latest")
+	srv.refstore.Pin("gitpod.io/ide:commit-1234")
+
+	rec := httptest.NewRecorder()
+	srv.AdminHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/unpin", strings.NewReader(`{"refs":["gitpod.io/ide:latest"]}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d", rec.Code)
+	}
+	if diff := cmp.Diff([]string{"gitpod.io/ide:commit-1234"}, pinnedRefs(srv.refstore)); diff != "" {
+		t.Errorf("unpin mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAdminInventory(t *testing.T) {
+	srv := newTestAdminServer(map[string]blobstate{
+		"blob1": blobReady,
+		"blob2": blobReady,
+		"blob3": blobUnready,
+	})
+	defer srv.refstore.Close()
+	srv.refstore.refcache["gitpod.io/ide:latest"] = &refstate{Digest: "blob1"}
+	srv.refstore.refcache["gitpod.io/ide:commit-1234"] = &refstate{Digest: "blob1"}
+	srv.refstore.refcache["gitpod.io/ide:commit-5678"] = &refstate{Digest: "blob2"}
+	srv.refstore.Pin("gitpod.io/ide:commit-1234")
+
+	rec := httptest.NewRecorder()
+	srv.AdminHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/inventory", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d", rec.Code)
+	}
+
+	var act InventoryResponse
+	err := json.NewDecoder(rec.Body).Decode(&act)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := InventoryResponse{
+		Blobs: []InventoryBlob{
+			{Digest: "blob1", Refs: []string{"gitpod.io/ide:commit-1234", "gitpod.io/ide:latest"}, Ready: true, Pinned: true},
+			{Digest: "blob2", Refs: []string{"gitpod.io/ide:commit-5678"}, Ready: true},
+			{Digest: "blob3"},
+		},
+	}
+	if diff := cmp.Diff(expected, act); diff != "" {
+		t.Errorf("inventory mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func pinnedRefs(store *refstore) []string {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	var res []string
+	for ref := range store.pinned {
+		res = append(res, ref)
+	}
+	sort.Strings(res)
+	return res
+}
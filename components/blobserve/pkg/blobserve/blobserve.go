@@ -7,11 +7,13 @@ package blobserve
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"html"
 	"io"
 	"net/http"
+	"os"
 	"path/filepath"
 	"regexp"
 	"runtime/debug"
@@ -22,6 +24,7 @@ import (
 	"github.com/containerd/containerd/remotes"
 	"github.com/docker/distribution/reference"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/xerrors"
 
 	blobserve_config "github.com/gitpod-io/gitpod/blobserve/pkg/config"
@@ -37,6 +40,7 @@ type Server struct {
 	Resolver ResolverProvider
 
 	refstore *refstore
+	metrics  *metrics
 }
 
 type BlobserveInlineVars struct {
@@ -81,8 +85,13 @@ var ReferenceRegexp = expression(reference.NameRegexp,
 	optional(literal("@"), reference.DigestRegexp))
 
 // NewServer creates a new blob server
-func NewServer(cfg blobserve_config.BlobServe, resolver ResolverProvider) (*Server, error) {
-	refstore, err := newRefStore(cfg, resolver)
+func NewServer(cfg blobserve_config.BlobServe, resolver ResolverProvider, reg prometheus.Registerer) (*Server, error) {
+	metrics, err := newMetrics(reg)
+	if err != nil {
+		return nil, err
+	}
+
+	refstore, err := newRefStore(cfg, resolver, metrics)
 	if err != nil {
 		return nil, err
 	}
@@ -91,11 +100,13 @@ func NewServer(cfg blobserve_config.BlobServe, resolver ResolverProvider) (*Serv
 		Config:   cfg,
 		Resolver: resolver,
 		refstore: refstore,
+		metrics:  metrics,
 	}
 	for repo, repoCfg := range cfg.Repos {
 		for _, ver := range repoCfg.PrePull {
 			ref := repo + ":" + ver
 			log.WithField("ref", ref).Info("preparing blob server")
+			refstore.Pin(ref)
 			err := s.Prepare(context.Background(), ref)
 			if err != nil {
 				return nil, err
@@ -169,9 +180,11 @@ func (reg *Server) serve(w http.ResponseWriter, req *http.Request) {
 
 	var workdir string
 	var inlineReplacements []blobserve_config.InlineReplacement
+	repoLabel := otherRepo
 	if cfg, ok := reg.Config.Repos[repo]; ok {
 		workdir = cfg.Workdir
 		inlineReplacements = cfg.InlineStatic
+		repoLabel = repo
 	} else if !reg.Config.AllowAnyRepo {
 		log.WithField("repo", repo).Debug("forbidden repo access attempt")
 		http.Error(w, fmt.Sprintf("forbidden repo: %q", html.EscapeString(repo)), http.StatusForbidden)
@@ -202,7 +215,7 @@ func (reg *Server) serve(w http.ResponseWriter, req *http.Request) {
 		req.URL.Path += "/"
 	}
 
-	w.Header().Set("ETag", hash)
+	w.Header().Set("ETag", fmt.Sprintf(`"%s"`, hash))
 
 	inlineVarsValue := req.Header.Get("X-BlobServe-InlineVars")
 	if inlineVarsValue == "" {
@@ -223,6 +236,15 @@ func (reg *Server) serve(w http.ResponseWriter, req *http.Request) {
 	if resourcePath == "/" {
 		resourcePath = "/index.html"
 	}
+	applyInlineVars := strings.HasSuffix(resourcePath, "/index.html") && len(inlineReplacements) > 0 && inlineVarsValue != ""
+	if !applyInlineVars {
+		if encoding := serveCompressed(w, req, fs, resourcePath, hash); encoding != "" {
+			reg.metrics.ServedFilesCounter.WithLabelValues(repoLabel, encoding).Inc()
+			return
+		}
+	}
+	reg.metrics.ServedFilesCounter.WithLabelValues(repoLabel, "identity").Inc()
+
 	if strings.HasSuffix(resourcePath, "/index.html") {
 		fc, err := fs.Open(resourcePath)
 		if err != nil {
@@ -243,6 +265,10 @@ func (reg *Server) serve(w http.ResponseWriter, req *http.Request) {
 		if err != nil {
 			log.WithError(err).Error()
 		}
+		if applyInlineVars {
+			// the content depends on the inline vars
+			w.Header().Set("ETag", fmt.Sprintf(`"%s-%x"`, hash, sha256.Sum256([]byte(inlineVarsValue))))
+		}
 
 		http.ServeContent(w, req, stat.Name(), stat.ModTime(), content)
 		return
@@ -299,3 +325,11 @@ type prefixingFilesystem struct {
 func (p prefixingFilesystem) Open(name string) (http.File, error) {
 	return p.FS.Open(filepath.Join(p.Prefix, name))
 }
+
+func (p prefixingFilesystem) OpenCompressed(name string, enc contentEncoding) (http.File, error) {
+	cfs, ok := p.FS.(compressedFileSystem)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return cfs.OpenCompressed(filepath.Join(p.Prefix, name), enc)
+}
@@ -16,11 +16,13 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/containerd/containerd/errdefs"
 	"golang.org/x/xerrors"
 
+	blobserve_config "github.com/gitpod-io/gitpod/blobserve/pkg/config"
 	"github.com/gitpod-io/gitpod/common-go/log"
 )
 
@@ -30,14 +32,22 @@ type FileModifier func(in io.Reader, out io.Writer) error
 type blobspace interface {
 	Get(name string) (fs http.FileSystem, state blobstate)
 	AddFromTarGzip(ctx context.Context, name string, in io.Reader, modifications []blobModifier) (err error)
+	List() ([]gcBlob, error)
 }
 
 type diskBlobspace struct {
 	Location string
 	MaxSize  int64
+	MaxAge   time.Duration
+
+	// Compressor produces the compressed variants of files when a blob is added. Nil if compression is disabled.
+	Compressor *compressor
+	// IsPinned returns true if a blob must not be evicted. Nil if no blob is pinned.
+	IsPinned func(name string) bool
 }
 
-func newBlobSpace(loc string, maxSize int64, housekeepingInterval time.Duration) (bs *diskBlobspace, err error) {
+func newBlobSpace(cfg blobserve_config.BlobSpace, isPinned func(name string) bool, housekeepingInterval time.Duration) (bs *diskBlobspace, err error) {
+	loc := cfg.Location
 	if tproot := os.Getenv("TELEPRESENCE_ROOT"); tproot != "" {
 		loc = filepath.Join(tproot, loc)
 	}
@@ -48,10 +58,13 @@ func newBlobSpace(loc string, maxSize int64, housekeepingInterval time.Duration)
 	}
 
 	bs = &diskBlobspace{
-		Location: loc,
-		MaxSize:  maxSize,
+		Location:   loc,
+		MaxSize:    cfg.MaxSize,
+		MaxAge:     time.Duration(cfg.MaxAge),
+		Compressor: newCompressor(cfg.Compression),
+		IsPinned:   isPinned,
 	}
-	if maxSize > 0 {
+	if bs.MaxSize > 0 || bs.MaxAge > 0 {
 		go bs.collectGarbage(housekeepingInterval)
 	}
 	return
@@ -75,73 +88,109 @@ func (b *diskBlobspace) collectGarbage(interval time.Duration) {
 
 	for {
 		log.Debug("starting blobspace GC")
-		var (
-			blobs     []gcBlob
-			totalSize int64
-		)
+		spaceFreed := b.collectGarbageOnce()
+		log.WithField("spaceFreed", spaceFreed).Info("blobspace GC complete")
 
-		files, err := os.ReadDir(b.Location)
-		if err != nil {
-			log.WithError(err).WithField("location", b.Location).Error("blobspace cannot list files in working area")
-		}
+		<-t.C
+	}
+}
 
-		for _, f := range files {
-			if !f.IsDir() {
-				continue
-			}
+// collectGarbageOnce removes unready blobs which have been around for too long, blobs which have not been used
+// for longer than MaxAge and the least recently used blobs until the blobspace is smaller than MaxSize.
+// Pinned blobs are never removed.
+func (b *diskBlobspace) collectGarbageOnce() (spaceFreed int64) {
+	all, err := b.List()
+	if err != nil {
+		log.WithError(err).WithField("location", b.Location).Error("blobspace cannot list files in working area")
+	}
 
-			blob := getGCBlob(b.Location, f)
-			if blob.Size == 0 && time.Since(blob.LastUsed) > minBlobAge {
+	var (
+		blobs     []gcBlob
+		totalSize int64
+	)
+	for _, blob := range all {
+		if !blob.Ready {
+			if time.Since(blob.LastUsed) > minBlobAge {
 				// this blob has neither been used nor ready for long enough
 				// let's remove it
 
 				// TODO: also remove this blob if we're not aware of it being initialized at the moment
 				log.WithField("location", blob.F).Info("removing too old unready blob")
+				_ = b.removeBlob(blob)
+			}
+			continue
+		}
 
-				err = os.RemoveAll(blob.F)
-				if err != nil {
-					log.WithError(err).WithField("location", blob.F).Error("cannot remove blob")
-				}
-				continue
+		pinned := b.IsPinned != nil && b.IsPinned(blob.Name)
+		if !pinned && b.MaxAge > 0 && time.Since(blob.LastUsed) > b.MaxAge {
+			log.WithField("location", blob.F).WithField("lastUsed", blob.LastUsed.Format(time.RFC3339Nano)).Info("removing unused blob")
+			if b.removeBlob(blob) == nil {
+				spaceFreed += blob.Size
 			}
+			continue
+		}
 
-			blobs = append(blobs, blob)
-			totalSize += blob.Size
+		totalSize += blob.Size
+		if pinned {
+			continue
 		}
+		blobs = append(blobs, blob)
+	}
 
-		var spaceFreed int64
-		if totalSize > b.MaxSize {
-			// oldest first
-			sort.Slice(blobs, func(i, j int) bool { return blobs[j].LastUsed.After(blobs[i].LastUsed) })
-
-			for totalSize > b.MaxSize && len(blobs) > 0 {
-				blob := blobs[0]
-				blobs = blobs[1:]
-
-				log.WithField("location", blob.F).WithField("lastUsed", blob.LastUsed.Format(time.RFC3339Nano)).Info("removing old blob to make some space")
-
-				os.Remove(fmt.Sprintf("%s.ready", blob.F))
-				os.Remove(fmt.Sprintf("%s.size", blob.F))
-				os.Remove(fmt.Sprintf("%s.used", blob.F))
-				err = os.RemoveAll(blob.F)
-				if err != nil {
-					log.WithError(err).WithField("location", blob.F).Error("cannot remove blob")
-					continue
-				}
-				totalSize -= blob.Size
-				spaceFreed += blob.Size
+	if b.MaxSize > 0 && totalSize > b.MaxSize {
+		// oldest first
+		sort.Slice(blobs, func(i, j int) bool { return blobs[j].LastUsed.After(blobs[i].LastUsed) })
+
+		for totalSize > b.MaxSize && len(blobs) > 0 {
+			blob := blobs[0]
+			blobs = blobs[1:]
+
+			log.WithField("location", blob.F).WithField("lastUsed", blob.LastUsed.Format(time.RFC3339Nano)).Info("removing old blob to make some space")
+			if b.removeBlob(blob) != nil {
+				continue
 			}
+			totalSize -= blob.Size
+			spaceFreed += blob.Size
 		}
-		log.WithField("spaceFreed", spaceFreed).Info("blobspace GC complete")
+	}
+	return spaceFreed
+}
 
-		<-t.C
+func (b *diskBlobspace) removeBlob(blob gcBlob) error {
+	os.Remove(fmt.Sprintf("%s.ready", blob.F))
+	os.Remove(fmt.Sprintf("%s.size", blob.F))
+	os.Remove(fmt.Sprintf("%s.used", blob.F))
+	os.RemoveAll(blob.F + compressedSuffix)
+	err := os.RemoveAll(blob.F)
+	if err != nil {
+		log.WithError(err).WithField("location", blob.F).Error("cannot remove blob")
+	}
+	return err
+}
+
+// List returns all blobs in the blobspace
+func (b *diskBlobspace) List() ([]gcBlob, error) {
+	files, err := os.ReadDir(b.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []gcBlob
+	for _, f := range files {
+		if !f.IsDir() || strings.HasSuffix(f.Name(), compressedSuffix) {
+			continue
+		}
+		res = append(res, getGCBlob(b.Location, f))
 	}
+	return res, nil
 }
 
 type gcBlob struct {
 	F        string
+	Name     string
 	LastUsed time.Time
 	Size     int64
+	Ready    bool
 }
 
 func getGCBlob(wd string, f os.DirEntry) (blob gcBlob) {
@@ -150,12 +199,14 @@ func getGCBlob(wd string, f os.DirEntry) (blob gcBlob) {
 	fn := filepath.Join(wd, f.Name())
 	blob = gcBlob{
 		F:        fn,
+		Name:     f.Name(),
 		LastUsed: finfo.ModTime(),
 		Size:     0,
 	}
 	if _, err := os.Stat(fmt.Sprintf("%s.ready", fn)); os.IsNotExist(err) {
 		return
 	}
+	blob.Ready = true
 
 	if rawSize, err := os.ReadFile(fmt.Sprintf("%s.size", fn)); err == nil {
 		if size, err := strconv.ParseInt(string(rawSize), 10, 64); err == nil {
@@ -180,7 +231,7 @@ func (b *diskBlobspace) Get(name string) (fs http.FileSystem, state blobstate) {
 	}
 
 	_ = os.WriteFile(fmt.Sprintf("%s.used", fn), nil, 0644)
-	return http.Dir(fn), blobReady
+	return blobFS{Dir: http.Dir(fn), Compressed: http.Dir(fn + compressedSuffix)}, blobReady
 }
 
 // AddFromTar adds content to this store under the given name.
@@ -220,6 +271,20 @@ func (b *diskBlobspace) AddFromTar(ctx context.Context, name string, in io.Reade
 		}
 	}
 
+	if b.Compressor != nil {
+		// compressed variants are produced after modification so that they match the files we serve
+		compressed := fn + compressedSuffix
+		_ = os.RemoveAll(compressed)
+		n, err := b.Compressor.CompressAll(ctx, fn, compressed)
+		if err != nil {
+			// we can still serve the blob uncompressed
+			log.WithError(err).WithField("blob", name).Warn("cannot compress blob files")
+			_ = os.RemoveAll(compressed)
+		} else {
+			cw.C += n
+		}
+	}
+
 	_ = os.WriteFile(fmt.Sprintf("%s.size", fn), []byte(fmt.Sprintf("%d", cw.C)), 0644)
 	_ = os.WriteFile(fmt.Sprintf("%s.used", fn), nil, 0644)
 	_ = os.WriteFile(fmt.Sprintf("%s.ready", fn), nil, 0644)
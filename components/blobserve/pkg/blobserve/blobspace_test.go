@@ -11,7 +11,9 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
 	blobserve_config "github.com/gitpod-io/gitpod/blobserve/pkg/config"
 	"github.com/google/go-cmp/cmp"
@@ -188,3 +190,127 @@ func Test_inlineVars(t *testing.T) {
 		})
 	}
 }
+
+func Test_diskBlobspace_collectGarbageOnce(t *testing.T) {
+	type Blob struct {
+		Name  string
+		Size  int64
+		Age   time.Duration
+		Ready bool
+	}
+	blobs := []Blob{
+		{Name: "old", Size: 100, Age: 3 * time.Hour, Ready: true},
+		{Name: "mid", Size: 100, Age: 2 * time.Hour, Ready: true},
+		{Name: "new", Size: 100, Age: 1 * time.Hour, Ready: true},
+		{Name: "stale-unready", Age: 30 * time.Minute},
+		{Name: "unready", Age: time.Minute},
+	}
+
+	tests := []struct {
+		Name       string
+		MaxSize    int64
+		MaxAge     time.Duration
+		Pinned     []string
+		Expected   []string
+		SpaceFreed int64
+	}{
+		{
+			Name:     "no limits",
+			Expected: []string{"mid", "new", "old", "unready"},
+		},
+		{
+			Name:       "max size evicts least recently used",
+			MaxSize:    250,
+			Expected:   []string{"mid", "new", "unready"},
+			SpaceFreed: 100,
+		},
+		{
+			Name:       "max size skips pinned blobs",
+			MaxSize:    250,
+			Pinned:     []string{"old"},
+			Expected:   []string{"new", "old", "unready"},
+			SpaceFreed: 100,
+		},
+		{
+			Name:       "pinned blobs exceed max size",
+			MaxSize:    50,
+			Pinned:     []string{"old"},
+			Expected:   []string{"old", "unready"},
+			SpaceFreed: 200,
+		},
+		{
+			Name:       "max age",
+			MaxAge:     90 * time.Minute,
+			Expected:   []string{"new", "unready"},
+			SpaceFreed: 200,
+		},
+		{
+			Name:       "max age skips pinned blobs",
+			MaxAge:     90 * time.Minute,
+			Pinned:     []string{"mid"},
+			Expected:   []string{"mid", "new", "unready"},
+			SpaceFreed: 100,
+		},
+		{
+			Name:       "max age and max size",
+			MaxSize:    50,
+			MaxAge:     150 * time.Minute,
+			Expected:   []string{"unready"},
+			SpaceFreed: 300,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			loc := t.TempDir()
+			for _, blob := range blobs {
+				fn := filepath.Join(loc, blob.Name)
+				err := os.MkdirAll(fn, 0755)
+				if err != nil {
+					t.Fatal(err)
+				}
+				ts := time.Now().Add(-blob.Age)
+				if blob.Ready {
+					_ = os.WriteFile(fn+".size", []byte(strconv.FormatInt(blob.Size, 10)), 0644)
+					_ = os.WriteFile(fn+".used", nil, 0644)
+					_ = os.WriteFile(fn+".ready", nil, 0644)
+					err = os.Chtimes(fn+".used", ts, ts)
+				} else {
+					err = os.Chtimes(fn, ts, ts)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			pinned := make(map[string]struct{})
+			for _, p := range tt.Pinned {
+				pinned[p] = struct{}{}
+			}
+			b := &diskBlobspace{
+				Location: loc,
+				MaxSize:  tt.MaxSize,
+				MaxAge:   tt.MaxAge,
+				IsPinned: func(name string) bool {
+					_, ok := pinned[name]
+					return ok
+				},
+			}
+			spaceFreed := b.collectGarbageOnce()
+
+			remaining, err := b.List()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var act []string
+			for _, blob := range remaining {
+				act = append(act, blob.Name)
+			}
+			if diff := cmp.Diff(tt.Expected, act); diff != "" {
+				t.Errorf("collectGarbageOnce() remaining blobs mismatch (-want +got):\n%s", diff)
+			}
+			if spaceFreed != tt.SpaceFreed {
+				t.Errorf("collectGarbageOnce() freed %d bytes, expected %d", spaceFreed, tt.SpaceFreed)
+			}
+		})
+	}
+}
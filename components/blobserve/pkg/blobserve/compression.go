@@ -0,0 +1,316 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package blobserve
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"golang.org/x/sync/errgroup"
+
+	blobserve_config "github.com/gitpod-io/gitpod/blobserve/pkg/config"
+)
+
+const (
+	// compressedSuffix is appended to the location of a blob to get the location of its compressed files
+	compressedSuffix = ".compressed"
+
+	defaultCompressionMinSize = 1024
+)
+
+var defaultCompressionExtensions = []string{
+	".js", ".mjs", ".css", ".html", ".htm", ".json", ".map", ".svg", ".txt", ".xml", ".wasm", ".ttf", ".otf", ".eot",
+}
+
+type contentEncoding struct {
+	Name      string
+	Extension string
+	Compress  func(out io.Writer) io.WriteCloser
+}
+
+// contentEncodings lists the encodings we produce in order of preference
+var contentEncodings = []contentEncoding{
+	{
+		Name:      "br",
+		Extension: ".br",
+		Compress: func(out io.Writer) io.WriteCloser {
+			return brotli.NewWriterLevel(out, 9)
+		},
+	},
+	{
+		Name:      "gzip",
+		Extension: ".gz",
+		Compress: func(out io.Writer) io.WriteCloser {
+			w, _ := gzip.NewWriterLevel(out, gzip.BestCompression)
+			return w
+		},
+	},
+}
+
+type compressor struct {
+	Extensions map[string]struct{}
+	MinSize    int64
+}
+
+func newCompressor(cfg *blobserve_config.Compression) *compressor {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	exts := cfg.Extensions
+	if len(exts) == 0 {
+		exts = defaultCompressionExtensions
+	}
+	res := &compressor{
+		Extensions: make(map[string]struct{}, len(exts)),
+		MinSize:    cfg.MinSize,
+	}
+	for _, ext := range exts {
+		res.Extensions[strings.ToLower(ext)] = struct{}{}
+	}
+	if res.MinSize == 0 {
+		res.MinSize = defaultCompressionMinSize
+	}
+	return res
+}
+
+// CompressAll compresses the files in src and places the compressed variants in dst.
+// Variants which are not smaller than the original are dropped. Returns the size of all variants.
+func (c *compressor) CompressAll(ctx context.Context, src, dst string) (size int64, err error) {
+	var files []string
+	err = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if _, ok := c.Extensions[strings.ToLower(filepath.Ext(path))]; !ok {
+			return nil
+		}
+		stat, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if stat.Size() < c.MinSize {
+			return nil
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	sizes := make([]int64, len(files))
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(runtime.NumCPU())
+	for i, fn := range files {
+		i, fn := i, fn
+		eg.Go(func() error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			n, err := compressFile(filepath.Join(src, fn), filepath.Join(dst, fn))
+			sizes[i] = n
+			return err
+		})
+	}
+	err = eg.Wait()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, n := range sizes {
+		size += n
+	}
+	return size, nil
+}
+
+func compressFile(src, dst string) (size int64, err error) {
+	stat, err := os.Stat(src)
+	if err != nil {
+		return 0, err
+	}
+	err = os.MkdirAll(filepath.Dir(dst), 0755)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, enc := range contentEncodings {
+		n, err := compressFileWith(src, dst+enc.Extension, enc)
+		if err != nil {
+			return 0, err
+		}
+		if n >= stat.Size() {
+			// compression does not pay off for this file
+			_ = os.Remove(dst + enc.Extension)
+			continue
+		}
+		size += n
+	}
+	return size, nil
+}
+
+func compressFileWith(src, dst string, enc contentEncoding) (size int64, err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+
+	w := enc.Compress(out)
+	_, err = io.Copy(w, in)
+	if err != nil {
+		return 0, err
+	}
+	err = w.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	stat, err := out.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return stat.Size(), nil
+}
+
+// compressedFileSystem is a file system which provides compressed variants of its files
+type compressedFileSystem interface {
+	http.FileSystem
+
+	// OpenCompressed opens the variant of a file compressed with the given encoding
+	OpenCompressed(name string, enc contentEncoding) (http.File, error)
+}
+
+// blobFS serves the files of a blob and their compressed variants
+type blobFS struct {
+	http.Dir
+	Compressed http.Dir
+}
+
+func (b blobFS) OpenCompressed(name string, enc contentEncoding) (http.File, error) {
+	return b.Compressed.Open(name + enc.Extension)
+}
+
+// acceptedEncodings returns the content encodings we produce which are accepted by the client
+// in order of the client's preference.
+func acceptedEncodings(acceptEncoding string) []contentEncoding {
+	var (
+		qvalues  = make(map[string]float64)
+		wildcard = -1.0
+	)
+	for _, e := range strings.Split(acceptEncoding, ",") {
+		segs := strings.Split(e, ";")
+		name := strings.ToLower(strings.TrimSpace(segs[0]))
+		if name == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range segs[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			v, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+			if err == nil {
+				q = v
+			}
+		}
+
+		if name == "*" {
+			wildcard = q
+		} else {
+			qvalues[name] = q
+		}
+	}
+
+	var res []contentEncoding
+	for _, enc := range contentEncodings {
+		q, ok := qvalues[enc.Name]
+		if !ok {
+			q = wildcard
+		}
+		if q <= 0 {
+			continue
+		}
+		qvalues[enc.Name] = q
+		res = append(res, enc)
+	}
+	sort.SliceStable(res, func(i, j int) bool { return qvalues[res[i].Name] > qvalues[res[j].Name] })
+	return res
+}
+
+// serveCompressed serves the compressed variant of a file if the client accepts one of its encodings.
+// Returns the encoding of the served variant, or an empty string if no compressed variant was served.
+func serveCompressed(w http.ResponseWriter, req *http.Request, fs http.FileSystem, name string, etag string) (encoding string) {
+	cfs, ok := fs.(compressedFileSystem)
+	if !ok {
+		return ""
+	}
+	w.Header().Add("Vary", "Accept-Encoding")
+
+	for _, enc := range acceptedEncodings(req.Header.Get("Accept-Encoding")) {
+		f, err := cfs.OpenCompressed(name, enc)
+		if err != nil {
+			continue
+		}
+		defer f.Close()
+
+		stat, err := f.Stat()
+		if err != nil || stat.IsDir() {
+			continue
+		}
+
+		ctype := mime.TypeByExtension(filepath.Ext(name))
+		if ctype == "" {
+			// we must not sniff the content type from the compressed content
+			ctype = detectContentType(fs, name)
+		}
+
+		w.Header().Set("Content-Type", ctype)
+		w.Header().Set("Content-Encoding", enc.Name)
+		w.Header().Set("ETag", `"`+etag+"-"+enc.Name+`"`)
+		http.ServeContent(w, req, name, stat.ModTime(), f)
+		return enc.Name
+	}
+	return ""
+}
+
+func detectContentType(fs http.FileSystem, name string) string {
+	f, err := fs.Open(name)
+	if err != nil {
+		return "application/octet-stream"
+	}
+	defer f.Close()
+
+	var buf [512]byte
+	n, _ := io.ReadFull(f, buf[:])
+	return http.DetectContentType(buf[:n])
+}
@@ -0,0 +1,310 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package blobserve
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/google/go-cmp/cmp"
+
+	blobserve_config "github.com/gitpod-io/gitpod/blobserve/pkg/config"
+)
+
+func Test_acceptedEncodings(t *testing.T) {
+	tests := []struct {
+		Name           string
+		AcceptEncoding string
+		Expected       []string
+	}{
+		{Name: "none", AcceptEncoding: ""},
+		{Name: "identity only", AcceptEncoding: "identity"},
+		{Name: "browser", AcceptEncoding: "gzip, deflate, br", Expected: []string{"br", "gzip"}},
+		{Name: "gzip only", AcceptEncoding: "gzip", Expected: []string{"gzip"}},
+		{Name: "client preference", AcceptEncoding: "br;q=0.5, gzip;q=0.8", Expected: []string{"gzip", "br"}},
+		{Name: "disabled", AcceptEncoding: "br;q=0, gzip", Expected: []string{"gzip"}},
+		{Name: "wildcard", AcceptEncoding: "*", Expected: []string{"br", "gzip"}},
+		{Name: "wildcard with exclusion", AcceptEncoding: "*, br;q=0", Expected: []string{"gzip"}},
+		{Name: "case insensitive", AcceptEncoding: "GZIP", Expected: []string{"gzip"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			var act []string
+			for _, enc := range acceptedEncodings(tt.AcceptEncoding) {
+				act = append(act, enc.Name)
+			}
+			if diff := cmp.Diff(tt.Expected, act); diff != "" {
+				t.Errorf("acceptedEncodings() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_diskBlobspace_AddFromTarCompression(t *testing.T) {
+	compressible := strings.Repeat("console.log('hello world');\n", 100)
+	files := map[string]string{
+		"main.js":       compressible,
+		"sub/style.css": compressible,
+		"small.js":      "x",
+		"image.png":     compressible,
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tw.Write([]byte(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := tw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bs, err := newBlobSpace(blobserve_config.BlobSpace{Location: t.TempDir(), Compression: &blobserve_config.Compression{Enabled: true}}, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bs.AddFromTar(context.Background(), "blob", &buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var compressed []string
+	err = filepath.WalkDir(filepath.Join(bs.Location, "blob"+compressedSuffix), func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(filepath.Join(bs.Location, "blob"+compressedSuffix), path)
+		compressed = append(compressed, rel)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"main.js.br", "main.js.gz", "sub/style.css.br", "sub/style.css.gz"}
+	if diff := cmp.Diff(expected, compressed); diff != "" {
+		t.Errorf("unexpected compressed files (-want +got):\n%s", diff)
+	}
+
+	fs, state := bs.Get("blob")
+	if state != blobReady {
+		t.Fatalf("blob is not ready: %v", state)
+	}
+	f, err := fs.(compressedFileSystem).OpenCompressed("/main.js", contentEncodings[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	content, err := io.ReadAll(brotli.NewReader(f))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != compressible {
+		t.Errorf("compressed file has unexpected content")
+	}
+}
+
+func Test_serveCompressed(t *testing.T) {
+	const etag = "1234"
+	content := strings.Repeat("console.log('hello world');\n", 100)
+
+	loc := t.TempDir()
+	err := os.WriteFile(filepath.Join(loc, "main.js"), []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(loc, "noext"), []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressor := newCompressor(&blobserve_config.Compression{Enabled: true, Extensions: []string{".js", ""}})
+	_, err = compressor.CompressAll(context.Background(), loc, loc+compressedSuffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := blobFS{Dir: http.Dir(loc), Compressed: http.Dir(loc + compressedSuffix)}
+
+	type Expectation struct {
+		Encoding        string
+		Status          int
+		ContentEncoding string
+		ContentType     string
+		ETag            string
+		Body            string
+	}
+	tests := []struct {
+		Name        string
+		File        string
+		Header      http.Header
+		Expectation Expectation
+	}{
+		{
+			Name:        "no accept-encoding",
+			File:        "/main.js",
+			Expectation: Expectation{},
+		},
+		{
+			Name:   "brotli",
+			File:   "/main.js",
+			Header: http.Header{"Accept-Encoding": []string{"gzip, br"}},
+			Expectation: Expectation{
+				Encoding:        "br",
+				Status:          http.StatusOK,
+				ContentEncoding: "br",
+				ContentType:     "text/javascript; charset=utf-8",
+				ETag:            `"1234-br"`,
+				Body:            content,
+			},
+		},
+		{
+			Name:   "gzip",
+			File:   "/main.js",
+			Header: http.Header{"Accept-Encoding": []string{"gzip"}},
+			Expectation: Expectation{
+				Encoding:        "gzip",
+				Status:          http.StatusOK,
+				ContentEncoding: "gzip",
+				ContentType:     "text/javascript; charset=utf-8",
+				ETag:            `"1234-gzip"`,
+				Body:            content,
+			},
+		},
+		{
+			Name:   "sniffs content type of original file",
+			File:   "/noext",
+			Header: http.Header{"Accept-Encoding": []string{"gzip"}},
+			Expectation: Expectation{
+				Encoding:        "gzip",
+				Status:          http.StatusOK,
+				ContentEncoding: "gzip",
+				ContentType:     "text/plain; charset=utf-8",
+				ETag:            `"1234-gzip"`,
+				Body:            content,
+			},
+		},
+		{
+			Name: "not modified",
+			File: "/main.js",
+			Header: http.Header{
+				"Accept-Encoding": []string{"gzip"},
+				"If-None-Match":   []string{`"1234-gzip"`},
+			},
+			Expectation: Expectation{
+				Encoding: "gzip",
+				Status:   http.StatusNotModified,
+				ETag:     `"1234-gzip"`,
+			},
+		},
+		{
+			Name: "other representation was modified",
+			File: "/main.js",
+			Header: http.Header{
+				"Accept-Encoding": []string{"gzip"},
+				"If-None-Match":   []string{`"1234-br"`},
+			},
+			Expectation: Expectation{
+				Encoding:        "gzip",
+				Status:          http.StatusOK,
+				ContentEncoding: "gzip",
+				ContentType:     "text/javascript; charset=utf-8",
+				ETag:            `"1234-gzip"`,
+				Body:            content,
+			},
+		},
+		{
+			Name:        "file does not exist",
+			File:        "/other.js",
+			Header:      http.Header{"Accept-Encoding": []string{"gzip"}},
+			Expectation: Expectation{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.File, nil)
+			for k, v := range tt.Header {
+				req.Header[k] = v
+			}
+			rec := httptest.NewRecorder()
+
+			var act Expectation
+			act.Encoding = serveCompressed(rec, req, fs, tt.File, etag)
+			if act.Encoding != "" {
+				resp := rec.Result()
+				act.Status = resp.StatusCode
+				act.ContentEncoding = resp.Header.Get("Content-Encoding")
+				act.ContentType = resp.Header.Get("Content-Type")
+				act.ETag = resp.Header.Get("ETag")
+				act.Body = decompress(t, act.ContentEncoding, resp.Body)
+			}
+
+			if diff := cmp.Diff(tt.Expectation, act); diff != "" {
+				t.Errorf("serveCompressed() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("range", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/main.js", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		req.Header.Set("Range", "bytes=0-9")
+		rec := httptest.NewRecorder()
+		if serveCompressed(rec, req, fs, "/main.js", etag) != "gzip" {
+			t.Fatal("compressed file was not served")
+		}
+
+		gz, err := os.ReadFile(filepath.Join(loc+compressedSuffix, "main.js.gz"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp := rec.Result()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusPartialContent {
+			t.Errorf("unexpected status: %d", resp.StatusCode)
+		}
+		if diff := cmp.Diff(gz[:10], body); diff != "" {
+			t.Errorf("unexpected range (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func decompress(t *testing.T, encoding string, r io.Reader) string {
+	var err error
+	switch encoding {
+	case "br":
+		r = brotli.NewReader(r)
+	case "gzip":
+		r, err = gzip.NewReader(r)
+		if err == io.EOF {
+			return ""
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(res)
+}
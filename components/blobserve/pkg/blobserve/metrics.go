@@ -0,0 +1,44 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package blobserve
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// otherRepo is the repo label of repos which are not configured, so that AllowAnyRepo does not blow up the label cardinality
+	otherRepo = "other"
+)
+
+type metrics struct {
+	BlobCacheCounter   *prometheus.CounterVec
+	ServedFilesCounter *prometheus.CounterVec
+}
+
+func newMetrics(reg prometheus.Registerer) (*metrics, error) {
+	blobCacheCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "blob_cache_total",
+		Help: "number of blob lookups by result, i.e. hit if the blob was in the blobspace and miss if it had to be downloaded",
+	}, []string{"repo", "result"})
+	err := reg.Register(blobCacheCounter)
+	if err != nil {
+		return nil, err
+	}
+
+	servedFilesCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "served_files_total",
+		Help: "number of files served by content encoding",
+	}, []string{"repo", "encoding"})
+	err = reg.Register(servedFilesCounter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &metrics{
+		BlobCacheCounter:   blobCacheCounter,
+		ServedFilesCounter: servedFilesCounter,
+	}, nil
+}
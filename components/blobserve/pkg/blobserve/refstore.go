@@ -7,6 +7,7 @@ package blobserve
 import (
 	"context"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
@@ -49,17 +50,15 @@ type refstore struct {
 	requests  chan downloadRequest
 	blobspace blobspace
 	config    map[string]blobConfig
+	metrics   *metrics
+	// pinned lists the refs whose blobs must not be evicted from the blobspace
+	pinned map[string]struct{}
 
 	close chan struct{}
 	once  *sync.Once
 }
 
-func newRefStore(cfg blobserve_config.BlobServe, resolver ResolverProvider) (*refstore, error) {
-	bs, err := newBlobSpace(cfg.BlobSpace.Location, cfg.BlobSpace.MaxSize, 10*time.Minute)
-	if err != nil {
-		return nil, err
-	}
-
+func newRefStore(cfg blobserve_config.BlobServe, resolver ResolverProvider, metrics *metrics) (*refstore, error) {
 	config := make(map[string]blobConfig)
 	for ref, repo := range cfg.Repos {
 		mods := make([]blobModifier, 0, len(repo.Replacements))
@@ -74,14 +73,21 @@ func newRefStore(cfg blobserve_config.BlobServe, resolver ResolverProvider) (*re
 	}
 
 	res := &refstore{
-		Resolver:  resolver,
-		blobspace: bs,
-		config:    config,
-		refcache:  make(map[string]*refstate),
-		requests:  make(chan downloadRequest),
-		once:      &sync.Once{},
-		close:     make(chan struct{}),
+		Resolver: resolver,
+		config:   config,
+		metrics:  metrics,
+		refcache: make(map[string]*refstate),
+		pinned:   make(map[string]struct{}),
+		requests: make(chan downloadRequest),
+		once:     &sync.Once{},
+		close:    make(chan struct{}),
+	}
+	bs, err := newBlobSpace(cfg.BlobSpace, res.isPinned, 10*time.Minute)
+	if err != nil {
+		return nil, err
 	}
+	res.blobspace = bs
+
 	for i := 0; i < parallelBlobDownloads; i++ {
 		go res.serveRequests()
 	}
@@ -148,6 +154,7 @@ func (store *refstore) BlobFor(ctx context.Context, ref string, readOnly bool) (
 		// hence blobState can validly be blobUnknown.
 		fs, blobState = store.blobspace.Get(rs.Digest)
 	}
+	store.observeBlobLookup(ref, blobState != blobUnknown)
 	if blobState == blobUnknown {
 		// if refcache thinks the blob should exist, but it doesn't, we force a redownload.
 		err = store.downloadBlobFor(ctx, ref, exists)
@@ -182,6 +189,107 @@ func (store *refstore) BlobFor(ctx context.Context, ref string, readOnly bool) (
 	return fs, rs.Digest, nil
 }
 
+// Refresh re-resolves ref and downloads its blob, even if ref was resolved before, e.g. because its tag was moved.
+// If ref cannot be resolved, the blob it was resolved to before continues to be served.
+func (store *refstore) Refresh(ctx context.Context, ref string) error {
+	return store.downloadBlobFor(ctx, ref, true)
+}
+
+func (store *refstore) observeBlobLookup(ref string, hit bool) {
+	if store.metrics == nil {
+		return
+	}
+
+	repo := otherRepo
+	if pref, err := reference.ParseNamed(ref); err == nil {
+		if _, ok := store.config[pref.Name()]; ok {
+			repo = pref.Name()
+		}
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	store.metrics.BlobCacheCounter.WithLabelValues(repo, result).Inc()
+}
+
+// Pin prevents the blob of a ref from being evicted from the blobspace
+func (store *refstore) Pin(ref string) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.pinned[ref] = struct{}{}
+}
+
+// Unpin allows the blob of a ref to be evicted from the blobspace again
+func (store *refstore) Unpin(ref string) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	delete(store.pinned, ref)
+}
+
+// isPinned returns true if the blob with the given digest belongs to a pinned ref
+func (store *refstore) isPinned(digest string) bool {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	for ref := range store.pinned {
+		rs, ok := store.refcache[ref]
+		if ok && rs.Digest == digest {
+			return true
+		}
+	}
+	return false
+}
+
+// InventoryBlob describes a blob in the blobspace
+type InventoryBlob struct {
+	Digest   string    `json:"digest"`
+	Refs     []string  `json:"refs,omitempty"`
+	Size     int64     `json:"sizeBytes"`
+	LastUsed time.Time `json:"lastUsed"`
+	Ready    bool      `json:"ready"`
+	Pinned   bool      `json:"pinned"`
+}
+
+// Inventory lists the blobs which are currently in the blobspace together with the refs they were downloaded for
+func (store *refstore) Inventory() ([]InventoryBlob, error) {
+	blobs, err := store.blobspace.List()
+	if err != nil {
+		return nil, err
+	}
+
+	store.mu.RLock()
+	var (
+		refs   = make(map[string][]string)
+		pinned = make(map[string]bool)
+	)
+	for ref, rs := range store.refcache {
+		if rs.Digest == "" {
+			continue
+		}
+		refs[rs.Digest] = append(refs[rs.Digest], ref)
+		if _, ok := store.pinned[ref]; ok {
+			pinned[rs.Digest] = true
+		}
+	}
+	store.mu.RUnlock()
+
+	res := make([]InventoryBlob, 0, len(blobs))
+	for _, blob := range blobs {
+		blobRefs := refs[blob.Name]
+		sort.Strings(blobRefs)
+		res = append(res, InventoryBlob{
+			Digest:   blob.Name,
+			Refs:     blobRefs,
+			Size:     blob.Size,
+			LastUsed: blob.LastUsed,
+			Ready:    blob.Ready,
+			Pinned:   pinned[blob.Name],
+		})
+	}
+	return res, nil
+}
+
 func (store *refstore) Close() {
 	store.once.Do(func() {
 		close(store.requests)
@@ -223,20 +331,27 @@ func (store *refstore) serveRequests() {
 
 func (store *refstore) handleRequest(ctx context.Context, ref string, force bool) (err error) {
 	store.mu.Lock()
-	rs, exists := store.refcache[ref]
+	prev, exists := store.refcache[ref]
 	if exists && !force {
 		// someone has handled this request already
 		store.mu.Unlock()
 		return nil
 	}
-	rs = &refstate{ch: make(chan error)}
+	rs := &refstate{ch: make(chan error)}
 	store.refcache[ref] = rs
 	store.mu.Unlock()
 
 	defer func() {
 		if err != nil {
 			store.mu.Lock()
-			delete(store.refcache, ref)
+			if store.refcache[ref] == rs {
+				if exists && prev.Done() {
+					// the blob the ref was resolved to before continues to be served
+					store.refcache[ref] = prev
+				} else {
+					delete(store.refcache, ref)
+				}
+			}
 			store.mu.Unlock()
 		}
 		rs.MarkDone(err)
@@ -249,7 +364,10 @@ func (store *refstore) handleRequest(ctx context.Context, ref string, force bool
 		return err
 	}
 	digest := layer.Digest.Hex()
+	// the digest is read by the GC and inventory while the download is in progress
+	store.mu.Lock()
 	rs.Digest = digest
+	store.mu.Unlock()
 
 	fetcher, err := resolver.Fetcher(ctx, ref)
 	if err != nil {
@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"sort"
 	"sync"
 	"testing"
 
@@ -27,6 +28,9 @@ func TestBlobFor(t *testing.T) {
 		refDescriptor = "gitpod.io/test:tag"
 		hashManifest  = "5de870aced7e6c182a10e032e94de15893c95edd80d9cc20348b0f1627826d93"
 		hashLayer     = "4970405cb2a3a461cc00fd755712beded51919d7e69270d7d10d0dcf5e209714"
+
+		hashMovedManifest = "c8f3c8a1bd2b5a6f0c1f1b5e1b1e8a3d1f6b0a2e9c1d4b7a8e5f2c3d6b9a0e1f"
+		hashMovedLayer    = "2d4f6b8a0c1e3f5a7b9d1c3e5f7a9b0d2c4e6f8a1b3d5c7e9f0a2b4c6d8e0f1a"
 	)
 	var (
 		provideDescriptor = func() ([]byte, error) {
@@ -203,6 +207,84 @@ func TestBlobFor(t *testing.T) {
 				},
 			},
 		},
+		{
+			Desc: "refresh moved tag",
+			FetchableContent: map[string]provider{
+				refDescriptor: provideDescriptor,
+				hashManifest:  provideManifest,
+				hashLayer:     provideLayer,
+			},
+			ExtraAction: func(t *testing.T, s *refstore) (err error) {
+				_, _, err = s.BlobFor(context.Background(), refDescriptor, false)
+				if err != nil {
+					return err
+				}
+
+				s.Resolver = func() remotes.Resolver {
+					return &fakeFetcher{
+						Content: map[string]provider{
+							refDescriptor: func() ([]byte, error) {
+								return json.Marshal(ociv1.Descriptor{
+									MediaType: ociv1.MediaTypeImageManifest,
+									Digest:    "sha256:" + hashMovedManifest,
+									Size:      10,
+								})
+							},
+							hashMovedManifest: func() ([]byte, error) {
+								return json.Marshal(ociv1.Manifest{
+									Layers: []ociv1.Descriptor{{
+										MediaType: ociv1.MediaTypeImageLayerGzip,
+										Digest:    "sha256:" + hashMovedLayer,
+										Size:      10,
+									}},
+								})
+							},
+							hashMovedLayer: provideLayer,
+						},
+					}
+				}
+				return s.Refresh(context.Background(), refDescriptor)
+			},
+			Expectation: Expectation{
+				Content: map[string]blobstate{hashLayer: blobReady, hashMovedLayer: blobReady},
+				Refcache: map[string]string{
+					refDescriptor: hashMovedLayer,
+				},
+			},
+		},
+		{
+			Desc: "failed refresh",
+			FetchableContent: map[string]provider{
+				refDescriptor: provideDescriptor,
+				hashManifest:  provideManifest,
+				hashLayer:     provideLayer,
+			},
+			ExtraAction: func(t *testing.T, s *refstore) (err error) {
+				_, _, err = s.BlobFor(context.Background(), refDescriptor, false)
+				if err != nil {
+					return err
+				}
+
+				s.Resolver = func() remotes.Resolver { return &fakeFetcher{} }
+				err = s.Refresh(context.Background(), refDescriptor)
+				if err == nil {
+					return xerrors.Errorf("refreshed ref although it cannot be resolved")
+				}
+
+				// the ref continues to be served from the blob it was resolved to before
+				_, hash, err := s.BlobFor(context.Background(), refDescriptor, false)
+				if diff := cmp.Diff(hashLayer, hash); diff != "" {
+					t.Errorf("unexpected blob hash (-want +got):\n%s", diff)
+				}
+				return err
+			},
+			Expectation: Expectation{
+				Content: map[string]blobstate{hashLayer: blobReady},
+				Refcache: map[string]string{
+					refDescriptor: hashLayer,
+				},
+			},
+		},
 		{
 			Desc: "1000 parallel downloads",
 			ExtraAction: func(t *testing.T, s *refstore) error {
@@ -452,6 +534,15 @@ func (s *inMemoryBlobspace) AddFromTarGzip(ctx context.Context, name string, in
 	return s.Adder(ctx, name, in)
 }
 
+func (s *inMemoryBlobspace) List() ([]gcBlob, error) {
+	res := make([]gcBlob, 0, len(s.Content))
+	for name, state := range s.Content {
+		res = append(res, gcBlob{F: name, Name: name, Ready: state == blobReady})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res, nil
+}
+
 type provider func() ([]byte, error)
 
 type fakeFetcher struct {
@@ -29,6 +29,7 @@ type InlineReplacement struct {
 }
 
 type Repo struct {
+	// PrePull lists the tags which are downloaded on startup. Their blobs are never evicted from the blobspace.
 	PrePull      []string            `json:"prePull,omitempty"`
 	Workdir      string              `json:"workdir,omitempty"`
 	Replacements []StringReplacement `json:"replacements,omitempty"`
@@ -37,5 +38,19 @@ type Repo struct {
 
 type BlobSpace struct {
 	Location string `json:"location"`
-	MaxSize  int64  `json:"maxSizeBytes,omitempty"`
+	// MaxSize is the size the blobspace is kept under by evicting the least recently used blobs
+	MaxSize int64 `json:"maxSizeBytes,omitempty"`
+	// MaxAge evicts blobs which have not been used for longer than this duration
+	MaxAge      util.Duration `json:"maxAge,omitempty"`
+	Compression *Compression  `json:"compression,omitempty"`
+}
+
+// Compression configures the brotli and gzip compression of files when blobs are added to the blobspace.
+// Compressed variants are served to clients which accept them.
+type Compression struct {
+	Enabled bool `json:"enabled"`
+	// Extensions lists the extensions of the files to compress, e.g. ".js". Defaults to common text formats.
+	Extensions []string `json:"extensions,omitempty"`
+	// MinSize is the minimum size of files to compress. Defaults to 1 KiB.
+	MinSize int64 `json:"minSizeBytes,omitempty"`
 }
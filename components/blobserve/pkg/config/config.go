@@ -16,6 +16,9 @@ type Config struct {
 	PProfAddr          string    `json:"pprofAddr"`
 	PrometheusAddr     string    `json:"prometheusAddr"`
 	ReadinessProbeAddr string    `json:"readinessProbeAddr"`
+	// AdminAddr is the address of the admin API which warms up refs and lists the blobspace inventory.
+	// The admin API is unauthenticated and must not be exposed.
+	AdminAddr string `json:"adminAddr,omitempty"`
 }
 
 // getConfig loads and validates the configuration
@@ -24,6 +24,8 @@ const (
 	ServiceContextField        = "serviceContext"
 	PersonalAccessTokenIDField = "patId"
 	OIDCClientConfigIDField    = "oidcClientConfigId"
+	WebhookIDField             = "webhookId"
+	WebhookDeliveryIDField     = "webhookDeliveryId"
 )
 
 // OWI builds a structure meant for logrus which contains the owner, workspace and instance.
@@ -84,6 +86,14 @@ func OIDCClientConfigID(id string) log.Fields {
 	return String(OIDCClientConfigIDField, id)
 }
 
+func WebhookID(id string) log.Fields {
+	return String(WebhookIDField, id)
+}
+
+func WebhookDeliveryID(id string) log.Fields {
+	return String(WebhookDeliveryIDField, id)
+}
+
 func UserID(userID string) log.Fields {
 	return String(UserIDField, userID)
 }
@@ -27,6 +27,12 @@ type StorageConfig struct {
 	// S3Config configures the S3 remote storage
 	S3Config *S3Config `json:"s3,omitempty"`
 
+	// FileConfig configures the local filesystem remote storage
+	FileConfig *FileConfig `json:"file,omitempty"`
+
+	// Encryption enables client-side encryption of workspace backups
+	Encryption *EncryptionConfig `json:"encryption,omitempty"`
+
 	BlobQuota int64 `json:"blobQuota"`
 }
 
@@ -58,6 +64,10 @@ const (
 	// exist in the environment. See https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/config#LoadDefaultConfig for more details.
 	S3Storage RemoteStorageType = "s3"
 
+	// FileStorage stores workspaces in a local directory, e.g. a network file system mount shared by all nodes.
+	// Signed up- and download URLs are served by content-service itself.
+	FileStorage RemoteStorageType = "file"
+
 	// NullStorage does not synchronize workspaces at all
 	NullStorage RemoteStorageType = ""
 )
@@ -116,6 +126,37 @@ type S3Config struct {
 	CredentialsFile string `json:"credentialsFile"`
 }
 
+// FileConfig configures the local filesystem remote storage backend
+type FileConfig struct {
+	// Path is the directory in which all buckets are stored
+	Path string `json:"path"`
+
+	// URL is the externally reachable base URL under which content-service serves signed up- and downloads
+	URL string `json:"url"`
+
+	// SigningKey is the secret used to sign up- and download URLs
+	SigningKey string `json:"signingKey,omitempty"`
+
+	// SigningKeyFile allows for the signing key to be read from a file
+	SigningKeyFile string `json:"signingKeyFile,omitempty"`
+
+	// BucketName, if set, stores all content in a single bucket
+	BucketName string `json:"bucket,omitempty"`
+
+	// MaxUploadSize is the maximum size in bytes of objects uploaded using signed URLs. Defaults to 5 GiB.
+	MaxUploadSize int64 `json:"maxUploadSize,omitempty"`
+}
+
+// EncryptionConfig configures client-side envelope encryption of workspace backups
+type EncryptionConfig struct {
+	// KeyFile contains the base64-encoded 256 bit key-encryption key which protects the data keys of new backups
+	KeyFile string `json:"keyFile"`
+
+	// PreviousKeyFiles contain key-encryption keys which are no longer used for new backups,
+	// but are still required to restore existing ones.
+	PreviousKeyFiles []string `json:"previousKeyFiles,omitempty"`
+}
+
 type PProf struct {
 	Addr string `json:"address"`
 }
@@ -128,6 +169,8 @@ type UsageReportConfig struct {
 type ServiceConfig struct {
 	Service baseserver.ServerConfiguration `json:"service"`
 	Storage StorageConfig                  `json:"storage"`
+	// HTTP configures the server for signed up- and downloads, required by the file storage
+	HTTP *baseserver.ServerConfiguration `json:"http,omitempty"`
 	// Deprecated
 	_ UsageReportConfig `json:"usageReport"`
 }
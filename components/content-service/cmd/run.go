@@ -8,7 +8,9 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/baseserver"
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/api/config"
 	"github.com/gitpod-io/gitpod/content-service/pkg/service"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -20,14 +22,30 @@ var runCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg := getConfig()
 
-		srv, err := baseserver.New("content-service",
+		opts := []baseserver.Option{
 			baseserver.WithGRPC(&cfg.Service),
 			baseserver.WithVersion(Version),
-		)
+		}
+		if cfg.Storage.Kind == config.FileStorage {
+			if cfg.HTTP == nil {
+				log.Fatal("File storage requires an HTTP server to serve signed URLs - please configure http.")
+			}
+			opts = append(opts, baseserver.WithHTTP(cfg.HTTP))
+		}
+
+		srv, err := baseserver.New("content-service", opts...)
 		if err != nil {
 			log.WithError(err).Fatal("Failed to create server.")
 		}
 
+		if cfg.Storage.Kind == config.FileStorage {
+			pattern, handler, err := storage.NewFileStorageHandler(cfg.Storage.FileConfig)
+			if err != nil {
+				log.WithError(err).Fatal("Cannot create file storage handler")
+			}
+			srv.HTTPMux().Handle(pattern, handler)
+		}
+
 		contentService, err := service.NewContentService(cfg.Storage)
 		if err != nil {
 			log.WithError(err).Fatalf("Cannot create content service")
@@ -0,0 +1,270 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Package chunked implements a backup format which splits a tar stream into content-defined,
+// zstd-compressed chunks keyed by their digest. A manifest lists the chunks in order.
+// Subsequent backups only need to store chunks which do not exist yet.
+package chunked
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// MediaTypeManifest is the media type of a chunked backup manifest
+	MediaTypeManifest = "application/vnd.gitpod.chunked-backup.manifest.v1+json"
+
+	// MediaTypeChunk is the media type of a single backup chunk
+	MediaTypeChunk = "application/vnd.gitpod.chunked-backup.chunk.v1+zstd"
+
+	// DefaultConcurrency is the number of chunks fetched in parallel during restore
+	DefaultConcurrency = 4
+
+	// ChunkPrefix is the common prefix of all chunk object names
+	ChunkPrefix = "chunks"
+)
+
+// Manifest lists the chunks which make up a backup
+type Manifest struct {
+	MediaType string `json:"mediaType"`
+
+	// Digest is the digest of the complete, uncompressed tar stream
+	Digest digest.Digest `json:"digest"`
+
+	// Size is the size of the complete, uncompressed tar stream
+	Size int64 `json:"size"`
+
+	Chunks []Chunk `json:"chunks"`
+}
+
+// Chunk is a section of the tar stream
+type Chunk struct {
+	// Digest is the digest of the uncompressed chunk content
+	Digest digest.Digest `json:"digest"`
+
+	// Size is the size of the uncompressed chunk content
+	Size int64 `json:"size"`
+}
+
+// Name is the storage object name of the chunk
+func (c Chunk) Name() string {
+	return ChunkName(c.Digest)
+}
+
+// ChunkName produces the storage object name of a chunk with the given digest
+func ChunkName(dgst digest.Digest) string {
+	return path.Join(ChunkPrefix, fmt.Sprintf("%s-%s", dgst.Algorithm(), dgst.Encoded()))
+}
+
+// ParseManifest parses and validates a chunked backup manifest
+func ParseManifest(r io.Reader) (*Manifest, error) {
+	var mf Manifest
+	err := json.NewDecoder(r).Decode(&mf)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal chunked backup manifest: %w", err)
+	}
+	if mf.MediaType != MediaTypeManifest {
+		return nil, xerrors.Errorf("unsupported chunked backup manifest media type: %s", mf.MediaType)
+	}
+	for _, c := range mf.Chunks {
+		if err := c.Digest.Validate(); err != nil {
+			return nil, xerrors.Errorf("invalid chunk digest %s: %w", c.Digest, err)
+		}
+	}
+	return &mf, nil
+}
+
+// ChunkExists tells if a chunk is already stored and needs no upload
+type ChunkExists func(chunk Chunk) bool
+
+// ChunkUploader stores the zstd-compressed chunk content found in the file at compressedPath
+type ChunkUploader func(ctx context.Context, chunk Chunk, compressedPath string) error
+
+// Write splits src into chunks and uploads all chunks for which exists returns false.
+// Chunks are staged in tmpdir before upload.
+func Write(ctx context.Context, src io.Reader, tmpdir string, exists ChunkExists, upload ChunkUploader) (*Manifest, error) {
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer enc.Close()
+
+	var (
+		mf = &Manifest{MediaType: MediaTypeManifest}
+		dg = digest.Canonical.Digester()
+		ch = NewChunker(io.TeeReader(src, dg.Hash()))
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		data, err := ch.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("cannot read backup: %w", err)
+		}
+
+		chunk := Chunk{
+			Digest: digest.FromBytes(data),
+			Size:   int64(len(data)),
+		}
+		mf.Chunks = append(mf.Chunks, chunk)
+		mf.Size += chunk.Size
+		if exists(chunk) {
+			continue
+		}
+
+		err = writeChunk(ctx, enc, data, chunk, tmpdir, upload)
+		if err != nil {
+			return nil, err
+		}
+	}
+	mf.Digest = dg.Digest()
+
+	return mf, nil
+}
+
+func writeChunk(ctx context.Context, enc *zstd.Encoder, data []byte, chunk Chunk, tmpdir string, upload ChunkUploader) error {
+	f, err := os.CreateTemp(tmpdir, "chunk-*.zst")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	enc.Reset(f)
+	_, err = enc.Write(data)
+	if err == nil {
+		err = enc.Close()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return xerrors.Errorf("cannot compress chunk %s: %w", chunk.Digest, err)
+	}
+
+	err = upload(ctx, chunk, f.Name())
+	if err != nil {
+		return xerrors.Errorf("cannot upload chunk %s: %w", chunk.Digest, err)
+	}
+	return nil
+}
+
+// ChunkFetcher provides the zstd-compressed content of a chunk
+type ChunkFetcher func(ctx context.Context, chunk Chunk) (io.ReadCloser, error)
+
+// NewReader reassembles the tar stream described by the manifest. Up to concurrency chunks are fetched
+// in parallel. Chunks and the stream as a whole are verified against their digests.
+func NewReader(ctx context.Context, mf *Manifest, fetch ChunkFetcher, concurrency int) io.ReadCloser {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	pr, pw := io.Pipe()
+
+	type chunkResult struct {
+		Data []byte
+		Err  error
+	}
+	results := make(chan chan chunkResult, concurrency)
+	go func() {
+		defer close(results)
+		for _, c := range mf.Chunks {
+			res := make(chan chunkResult, 1)
+			select {
+			case results <- res:
+			case <-ctx.Done():
+				return
+			}
+			go func(c Chunk) {
+				data, err := fetchChunk(ctx, fetch, c)
+				res <- chunkResult{Data: data, Err: err}
+			}(c)
+		}
+	}()
+
+	go func() {
+		defer cancel()
+
+		var (
+			err error
+			dg  = digest.Canonical.Digester()
+			w   = io.MultiWriter(pw, dg.Hash())
+		)
+		for res := range results {
+			var r chunkResult
+			select {
+			case r = <-res:
+			case <-ctx.Done():
+				r.Err = ctx.Err()
+			}
+			if r.Err != nil {
+				err = r.Err
+				break
+			}
+			_, err = w.Write(r.Data)
+			if err != nil {
+				break
+			}
+		}
+		if err == nil && dg.Digest() != mf.Digest {
+			err = xerrors.Errorf("backup digest mismatch: expected %s, got %s", mf.Digest, dg.Digest())
+		}
+		pw.CloseWithError(err)
+	}()
+
+	return &reader{PipeReader: pr, cancel: cancel}
+}
+
+type reader struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+}
+
+func (r *reader) Close() error {
+	r.cancel()
+	return r.PipeReader.Close()
+}
+
+func fetchChunk(ctx context.Context, fetch ChunkFetcher, chunk Chunk) ([]byte, error) {
+	rc, err := fetch(ctx, chunk)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot fetch chunk %s: %w", chunk.Digest, err)
+	}
+	defer rc.Close()
+
+	dec, err := zstd.NewReader(rc, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	defer dec.Close()
+
+	var buf bytes.Buffer
+	buf.Grow(int(chunk.Size))
+	_, err = io.Copy(&buf, io.LimitReader(dec, chunk.Size+1))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot decompress chunk %s: %w", chunk.Digest, err)
+	}
+	if int64(buf.Len()) != chunk.Size {
+		return nil, xerrors.Errorf("chunk %s size mismatch: expected %d, got %d", chunk.Digest, chunk.Size, buf.Len())
+	}
+	if act := digest.FromBytes(buf.Bytes()); act != chunk.Digest {
+		return nil, xerrors.Errorf("chunk digest mismatch: expected %s, got %s", chunk.Digest, act)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package chunked
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+type memStore map[digest.Digest][]byte
+
+func (s memStore) Exists(c Chunk) bool {
+	_, ok := s[c.Digest]
+	return ok
+}
+
+func (s memStore) Upload(ctx context.Context, c Chunk, fn string) error {
+	fc, err := os.ReadFile(fn)
+	if err != nil {
+		return err
+	}
+	s[c.Digest] = fc
+	return nil
+}
+
+func (s memStore) Fetch(ctx context.Context, c Chunk) (io.ReadCloser, error) {
+	fc, ok := s[c.Digest]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return io.NopCloser(bytes.NewReader(fc)), nil
+}
+
+func randomData(seed int64, size int) []byte {
+	res := make([]byte, size)
+	rand.New(rand.NewSource(seed)).Read(res)
+	return res
+}
+
+func TestChunkerBounds(t *testing.T) {
+	data := randomData(42, 32*1024*1024)
+	ch := NewChunker(bytes.NewReader(data))
+
+	var total int
+	for {
+		c, err := ch.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(c) > MaxChunkSize {
+			t.Errorf("chunk exceeds max size: %d", len(c))
+		}
+		if len(c) < MinChunkSize && total+len(c) != len(data) {
+			t.Errorf("chunk below min size which isn't the last chunk: %d", len(c))
+		}
+		if !bytes.Equal(c, data[total:total+len(c)]) {
+			t.Fatalf("chunk at offset %d does not match input", total)
+		}
+		total += len(c)
+	}
+	if total != len(data) {
+		t.Errorf("chunks do not cover the input: %d != %d", total, len(data))
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		Name string
+		Data []byte
+	}{
+		{Name: "empty", Data: nil},
+		{Name: "small", Data: []byte("hello world")},
+		{Name: "large", Data: randomData(1, 20*1024*1024)},
+		{Name: "compressible", Data: []byte(strings.Repeat("gitpod", 4*1024*1024))},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx := context.Background()
+			store := make(memStore)
+			mf, err := Write(ctx, bytes.NewReader(test.Data), t.TempDir(), store.Exists, store.Upload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mf.Size != int64(len(test.Data)) {
+				t.Errorf("unexpected manifest size: %d", mf.Size)
+			}
+
+			rd := NewReader(ctx, mf, store.Fetch, DefaultConcurrency)
+			defer rd.Close()
+			act, err := io.ReadAll(rd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(act, test.Data) {
+				t.Errorf("restored data does not match original")
+			}
+		})
+	}
+}
+
+func TestIncrementalWrite(t *testing.T) {
+	ctx := context.Background()
+	store := make(memStore)
+	data := randomData(2, 24*1024*1024)
+
+	_, err := Write(ctx, bytes.NewReader(data), t.TempDir(), store.Exists, store.Upload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// insert some bytes in the middle, which shifts all subsequent content
+	changed := append(append(append([]byte{}, data[:10*1024*1024]...), []byte("some change")...), data[10*1024*1024:]...)
+
+	var uploads int
+	mf, err := Write(ctx, bytes.NewReader(changed), t.TempDir(), store.Exists, func(ctx context.Context, chunk Chunk, fn string) error {
+		uploads++
+		return store.Upload(ctx, chunk, fn)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uploads == 0 || uploads > 2 {
+		t.Errorf("expected one or two chunks to be uploaded, got %d of %d", uploads, len(mf.Chunks))
+	}
+}
+
+func TestReaderVerifiesChunks(t *testing.T) {
+	ctx := context.Background()
+	store := make(memStore)
+	mf, err := Write(ctx, bytes.NewReader(randomData(3, 4*1024*1024)), t.TempDir(), store.Exists, store.Upload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// replace the content of the last chunk with that of the first
+	first, last := mf.Chunks[0], mf.Chunks[len(mf.Chunks)-1]
+	store[last.Digest] = store[first.Digest]
+
+	rd := NewReader(ctx, mf, store.Fetch, DefaultConcurrency)
+	defer rd.Close()
+	_, err = io.ReadAll(rd)
+	if err == nil {
+		t.Fatal("expected tampered chunk to fail the restore")
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	_, err := ParseManifest(strings.NewReader(`{"mediaType":"application/json"}`))
+	if err == nil {
+		t.Error("expected manifest with wrong media type to be rejected")
+	}
+
+	mf, err := ParseManifest(strings.NewReader(`{"mediaType":"` + MediaTypeManifest + `","chunks":[{"digest":"sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855","size":0}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := mf.Chunks[0].Name(); n != "chunks/sha256-e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Errorf("unexpected chunk name: %s", n)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package chunked
+
+import (
+	"io"
+)
+
+const (
+	// MinChunkSize is the smallest chunk the chunker produces, unless the input ends
+	MinChunkSize = 512 * 1024
+	// MaxChunkSize is the largest chunk the chunker produces
+	MaxChunkSize = 8 * 1024 * 1024
+
+	// avgChunkBits determines the average chunk size (2^21 = 2 MiB)
+	avgChunkBits = 21
+)
+
+// cutMask selects the top bits of the rolling hash. Using the top bits makes a cut point
+// depend on the last 64 bytes of input, rather than just the last few.
+const cutMask = uint64(1<<avgChunkBits-1) << (64 - avgChunkBits)
+
+// gearTable maps bytes to random values for the gear rolling hash. The table must never change,
+// as that would change all chunk boundaries and hence defeat deduplication against existing backups.
+var gearTable = func() (res [256]uint64) {
+	// splitmix64 with a fixed seed
+	x := uint64(0x6769747064636463)
+	for i := range res {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		res[i] = z ^ (z >> 31)
+	}
+	return
+}()
+
+// Chunker splits a stream into content-defined chunks, i.e. chunk boundaries depend on the content
+// rather than the offset. Inserting or removing data only affects the chunks around the change.
+type Chunker struct {
+	r   io.Reader
+	buf []byte
+	// start and end delimit the unconsumed data in buf
+	start, end int
+	eof        bool
+}
+
+// NewChunker produces a new chunker reading from r
+func NewChunker(r io.Reader) *Chunker {
+	return &Chunker{
+		r:   r,
+		buf: make([]byte, 2*MaxChunkSize),
+	}
+}
+
+// Next returns the next chunk or io.EOF if there is no more data.
+// The returned slice is only valid until the next call to Next.
+func (c *Chunker) Next() ([]byte, error) {
+	err := c.fill()
+	if err != nil {
+		return nil, err
+	}
+	if c.start == c.end {
+		return nil, io.EOF
+	}
+
+	n := cutPoint(c.buf[c.start:c.end])
+	chunk := c.buf[c.start : c.start+n]
+	c.start += n
+	return chunk, nil
+}
+
+// fill makes sure there are at least MaxChunkSize bytes available, unless the reader is exhausted
+func (c *Chunker) fill() error {
+	if c.eof || c.end-c.start >= MaxChunkSize {
+		return nil
+	}
+
+	c.end = copy(c.buf, c.buf[c.start:c.end])
+	c.start = 0
+	for c.end < len(c.buf) {
+		n, err := c.r.Read(c.buf[c.end:])
+		c.end += n
+		if err == io.EOF {
+			c.eof = true
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cutPoint finds the length of the first chunk in data
+func cutPoint(data []byte) int {
+	if len(data) <= MinChunkSize {
+		return len(data)
+	}
+	if len(data) > MaxChunkSize {
+		data = data[:MaxChunkSize]
+	}
+
+	var hash uint64
+	for i := MinChunkSize; i < len(data); i++ {
+		hash = (hash << 1) + gearTable[data[i]]
+		if hash&cutMask == 0 {
+			return i + 1
+		}
+	}
+	return len(data)
+}
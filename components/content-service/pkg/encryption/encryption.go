@@ -0,0 +1,500 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Package encryption implements envelope encryption for workspace content stored in remote storage.
+// Content is encrypted with a per-owner data key, which in turn is wrapped with a key-encryption key (KEK).
+// The wrapped data key, the ID of the KEK and the owner of the data key are stored in a header in front of
+// the encrypted content. Data keys are derived from the KEK and the owner, so that the data keys of a single
+// owner can be handed out for decryption without exposing the KEK.
+package encryption
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+)
+
+const (
+	// KeySize is the size of key-encryption and data keys in bytes
+	KeySize = 32
+
+	// segmentSize is the amount of plaintext encrypted per AEAD operation
+	segmentSize = 64 * 1024
+
+	noncePrefixSize = 8
+
+	segmentFlagIntermediate byte = 0
+	segmentFlagFinal        byte = 1
+
+	dataKeyLabel = "gitpod data key\x00"
+)
+
+var (
+	// magic identifies encrypted content
+	magic = []byte("GPENC\x01")
+
+	// ErrUnknownKey is returned when content was encrypted with a key that's not in the key ring
+	ErrUnknownKey = errors.New("unknown key-encryption key")
+
+	// ErrNotEncrypted is returned when content which is expected to be encrypted is not
+	ErrNotEncrypted = errors.New("content is not encrypted")
+)
+
+// KeyID produces the ID of a key-encryption key
+func KeyID(kek []byte) string {
+	h := sha256.Sum256(kek)
+	return hex.EncodeToString(h[:8])
+}
+
+// KeyRing holds the key-encryption keys. The primary key protects the data keys of newly encrypted content,
+// all keys can be used for decryption.
+//
+// A key ring created using NewOwnerKeyRing holds the data keys of some owners instead. It can only
+// decrypt content of those owners and cannot encrypt at all.
+type KeyRing struct {
+	primary string
+	keys    map[string][]byte
+
+	// dataKeys maps owners to their data keys, indexed by key-encryption key ID
+	dataKeys map[string]map[string][]byte
+}
+
+type dataKey struct {
+	Key     []byte
+	Wrapped []byte
+}
+
+// NewKeyRing creates a new key ring. The primary key is used for encryption,
+// the previous keys are only used for decryption.
+func NewKeyRing(primary []byte, previous ...[]byte) (*KeyRing, error) {
+	res := &KeyRing{
+		keys: make(map[string][]byte),
+	}
+	for i, k := range append([][]byte{primary}, previous...) {
+		if len(k) != KeySize {
+			return nil, xerrors.Errorf("key-encryption key must be %d bytes, not %d", KeySize, len(k))
+		}
+		id := KeyID(k)
+		if i == 0 {
+			res.primary = id
+		}
+		res.keys[id] = k
+	}
+	return res, nil
+}
+
+// LoadKeyRing loads the key-encryption keys from the files configured
+func LoadKeyRing(cfg *config.EncryptionConfig) (*KeyRing, error) {
+	if cfg == nil || cfg.KeyFile == "" {
+		return nil, xerrors.Errorf("no key-encryption key configured")
+	}
+
+	primary, err := loadKey(cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	previous := make([][]byte, 0, len(cfg.PreviousKeyFiles))
+	for _, fn := range cfg.PreviousKeyFiles {
+		k, err := loadKey(fn)
+		if err != nil {
+			return nil, err
+		}
+		previous = append(previous, k)
+	}
+	return NewKeyRing(primary, previous...)
+}
+
+func loadKey(fn string) ([]byte, error) {
+	fc, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read key-encryption key: %w", err)
+	}
+	k, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(fc)))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot decode key-encryption key %s: %w", fn, err)
+	}
+	return k, nil
+}
+
+// NewOwnerKeyRing creates a key ring from the data keys produced by OwnerKeys, indexed by owner
+func NewOwnerKeyRing(dataKeys map[string]map[string][]byte) (*KeyRing, error) {
+	res := &KeyRing{
+		dataKeys: make(map[string]map[string][]byte, len(dataKeys)),
+	}
+	for owner, keys := range dataKeys {
+		res.dataKeys[owner] = make(map[string][]byte, len(keys))
+		for id, k := range keys {
+			if len(k) != KeySize {
+				return nil, xerrors.Errorf("data key must be %d bytes, not %d", KeySize, len(k))
+			}
+			res.dataKeys[owner][id] = k
+		}
+	}
+	return res, nil
+}
+
+// OwnerKeys returns the data keys of an owner for all key-encryption keys, indexed by key ID
+func (kr *KeyRing) OwnerKeys(owner string) map[string][]byte {
+	res := make(map[string][]byte, len(kr.keys))
+	for id, kek := range kr.keys {
+		res[id] = deriveDataKey(kek, owner)
+	}
+	return res
+}
+
+// PrimaryKeyID returns the ID of the key-encryption key used for new content
+func (kr *KeyRing) PrimaryKeyID() string {
+	return kr.primary
+}
+
+func deriveDataKey(kek []byte, owner string) []byte {
+	mac := hmac.New(sha256.New, kek)
+	mac.Write([]byte(dataKeyLabel))
+	mac.Write([]byte(owner))
+	return mac.Sum(nil)
+}
+
+// dataKey returns the data key of an owner, wrapped with the primary key
+func (kr *KeyRing) dataKey(owner string) (*dataKey, error) {
+	kek, ok := kr.keys[kr.primary]
+	if !ok {
+		return nil, xerrors.Errorf("key ring has no key-encryption key")
+	}
+
+	key := deriveDataKey(kek, owner)
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dataKey{
+		Key:     key,
+		Wrapped: aead.Seal(nonce, nonce, key, wrapAdditionalData(kr.primary, owner)),
+	}, nil
+}
+
+// wrapAdditionalData binds a wrapped data key to its key-encryption key and owner
+func wrapAdditionalData(keyID, owner string) []byte {
+	return []byte(keyID + "\x00" + owner)
+}
+
+func (kr *KeyRing) unwrap(keyID, owner string, wrapped []byte) ([]byte, error) {
+	kek, ok := kr.keys[keyID]
+	if !ok {
+		if key, ok := kr.dataKeys[owner][keyID]; ok {
+			return key, nil
+		}
+		return nil, xerrors.Errorf("%w: %s of owner %s", ErrUnknownKey, keyID, owner)
+	}
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, xerrors.Errorf("wrapped data key is too short")
+	}
+	key, err := aead.Open(nil, wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():], wrapAdditionalData(keyID, owner))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unwrap data key: %w", err)
+	}
+	return key, nil
+}
+
+// Encrypt encrypts src using the owner's data key and writes the result to dst
+func (kr *KeyRing) Encrypt(owner string, dst io.Writer, src io.Reader) error {
+	dk, err := kr.dataKey(owner)
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(dk.Key)
+	if err != nil {
+		return err
+	}
+	noncePrefix := make([]byte, noncePrefixSize)
+	_, err = rand.Read(noncePrefix)
+	if err != nil {
+		return err
+	}
+
+	var hdr bytes.Buffer
+	hdr.Write(magic)
+	writeField(&hdr, []byte(kr.primary))
+	writeField(&hdr, []byte(owner))
+	writeField(&hdr, dk.Wrapped)
+	hdr.Write(noncePrefix)
+	_, err = dst.Write(hdr.Bytes())
+	if err != nil {
+		return err
+	}
+
+	var (
+		in  = bufio.NewReaderSize(src, segmentSize)
+		buf = make([]byte, segmentSize)
+		out = make([]byte, 0, 1+segmentSize+aead.Overhead())
+	)
+	for counter := uint32(0); ; counter++ {
+		n, err := io.ReadFull(in, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		flag := segmentFlagIntermediate
+		if n < segmentSize {
+			flag = segmentFlagFinal
+		} else if _, perr := in.Peek(1); perr == io.EOF {
+			flag = segmentFlagFinal
+		}
+
+		out = append(out[:0], flag)
+		out = aead.Seal(out, segmentNonce(noncePrefix, counter), buf[:n], []byte{flag})
+		_, err = dst.Write(out)
+		if err != nil {
+			return err
+		}
+		if flag == segmentFlagFinal {
+			return nil
+		}
+		if counter == ^uint32(0) {
+			return xerrors.Errorf("content too large to encrypt")
+		}
+	}
+}
+
+// EncryptFile encrypts the file src using the owner's data key and writes the result to the file dst
+func (kr *KeyRing) EncryptFile(owner, src, dst string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		cerr := out.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	return kr.Encrypt(owner, out, in)
+}
+
+// IsEncrypted checks whether the content in r starts with the encryption header, without consuming it
+func IsEncrypted(r *bufio.Reader) (bool, error) {
+	hdr, err := r.Peek(len(magic))
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(hdr, magic), nil
+}
+
+// Header describes who encrypted content can be decrypted by
+type Header struct {
+	// KeyID is the ID of the key-encryption key which wraps the data key
+	KeyID string
+	// Owner is the owner of the data key
+	Owner string
+
+	wrapped     []byte
+	noncePrefix []byte
+}
+
+// ReadHeader reads the encryption header of the content in r. Returns ErrNotEncrypted if the content is not encrypted.
+func ReadHeader(r io.Reader) (*Header, error) {
+	hdr := make([]byte, len(magic))
+	_, err := io.ReadFull(r, hdr)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, ErrNotEncrypted
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read encryption header: %w", err)
+	}
+	if !bytes.Equal(hdr, magic) {
+		return nil, ErrNotEncrypted
+	}
+	keyID, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	noncePrefix := make([]byte, noncePrefixSize)
+	_, err = io.ReadFull(r, noncePrefix)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read encryption header: %w", err)
+	}
+
+	return &Header{
+		KeyID:       string(keyID),
+		Owner:       string(owner),
+		wrapped:     wrapped,
+		noncePrefix: noncePrefix,
+	}, nil
+}
+
+// NewReader returns a reader which decrypts the content of r
+func (kr *KeyRing) NewReader(r io.Reader) (io.Reader, error) {
+	hdr, err := ReadHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := kr.unwrap(hdr.KeyID, hdr.Owner, hdr.wrapped)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reader{
+		r:           r,
+		aead:        aead,
+		noncePrefix: hdr.noncePrefix,
+		in:          make([]byte, 1+segmentSize+aead.Overhead()),
+	}, nil
+}
+
+type reader struct {
+	r           io.Reader
+	aead        cipher.AEAD
+	noncePrefix []byte
+	counter     uint32
+
+	in   []byte
+	buf  []byte
+	done bool
+}
+
+func (r *reader) Read(p []byte) (n int, err error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		err = r.nextSegment()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n = copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *reader) nextSegment() error {
+	_, err := io.ReadFull(r.r, r.in[:1])
+	if err == io.EOF {
+		return xerrors.Errorf("encrypted content is truncated")
+	}
+	if err != nil {
+		return err
+	}
+
+	var (
+		flag = r.in[0]
+		ct   []byte
+	)
+	switch flag {
+	case segmentFlagIntermediate:
+		ct = r.in[1:]
+		_, err = io.ReadFull(r.r, ct)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return xerrors.Errorf("encrypted content is truncated")
+		}
+	case segmentFlagFinal:
+		var n int
+		n, err = io.ReadFull(r.r, r.in[1:])
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			err = nil
+		} else if err == nil {
+			var extra [1]byte
+			if m, _ := io.ReadFull(r.r, extra[:]); m > 0 {
+				return xerrors.Errorf("encrypted content has trailing data")
+			}
+		}
+		ct = r.in[1 : 1+n]
+		r.done = true
+	default:
+		return xerrors.Errorf("invalid encrypted segment")
+	}
+	if err != nil {
+		return err
+	}
+
+	r.buf, err = r.aead.Open(ct[:0], segmentNonce(r.noncePrefix, r.counter), ct, []byte{flag})
+	if err != nil {
+		return xerrors.Errorf("cannot decrypt content: %w", err)
+	}
+	r.counter++
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func segmentNonce(prefix []byte, counter uint32) []byte {
+	nonce := make([]byte, noncePrefixSize+4)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[noncePrefixSize:], counter)
+	return nonce
+}
+
+func writeField(w *bytes.Buffer, data []byte) {
+	var l [2]byte
+	binary.BigEndian.PutUint16(l[:], uint16(len(data)))
+	w.Write(l[:])
+	w.Write(data)
+}
+
+func readField(r io.Reader) ([]byte, error) {
+	var l [2]byte
+	_, err := io.ReadFull(r, l[:])
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read encryption header: %w", err)
+	}
+	res := make([]byte, binary.BigEndian.Uint16(l[:]))
+	_, err = io.ReadFull(r, res)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read encryption header: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package encryption
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+)
+
+func newKey(t *testing.T) []byte {
+	key := make([]byte, KeySize)
+	_, err := rand.Read(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func encrypt(t *testing.T, kr *KeyRing, data []byte) []byte {
+	var buf bytes.Buffer
+	err := kr.Encrypt("owner", &buf, bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decrypt(kr *KeyRing, data []byte) ([]byte, error) {
+	rd, err := kr.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(rd)
+}
+
+func TestRoundTrip(t *testing.T) {
+	kr, err := NewKeyRing(newKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, size := range []int{0, 1, segmentSize - 1, segmentSize, segmentSize + 1, 3 * segmentSize, 3*segmentSize + 42} {
+		data := make([]byte, size)
+		_, _ = rand.Read(data)
+
+		enc := encrypt(t, kr, data)
+		if encrypted, err := IsEncrypted(bufio.NewReader(bytes.NewReader(enc))); err != nil || !encrypted {
+			t.Errorf("size %d: encrypted content is not recognised: %v", size, err)
+		}
+
+		act, err := decrypt(kr, enc)
+		if err != nil {
+			t.Errorf("size %d: %v", size, err)
+			continue
+		}
+		if !bytes.Equal(act, data) {
+			t.Errorf("size %d: decrypted content does not match", size)
+		}
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldKey, newKey := newKey(t), newKey(t)
+	oldKR, err := NewKeyRing(oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc := encrypt(t, oldKR, []byte("hello world"))
+
+	rotated, err := NewKeyRing(newKey, oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	act, err := decrypt(rotated, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(act) != "hello world" {
+		t.Errorf("unexpected content: %q", act)
+	}
+
+	unrelated, err := NewKeyRing(newKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = decrypt(unrelated, enc)
+	if !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expected ErrUnknownKey, got %v", err)
+	}
+}
+
+func TestTampering(t *testing.T) {
+	kr, err := NewKeyRing(newKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := make([]byte, 2*segmentSize+100)
+	enc := encrypt(t, kr, data)
+
+	tests := []struct {
+		Name    string
+		Content []byte
+	}{
+		{Name: "flipped bit", Content: func() []byte {
+			c := append([]byte{}, enc...)
+			c[len(c)-20] ^= 1
+			return c
+		}()},
+		{Name: "truncated at segment boundary", Content: enc[:len(enc)-(1+100+16)]},
+		{Name: "truncated", Content: enc[:len(enc)-1]},
+		{Name: "trailing data", Content: append(append([]byte{}, enc...), 0)},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := decrypt(kr, test.Content)
+			if err == nil {
+				t.Error("expected tampered content to fail decryption")
+			}
+		})
+	}
+}
+
+func TestOwnerKeyRing(t *testing.T) {
+	oldKey, newKey := newKey(t), newKey(t)
+	oldKR, err := NewKeyRing(oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kr, err := NewKeyRing(newKey, oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var foreign bytes.Buffer
+	err = kr.Encrypt("someone-else", &foreign, bytes.NewReader([]byte("secret")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hdr, err := ReadHeader(bytes.NewReader(foreign.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hdr.Owner != "someone-else" || hdr.KeyID != kr.PrimaryKeyID() {
+		t.Errorf("unexpected header: %+v", hdr)
+	}
+	_, err = ReadHeader(bytes.NewReader([]byte("plain content")))
+	if !errors.Is(err, ErrNotEncrypted) {
+		t.Errorf("expected ErrNotEncrypted for plain content, got %v", err)
+	}
+
+	okr, err := NewOwnerKeyRing(map[string]map[string][]byte{"owner": kr.OwnerKeys("owner")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, enc := range map[string][]byte{
+		"primary key":  encrypt(t, kr, []byte("hello world")),
+		"previous key": encrypt(t, oldKR, []byte("hello world")),
+	} {
+		act, err := decrypt(okr, enc)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if string(act) != "hello world" {
+			t.Errorf("%s: unexpected content: %q", name, act)
+		}
+	}
+
+	_, err = decrypt(okr, foreign.Bytes())
+	if !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expected content of another owner to fail decryption with ErrUnknownKey, got %v", err)
+	}
+
+	// content is decrypted with the data keys of the owner who encrypted it, no matter who else's keys are present
+	both, err := NewOwnerKeyRing(map[string]map[string][]byte{
+		"owner":        kr.OwnerKeys("owner"),
+		"someone-else": kr.OwnerKeys("someone-else"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	act, err := decrypt(both, foreign.Bytes())
+	if err != nil {
+		t.Fatalf("cannot decrypt content of another owner with their keys: %v", err)
+	}
+	if string(act) != "secret" {
+		t.Errorf("unexpected content: %q", act)
+	}
+	err = okr.Encrypt("owner", io.Discard, bytes.NewReader(nil))
+	if err == nil {
+		t.Error("expected owner key ring to refuse encryption")
+	}
+}
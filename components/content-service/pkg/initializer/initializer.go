@@ -220,7 +220,7 @@ func (bi *fromBackupInitializer) Run(ctx context.Context, mappings []archive.IDM
 		log.WithError(fsErr).Error("could not get disk usage")
 	}
 
-	hasBackup, err := bi.RemoteStorage.Download(ctx, bi.Location, storage.DefaultBackup, mappings)
+	hasBackup, err := bi.download(ctx, mappings)
 	if !hasBackup {
 		if err != nil {
 			return src, nil, xerrors.Errorf("no backup found, error: %w", err)
@@ -247,6 +247,18 @@ func (bi *fromBackupInitializer) Run(ctx context.Context, mappings []archive.IDM
 	return csapi.WorkspaceInitFromBackup, stats, nil
 }
 
+// download restores the chunked backup if there is one, and the legacy full tar backup otherwise
+func (bi *fromBackupInitializer) download(ctx context.Context, mappings []archive.IDMapping) (found bool, err error) {
+	if cd, ok := bi.RemoteStorage.(storage.ChunkedDownloader); ok {
+		found, err = cd.DownloadChunked(ctx, bi.Location, storage.DefaultChunkedBackup, mappings)
+		if found || err != nil {
+			return found, err
+		}
+	}
+
+	return bi.RemoteStorage.Download(ctx, bi.Location, storage.DefaultBackup, mappings)
+}
+
 // newGitInitializer creates a Git initializer based on the request.
 // Returns gRPC errors.
 func newGitInitializer(ctx context.Context, loc string, req *csapi.GitInitializer, forceGitpodUser bool) (*GitInitializer, error) {
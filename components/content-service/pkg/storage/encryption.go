@@ -0,0 +1,148 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+	"github.com/gitpod-io/gitpod/content-service/pkg/encryption"
+)
+
+// ObjectAnnotationEncryptionKeyID is the ID of the key-encryption key protecting the object, if the object is encrypted
+const ObjectAnnotationEncryptionKeyID = "gitpod-encryption-keyid"
+
+// WithoutEncryption stores the object as is, even if encryption is configured.
+// Use this for objects which hold no workspace content, e.g. manifests.
+func WithoutEncryption() UploadOption {
+	return func(opts *UploadOptions) error {
+		opts.Plaintext = true
+		return nil
+	}
+}
+
+type keyRingContextKey struct{}
+
+// DecryptIfEncrypted returns a reader which decrypts r if its content is encrypted.
+// Plain content is passed through as is, so that backups made before encryption was enabled remain readable.
+func DecryptIfEncrypted(ctx context.Context, r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	encrypted, err := encryption.IsEncrypted(br)
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		return br, nil
+	}
+
+	kr, _ := ctx.Value(keyRingContextKey{}).(*encryption.KeyRing)
+	if kr == nil {
+		return nil, xerrors.Errorf("content is encrypted but no encryption keys are configured")
+	}
+	return kr.NewReader(br)
+}
+
+// WithDecryption makes rs decrypt encrypted content during download
+func WithDecryption(rs DirectDownloader, kr *encryption.KeyRing) DirectDownloader {
+	if kr == nil {
+		return rs
+	}
+	return &decryptingDownloader{Delegate: rs, KeyRing: kr}
+}
+
+type decryptingDownloader struct {
+	Delegate DirectDownloader
+	KeyRing  *encryption.KeyRing
+}
+
+// Download implements DirectDownloader
+func (d *decryptingDownloader) Download(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (found bool, err error) {
+	return d.Delegate.Download(context.WithValue(ctx, keyRingContextKey{}, d.KeyRing), destination, name, mappings)
+}
+
+// DownloadSnapshot implements DirectDownloader
+func (d *decryptingDownloader) DownloadSnapshot(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (found bool, err error) {
+	return d.Delegate.DownloadSnapshot(context.WithValue(ctx, keyRingContextKey{}, d.KeyRing), destination, name, mappings)
+}
+
+// DownloadChunked implements ChunkedDownloader
+func (d *decryptingDownloader) DownloadChunked(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (found bool, err error) {
+	cd, ok := d.Delegate.(ChunkedDownloader)
+	if !ok {
+		return false, nil
+	}
+	return cd.DownloadChunked(context.WithValue(ctx, keyRingContextKey{}, d.KeyRing), destination, name, mappings)
+}
+
+// encryptingStorage encrypts all backups before they leave the node
+type encryptingStorage struct {
+	DirectAccess
+	KeyRing *encryption.KeyRing
+
+	owner string
+}
+
+func newEncryptingStorage(rs DirectAccess, kr *encryption.KeyRing) *encryptingStorage {
+	return &encryptingStorage{DirectAccess: rs, KeyRing: kr}
+}
+
+// Init implements DirectAccess
+func (s *encryptingStorage) Init(ctx context.Context, owner, workspace, instance string) error {
+	s.owner = owner
+	return s.DirectAccess.Init(ctx, owner, workspace, instance)
+}
+
+// Download implements DirectAccess
+func (s *encryptingStorage) Download(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (found bool, err error) {
+	return s.DirectAccess.Download(context.WithValue(ctx, keyRingContextKey{}, s.KeyRing), destination, name, mappings)
+}
+
+// DownloadSnapshot implements DirectAccess
+func (s *encryptingStorage) DownloadSnapshot(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (found bool, err error) {
+	return s.DirectAccess.DownloadSnapshot(context.WithValue(ctx, keyRingContextKey{}, s.KeyRing), destination, name, mappings)
+}
+
+// Upload implements DirectAccess
+func (s *encryptingStorage) Upload(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, obj string, err error) {
+	options, err := GetUploadOptions(opts)
+	if err != nil {
+		return "", "", err
+	}
+	if options.Plaintext {
+		return s.DirectAccess.Upload(ctx, source, name, opts...)
+	}
+	if s.owner == "" {
+		return "", "", xerrors.Errorf("cannot encrypt %s: storage is not initialized", name)
+	}
+
+	// callers stage uploads in their configured temp dir, hence we keep the encrypted copy next to it
+	f, err := os.CreateTemp(filepath.Dir(source), "encrypted-upload-*")
+	if err != nil {
+		return "", "", err
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	err = s.KeyRing.EncryptFile(s.owner, source, f.Name())
+	if err != nil {
+		return "", "", xerrors.Errorf("cannot encrypt %s: %w", name, err)
+	}
+
+	annotations := make(map[string]string, len(options.Annotations)+1)
+	for k, v := range options.Annotations {
+		annotations[k] = v
+	}
+	annotations[ObjectAnnotationEncryptionKeyID] = s.KeyRing.PrimaryKeyID()
+
+	// the annotation option must come last to replace any annotations passed by the caller
+	opts = append(opts, WithAnnotations(annotations))
+	return s.DirectAccess.Upload(ctx, f.Name(), name, opts...)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/encryption"
+)
+
+func TestEncryptingStorage(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.FileConfig{Path: t.TempDir()}
+
+	key := make([]byte, encryption.KeySize)
+	_, _ = rand.Read(key)
+	kr, err := encryption.NewKeyRing(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs, err := newDirectFileAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := newEncryptingStorage(fs, kr)
+	err = rs.Init(ctx, "owner", "workspace", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(t.TempDir(), "backup.tar")
+	f, err := os.Create(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw := tar.NewWriter(f)
+	_ = tw.WriteHeader(&tar.Header{Name: "hello.txt", Mode: 0644, Size: 11, Typeflag: tar.TypeReg})
+	_, _ = tw.Write([]byte("hello world"))
+	_ = tw.Close()
+	f.Close()
+
+	bkt, obj, err := rs.Upload(ctx, src, DefaultBackup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = rs.Upload(ctx, src, "plain.tar", WithoutEncryption())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isEncrypted := func(obj string) bool {
+		fn, err := fileObjectPath(cfg.Path, bkt, obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := os.Open(fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		res, err := encryption.IsEncrypted(bufio.NewReader(f))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+	if !isEncrypted(obj) {
+		t.Error("expected backup to be stored encrypted")
+	}
+	if isEncrypted(fs.BackupObject("plain.tar")) {
+		t.Error("expected object uploaded without encryption to be stored in plain")
+	}
+
+	dst := t.TempDir()
+	found, err := rs.Download(ctx, dst, DefaultBackup, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatal("backup not found")
+	}
+	fc, err := os.ReadFile(filepath.Join(dst, "hello.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(fc) != "hello world" {
+		t.Errorf("unexpected content: %q", fc)
+	}
+
+	_, err = fs.Download(ctx, t.TempDir(), DefaultBackup, nil)
+	if err == nil {
+		t.Error("expected download without encryption keys to fail")
+	}
+}
@@ -0,0 +1,890 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+)
+
+const (
+	// fileMetaDir is the directory below the storage path where object metadata is kept
+	fileMetaDir = ".meta"
+	// fileTempDir is the directory below the storage path where uploads are staged before they're moved in place
+	fileTempDir = ".tmp"
+
+	fileSignedURLExpiry = 30 * time.Minute
+	// fileDefaultMaxUploadSize is the largest object which can be uploaded using a signed URL by default,
+	// which is the same as the largest single PUT S3 accepts
+	fileDefaultMaxUploadSize = 5 * 1024 * 1024 * 1024
+
+	fileURLParamExpires     = "expires"
+	fileURLParamContentType = "contentType"
+	fileURLParamSignature   = "signature"
+)
+
+// fileAnnotationHeaderPrefixes are the prefixes of the headers which annotate signed uploads. They are the
+// same that clients use for the metadata of uploads to S3 and GCS.
+var fileAnnotationHeaderPrefixes = []string{"X-Amz-Meta-", "X-Goog-Meta-"}
+
+// fileKnownAnnotations are matched case-insensitively, as header names are canonicalized
+var fileKnownAnnotations = []string{
+	ObjectAnnotationDigest,
+	ObjectAnnotationUncompressedDigest,
+	ObjectAnnotationOCIContentType,
+}
+
+var _ DirectAccess = &DirectFileStorage{}
+var _ PresignedAccess = &presignedFileStorage{}
+
+// ValidateFileConfig checks if the file storage config is valid
+func ValidateFileConfig(c *config.FileConfig) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Path, validation.Required),
+	)
+}
+
+// validatePresignedFileConfig checks if the file storage config is valid to produce signed URLs
+func validatePresignedFileConfig(c *config.FileConfig) error {
+	err := ValidateFileConfig(c)
+	if err != nil {
+		return err
+	}
+	return validation.ValidateStruct(c,
+		validation.Field(&c.URL, validation.Required),
+		validation.Field(&c.SigningKey, validation.Required),
+	)
+}
+
+// addFileParamsFromMounts allows for the signing key to be read from a file
+func addFileParamsFromMounts(c *config.FileConfig) error {
+	if c.SigningKeyFile != "" {
+		value, err := os.ReadFile(c.SigningKeyFile)
+		if err != nil {
+			return err
+		}
+		c.SigningKey = strings.TrimSpace(string(value))
+	}
+	return nil
+}
+
+// newDirectFileAccess provides direct access to the remote storage system
+func newDirectFileAccess(cfg *config.FileConfig) (*DirectFileStorage, error) {
+	if cfg == nil {
+		return nil, xerrors.Errorf("missing file storage config")
+	}
+	if err := ValidateFileConfig(cfg); err != nil {
+		return nil, err
+	}
+	return &DirectFileStorage{FileConfig: *cfg}, nil
+}
+
+// DirectFileStorage implements a local directory as remote storage backend
+type DirectFileStorage struct {
+	Username      string
+	WorkspaceName string
+	InstanceID    string
+	FileConfig    config.FileConfig
+}
+
+// Validate checks if the file storage is configured properly
+func (rs *DirectFileStorage) Validate() error {
+	err := ValidateFileConfig(&rs.FileConfig)
+	if err != nil {
+		return err
+	}
+
+	return validation.ValidateStruct(rs,
+		validation.Field(&rs.Username, validation.Required),
+		validation.Field(&rs.WorkspaceName, validation.Required),
+	)
+}
+
+// Init initializes the remote storage - call this before calling anything else on the interface
+func (rs *DirectFileStorage) Init(ctx context.Context, owner, workspace, instance string) (err error) {
+	rs.Username = owner
+	rs.WorkspaceName = workspace
+	rs.InstanceID = instance
+
+	return rs.Validate()
+}
+
+// EnsureExists makes sure that the remote storage location exists and can be up- or downloaded from
+func (rs *DirectFileStorage) EnsureExists(ctx context.Context) (err error) {
+	return fileEnsureExists(rs.FileConfig.Path, rs.bucketName())
+}
+
+func (rs *DirectFileStorage) download(ctx context.Context, destination string, bkt string, obj string, mappings []archive.IDMapping) (found bool, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "download")
+	span.SetTag("bucket", bkt)
+	span.SetTag("object", obj)
+	defer tracing.FinishSpan(span, &err)
+
+	fn, err := fileObjectPath(rs.FileConfig.Path, bkt, obj)
+	if err != nil {
+		return false, err
+	}
+	f, err := os.Open(fn)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	err = extractTarbal(ctx, destination, f, mappings)
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// Download takes the latest state from the remote storage and downloads it to a local path
+func (rs *DirectFileStorage) Download(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (bool, error) {
+	return rs.download(ctx, destination, rs.bucketName(), rs.objectName(name), mappings)
+}
+
+// DownloadSnapshot downloads a snapshot. The snapshot name is expected to be one produced by Qualify
+func (rs *DirectFileStorage) DownloadSnapshot(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (bool, error) {
+	bkt, obj, err := ParseSnapshotName(name)
+	if err != nil {
+		return false, err
+	}
+
+	return rs.download(ctx, destination, bkt, obj, mappings)
+}
+
+// ListObjects returns all objects found with the given prefix. Returns an empty list if the bucket does not exuist (yet).
+func (rs *DirectFileStorage) ListObjects(ctx context.Context, prefix string) (objects []string, err error) {
+	infos, err := listFileObjects(rs.FileConfig.Path, rs.bucketName(), prefix)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list objects: %w", err)
+	}
+	for _, info := range infos {
+		objects = append(objects, info.Name)
+	}
+	return objects, nil
+}
+
+// Qualify fully qualifies a snapshot name so that it can be downloaded using DownloadSnapshot
+func (rs *DirectFileStorage) Qualify(name string) string {
+	return fmt.Sprintf("%s@%s", rs.objectName(name), rs.bucketName())
+}
+
+// UploadInstance takes all files from a local location and uploads it to the per-instance remote storage
+func (rs *DirectFileStorage) UploadInstance(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, object string, err error) {
+	if rs.InstanceID == "" {
+		return "", "", xerrors.Errorf("instanceID is required to comput object name")
+	}
+	return rs.Upload(ctx, source, InstanceObjectName(rs.InstanceID, name), opts...)
+}
+
+// Upload takes all files from a local location and uploads it to the remote storage
+func (rs *DirectFileStorage) Upload(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, obj string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DirectUpload")
+	defer tracing.FinishSpan(span, &err)
+
+	options, err := GetUploadOptions(opts)
+	if err != nil {
+		err = xerrors.Errorf("cannot get options: %w", err)
+		return
+	}
+
+	f, err := os.Open(source)
+	if err != nil {
+		err = xerrors.Errorf("cannot read backup file: %w", err)
+		return
+	}
+	defer f.Close()
+
+	bucket = rs.bucketName()
+	obj = rs.objectName(name)
+	span.LogKV("bucket", bucket)
+	span.LogKV("obj", obj)
+
+	err = writeFileObject(rs.FileConfig.Path, bucket, obj, f, &fileObjectMeta{
+		ContentType: options.ContentType,
+		Annotations: options.Annotations,
+	})
+	return
+}
+
+// Bucket provides the bucket name for a particular user
+func (rs *DirectFileStorage) Bucket(ownerID string) string {
+	return fileBucketName(ownerID, rs.FileConfig.BucketName)
+}
+
+// BackupObject returns a backup's object name that a direct downloader would download
+func (rs *DirectFileStorage) BackupObject(name string) string {
+	return rs.objectName(name)
+}
+
+func (rs *DirectFileStorage) bucketName() string {
+	return fileBucketName(rs.Username, rs.FileConfig.BucketName)
+}
+
+func (rs *DirectFileStorage) objectName(name string) string {
+	var username string
+	if rs.FileConfig.BucketName != "" {
+		username = rs.Username
+	}
+	return fileWorkspaceBackupObjectName(username, rs.WorkspaceName, name)
+}
+
+func newPresignedFileAccess(cfg *config.FileConfig) (*presignedFileStorage, error) {
+	if cfg == nil {
+		return nil, xerrors.Errorf("missing file storage config")
+	}
+	c := *cfg
+	err := addFileParamsFromMounts(&c)
+	if err != nil {
+		return nil, err
+	}
+	err = validatePresignedFileConfig(&c)
+	if err != nil {
+		return nil, err
+	}
+	return &presignedFileStorage{FileConfig: c}, nil
+}
+
+type presignedFileStorage struct {
+	FileConfig config.FileConfig
+}
+
+// EnsureExists makes sure that the remote storage location exists and can be up- or downloaded from
+func (s *presignedFileStorage) EnsureExists(ctx context.Context, bucket string) (err error) {
+	return fileEnsureExists(s.FileConfig.Path, bucket)
+}
+
+// DiskUsage gives the total objects size of objects that have the given prefix
+func (s *presignedFileStorage) DiskUsage(ctx context.Context, bucket string, prefix string) (size int64, err error) {
+	infos, err := listFileObjects(s.FileConfig.Path, bucket, prefix)
+	if err != nil {
+		return 0, err
+	}
+	for _, info := range infos {
+		size += info.Size
+	}
+	return size, nil
+}
+
+// SignDownload describes an object for download - if the object is not found, ErrNotFound is returned
+func (s *presignedFileStorage) SignDownload(ctx context.Context, bucket, object string, options *SignedURLOptions) (info *DownloadInfo, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "file.SignDownload")
+	defer func() {
+		if err == ErrNotFound {
+			span.LogKV("found", false)
+			tracing.FinishSpan(span, nil)
+			return
+		}
+
+		tracing.FinishSpan(span, &err)
+	}()
+
+	fn, err := fileObjectPath(s.FileConfig.Path, bucket, object)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(fn)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	meta, err := readFileObjectMeta(s.FileConfig.Path, bucket, object)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := signFileURL(&s.FileConfig, http.MethodGet, bucket, object, "", time.Now().Add(fileSignedURLExpiry))
+	if err != nil {
+		return nil, err
+	}
+
+	return &DownloadInfo{
+		Meta: ObjectMeta{
+			ContentType:        meta.ContentType,
+			OCIMediaType:       meta.Annotations[ObjectAnnotationOCIContentType],
+			Digest:             meta.Annotations[ObjectAnnotationDigest],
+			UncompressedDigest: meta.Annotations[ObjectAnnotationUncompressedDigest],
+		},
+		Size:         stat.Size(),
+		URL:          url,
+		LastModified: stat.ModTime(),
+	}, nil
+}
+
+// SignUpload describes an object for upload
+func (s *presignedFileStorage) SignUpload(ctx context.Context, bucket, obj string, options *SignedURLOptions) (info *UploadInfo, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "file.SignUpload")
+	defer tracing.FinishSpan(span, &err)
+
+	var contentType string
+	if options != nil {
+		contentType = options.ContentType
+	}
+
+	url, err := signFileURL(&s.FileConfig, http.MethodPut, bucket, obj, contentType, time.Now().Add(fileSignedURLExpiry))
+	if err != nil {
+		return nil, err
+	}
+	return &UploadInfo{URL: url}, nil
+}
+
+// DeleteObject deletes objects in the given bucket specified by the given query
+func (s *presignedFileStorage) DeleteObject(ctx context.Context, bucket string, query *DeleteObjectQuery) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "file.DeleteObject")
+	defer tracing.FinishSpan(span, &err)
+
+	var names []string
+	switch {
+	case query.Name != "":
+		names = []string{query.Name}
+	case query.Prefix != "":
+		infos, err := listFileObjects(s.FileConfig.Path, bucket, query.Prefix)
+		if err != nil {
+			return err
+		}
+		for _, info := range infos {
+			names = append(names, info.Name)
+		}
+	}
+
+	for _, name := range names {
+		err = deleteFileObject(s.FileConfig.Path, bucket, name)
+		if err != nil {
+			log.WithField("bucket", bucket).WithField("object", name).Error(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteBucket deletes a bucket
+func (s *presignedFileStorage) DeleteBucket(ctx context.Context, userID, bucket string) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "file.DeleteBucket")
+	defer tracing.FinishSpan(span, &err)
+
+	if s.FileConfig.BucketName != "" {
+		// all users share the same bucket - we must only delete the user's content
+		return s.DeleteObject(ctx, bucket, &DeleteObjectQuery{Prefix: userID + "/"})
+	}
+
+	err = validateFileBucketName(bucket)
+	if err != nil {
+		return err
+	}
+	err = os.RemoveAll(filepath.Join(s.FileConfig.Path, bucket))
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(s.FileConfig.Path, fileMetaDir, bucket))
+}
+
+// ObjectHash gets a hash value of an object
+func (s *presignedFileStorage) ObjectHash(ctx context.Context, bucket string, obj string) (hash string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "file.ObjectHash")
+	defer tracing.FinishSpan(span, &err)
+
+	fn, err := fileObjectPath(s.FileConfig.Path, bucket, obj)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(fn)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ObjectExists tells whether the given object exists or not
+func (s *presignedFileStorage) ObjectExists(ctx context.Context, bucket, obj string) (exists bool, err error) {
+	fn, err := fileObjectPath(s.FileConfig.Path, bucket, obj)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(fn)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Bucket provides the bucket name for a particular user
+func (s *presignedFileStorage) Bucket(ownerID string) string {
+	return fileBucketName(ownerID, s.FileConfig.BucketName)
+}
+
+// BlobObject returns a blob's object name
+func (s *presignedFileStorage) BlobObject(userID, name string) (string, error) {
+	blb, err := blobObjectName(name)
+	if err != nil {
+		return "", err
+	}
+	if s.FileConfig.BucketName != "" {
+		return filepath.Join(userID, blb), nil
+	}
+	return blb, nil
+}
+
+// BackupObject returns a backup's object name that a direct downloader would download
+func (s *presignedFileStorage) BackupObject(ownerID string, workspaceID, name string) string {
+	var username string
+	if s.FileConfig.BucketName != "" {
+		username = ownerID
+	}
+	return fileWorkspaceBackupObjectName(username, workspaceID, name)
+}
+
+// InstanceObject returns a instance's object name that a direct downloader would download
+func (s *presignedFileStorage) InstanceObject(ownerID string, workspaceID string, instanceID string, name string) string {
+	return s.BackupObject(ownerID, workspaceID, InstanceObjectName(instanceID, name))
+}
+
+// NewFileStorageHandler produces an HTTP handler which serves the up- and download URLs signed by the
+// file storage. It returns the path under which the handler expects to be registered.
+func NewFileStorageHandler(cfg *config.FileConfig) (pattern string, handler http.Handler, err error) {
+	s, err := newPresignedFileAccess(cfg)
+	if err != nil {
+		return "", nil, err
+	}
+	u, err := url.Parse(s.FileConfig.URL)
+	if err != nil {
+		return "", nil, xerrors.Errorf("invalid file storage URL: %w", err)
+	}
+
+	prefix := strings.TrimSuffix(u.Path, "/")
+	return prefix + "/", http.StripPrefix(prefix, &fileStorageHandler{Config: s.FileConfig}), nil
+}
+
+type fileStorageHandler struct {
+	Config config.FileConfig
+}
+
+// ServeHTTP implements http.Handler
+func (h *fileStorageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodPut {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	segs := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	if len(segs) != 2 || segs[0] == "" || segs[1] == "" {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	bucket, obj := segs[0], segs[1]
+
+	method := r.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	query := r.URL.Query()
+	contentType := query.Get(fileURLParamContentType)
+	err := verifyFileURL(h.Config.SigningKey, method, bucket, obj, contentType, query.Get(fileURLParamExpires), query.Get(fileURLParamSignature))
+	if err != nil {
+		log.WithError(err).WithField("bucket", bucket).WithField("object", obj).Debug("rejecting file storage request")
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		h.serveUpload(w, r, bucket, obj, contentType)
+		return
+	}
+	h.serveDownload(w, r, bucket, obj)
+}
+
+func (h *fileStorageHandler) serveDownload(w http.ResponseWriter, r *http.Request, bucket, obj string) {
+	fn, err := fileObjectPath(h.Config.Path, bucket, obj)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f, err := os.Open(fn)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField("bucket", bucket).WithField("object", obj).Error("cannot open object")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	meta, err := readFileObjectMeta(h.Config.Path, bucket, obj)
+	if err != nil {
+		log.WithError(err).WithField("bucket", bucket).WithField("object", obj).Warn("cannot read object metadata")
+	} else if meta.ContentType != "" {
+		w.Header().Set("Content-Type", meta.ContentType)
+	}
+	http.ServeContent(w, r, "", stat.ModTime(), f)
+}
+
+func (h *fileStorageHandler) serveUpload(w http.ResponseWriter, r *http.Request, bucket, obj, contentType string) {
+	if contentType != "" && r.Header.Get("Content-Type") != contentType {
+		http.Error(w, "content type does not match signed URL", http.StatusForbidden)
+		return
+	}
+	if contentType == "" {
+		contentType = r.Header.Get("Content-Type")
+	}
+
+	maxSize := h.Config.MaxUploadSize
+	if maxSize <= 0 {
+		maxSize = fileDefaultMaxUploadSize
+	}
+	if r.ContentLength > maxSize {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+	body := http.MaxBytesReader(w, r.Body, maxSize)
+
+	err := writeFileObject(h.Config.Path, bucket, obj, body, &fileObjectMeta{
+		ContentType: contentType,
+		Annotations: fileAnnotationsFromHeader(r.Header),
+	})
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField("bucket", bucket).WithField("object", obj).Error("cannot store object")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// fileAnnotationsFromHeader returns the annotations of an upload, which are passed as metadata headers
+func fileAnnotationsFromHeader(header http.Header) map[string]string {
+	var res map[string]string
+	for key, values := range header {
+		if len(values) == 0 {
+			continue
+		}
+		for _, prefix := range fileAnnotationHeaderPrefixes {
+			if !strings.HasPrefix(key, prefix) || len(key) == len(prefix) {
+				continue
+			}
+
+			name := strings.ToLower(strings.TrimPrefix(key, prefix))
+			for _, known := range fileKnownAnnotations {
+				if strings.EqualFold(name, known) {
+					name = known
+					break
+				}
+			}
+			if res == nil {
+				res = make(map[string]string)
+			}
+			res[name] = values[0]
+			break
+		}
+	}
+	return res
+}
+
+func signFileURL(cfg *config.FileConfig, method, bucket, obj, contentType string, expires time.Time) (string, error) {
+	_, err := fileObjectPath(cfg.Path, bucket, obj)
+	if err != nil {
+		return "", err
+	}
+
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	params := url.Values{}
+	params.Set(fileURLParamExpires, exp)
+	if contentType != "" {
+		params.Set(fileURLParamContentType, contentType)
+	}
+	params.Set(fileURLParamSignature, fileURLSignature(cfg.SigningKey, method, bucket, obj, contentType, exp))
+
+	segs := strings.Split(obj, "/")
+	for i := range segs {
+		segs[i] = url.PathEscape(segs[i])
+	}
+	return fmt.Sprintf("%s/%s/%s?%s", strings.TrimSuffix(cfg.URL, "/"), url.PathEscape(bucket), strings.Join(segs, "/"), params.Encode()), nil
+}
+
+func verifyFileURL(key, method, bucket, obj, contentType, expires, signature string) error {
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return xerrors.Errorf("invalid expiry: %w", err)
+	}
+	if time.Now().After(time.Unix(exp, 0)) {
+		return xerrors.Errorf("URL has expired")
+	}
+
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return xerrors.Errorf("invalid signature: %w", err)
+	}
+	expected, _ := hex.DecodeString(fileURLSignature(key, method, bucket, obj, contentType, expires))
+	if !hmac.Equal(sig, expected) {
+		return xerrors.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+func fileURLSignature(key, method, bucket, obj, contentType, expires string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n%s", method, bucket, obj, contentType, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// fileObjectMeta is the metadata we keep next to each object
+type fileObjectMeta struct {
+	ContentType string            `json:"contentType,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type fileObjectInfo struct {
+	Name string
+	Size int64
+}
+
+func fileBucketName(ownerID, bucketName string) string {
+	if bucketName != "" {
+		return bucketName
+	}
+
+	return fmt.Sprintf("gitpod-user-%s", ownerID)
+}
+
+func fileWorkspaceBackupObjectName(ownerID, workspaceID, name string) string {
+	return filepath.Join(ownerID, "workspaces", workspaceID, name)
+}
+
+func validateFileBucketName(bucket string) error {
+	if bucket == "" || strings.HasPrefix(bucket, ".") || strings.ContainsAny(bucket, `/\`) {
+		return xerrors.Errorf("invalid bucket name: %q", bucket)
+	}
+	return nil
+}
+
+// fileObjectPath returns the location of an object on disk and makes sure it does not escape its bucket
+func fileObjectPath(root, bucket, obj string) (string, error) {
+	err := validateFileBucketName(bucket)
+	if err != nil {
+		return "", err
+	}
+
+	clean := path.Clean("/" + obj)
+	if clean == "/" || clean != "/"+obj {
+		return "", xerrors.Errorf("invalid object name: %q", obj)
+	}
+	return filepath.Join(root, bucket, filepath.FromSlash(clean)), nil
+}
+
+func fileMetaPath(root, bucket, obj string) (string, error) {
+	fn, err := fileObjectPath(filepath.Join(root, fileMetaDir), bucket, obj)
+	if err != nil {
+		return "", err
+	}
+	return fn + ".json", nil
+}
+
+func fileEnsureExists(root, bucket string) error {
+	err := validateFileBucketName(bucket)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Join(root, bucket), 0755)
+	if err != nil {
+		return xerrors.Errorf("cannot create bucket: %w", err)
+	}
+	return nil
+}
+
+// writeFileObject stores an object and its metadata. The object is staged in a temporary file
+// and moved in place once complete, so that readers never observe partial content.
+func writeFileObject(root, bucket, obj string, src io.Reader, meta *fileObjectMeta) (err error) {
+	dst, err := fileObjectPath(root, bucket, obj)
+	if err != nil {
+		return err
+	}
+	metaDst, err := fileMetaPath(root, bucket, obj)
+	if err != nil {
+		return err
+	}
+
+	tmpdir := filepath.Join(root, fileTempDir)
+	err = os.MkdirAll(tmpdir, 0755)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(tmpdir, "upload-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	_, err = io.Copy(tmp, src)
+	if err != nil {
+		tmp.Close()
+		return xerrors.Errorf("cannot write object: %w", err)
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	for _, fn := range []string{dst, metaDst} {
+		err = os.MkdirAll(filepath.Dir(fn), 0755)
+		if err != nil {
+			return err
+		}
+	}
+
+	md, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(metaDst, md, 0644)
+	if err != nil {
+		return xerrors.Errorf("cannot write object metadata: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), dst)
+}
+
+func readFileObjectMeta(root, bucket, obj string) (*fileObjectMeta, error) {
+	fn, err := fileMetaPath(root, bucket, obj)
+	if err != nil {
+		return nil, err
+	}
+	var res fileObjectMeta
+	fc, err := os.ReadFile(fn)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(fc, &res)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal object metadata: %w", err)
+	}
+	return &res, nil
+}
+
+func deleteFileObject(root, bucket, obj string) error {
+	fn, err := fileObjectPath(root, bucket, obj)
+	if err != nil {
+		return err
+	}
+	metaFn, err := fileMetaPath(root, bucket, obj)
+	if err != nil {
+		return err
+	}
+	for _, f := range []string{fn, metaFn} {
+		err = os.Remove(f)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// listFileObjects lists all objects in a bucket whose name starts with prefix.
+// Returns an empty list if the bucket does not exist (yet).
+func listFileObjects(root, bucket, prefix string) ([]fileObjectInfo, error) {
+	err := validateFileBucketName(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	bucketDir := filepath.Join(root, bucket)
+	var res []fileObjectInfo
+	err = filepath.WalkDir(bucketDir, func(fn string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(bucketDir, fn)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if !strings.HasPrefix(name, strings.TrimPrefix(prefix, "/")) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		res = append(res, fileObjectInfo{Name: name, Size: info.Size()})
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
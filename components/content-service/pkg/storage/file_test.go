@@ -0,0 +1,304 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+)
+
+func TestFileObjectPath(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Bucket        string
+		Object        string
+		Expectation   string
+		ExpectedError bool
+	}{
+		{Name: "valid object", Bucket: "gitpod-user-foo", Object: "workspaces/ws/full.tar", Expectation: "/data/gitpod-user-foo/workspaces/ws/full.tar"},
+		{Name: "escaping object", Bucket: "gitpod-user-foo", Object: "../gitpod-user-bar/full.tar", ExpectedError: true},
+		{Name: "unclean object", Bucket: "gitpod-user-foo", Object: "workspaces/../../full.tar", ExpectedError: true},
+		{Name: "empty object", Bucket: "gitpod-user-foo", Object: "", ExpectedError: true},
+		{Name: "hidden bucket", Bucket: fileMetaDir, Object: "full.tar", ExpectedError: true},
+		{Name: "nested bucket", Bucket: "foo/bar", Object: "full.tar", ExpectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := fileObjectPath("/data", test.Bucket, test.Object)
+			if (err != nil) != test.ExpectedError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if act != test.Expectation {
+				t.Errorf("unexpected path: is '%s' but expected '%s'", act, test.Expectation)
+			}
+		})
+	}
+}
+
+func TestFileStorageDirectAccess(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.FileConfig{Path: t.TempDir()}
+
+	rs, err := newDirectFileAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rs.Init(ctx, "owner", "workspace", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(t.TempDir(), "backup")
+	err = os.WriteFile(src, []byte("hello world"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bkt, obj, err := rs.Upload(ctx, src, DefaultBackup, WithAnnotations(map[string]string{ObjectAnnotationDigest: "sha256:foo"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bkt != "gitpod-user-owner" || obj != "workspaces/workspace/full.tar" {
+		t.Errorf("unexpected upload location: %s@%s", obj, bkt)
+	}
+	_, _, err = rs.UploadInstance(ctx, src, "logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objs, err := rs.ListObjects(ctx, "workspaces/workspace/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"workspaces/workspace/full.tar", "workspaces/workspace/instances/instance/logs"}, objs); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%s", diff)
+	}
+
+	found, err := rs.Download(ctx, t.TempDir(), "does-not-exist", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found {
+		t.Errorf("expected non-existent object not to be found")
+	}
+
+	ps, err := newPresignedFileAccess(&config.FileConfig{Path: cfg.Path, URL: "http://localhost/storage", SigningKey: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	size, err := ps.DiskUsage(ctx, bkt, "workspaces/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 22 {
+		t.Errorf("unexpected disk usage: %d", size)
+	}
+	info, err := ps.SignDownload(ctx, bkt, obj, &SignedURLOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Meta.Digest != "sha256:foo" {
+		t.Errorf("unexpected digest: %s", info.Meta.Digest)
+	}
+
+	err = ps.DeleteBucket(ctx, "owner", bkt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exists, err := ps.ObjectExists(ctx, bkt, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists {
+		t.Errorf("expected object to be deleted with its bucket")
+	}
+}
+
+func TestFileStorageSignedURLs(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.FileConfig{Path: t.TempDir(), SigningKey: "secret"}
+
+	pattern, handler, err := NewFileStorageHandler(&config.FileConfig{Path: cfg.Path, URL: "http://localhost/storage", SigningKey: cfg.SigningKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(pattern, handler)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cfg.URL = srv.URL + "/storage"
+	ps, err := newPresignedFileAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		bkt = "gitpod-user-owner"
+		obj = "blobs/some-blob"
+	)
+	_, err = ps.SignDownload(ctx, bkt, obj, &SignedURLOptions{})
+	if err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	upload, err := ps.SignUpload(ctx, bkt, obj, &SignedURLOptions{ContentType: "text/plain"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	put := func(u, contentType string) int {
+		req, _ := http.NewRequest(http.MethodPut, u, strings.NewReader("hello world"))
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if code := put(upload.URL, "application/json"); code != http.StatusForbidden {
+		t.Errorf("expected upload with wrong content type to be rejected, got %d", code)
+	}
+	tampered, _ := url.Parse(upload.URL)
+	tampered.Path = strings.Replace(tampered.Path, "some-blob", "other-blob", 1)
+	if code := put(tampered.String(), "text/plain"); code != http.StatusForbidden {
+		t.Errorf("expected upload to tampered URL to be rejected, got %d", code)
+	}
+	if code := put(upload.URL, "text/plain"); code != http.StatusOK {
+		t.Fatalf("unexpected upload status code: %d", code)
+	}
+
+	download, err := ps.SignDownload(ctx, bkt, obj, &SignedURLOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if download.Size != 11 || download.Meta.ContentType != "text/plain" {
+		t.Errorf("unexpected download info: %+v", download)
+	}
+	resp, err := http.Get(download.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != "hello world" {
+		t.Errorf("unexpected download: %d %q", resp.StatusCode, string(body))
+	}
+
+	expired, _ := url.Parse(download.URL)
+	q := expired.Query()
+	q.Set(fileURLParamExpires, "1")
+	expired.RawQuery = q.Encode()
+	resp, err = http.Get(expired.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected expired URL to be rejected, got %d", resp.StatusCode)
+	}
+}
+
+func TestFileStorageSignedUpload(t *testing.T) {
+	tests := []struct {
+		Name             string
+		Body             string
+		Header           map[string]string
+		ExpectedCode     int
+		ExpectedMeta     ObjectMeta
+		ExpectedNotFound bool
+	}{
+		{
+			Name: "annotations",
+			Body: "hello world",
+			Header: map[string]string{
+				"X-Amz-Meta-Gitpod-Digest":             "sha256:digest",
+				"X-Amz-Meta-Gitpod-Uncompresseddigest": "sha256:uncompressed",
+				"X-Goog-Meta-Gitpod-Oci-Contenttype":   "application/vnd.oci.image.layer.v1.tar",
+			},
+			ExpectedCode: http.StatusOK,
+			ExpectedMeta: ObjectMeta{
+				ContentType:        "text/plain",
+				Digest:             "sha256:digest",
+				UncompressedDigest: "sha256:uncompressed",
+				OCIMediaType:       "application/vnd.oci.image.layer.v1.tar",
+			},
+		},
+		{
+			Name:             "too large",
+			Body:             "hello world, this is too large",
+			ExpectedCode:     http.StatusRequestEntityTooLarge,
+			ExpectedNotFound: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cfg := &config.FileConfig{Path: t.TempDir(), SigningKey: "secret", MaxUploadSize: 16}
+			pattern, handler, err := NewFileStorageHandler(&config.FileConfig{Path: cfg.Path, URL: "http://localhost/storage", SigningKey: cfg.SigningKey, MaxUploadSize: cfg.MaxUploadSize})
+			if err != nil {
+				t.Fatal(err)
+			}
+			mux := http.NewServeMux()
+			mux.Handle(pattern, handler)
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			cfg.URL = srv.URL + "/storage"
+			ps, err := newPresignedFileAccess(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			const (
+				bkt = "gitpod-user-owner"
+				obj = "blobs/some-blob"
+			)
+			upload, err := ps.SignUpload(ctx, bkt, obj, &SignedURLOptions{ContentType: "text/plain"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			req, _ := http.NewRequest(http.MethodPut, upload.URL, strings.NewReader(test.Body))
+			req.Header.Set("Content-Type", "text/plain")
+			for k, v := range test.Header {
+				req.Header.Set(k, v)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != test.ExpectedCode {
+				t.Errorf("unexpected upload status code: %d", resp.StatusCode)
+			}
+
+			download, err := ps.SignDownload(ctx, bkt, obj, &SignedURLOptions{})
+			if test.ExpectedNotFound {
+				if err != ErrNotFound {
+					t.Errorf("expected ErrNotFound, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.ExpectedMeta, download.Meta); diff != "" {
+				t.Errorf("unexpected object meta (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
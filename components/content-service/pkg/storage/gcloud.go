@@ -631,9 +631,10 @@ func (p *PresignedGCPStorage) downloadInfo(ctx context.Context, client *gcpstora
 	}
 
 	return &DownloadInfo{
-		Meta: *meta,
-		URL:  url,
-		Size: obj.Size,
+		Meta:         *meta,
+		URL:          url,
+		Size:         obj.Size,
+		LastModified: obj.Updated,
 	}, nil
 }
 
@@ -419,8 +419,9 @@ func (s *presignedMinIOStorage) SignDownload(ctx context.Context, bucket, object
 			Digest:             stat.Metadata.Get(annotationToAmzMetaHeader(ObjectAnnotationDigest)),
 			UncompressedDigest: stat.Metadata.Get(annotationToAmzMetaHeader(ObjectAnnotationUncompressedDigest)),
 		},
-		Size: stat.Size,
-		URL:  url.String(),
+		Size:         stat.Size,
+		URL:          url.String(),
+		LastModified: stat.LastModified,
 	}, nil
 }
 
@@ -234,8 +234,9 @@ func (rs *PresignedS3Storage) SignDownload(ctx context.Context, bucket string, o
 		Meta: ObjectMeta{
 			// TODO(cw): implement this if we need to support FWB with S3
 		},
-		Size: resp.ObjectSize,
-		URL:  req.URL,
+		Size:         resp.ObjectSize,
+		URL:          req.URL,
+		LastModified: aws.ToTime(resp.LastModified),
 	}, nil
 }
 
@@ -315,9 +316,9 @@ func (s3st *s3Storage) download(ctx context.Context, destination string, obj str
 		return false, err
 	}
 
-	err = archive.ExtractTarbal(ctx, s3File, destination, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
+	err = extractTarbal(ctx, destination, s3File, mappings)
 	if err != nil {
-		return true, xerrors.Errorf("tar %s: %s", destination, err.Error())
+		return true, err
 	}
 
 	return true, nil
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"time"
 
 	"golang.org/x/xerrors"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/log"
 	config "github.com/gitpod-io/gitpod/content-service/api/config"
 	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+	"github.com/gitpod-io/gitpod/content-service/pkg/encryption"
 )
 
 const (
@@ -31,6 +33,9 @@ const (
 
 	// FmtFullWorkspaceBackup is the format for names of full workspace backups
 	FmtFullWorkspaceBackup = "wsfull-%d.tar"
+
+	// DefaultChunkedBackup is the name of the manifest of a regular backup uploaded in the chunked format
+	DefaultChunkedBackup = "chunked.json"
 )
 
 var (
@@ -111,6 +116,8 @@ type DownloadInfo struct {
 	Meta ObjectMeta
 	URL  string
 	Size int64
+	// LastModified is the time the object was last written, if the storage provides it
+	LastModified time.Time
 }
 
 // UploadInfo describes an object for upload
@@ -141,6 +148,12 @@ type DirectDownloader interface {
 	DownloadSnapshot(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (found bool, err error)
 }
 
+// ChunkedDownloader downloads backups stored in the chunked format
+type ChunkedDownloader interface {
+	// DownloadChunked restores the chunked backup whose manifest has the given name to a local path
+	DownloadChunked(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (found bool, err error)
+}
+
 // DirectAccess represents a remote location where we can store data
 type DirectAccess interface {
 	BucketNamer
@@ -172,6 +185,9 @@ type UploadOptions struct {
 	Annotations map[string]string
 
 	ContentType string
+
+	// Plaintext disables encryption of the object, if encryption is configured
+	Plaintext bool
 }
 
 // UploadOption configures a particular aspect of remote storage upload
@@ -218,6 +234,22 @@ const (
 
 // NewDirectAccess provides direct access to a storage system
 func NewDirectAccess(c *config.StorageConfig) (DirectAccess, error) {
+	rs, err := newDirectAccess(c)
+	if err != nil {
+		return nil, err
+	}
+	if c.Encryption == nil {
+		return rs, nil
+	}
+
+	kr, err := encryption.LoadKeyRing(c.Encryption)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot load encryption keys: %w", err)
+	}
+	return newEncryptingStorage(rs, kr), nil
+}
+
+func newDirectAccess(c *config.StorageConfig) (DirectAccess, error) {
 	stage := c.GetStage()
 	if stage == "" {
 		return nil, xerrors.Errorf("missing storage stage")
@@ -237,6 +269,8 @@ func NewDirectAccess(c *config.StorageConfig) (DirectAccess, error) {
 		return newDirectS3Access(s3.NewFromConfig(*cfg), S3Config{
 			Bucket: c.S3Config.Bucket,
 		}), nil
+	case config.FileStorage:
+		return newDirectFileAccess(c.FileConfig)
 	default:
 		return &DirectNoopStorage{}, nil
 	}
@@ -263,6 +297,8 @@ func NewPresignedAccess(c *config.StorageConfig) (PresignedAccess, error) {
 		return NewPresignedS3Access(s3.NewFromConfig(*cfg), S3Config{
 			Bucket: c.S3Config.Bucket,
 		}), nil
+	case config.FileStorage:
+		return newPresignedFileAccess(c.FileConfig)
 	default:
 		log.Warnf("falling back to noop presigned storage access. Is this intentional? (storage kind: %s)", c.Kind)
 		return &PresignedNoopStorage{}, nil
@@ -288,7 +324,12 @@ func loadAwsConfig(s3config *config.S3Config) (*aws.Config, error) {
 }
 
 func extractTarbal(ctx context.Context, dest string, src io.Reader, mappings []archive.IDMapping) error {
-	err := archive.ExtractTarbal(ctx, src, dest, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
+	src, err := DecryptIfEncrypted(ctx, src)
+	if err != nil {
+		return xerrors.Errorf("cannot decrypt %s: %w", dest, err)
+	}
+
+	err = archive.ExtractTarbal(ctx, src, dest, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
 	if err != nil {
 		return xerrors.Errorf("tar %s: %s", dest, err.Error())
 	}
@@ -0,0 +1,69 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/procfs"
+	"github.com/spf13/cobra"
+
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/detector"
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/rules"
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// rulesSnapshotCmd represents the rules snapshot command
+var rulesSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <pid>",
+	Short: "captures a snapshot of a workspace process to test rules against",
+	Long: `Captures a snapshot of a workspace process running on this node and prints it as JSON.
+The snapshot includes the environment of the process, which may contain secrets. Use --no-env to omit it.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		pid, err := strconv.Atoi(args[0])
+		if err != nil {
+			log.WithError(err).Fatal("invalid pid")
+		}
+
+		ws, err := detector.FindWorkspace(pid)
+		if err != nil {
+			log.WithError(err).Fatal("cannot find workspace")
+		}
+		if ws == nil {
+			log.WithField("pid", pid).Warn("process does not belong to a workspace")
+		}
+
+		fs, err := procfs.NewFS("/proc")
+		if err != nil {
+			log.WithError(err).Fatal("cannot read procfs")
+		}
+		snapshot, err := rules.Capture(fs, pid, ws, time.Now())
+		if err != nil {
+			log.WithError(err).Fatal("cannot capture snapshot")
+		}
+		if noEnv, _ := cmd.Flags().GetBool("no-env"); noEnv {
+			snapshot.Process.Environment = nil
+			if snapshot.Process.Parent != nil {
+				snapshot.Process.Parent.Environment = nil
+			}
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(snapshot)
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	rulesCmd.AddCommand(rulesSnapshotCmd)
+	rulesSnapshotCmd.Flags().Bool("no-env", false, "omit the environment of the processes")
+}
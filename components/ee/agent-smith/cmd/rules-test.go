@@ -0,0 +1,98 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/config"
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/rules"
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// rulesTestCmd represents the rules test command
+var rulesTestCmd = &cobra.Command{
+	Use:   "test <snapshot.json> ...",
+	Short: "evaluates the configured rules against captured process snapshots",
+	Long: `Evaluates the rules of the config against snapshots produced by "rules snapshot".
+Each file may contain several snapshots. Exits with a non-zero code if any rule matched.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.GetConfig(cfgFile)
+		if err != nil {
+			log.WithError(err).Fatal("cannot get config")
+		}
+		if cfg.Rules == nil || len(cfg.Rules.Rules) == 0 {
+			log.Fatal("no rules configured")
+		}
+		engine, err := rules.NewEngine(*cfg.Rules)
+		if err != nil {
+			log.WithError(err).Fatal("cannot compile rules")
+		}
+
+		var matched bool
+		for _, fn := range args {
+			snapshots, err := readSnapshots(fn)
+			if err != nil {
+				log.WithError(err).WithField("file", fn).Fatal("cannot read snapshots")
+			}
+			for _, s := range snapshots {
+				fmt.Printf("%s: pid %d (%s)\n", fn, s.Process.PID, strings.Join(s.Process.CommandLine, " "))
+				for _, r := range engine.Evaluate(s) {
+					switch {
+					case r.Err != nil:
+						fmt.Printf("\t%s: error: %v\n", r.Rule, r.Err)
+					case r.Matched && r.DryRun:
+						matched = true
+						fmt.Printf("\t%s: match (dry run, penalty: %q)\n", r.Rule, r.Penalty)
+					case r.Matched:
+						matched = true
+						fmt.Printf("\t%s: match (penalty: %q)\n", r.Rule, r.Penalty)
+					default:
+						fmt.Printf("\t%s: no match\n", r.Rule)
+					}
+				}
+			}
+		}
+
+		if matched {
+			os.Exit(1)
+		}
+	},
+}
+
+func readSnapshots(fn string) ([]*rules.Snapshot, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var res []*rules.Snapshot
+	dec := json.NewDecoder(f)
+	for {
+		var s rules.Snapshot
+		err := dec.Decode(&s)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, &s)
+	}
+	return res, nil
+}
+
+func init() {
+	rulesCmd.AddCommand(rulesTestCmd)
+}
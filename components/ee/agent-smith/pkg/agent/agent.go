@@ -24,6 +24,7 @@ import (
 	"github.com/gitpod-io/gitpod/agent-smith/pkg/common"
 	"github.com/gitpod-io/gitpod/agent-smith/pkg/config"
 	"github.com/gitpod-io/gitpod/agent-smith/pkg/detector"
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/rules"
 	"github.com/gitpod-io/gitpod/common-go/log"
 	gitpod "github.com/gitpod-io/gitpod/gitpod-protocol"
 )
@@ -46,6 +47,12 @@ type Smith struct {
 
 	detector   detector.ProcessDetector
 	classifier classifier.ProcessClassifier
+
+	connectionDetector   detector.ConnectionDetector
+	connectionClassifier classifier.ConnectionClassifier
+
+	rules     *rules.Engine
+	ruleWatch *processWatch
 }
 
 // NewAgentSmith creates a new agent smith
@@ -96,16 +103,48 @@ func NewAgentSmith(cfg config.Config) (*Smith, error) {
 		}
 	}
 
+	var detec detector.ProcessDetector
 	detec, err := detector.NewProcfsDetector()
 	if err != nil {
 		return nil, err
 	}
+	if cfg.Detection.ProcConnector {
+		src, err := detector.NewProcConnectorSource()
+		if err != nil {
+			return nil, xerrors.Errorf("cannot subscribe to exec events: %w", err)
+		}
+		execDetec, err := detector.NewExecDetector(src)
+		if err != nil {
+			return nil, err
+		}
+		detec = detector.CompositeDetector{detec, execDetec}
+	}
+
+	var (
+		connDetec detector.ConnectionDetector
+		connClass classifier.ConnectionClassifier
+	)
+	if ports := cfg.Blocklists.Ports(); len(ports) > 0 {
+		connDetec, err = detector.NewNetworkDetector(ports)
+		if err != nil {
+			return nil, err
+		}
+		connClass = cfg.Blocklists.ConnectionClassifier()
+	}
 
 	class, err := cfg.Blocklists.Classifier()
 	if err != nil {
 		return nil, err
 	}
 
+	var engine *rules.Engine
+	if cfg.Rules != nil && len(cfg.Rules.Rules) > 0 {
+		engine, err = rules.NewEngine(*cfg.Rules)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot compile rules: %w", err)
+		}
+	}
+
 	m := newAgentMetrics()
 	res := &Smith{
 		EnforcementRules: map[string]config.EnforcementRules{
@@ -113,6 +152,10 @@ func NewAgentSmith(cfg config.Config) (*Smith, error) {
 				config.GradeKind(config.InfringementExec, common.SeverityBarely): config.PenaltyLimitCPU,
 				config.GradeKind(config.InfringementExec, common.SeverityAudit):  config.PenaltyStopWorkspace,
 				config.GradeKind(config.InfringementExec, common.SeverityVery):   config.PenaltyStopWorkspaceAndBlockUser,
+
+				config.GradeKind(config.InfringementConnection, common.SeverityBarely): config.PenaltyLimitCPU,
+				config.GradeKind(config.InfringementConnection, common.SeverityAudit):  config.PenaltyStopWorkspace,
+				config.GradeKind(config.InfringementConnection, common.SeverityVery):   config.PenaltyStopWorkspaceAndBlockUser,
 			},
 		},
 		Config:     cfg,
@@ -122,6 +165,12 @@ func NewAgentSmith(cfg config.Config) (*Smith, error) {
 		detector:   detec,
 		classifier: class,
 
+		connectionDetector:   connDetec,
+		connectionClassifier: connClass,
+
+		rules:     engine,
+		ruleWatch: newProcessWatch(),
+
 		notifiedInfringements: lru.New(notificationCacheSize),
 		metrics:               m,
 		timeElapsedHandler:    time.Since,
@@ -202,6 +251,14 @@ func (agent *Smith) Start(ctx context.Context, callback func(InfringingWorkspace
 	if err != nil {
 		log.WithError(err).Fatal("cannot start process detector")
 	}
+	// receiving from a nil channel blocks forever, i.e. without a connection detector we never see a connection
+	var conns <-chan detector.Connection
+	if agent.connectionDetector != nil {
+		conns, err = agent.connectionDetector.DiscoverConnections(ctx)
+		if err != nil {
+			log.WithError(err).Fatal("cannot start connection detector")
+		}
+	}
 
 	var (
 		wg  sync.WaitGroup
@@ -226,6 +283,10 @@ func (agent *Smith) Start(ctx context.Context, callback func(InfringingWorkspace
 					workspaces[i.Workspace.PID] = i.Workspace
 				}
 				wsMutex.Unlock()
+				// rules are evaluated against all workload processes, not just those the classifiers match
+				if agent.rules != nil && !agent.ruleWatch.Add(i, time.Now()) {
+					log.WithField("pid", i.PID).Debug("too many watched processes - not evaluating rules")
+				}
 				// perform classification of the process
 				class, err := agent.classifier.Matches(i.Path, i.CommandLine)
 				// optimisation: early out to not block on the CLO chan
@@ -239,6 +300,10 @@ func (agent *Smith) Start(ctx context.Context, callback func(InfringingWorkspace
 
 	defer log.Info("agent smith main loop ended")
 
+	if agent.rules != nil {
+		go agent.evaluateRules(ctx)
+	}
+
 	// We want to fill the classifier in a Go routine seaparete from using the classification
 	// results, to ensure we're not deadlocking/block ourselves. If this were in the same loop,
 	// we could easily get into a situation where we'd need to scale the queues to match the proc index.
@@ -288,6 +353,29 @@ func (agent *Smith) Start(ctx context.Context, callback func(InfringingWorkspace
 					},
 				},
 			})
+		case conn := <-conns:
+			cl, err := agent.connectionClassifier.MatchesConnection(conn.RemoteAddr, conn.RemotePort)
+			if err != nil {
+				log.WithError(err).WithFields(log.OWI(conn.Workspace.OwnerID, conn.Workspace.WorkspaceID, conn.Workspace.InstanceID)).WithField("remotePort", conn.RemotePort).Error("cannot classify connection")
+				continue
+			}
+			if cl == nil || cl.Level == classifier.LevelNoMatch {
+				continue
+			}
+
+			_, _ = agent.Penalize(InfringingWorkspace{
+				SupervisorPID: conn.Workspace.PID,
+				Owner:         conn.Workspace.OwnerID,
+				InstanceID:    conn.Workspace.InstanceID,
+				WorkspaceID:   conn.Workspace.WorkspaceID,
+				GitRemoteURL:  []string{conn.Workspace.GitURL},
+				Infringements: []Infringement{
+					{
+						Kind:        config.GradeKind(config.InfringementConnection, common.Severity(cl.Level)),
+						Description: fmt.Sprintf("%s: %s", cl.Classifier, cl.Message),
+					},
+				},
+			})
 		}
 	}
 }
@@ -299,9 +387,13 @@ func (agent *Smith) Penalize(ws InfringingWorkspace) ([]config.PenaltyKind, erro
 		remoteURL = ws.GitRemoteURL[0]
 	}
 
-	owi := log.OWI(ws.Owner, ws.WorkspaceID, ws.InstanceID)
-
 	penalty := getPenalty(agent.EnforcementRules[defaultRuleset], agent.EnforcementRules[remoteURL], ws.Infringements)
+	return agent.applyPenalties(ws, penalty)
+}
+
+// applyPenalties applies the first penalty it knows how to apply
+func (agent *Smith) applyPenalties(ws InfringingWorkspace, penalty []config.PenaltyKind) ([]config.PenaltyKind, error) {
+	owi := log.OWI(ws.Owner, ws.WorkspaceID, ws.InstanceID)
 	for _, p := range penalty {
 		switch p {
 		case config.PenaltyStopWorkspace:
@@ -389,10 +481,24 @@ func (agent *Smith) Describe(d chan<- *prometheus.Desc) {
 	agent.metrics.Describe(d)
 	agent.classifier.Describe(d)
 	agent.detector.Describe(d)
+	if agent.connectionDetector != nil {
+		agent.connectionDetector.Describe(d)
+		agent.connectionClassifier.Describe(d)
+	}
+	if agent.rules != nil {
+		agent.rules.Describe(d)
+	}
 }
 
 func (agent *Smith) Collect(m chan<- prometheus.Metric) {
 	agent.metrics.Collect(m)
 	agent.classifier.Collect(m)
 	agent.detector.Collect(m)
+	if agent.connectionDetector != nil {
+		agent.connectionDetector.Collect(m)
+		agent.connectionClassifier.Collect(m)
+	}
+	if agent.rules != nil {
+		agent.rules.Collect(m)
+	}
 }
@@ -0,0 +1,166 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/procfs"
+
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/common"
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/config"
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/detector"
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/rules"
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+const (
+	// defaultRuleWatchPeriod is the time for which we re-evaluate rules against a process
+	defaultRuleWatchPeriod = 10 * time.Minute
+	// ruleEvaluationInterval is the time between two evaluations of the rules against a watched process
+	ruleEvaluationInterval = 15 * time.Second
+	// maxWatchedProcesses bounds the number of processes we re-evaluate rules against
+	maxWatchedProcesses = 5000
+)
+
+type watchedProcess struct {
+	P     detector.Process
+	Since time.Time
+}
+
+// processWatch holds the processes rules are evaluated against until they match, exit or the watch period ends
+type processWatch struct {
+	mu    sync.Mutex
+	procs map[int]watchedProcess
+}
+
+func newProcessWatch() *processWatch {
+	return &processWatch{procs: make(map[int]watchedProcess)}
+}
+
+// Add starts watching a process. Returns false if too many processes are watched already.
+func (w *processWatch) Add(p detector.Process, now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, exists := w.procs[p.PID]; exists {
+		return true
+	}
+	if len(w.procs) >= maxWatchedProcesses {
+		return false
+	}
+	w.procs[p.PID] = watchedProcess{P: p, Since: now}
+	return true
+}
+
+func (w *processWatch) Remove(pid int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.procs, pid)
+}
+
+// List returns all watched processes and stops watching those which have been watched for longer than period
+func (w *processWatch) List(now time.Time, period time.Duration) []detector.Process {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	res := make([]detector.Process, 0, len(w.procs))
+	for pid, p := range w.procs {
+		if now.Sub(p.Since) > period {
+			delete(w.procs, pid)
+			continue
+		}
+		res = append(res, p.P)
+	}
+	return res
+}
+
+// evaluateRules periodically evaluates the policy rules against the watched processes
+func (agent *Smith) evaluateRules(ctx context.Context) {
+	fs, err := procfs.NewFS("/proc")
+	if err != nil {
+		log.WithError(err).Error("cannot evaluate rules")
+		return
+	}
+
+	period := defaultRuleWatchPeriod
+	if agent.Config.Rules.WatchMinutes > 0 {
+		period = time.Duration(agent.Config.Rules.WatchMinutes) * time.Minute
+	}
+
+	t := time.NewTicker(ruleEvaluationInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		now := time.Now()
+		for _, p := range agent.ruleWatch.List(now, period) {
+			snapshot, err := rules.Capture(fs, p.PID, p.Workspace, now)
+			if err != nil {
+				// the process is gone
+				agent.ruleWatch.Remove(p.PID)
+				continue
+			}
+			if agent.enforceRules(p, snapshot) {
+				agent.ruleWatch.Remove(p.PID)
+			}
+		}
+	}
+}
+
+// enforceRules evaluates the rules against the snapshot and applies the penalties of matching rules.
+// Returns true if any rule matched.
+func (agent *Smith) enforceRules(p detector.Process, snapshot *rules.Snapshot) (matched bool) {
+	owi := log.OWI(p.Workspace.OwnerID, p.Workspace.WorkspaceID, p.Workspace.InstanceID)
+
+	var (
+		infringements []Infringement
+		penalties     []config.PenaltyKind
+	)
+	for _, r := range agent.rules.Evaluate(snapshot) {
+		if r.Err != nil {
+			log.WithError(r.Err).WithFields(owi).WithField("pid", p.PID).Debug("cannot evaluate rule")
+			continue
+		}
+		if !r.Matched {
+			continue
+		}
+		matched = true
+
+		log.WithFields(owi).WithField("rule", r.Rule).WithField("penalty", r.Penalty).WithField("dryRun", r.DryRun).WithField("cmdline", p.CommandLine).Info("rule matched")
+		if r.DryRun {
+			continue
+		}
+		infringements = append(infringements, Infringement{
+			Kind:        config.GradeKind(config.InfringementRule, common.SeverityAudit),
+			Description: fmt.Sprintf("rule %s matched", r.Rule),
+			CommandLine: p.CommandLine,
+		})
+		if r.Penalty != config.PenaltyNone {
+			penalties = append(penalties, r.Penalty)
+		}
+	}
+	if len(infringements) == 0 {
+		return matched
+	}
+
+	_, _ = agent.applyPenalties(InfringingWorkspace{
+		SupervisorPID: p.Workspace.PID,
+		Owner:         p.Workspace.OwnerID,
+		InstanceID:    p.Workspace.InstanceID,
+		WorkspaceID:   p.Workspace.WorkspaceID,
+		GitRemoteURL:  []string{p.Workspace.GitURL},
+		Infringements: infringements,
+	}, penalties)
+	return matched
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package agent
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/detector"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProcessWatch(t *testing.T) {
+	var (
+		now   = time.Now()
+		watch = newProcessWatch()
+	)
+	watch.Add(detector.Process{PID: 1}, now.Add(-20*time.Minute))
+	watch.Add(detector.Process{PID: 2}, now.Add(-time.Minute))
+	watch.Add(detector.Process{PID: 3}, now)
+	// adding a process twice keeps the original watch start
+	watch.Add(detector.Process{PID: 1}, now)
+	watch.Remove(3)
+
+	pids := func() []int {
+		var res []int
+		for _, p := range watch.List(now, 10*time.Minute) {
+			res = append(res, p.PID)
+		}
+		sort.Ints(res)
+		return res
+	}
+	if diff := cmp.Diff([]int{2}, pids()); diff != "" {
+		t.Errorf("unexpected watched processes (-want +got):\n%s", diff)
+	}
+	// processes past the watch period are gone for good
+	if diff := cmp.Diff([]int{2}, pids()); diff != "" {
+		t.Errorf("unexpected watched processes (-want +got):\n%s", diff)
+	}
+
+	for i := 0; i < maxWatchedProcesses; i++ {
+		watch.Add(detector.Process{PID: 100 + i}, now)
+	}
+	if watch.Add(detector.Process{PID: 1}, now) {
+		t.Error("expected a full watch to reject processes")
+	}
+}
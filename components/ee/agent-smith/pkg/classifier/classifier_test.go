@@ -91,3 +91,49 @@ func TestCommandlineClassifier(t *testing.T) {
 		})
 	}
 }
+
+func TestPortClassifier(t *testing.T) {
+	type Input struct {
+		RemoteAddr string
+		RemotePort uint16
+	}
+	tests := []struct {
+		Name        string
+		Input       Input
+		Expectation *classifier.Classification
+	}{
+		{
+			Name:        "blocked port",
+			Input:       Input{RemoteAddr: "93.10.10.10", RemotePort: 3333},
+			Expectation: &classifier.Classification{Level: classifier.LevelVery, Classifier: classifier.ClassifierPort, Message: "connected to 93.10.10.10 on port 3333"},
+		},
+		{
+			Name:        "audited port",
+			Input:       Input{RemoteAddr: "93.10.10.10", RemotePort: 4444},
+			Expectation: &classifier.Classification{Level: classifier.LevelAudit, Classifier: classifier.ClassifierPort, Message: "connected to 93.10.10.10 on port 4444"},
+		},
+		{
+			Name:        "other port",
+			Input:       Input{RemoteAddr: "93.10.10.10", RemotePort: 443},
+			Expectation: &classifier.Classification{Level: classifier.LevelNoMatch, Classifier: classifier.ClassifierPort},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			class := classifier.NewPortClassifier(map[uint16]classifier.Level{
+				3333: classifier.LevelVery,
+				4444: classifier.LevelAudit,
+			})
+
+			act, err := class.MatchesConnection(test.Input.RemoteAddr, test.Input.RemotePort)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected PortClassifier (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
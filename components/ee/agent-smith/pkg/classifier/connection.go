@@ -0,0 +1,66 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package classifier
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const ClassifierPort string = "port"
+
+// ConnectionClassifier matches a network connection against a set of criteria
+type ConnectionClassifier interface {
+	prometheus.Collector
+
+	MatchesConnection(remoteAddr string, remotePort uint16) (*Classification, error)
+}
+
+// NewPortClassifier creates a classifier which grades connections by their remote port
+func NewPortClassifier(ports map[uint16]Level) *PortClassifier {
+	return &PortClassifier{
+		Ports: ports,
+		portHitTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gitpod_agent_smith",
+			Subsystem: "classifier_port",
+			Name:      "port_hit_total",
+			Help:      "total count of connections to blocklisted ports",
+		}, []string{"level"}),
+	}
+}
+
+// PortClassifier looks at the remote port of a connection
+type PortClassifier struct {
+	Ports map[uint16]Level
+
+	portHitTotal *prometheus.CounterVec
+}
+
+var _ ConnectionClassifier = &PortClassifier{}
+
+var portNoMatch = &Classification{Level: LevelNoMatch, Classifier: ClassifierPort}
+
+func (cl *PortClassifier) MatchesConnection(remoteAddr string, remotePort uint16) (*Classification, error) {
+	lvl, ok := cl.Ports[remotePort]
+	if !ok {
+		return portNoMatch, nil
+	}
+
+	cl.portHitTotal.WithLabelValues(string(lvl)).Inc()
+	return &Classification{
+		Level:      lvl,
+		Classifier: ClassifierPort,
+		Message:    fmt.Sprintf("connected to %s on port %d", remoteAddr, remotePort),
+	}, nil
+}
+
+func (cl *PortClassifier) Describe(d chan<- *prometheus.Desc) {
+	cl.portHitTotal.Describe(d)
+}
+
+func (cl *PortClassifier) Collect(m chan<- prometheus.Metric) {
+	cl.portHitTotal.Collect(m)
+}
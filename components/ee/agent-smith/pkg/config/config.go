@@ -73,12 +73,6 @@ func (er EnforcementRules) Validate() error {
 		}
 	}
 
-	validPenalties := map[PenaltyKind]struct{}{
-		PenaltyLimitCPU:                  {},
-		PenaltyNone:                      {},
-		PenaltyStopWorkspace:             {},
-		PenaltyStopWorkspaceAndBlockUser: {},
-	}
 	for _, v := range er {
 		if _, ok := validPenalties[v]; !ok {
 			return xerrors.Errorf("%s: unknown penalty", v)
@@ -88,12 +82,23 @@ func (er EnforcementRules) Validate() error {
 	return nil
 }
 
+var validPenalties = map[PenaltyKind]struct{}{
+	PenaltyLimitCPU:                  {},
+	PenaltyNone:                      {},
+	PenaltyStopWorkspace:             {},
+	PenaltyStopWorkspaceAndBlockUser: {},
+}
+
 // InfringementKind describes the kind of infringement
 type InfringementKind string
 
 const (
 	// InfringementExec means a user executed a blocklisted executable
 	InfringementExec InfringementKind = "blocklisted executable"
+	// InfringementConnection means a workspace connected to a blocklisted port, e.g. that of a mining pool
+	InfringementConnection InfringementKind = "blocklisted connection"
+	// InfringementRule means a process matched a policy rule
+	InfringementRule InfringementKind = "rule violation"
 )
 
 // PenaltyKind describes a kind of penalty for a violating workspace
@@ -138,6 +143,8 @@ func (g GradedInfringementKind) Kind() (InfringementKind, error) {
 
 	validKinds := []InfringementKind{
 		InfringementExec,
+		InfringementConnection,
+		InfringementRule,
 	}
 	for _, k := range validKinds {
 		if string(k) == wopfx {
@@ -174,10 +181,70 @@ type Config struct {
 	ExcessiveCPUCheck *ExcessiveCPUCheck `json:"excessiveCPUCheck,omitempty"`
 	SlackWebhooks     *SlackWebhooks     `json:"slackWebhooks,omitempty"`
 	Kubernetes        Kubernetes         `json:"kubernetes"`
+	Detection         Detection          `json:"detection,omitempty"`
+	Rules             *Rules             `json:"rules,omitempty"`
 
 	ProbePath string `json:"probePath,omitempty"`
 }
 
+// Detection configures how processes are found in addition to scanning procfs
+type Detection struct {
+	// ProcConnector enables detecting processes when they call exec using the kernel's proc connector.
+	// This finds short-lived processes which scanning procfs misses, and requires CAP_NET_ADMIN.
+	ProcConnector bool `json:"procConnector,omitempty"`
+}
+
+// Rules configures policy rules which are evaluated against every user workload process
+type Rules struct {
+	// DryRun logs rule matches instead of applying their penalties
+	DryRun bool `json:"dryRun,omitempty"`
+	// WatchMinutes is the time for which processes are re-evaluated after they were discovered.
+	// This allows for rules which depend on the runtime or CPU use of a process. Defaults to 10 minutes.
+	WatchMinutes int `json:"watchMinutes,omitempty"`
+
+	Rules []Rule `json:"rules,omitempty"`
+}
+
+// Rule applies a penalty when its expression evaluates to true
+type Rule struct {
+	Name string `json:"name"`
+	// Expression is a CEL expression over the process and workspace of a snapshot, e.g.
+	//   process.name == "xmrig" && process.runtime > duration("60s") && workspace.age < duration("10m")
+	Expression string      `json:"expression"`
+	Penalty    PenaltyKind `json:"penalty,omitempty"`
+	// DryRun logs matches of this rule instead of applying its penalty
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// Validate returns an error if the rules are invalid. It does not compile the rule expressions.
+func (r *Rules) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.WatchMinutes < 0 {
+		return xerrors.Errorf("watchMinutes must not be negative")
+	}
+
+	names := make(map[string]struct{}, len(r.Rules))
+	for i, rule := range r.Rules {
+		if rule.Name == "" {
+			return xerrors.Errorf("rules[%d]: name is required", i)
+		}
+		if _, exists := names[rule.Name]; exists {
+			return xerrors.Errorf("rules[%d]: duplicate name %s", i, rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+
+		if strings.TrimSpace(rule.Expression) == "" {
+			return xerrors.Errorf("%s: expression is required", rule.Name)
+		}
+		if _, ok := validPenalties[rule.Penalty]; !ok {
+			return xerrors.Errorf("%s: %s: unknown penalty", rule.Name, rule.Penalty)
+		}
+	}
+	return nil
+}
+
 // Slackwebhooks holds slack notification configuration for different levels of penalty severity
 type SlackWebhooks struct {
 	Audit   string `json:"audit,omitempty"`
@@ -234,11 +301,45 @@ type AllowList struct {
 	Commands []string `json:"commands,omitempty"`
 }
 
+// ConnectionClassifier classifies network connections by the remote ports of the blocklists.
+// If a port is listed on several levels, the most severe one wins.
+func (b *Blocklists) ConnectionClassifier() classifier.ConnectionClassifier {
+	ports := make(map[uint16]classifier.Level)
+	if b != nil {
+		for _, lvl := range []common.Severity{common.SeverityVery, common.SeverityBarely, common.SeverityAudit} {
+			bl, ok := b.Levels()[lvl]
+			if !ok {
+				continue
+			}
+			for _, p := range bl.Ports {
+				if _, exists := ports[p]; !exists {
+					ports[p] = classifier.Level(lvl)
+				}
+			}
+		}
+	}
+	return classifier.NewPortClassifier(ports)
+}
+
+// Ports returns all remote ports listed on any level
+func (b *Blocklists) Ports() []uint16 {
+	if b == nil {
+		return nil
+	}
+	var res []uint16
+	for _, bl := range b.Levels() {
+		res = append(res, bl.Ports...)
+	}
+	return res
+}
+
 // PerLevelBlocklist lists blacklists for level of infringement
 type PerLevelBlocklist struct {
 	Binaries   []string                `json:"binaries,omitempty"`
 	AllowList  []string                `json:"allowlist,omitempty"`
 	Signatures []*classifier.Signature `json:"signatures,omitempty"`
+	// Ports are remote ports, e.g. of mining pools, workspaces must not connect to
+	Ports []uint16 `json:"ports,omitempty"`
 }
 
 func (p *PerLevelBlocklist) Classifier(name string, level classifier.Level) (classifier.ProcessClassifier, error) {
@@ -6,6 +6,7 @@ package detector
 
 import (
 	"context"
+	"sync"
 
 	"github.com/gitpod-io/gitpod/agent-smith/pkg/common"
 	"github.com/prometheus/client_golang/prometheus"
@@ -13,6 +14,7 @@ import (
 
 // Process describes a process ont the node that might warant closer inspection
 type Process struct {
+	PID         int
 	Path        string
 	CommandLine []string
 	Kind        ProcessKind
@@ -36,3 +38,62 @@ const (
 	ProcessSupervisor
 	ProcessUserWorkload
 )
+
+// CompositeDetector combines the processes discovered by multiple detectors
+type CompositeDetector []ProcessDetector
+
+var _ ProcessDetector = CompositeDetector{}
+
+// DiscoverProcesses starts the discovery of all detectors and merges their results
+func (d CompositeDetector) DiscoverProcesses(ctx context.Context) (<-chan Process, error) {
+	var (
+		res = make(chan Process, 100)
+		wg  sync.WaitGroup
+	)
+	for _, det := range d {
+		ps, err := det.DiscoverProcesses(ctx)
+		if err != nil {
+			return nil, err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range ps {
+				res <- p
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(res)
+	}()
+	return res, nil
+}
+
+func (d CompositeDetector) Describe(desc chan<- *prometheus.Desc) {
+	for _, det := range d {
+		det.Describe(desc)
+	}
+}
+
+func (d CompositeDetector) Collect(m chan<- prometheus.Metric) {
+	for _, det := range d {
+		det.Collect(m)
+	}
+}
+
+// Connection describes an outbound network connection of a workspace that might warant closer inspection
+type Connection struct {
+	RemoteAddr string
+	RemotePort uint16
+	Workspace  *common.Workspace
+}
+
+// ConnectionDetector discovers network connections of workspaces on the node
+type ConnectionDetector interface {
+	prometheus.Collector
+
+	// DiscoverConnections starts the discovery process. The discovery process can send the same
+	// connection multiple times.
+	DiscoverConnections(ctx context.Context) (<-chan Connection, error)
+}
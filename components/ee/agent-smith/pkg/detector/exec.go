@@ -0,0 +1,225 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package detector
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs"
+
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/common"
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// maxAncestorDepth limits how far up the process tree we look for a workspace
+const maxAncestorDepth = 64
+
+type inspectableProcFS interface {
+	Stat(pid int) (*stat, error)
+	CmdLine(pid int) ([]string, error)
+	environProcFS
+}
+
+var _ inspectableProcFS = realProcfs{}
+
+func (fs realProcfs) Stat(pid int) (*stat, error) {
+	return statProc(pid)
+}
+
+func (fs realProcfs) CmdLine(pid int) ([]string, error) {
+	p, err := procfs.FS(fs).Proc(pid)
+	if err != nil {
+		return nil, err
+	}
+	return p.CmdLine()
+}
+
+var _ ProcessDetector = &ExecDetector{}
+
+// ExecDetector detects processes when they call exec. In contrast to the ProcfsDetector it does not
+// miss processes which only live between two procfs scans.
+type ExecDetector struct {
+	mu sync.RWMutex
+	ps chan Process
+
+	eventsTotal *prometheus.CounterVec
+
+	startOnce sync.Once
+
+	source ExecEventSource
+	proc   inspectableProcFS
+	// workspaces caches the workspaces of supervisor processes
+	workspaces *lru.Cache
+}
+
+// NewExecDetector creates a detector which consumes exec events from the source
+func NewExecDetector(source ExecEventSource) (*ExecDetector, error) {
+	p, err := procfs.NewFS("/proc")
+	if err != nil {
+		return nil, err
+	}
+	return newExecDetector(source, realProcfs(p))
+}
+
+func newExecDetector(source ExecEventSource, proc inspectableProcFS) (*ExecDetector, error) {
+	cache, err := lru.New(500)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExecDetector{
+		eventsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gitpod",
+			Subsystem: "agent_smith_exec_detector",
+			Name:      "events_total",
+			Help:      "exec events by outcome",
+		}, []string{"outcome"}),
+		source:     source,
+		proc:       proc,
+		workspaces: cache,
+	}, nil
+}
+
+func (det *ExecDetector) Describe(d chan<- *prometheus.Desc) {
+	det.eventsTotal.Describe(d)
+}
+
+func (det *ExecDetector) Collect(m chan<- prometheus.Metric) {
+	det.eventsTotal.Collect(m)
+}
+
+// DiscoverProcesses starts process discovery. Must not be called more than once.
+func (det *ExecDetector) DiscoverProcesses(ctx context.Context) (<-chan Process, error) {
+	det.mu.Lock()
+	defer det.mu.Unlock()
+
+	if det.ps != nil {
+		return nil, fmt.Errorf("already discovering processes")
+	}
+	evts, err := det.source.ExecEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make(chan Process, 100)
+	det.ps = res
+	det.startOnce.Do(func() {
+		go func() {
+			defer close(res)
+			for evt := range evts {
+				proc, ok := det.inspect(evt.PID)
+				if !ok {
+					continue
+				}
+				select {
+				case res <- proc:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		log.Info("exec detector started")
+	})
+
+	return res, nil
+}
+
+// inspect produces the process of an exec event if it is a user workload
+func (det *ExecDetector) inspect(pid int) (Process, bool) {
+	cmdline, err := det.proc.CmdLine(pid)
+	if err != nil {
+		// the process is gone already
+		det.eventsTotal.WithLabelValues("gone").Inc()
+		return Process{}, false
+	}
+	if isSupervisor(cmdline) {
+		det.eventsTotal.WithLabelValues("ignored").Inc()
+		return Process{}, false
+	}
+
+	ws := det.findWorkspace(pid)
+	if ws == nil {
+		det.eventsTotal.WithLabelValues("ignored").Inc()
+		return Process{}, false
+	}
+	det.eventsTotal.WithLabelValues("workload").Inc()
+
+	proc := Process{
+		PID: pid,
+		// Note: see realProcfs.Discover for why we don't resolve the exe symlink
+		Path:        filepath.Join("proc", strconv.Itoa(pid), "exe"),
+		CommandLine: cmdline,
+		Kind:        ProcessUserWorkload,
+		Workspace:   ws,
+	}
+	log.WithField("proc", proc).Debug("found process")
+	return proc, true
+}
+
+// findWorkspace walks up the process tree until it finds a supervisor process whose parent is workspacekit
+func (det *ExecDetector) findWorkspace(pid int) *common.Workspace {
+	digest := make([]byte, 16)
+	for i := 0; i < maxAncestorDepth && pid > 1; i++ {
+		st, err := det.proc.Stat(pid)
+		if err != nil {
+			return nil
+		}
+		parent := st.PPID
+		if parent <= 1 {
+			return nil
+		}
+
+		pst, err := det.proc.Stat(parent)
+		if err != nil {
+			return nil
+		}
+		// PIDs are reused, hence we identify processes by PID and start time
+		binary.LittleEndian.PutUint64(digest[0:8], uint64(parent))
+		binary.LittleEndian.PutUint64(digest[8:16], pst.Starttime)
+		key := xxhash.Sum64(digest)
+		if ws, ok := det.workspaces.Get(key); ok {
+			return ws.(*common.Workspace)
+		}
+
+		cmdline, err := det.proc.CmdLine(parent)
+		if err != nil {
+			return nil
+		}
+		if isSupervisor(cmdline) {
+			wkcmd, err := det.proc.CmdLine(pst.PPID)
+			if err != nil || len(wkcmd) < 2 || wkcmd[0] != "/proc/self/exe" || wkcmd[1] != "ring1" {
+				return nil
+			}
+			ws := extractWorkspaceFromWorkspacekit(det.proc, pst.PPID)
+			if ws == nil {
+				return nil
+			}
+			// like the ProcfsDetector we want the workspace PID to point to supervisor
+			ws.PID = parent
+			det.workspaces.Add(key, ws)
+			return ws
+		}
+
+		pid = parent
+	}
+	return nil
+}
+
+// FindWorkspace finds the workspace a process on this node belongs to. Returns nil if the process
+// is not part of a workspace.
+func FindWorkspace(pid int) (*common.Workspace, error) {
+	det, err := NewExecDetector(nil)
+	if err != nil {
+		return nil, err
+	}
+	return det.findWorkspace(pid), nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package detector
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type memoryInspectEntry struct {
+	PPID    int
+	Cmdline []string
+	Env     []string
+}
+
+type memoryInspectProc map[int]memoryInspectEntry
+
+func (p memoryInspectProc) Stat(pid int) (*stat, error) {
+	proc, ok := p[pid]
+	if !ok {
+		return nil, fmt.Errorf("process does not exist")
+	}
+	return &stat{PPID: proc.PPID, Starttime: uint64(pid)}, nil
+}
+
+func (p memoryInspectProc) CmdLine(pid int) ([]string, error) {
+	proc, ok := p[pid]
+	if !ok {
+		return nil, fmt.Errorf("process does not exist")
+	}
+	return proc.Cmdline, nil
+}
+
+func (p memoryInspectProc) Environ(pid int) ([]string, error) {
+	proc, ok := p[pid]
+	if !ok {
+		return nil, fmt.Errorf("process does not exist")
+	}
+	return proc.Env, nil
+}
+
+// recordProcEvent produces a netlink message as sent by the proc connector
+func recordProcEvent(what uint32, pid int) []byte {
+	msg := make([]byte, nlmsgHdrLen+cnMsgLen+procEventHdrLen+8)
+	hostByteOrder.PutUint32(msg[0:4], uint32(len(msg)))
+	hostByteOrder.PutUint16(msg[4:6], 0x3)
+	hostByteOrder.PutUint32(msg[nlmsgHdrLen:], cnIdxProc)
+	hostByteOrder.PutUint32(msg[nlmsgHdrLen+4:], cnValProc)
+	hostByteOrder.PutUint16(msg[nlmsgHdrLen+16:], procEventHdrLen+8)
+	evt := msg[nlmsgHdrLen+cnMsgLen:]
+	hostByteOrder.PutUint32(evt[0:4], what)
+	hostByteOrder.PutUint32(evt[procEventHdrLen:], uint32(pid))
+	hostByteOrder.PutUint32(evt[procEventHdrLen+4:], uint32(pid))
+	return msg
+}
+
+func TestRecordedExecEventSource(t *testing.T) {
+	const procEventFork = 0x00000001
+	var recording bytes.Buffer
+	recording.Write(recordProcEvent(procEventExec, 10))
+	recording.Write(recordProcEvent(procEventFork, 11))
+	recording.Write(recordProcEvent(procEventExec, 12))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	evts, err := (&RecordedExecEventSource{Reader: &recording}).ExecEvents(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res []ExecEvent
+	for evt := range evts {
+		res = append(res, evt)
+	}
+
+	if diff := cmp.Diff([]ExecEvent{{PID: 10}, {PID: 12}}, res); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
+
+func TestExecDetector(t *testing.T) {
+	proc := memoryInspectProc{
+		1:  {Cmdline: []string{"init"}},
+		2:  {PPID: 1, Cmdline: []string{"/proc/self/exe", "ring1"}, Env: []string{"GITPOD_WORKSPACE_ID=foobar", "GITPOD_INSTANCE_ID=baz"}},
+		3:  {PPID: 2, Cmdline: []string{"supervisor", "init"}},
+		4:  {PPID: 3, Cmdline: []string{"bash"}},
+		5:  {PPID: 4, Cmdline: []string{"bad-actor", "has", "args"}},
+		6:  {PPID: 3, Cmdline: []string{"another-bad-actor"}},
+		20: {PPID: 1, Cmdline: []string{"containerd"}},
+	}
+	var recording bytes.Buffer
+	for _, pid := range []int{5, 3, 20, 6, 99} {
+		recording.Write(recordProcEvent(procEventExec, pid))
+	}
+
+	det, err := newExecDetector(&RecordedExecEventSource{Reader: &recording}, proc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ps, err := det.DiscoverProcesses(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res []Process
+	for p := range ps {
+		res = append(res, p)
+	}
+
+	expectation := []Process{
+		{PID: 5, Path: "proc/5/exe", CommandLine: []string{"bad-actor", "has", "args"}, Kind: ProcessUserWorkload, Workspace: ws},
+		{PID: 6, Path: "proc/6/exe", CommandLine: []string{"another-bad-actor"}, Kind: ProcessUserWorkload, Workspace: ws},
+	}
+	if diff := cmp.Diff(expectation, res); diff != "" {
+		t.Errorf("unexpected processes (-want +got):\n%s", diff)
+	}
+
+	_, err = det.DiscoverProcesses(ctx)
+	if err == nil {
+		t.Error("expected an error when discovering processes twice")
+	}
+}
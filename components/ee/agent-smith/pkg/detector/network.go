@@ -0,0 +1,284 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package detector
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// tcpConnection is an entry of /proc/<pid>/net/tcp or /proc/<pid>/net/tcp6
+type tcpConnection struct {
+	LocalAddr  net.IP
+	LocalPort  uint16
+	RemoteAddr net.IP
+	RemotePort uint16
+	State      uint8
+}
+
+const (
+	// see include/net/tcp_states.h
+	tcpEstablished = 0x01
+	tcpSynSent     = 0x02
+	tcpListen      = 0x0a
+)
+
+type connectionProcFS interface {
+	discoverableProcFS
+	TCPConnections(pid int) ([]tcpConnection, error)
+}
+
+var _ connectionProcFS = realProcfs{}
+
+// TCPConnections lists the TCP connections of the network namespace of a process
+func (fs realProcfs) TCPConnections(pid int) ([]tcpConnection, error) {
+	var res []tcpConnection
+	for _, fn := range []string{"tcp", "tcp6"} {
+		f, err := os.Open(fmt.Sprintf("/proc/%d/net/%s", pid, fn))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		conns, err := parseProcNetTCP(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, conns...)
+	}
+	return res, nil
+}
+
+func parseProcNetTCP(r io.Reader) ([]tcpConnection, error) {
+	var res []tcpConnection
+	scan := bufio.NewScanner(r)
+	// skip the header
+	scan.Scan()
+	for scan.Scan() {
+		fields := strings.Fields(scan.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		localAddr, localPort, err := parseProcNetAddr(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		addr, port, err := parseProcNetAddr(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		state, err := strconv.ParseUint(fields[3], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse connection state %q: %w", fields[3], err)
+		}
+		res = append(res, tcpConnection{LocalAddr: localAddr, LocalPort: localPort, RemoteAddr: addr, RemotePort: port, State: uint8(state)})
+	}
+	if err := scan.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// parseProcNetAddr parses an address of the form 0100007F:1F90. The IP address is
+// a sequence of 32 bit words in host byte order.
+func parseProcNetAddr(s string) (net.IP, uint16, error) {
+	segs := strings.Split(s, ":")
+	if len(segs) != 2 {
+		return nil, 0, fmt.Errorf("invalid address %q", s)
+	}
+	ipb, err := hex.DecodeString(segs[0])
+	if err != nil || (len(ipb) != net.IPv4len && len(ipb) != net.IPv6len) {
+		return nil, 0, fmt.Errorf("invalid address %q", s)
+	}
+	ip := make(net.IP, len(ipb))
+	for i := 0; i < len(ipb); i += 4 {
+		binary.BigEndian.PutUint32(ip[i:i+4], hostByteOrder.Uint32(ipb[i:i+4]))
+	}
+	port, err := strconv.ParseUint(segs[1], 16, 16)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid port in %q: %w", s, err)
+	}
+	return ip, uint16(port), nil
+}
+
+var _ ConnectionDetector = &NetworkDetector{}
+
+// NetworkDetector detects outbound connections of workspaces to a set of remote ports, e.g. those of mining pools.
+// Every workspace has its own network namespace, which we inspect through the workspace's supervisor process.
+type NetworkDetector struct {
+	mu sync.RWMutex
+	cs chan Connection
+
+	connectionsTotal prometheus.Counter
+	scanErrorsTotal  prometheus.Counter
+
+	startOnce sync.Once
+
+	ports    map[uint16]struct{}
+	interval time.Duration
+	proc     connectionProcFS
+	cache    *lru.Cache
+}
+
+// NewNetworkDetector creates a detector which reports connections to the given remote ports
+func NewNetworkDetector(ports []uint16) (*NetworkDetector, error) {
+	p, err := procfs.NewFS("/proc")
+	if err != nil {
+		return nil, err
+	}
+	return newNetworkDetector(ports, realProcfs(p))
+}
+
+func newNetworkDetector(ports []uint16, proc connectionProcFS) (*NetworkDetector, error) {
+	cache, err := lru.New(2000)
+	if err != nil {
+		return nil, err
+	}
+
+	pm := make(map[uint16]struct{}, len(ports))
+	for _, p := range ports {
+		pm[p] = struct{}{}
+	}
+
+	return &NetworkDetector{
+		connectionsTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "gitpod",
+			Subsystem: "agent_smith_network_detector",
+			Name:      "connections_total",
+			Help:      "number of detected connections to watched ports",
+		}),
+		scanErrorsTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "gitpod",
+			Subsystem: "agent_smith_network_detector",
+			Name:      "scan_errors_total",
+			Help:      "number of workspaces whose connections could not be listed",
+		}),
+		ports:    pm,
+		interval: 30 * time.Second,
+		proc:     proc,
+		cache:    cache,
+	}, nil
+}
+
+func (det *NetworkDetector) Describe(d chan<- *prometheus.Desc) {
+	det.connectionsTotal.Describe(d)
+	det.scanErrorsTotal.Describe(d)
+}
+
+func (det *NetworkDetector) Collect(m chan<- prometheus.Metric) {
+	det.connectionsTotal.Collect(m)
+	det.scanErrorsTotal.Collect(m)
+}
+
+// DiscoverConnections starts connection discovery. Must not be called more than once.
+func (det *NetworkDetector) DiscoverConnections(ctx context.Context) (<-chan Connection, error) {
+	det.mu.Lock()
+	defer det.mu.Unlock()
+
+	if det.cs != nil {
+		return nil, fmt.Errorf("already discovering connections")
+	}
+	res := make(chan Connection, 100)
+	det.cs = res
+	det.startOnce.Do(func() {
+		go func() {
+			t := time.NewTicker(det.interval)
+			defer t.Stop()
+
+			for {
+				det.run(ctx, res)
+				select {
+				case <-ctx.Done():
+					return
+				case <-t.C:
+				}
+			}
+		}()
+		log.Info("network detector started")
+	})
+
+	return res, nil
+}
+
+func (det *NetworkDetector) run(ctx context.Context, connections chan<- Connection) {
+	idx := det.proc.Discover()
+	root, ok := idx[1]
+	if !ok {
+		log.Error("cannot find pid 1")
+		return
+	}
+	findWorkspaces(det.proc, root, 0, nil)
+
+	for _, p := range idx {
+		if p.Kind != ProcessSandbox || p.Workspace == nil {
+			continue
+		}
+
+		ws := p.Workspace
+		conns, err := det.proc.TCPConnections(ws.PID)
+		if err != nil {
+			det.scanErrorsTotal.Inc()
+			log.WithError(err).WithFields(log.OWI(ws.OwnerID, ws.WorkspaceID, ws.InstanceID)).Debug("cannot list connections of workspace")
+			continue
+		}
+		// Connections accepted by a listening socket share its local port. Those are inbound, e.g. someone
+		// connecting to a server in the workspace, and must not be mistaken for connections to a remote port.
+		listening := make(map[uint16]struct{})
+		for _, c := range conns {
+			if c.State == tcpListen {
+				listening[c.LocalPort] = struct{}{}
+			}
+		}
+		for _, c := range conns {
+			if c.State != tcpEstablished && c.State != tcpSynSent {
+				continue
+			}
+			if _, inbound := listening[c.LocalPort]; inbound && c.State == tcpEstablished {
+				continue
+			}
+			if _, watched := det.ports[c.RemotePort]; !watched {
+				continue
+			}
+
+			remote := net.JoinHostPort(c.RemoteAddr.String(), strconv.Itoa(int(c.RemotePort)))
+			key := ws.InstanceID + "/" + remote
+			if _, ok := det.cache.Get(key); ok {
+				continue
+			}
+			det.cache.Add(key, struct{}{})
+			det.connectionsTotal.Inc()
+
+			conn := Connection{
+				RemoteAddr: c.RemoteAddr.String(),
+				RemotePort: c.RemotePort,
+				Workspace:  ws,
+			}
+			log.WithField("connection", conn).Debug("found connection")
+			select {
+			case connections <- conn:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package detector
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type memoryConnectionProc struct {
+	memoryProc
+	Conns map[int][]tcpConnection
+}
+
+func (p memoryConnectionProc) TCPConnections(pid int) ([]tcpConnection, error) {
+	conns, ok := p.Conns[pid]
+	if !ok {
+		return nil, fmt.Errorf("process does not exist")
+	}
+	return conns, nil
+}
+
+func TestParseProcNetTCP(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Input       string
+		Expectation []tcpConnection
+	}{
+		{
+			Name: "ipv4",
+			Input: `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0100007F:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000 33333        0 12345 1 0000000000000000 100 0 0 10 0
+   1: 0A00000A:D2F4 0A0A0A5D:0D05 01 00000000:00000000 02:000A7B2E 00000000 33333        0 12346 2 0000000000000000 20 4 30 10 -1
+`,
+			Expectation: []tcpConnection{
+				{LocalAddr: net.IPv4(127, 0, 0, 1).To4(), LocalPort: 8080, RemoteAddr: net.IPv4(0, 0, 0, 0).To4(), RemotePort: 0, State: tcpListen},
+				{LocalAddr: net.IPv4(10, 0, 0, 10).To4(), LocalPort: 54004, RemoteAddr: net.IPv4(93, 10, 10, 10).To4(), RemotePort: 3333, State: tcpEstablished},
+			},
+		},
+		{
+			Name: "ipv6",
+			Input: `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000000000000000000001000000:0016 B80D0120000000000000000001000000:115C 02 00000000:00000000 00:00000000 00000000     0        0 1 1 0000000000000000 100 0 0 10 0
+`,
+			Expectation: []tcpConnection{
+				{LocalAddr: net.ParseIP("::1"), LocalPort: 22, RemoteAddr: net.ParseIP("2001:db8::1"), RemotePort: 4444, State: tcpSynSent},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := parseProcNetTCP(strings.NewReader(test.Input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected connections (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRunNetworkDetector(t *testing.T) {
+	procs := make(memoryProc)
+	procs[1] = memoryProcEntry{P: &process{PID: 1}}
+	procs[2] = memoryProcEntry{
+		P:   &process{PID: 2, Parent: procs[1].P, Cmdline: []string{"/proc/self/exe", "ring1"}},
+		Env: []string{"GITPOD_WORKSPACE_ID=foobar", "GITPOD_INSTANCE_ID=baz"},
+	}
+	procs[3] = memoryProcEntry{P: &process{PID: 3, Parent: procs[2].P, Cmdline: []string{"supervisor", "init"}}}
+	procs[1].P.Children = []*process{procs[2].P}
+	procs[2].P.Children = []*process{procs[3].P}
+
+	proc := memoryConnectionProc{
+		memoryProc: procs,
+		Conns: map[int][]tcpConnection{
+			3: {
+				{LocalPort: 54004, RemoteAddr: net.ParseIP("93.10.10.10"), RemotePort: 3333, State: tcpEstablished},
+				{LocalPort: 54005, RemoteAddr: net.ParseIP("93.10.10.10"), RemotePort: 443, State: tcpEstablished},
+				{LocalPort: 54006, RemoteAddr: net.ParseIP("93.10.10.11"), RemotePort: 4444, State: 0x06},
+				// inbound connection to a server listening in the workspace, whose client happens to use a watched port
+				{LocalPort: 8080, State: tcpListen},
+				{LocalPort: 8080, RemoteAddr: net.ParseIP("93.10.10.12"), RemotePort: 4444, State: tcpEstablished},
+			},
+		},
+	}
+	det, err := newNetworkDetector([]uint16{3333, 4444}, proc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := make(chan Connection, 10)
+	det.run(context.Background(), res)
+	// connections are reported once only
+	det.run(context.Background(), res)
+	close(res)
+
+	var act []Connection
+	for c := range res {
+		act = append(act, c)
+	}
+	expectation := []Connection{
+		{RemoteAddr: "93.10.10.10", RemotePort: 3333, Workspace: ws},
+	}
+	if diff := cmp.Diff(expectation, act); diff != "" {
+		t.Errorf("unexpected connections (-want +got):\n%s", diff)
+	}
+}
@@ -29,6 +29,10 @@ import (
 
 type discoverableProcFS interface {
 	Discover() map[int]*process
+	environProcFS
+}
+
+type environProcFS interface {
 	Environ(pid int) ([]string, error)
 }
 
@@ -375,6 +379,7 @@ func (det *ProcfsDetector) run(processes chan<- Process) {
 		det.cache.Add(p.Hash, struct{}{})
 
 		proc := Process{
+			PID:         p.PID,
 			Path:        p.Path,
 			CommandLine: p.Cmdline,
 			Kind:        p.Kind,
@@ -428,7 +433,7 @@ func isSupervisor(cmdline []string) bool {
 	return len(cmdline) == 2 && cmdline[0] == "supervisor" && cmdline[1] == "init"
 }
 
-func extractWorkspaceFromWorkspacekit(proc discoverableProcFS, pid int) *common.Workspace {
+func extractWorkspaceFromWorkspacekit(proc environProcFS, pid int) *common.Workspace {
 	env, err := proc.Environ(pid)
 	if err != nil {
 		log.WithError(err).Debug("cannot get environment from process - might have missed a workspace")
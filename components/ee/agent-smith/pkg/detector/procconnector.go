@@ -0,0 +1,140 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package detector
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"golang.org/x/xerrors"
+)
+
+// ExecEvent is emitted when a process calls exec
+type ExecEvent struct {
+	PID int
+}
+
+// ExecEventSource produces process-exec events
+type ExecEventSource interface {
+	// ExecEvents starts producing events. The channel is closed once the source is exhausted.
+	ExecEvents(ctx context.Context) (<-chan ExecEvent, error)
+}
+
+// The proc connector and procfs report values in host byte order. agent-smith runs on amd64 and arm64 nodes only.
+var hostByteOrder binary.ByteOrder = binary.LittleEndian
+
+const (
+	// see linux/netlink.h, linux/connector.h and linux/cn_proc.h
+	nlmsgHdrLen = 16
+	cnMsgLen    = 20
+
+	nlmsgNoop  = 0x1
+	nlmsgError = 0x2
+
+	cnIdxProc = 0x1
+	cnValProc = 0x1
+
+	procCnMcastListen = 1
+	procEventExec     = 0x00000002
+
+	// procEventHdrLen is the size of what, cpu and timestamp_ns of struct proc_event
+	procEventHdrLen = 16
+)
+
+// parseProcConnectorMessages parses the netlink messages of a single datagram of the proc connector
+// and returns the exec events they contain.
+func parseProcConnectorMessages(b []byte) ([]ExecEvent, error) {
+	var res []ExecEvent
+	for len(b) >= nlmsgHdrLen {
+		msgLen := int(hostByteOrder.Uint32(b[0:4]))
+		msgType := hostByteOrder.Uint16(b[4:6])
+		if msgLen < nlmsgHdrLen || msgLen > len(b) {
+			return res, xerrors.Errorf("invalid netlink message length %d", msgLen)
+		}
+		msg := b[nlmsgHdrLen:msgLen]
+		// netlink messages are 4-byte aligned
+		next := (msgLen + 3) &^ 3
+		if next > len(b) {
+			next = len(b)
+		}
+		b = b[next:]
+
+		if msgType == nlmsgNoop || msgType == nlmsgError {
+			continue
+		}
+		if evt, ok := parseProcEvent(msg); ok {
+			res = append(res, evt)
+		}
+	}
+	return res, nil
+}
+
+// parseProcEvent parses a cn_msg carrying a proc_event
+func parseProcEvent(msg []byte) (ExecEvent, bool) {
+	if len(msg) < cnMsgLen {
+		return ExecEvent{}, false
+	}
+	idx := hostByteOrder.Uint32(msg[0:4])
+	val := hostByteOrder.Uint32(msg[4:8])
+	if idx != cnIdxProc || val != cnValProc {
+		return ExecEvent{}, false
+	}
+	evt := msg[cnMsgLen:]
+	if len(evt) < procEventHdrLen+8 {
+		return ExecEvent{}, false
+	}
+	if hostByteOrder.Uint32(evt[0:4]) != procEventExec {
+		return ExecEvent{}, false
+	}
+	// exec_proc_event: process_pid, process_tgid
+	tgid := hostByteOrder.Uint32(evt[procEventHdrLen+4 : procEventHdrLen+8])
+	return ExecEvent{PID: int(tgid)}, true
+}
+
+// RecordedExecEventSource replays a recording of proc connector netlink messages, i.e. the concatenated
+// datagrams as received from the netlink socket.
+type RecordedExecEventSource struct {
+	Reader io.Reader
+}
+
+var _ ExecEventSource = &RecordedExecEventSource{}
+
+// ExecEvents replays the recorded events
+func (src *RecordedExecEventSource) ExecEvents(ctx context.Context) (<-chan ExecEvent, error) {
+	res := make(chan ExecEvent, 100)
+	go func() {
+		defer close(res)
+
+		hdr := make([]byte, nlmsgHdrLen)
+		for {
+			_, err := io.ReadFull(src.Reader, hdr)
+			if err != nil {
+				return
+			}
+			msgLen := int(hostByteOrder.Uint32(hdr[0:4]))
+			if msgLen < nlmsgHdrLen {
+				return
+			}
+			msg := make([]byte, (msgLen+3)&^3)
+			copy(msg, hdr)
+			n, err := io.ReadFull(src.Reader, msg[nlmsgHdrLen:])
+			if err != nil && (!errors.Is(err, io.ErrUnexpectedEOF) || nlmsgHdrLen+n < msgLen) {
+				return
+			}
+
+			evts, _ := parseProcConnectorMessages(msg[:msgLen])
+			for _, evt := range evts {
+				select {
+				case res <- evt:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return res, nil
+}
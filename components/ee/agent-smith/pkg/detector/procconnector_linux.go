@@ -0,0 +1,113 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package detector
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// procConnectorRecvTimeout bounds how long a receive blocks, i.e. how quickly the source notices it was stopped
+const procConnectorRecvTimeout = 1 * time.Second
+
+// ProcConnectorSource receives exec events from the kernel's proc connector. Unlike polling procfs
+// this sees every exec, including short-lived processes. Requires CAP_NET_ADMIN.
+type ProcConnectorSource struct {
+	fd int
+}
+
+var _ ExecEventSource = &ProcConnectorSource{}
+
+// NewProcConnectorSource subscribes to the proc connector
+func NewProcConnectorSource() (*ProcConnectorSource, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.NETLINK_CONNECTOR)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create netlink socket: %w", err)
+	}
+	err = unix.Bind(fd, &unix.SockaddrNetlink{
+		Family: unix.AF_NETLINK,
+		Groups: cnIdxProc,
+		Pid:    uint32(os.Getpid()),
+	})
+	if err != nil {
+		unix.Close(fd)
+		return nil, xerrors.Errorf("cannot bind netlink socket: %w", err)
+	}
+	// Closing the socket does not reliably unblock a pending receive, hence we time out receives and check
+	// whether we were stopped in between.
+	tv := unix.NsecToTimeval(procConnectorRecvTimeout.Nanoseconds())
+	err = unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv)
+	if err != nil {
+		unix.Close(fd)
+		return nil, xerrors.Errorf("cannot set netlink socket receive timeout: %w", err)
+	}
+
+	// nlmsghdr + cn_msg + enum proc_cn_mcast_op
+	msg := make([]byte, nlmsgHdrLen+cnMsgLen+4)
+	hostByteOrder.PutUint32(msg[0:4], uint32(len(msg)))
+	hostByteOrder.PutUint16(msg[4:6], unix.NLMSG_DONE)
+	hostByteOrder.PutUint32(msg[12:16], uint32(os.Getpid()))
+	hostByteOrder.PutUint32(msg[nlmsgHdrLen:nlmsgHdrLen+4], cnIdxProc)
+	hostByteOrder.PutUint32(msg[nlmsgHdrLen+4:nlmsgHdrLen+8], cnValProc)
+	hostByteOrder.PutUint16(msg[nlmsgHdrLen+16:nlmsgHdrLen+18], 4)
+	hostByteOrder.PutUint32(msg[nlmsgHdrLen+cnMsgLen:], procCnMcastListen)
+	err = unix.Sendto(fd, msg, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK})
+	if err != nil {
+		unix.Close(fd)
+		return nil, xerrors.Errorf("cannot subscribe to proc connector: %w", err)
+	}
+
+	return &ProcConnectorSource{fd: fd}, nil
+}
+
+// ExecEvents reads events from the proc connector until the context is canceled
+func (src *ProcConnectorSource) ExecEvents(ctx context.Context) (<-chan ExecEvent, error) {
+	res := make(chan ExecEvent, 100)
+	go func() {
+		defer close(res)
+		// the socket is only closed once we stopped receiving, so that its fd cannot be reused while still in use
+		defer unix.Close(src.fd)
+
+		buf := make([]byte, os.Getpagesize())
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			n, _, err := unix.Recvfrom(src.fd, buf, 0)
+			if err == unix.EINTR || err == unix.EAGAIN || err == unix.EWOULDBLOCK {
+				continue
+			}
+			if err == unix.ENOBUFS {
+				// the kernel dropped events because we did not keep up
+				log.Warn("proc connector receive buffer overflow - missed exec events")
+				continue
+			}
+			if err != nil {
+				log.WithError(err).Error("cannot receive from proc connector")
+				return
+			}
+
+			evts, err := parseProcConnectorMessages(buf[:n])
+			if err != nil {
+				log.WithError(err).Debug("cannot parse proc connector message")
+			}
+			for _, evt := range evts {
+				select {
+				case res <- evt:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return res, nil
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+//go:build !linux
+// +build !linux
+
+package detector
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+)
+
+// ProcConnectorSource receives exec events from the kernel's proc connector, which is available on Linux only
+type ProcConnectorSource struct{}
+
+// NewProcConnectorSource returns an error on this platform
+func NewProcConnectorSource() (*ProcConnectorSource, error) {
+	return nil, xerrors.Errorf("the proc connector is only supported on Linux")
+}
+
+// ExecEvents returns an error on this platform
+func (src *ProcConnectorSource) ExecEvents(ctx context.Context) (<-chan ExecEvent, error) {
+	return nil, xerrors.Errorf("the proc connector is only supported on Linux")
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package rules
+
+import (
+	"strconv"
+
+	"github.com/google/cel-go/cel"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/config"
+)
+
+// costLimit bounds the work a single rule evaluation may do, e.g. when iterating over the environment
+const costLimit = 100000
+
+// Result is the outcome of evaluating a rule against a snapshot
+type Result struct {
+	Rule    string
+	Matched bool
+	Penalty config.PenaltyKind
+	// DryRun is true if the penalty of the rule must not be applied
+	DryRun bool
+	Err    error
+}
+
+type compiledRule struct {
+	config.Rule
+	prg cel.Program
+}
+
+// Engine evaluates policy rules against process snapshots
+type Engine struct {
+	rules  []compiledRule
+	dryRun bool
+
+	matchesTotal    *prometheus.CounterVec
+	evalErrorsTotal *prometheus.CounterVec
+}
+
+// NewEngine compiles the rules. All rules are evaluated in dry-run mode if cfg.DryRun is set.
+func NewEngine(cfg config.Rules) (*Engine, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := cel.NewEnv(
+		cel.Variable("process", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("workspace", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create rule environment: %w", err)
+	}
+
+	res := &Engine{
+		dryRun: cfg.DryRun,
+		matchesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gitpod_agent_smith",
+			Subsystem: "rules",
+			Name:      "matches_total",
+			Help:      "total count of rule matches",
+		}, []string{"rule", "dry_run"}),
+		evalErrorsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gitpod_agent_smith",
+			Subsystem: "rules",
+			Name:      "evaluation_errors_total",
+			Help:      "total count of failed rule evaluations",
+		}, []string{"rule"}),
+	}
+	for _, rule := range cfg.Rules {
+		ast, iss := env.Compile(rule.Expression)
+		if iss.Err() != nil {
+			return nil, xerrors.Errorf("%s: cannot compile expression: %w", rule.Name, iss.Err())
+		}
+		if !cel.BoolType.IsAssignableType(ast.OutputType()) {
+			return nil, xerrors.Errorf("%s: expression must evaluate to bool, not %s", rule.Name, ast.OutputType())
+		}
+		prg, err := env.Program(ast, cel.CostLimit(costLimit))
+		if err != nil {
+			return nil, xerrors.Errorf("%s: %w", rule.Name, err)
+		}
+		res.rules = append(res.rules, compiledRule{Rule: rule, prg: prg})
+	}
+
+	return res, nil
+}
+
+// Len returns the number of rules of this engine
+func (e *Engine) Len() int {
+	return len(e.rules)
+}
+
+// Evaluate evaluates all rules against the snapshot. Rules whose evaluation fails,
+// e.g. because they access an environment variable the process does not have, do not match.
+func (e *Engine) Evaluate(s *Snapshot) []Result {
+	act := s.activation()
+
+	res := make([]Result, 0, len(e.rules))
+	for _, rule := range e.rules {
+		r := Result{
+			Rule:    rule.Name,
+			Penalty: rule.Penalty,
+			DryRun:  e.dryRun || rule.DryRun,
+		}
+
+		val, _, err := rule.prg.Eval(act)
+		if err != nil {
+			e.evalErrorsTotal.WithLabelValues(rule.Name).Inc()
+			r.Err = xerrors.Errorf("%s: %w", rule.Name, err)
+			res = append(res, r)
+			continue
+		}
+		matched, ok := val.Value().(bool)
+		if !ok {
+			e.evalErrorsTotal.WithLabelValues(rule.Name).Inc()
+			r.Err = xerrors.Errorf("%s: expression evaluated to %v instead of bool", rule.Name, val.Value())
+			res = append(res, r)
+			continue
+		}
+		r.Matched = matched
+		if matched {
+			e.matchesTotal.WithLabelValues(rule.Name, strconv.FormatBool(r.DryRun)).Inc()
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+func (e *Engine) Describe(d chan<- *prometheus.Desc) {
+	e.matchesTotal.Describe(d)
+	e.evalErrorsTotal.Describe(d)
+}
+
+func (e *Engine) Collect(m chan<- prometheus.Metric) {
+	e.matchesTotal.Collect(m)
+	e.evalErrorsTotal.Collect(m)
+}
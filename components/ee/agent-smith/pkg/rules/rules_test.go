@@ -0,0 +1,185 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package rules_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/config"
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/rules"
+)
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	miner := &rules.Snapshot{
+		Time: now,
+		Process: rules.ProcessSnapshot{
+			PID:         42,
+			Executable:  "/workspace/xmrig-6.18/xmrig",
+			CommandLine: []string{"./xmrig", "-o", "pool.example.com:3333"},
+			Environment: map[string]string{"GITPOD_REPO_ROOT": "/workspace/miner"},
+			StartedAt:   now.Add(-2 * time.Minute),
+			CPUSeconds:  240,
+			Parent: &rules.ProcessSnapshot{
+				PID:         41,
+				Executable:  "/usr/bin/bash",
+				CommandLine: []string{"bash"},
+				StartedAt:   now.Add(-3 * time.Minute),
+			},
+		},
+		Workspace: rules.WorkspaceSnapshot{
+			OwnerID:     "owner",
+			WorkspaceID: "workspace",
+			InstanceID:  "instance",
+			GitURL:      "https://github.com/gitpod-io/miner.git",
+			StartedAt:   now.Add(-5 * time.Minute),
+		},
+	}
+	young := *miner
+	young.Process.StartedAt = now.Add(-10 * time.Second)
+
+	tests := []struct {
+		Name        string
+		Rules       config.Rules
+		Snapshot    *rules.Snapshot
+		Expectation []rules.Result
+	}{
+		{
+			Name: "long running miner in young workspace",
+			Rules: config.Rules{Rules: []config.Rule{
+				{Name: "miner", Expression: `process.name.matches("xmrig") && process.runtime > duration("60s") && workspace.age < duration("10m")`, Penalty: config.PenaltyStopWorkspace},
+			}},
+			Snapshot:    miner,
+			Expectation: []rules.Result{{Rule: "miner", Matched: true, Penalty: config.PenaltyStopWorkspace}},
+		},
+		{
+			Name: "short running miner",
+			Rules: config.Rules{Rules: []config.Rule{
+				{Name: "miner", Expression: `process.name.matches("xmrig") && process.runtime > duration("60s")`, Penalty: config.PenaltyStopWorkspace},
+			}},
+			Snapshot:    &young,
+			Expectation: []rules.Result{{Rule: "miner", Penalty: config.PenaltyStopWorkspace}},
+		},
+		{
+			Name: "cpu, parent, cmdline and repo",
+			Rules: config.Rules{Rules: []config.Rule{
+				{Name: "cpu", Expression: `process.cpu >= 2.0`},
+				{Name: "parent", Expression: `process.parent.name == "bash"`},
+				{Name: "pool", Expression: `process.cmdline.exists(a, a.endsWith(":3333"))`},
+				{Name: "repo", Expression: `workspace.repo.startsWith("https://github.com/gitpod-io/")`},
+			}},
+			Snapshot: miner,
+			Expectation: []rules.Result{
+				{Rule: "cpu", Matched: true},
+				{Rule: "parent", Matched: true},
+				{Rule: "pool", Matched: true},
+				{Rule: "repo", Matched: true},
+			},
+		},
+		{
+			Name: "environment",
+			Rules: config.Rules{Rules: []config.Rule{
+				{Name: "has", Expression: `has(process.env.GITPOD_REPO_ROOT) && process.env.GITPOD_REPO_ROOT == "/workspace/miner"`},
+				{Name: "missing", Expression: `has(process.env.FOO)`},
+			}},
+			Snapshot: miner,
+			Expectation: []rules.Result{
+				{Rule: "has", Matched: true},
+				{Rule: "missing"},
+			},
+		},
+		{
+			Name: "missing parent",
+			Rules: config.Rules{Rules: []config.Rule{
+				{Name: "parent", Expression: `process.parent.name == "bash"`},
+			}},
+			Snapshot:    &rules.Snapshot{Time: now, Process: rules.ProcessSnapshot{PID: 1, Executable: "/bin/sh"}},
+			Expectation: []rules.Result{{Rule: "parent"}},
+		},
+		{
+			Name: "dry run",
+			Rules: config.Rules{DryRun: true, Rules: []config.Rule{
+				{Name: "miner", Expression: `process.name == "xmrig"`, Penalty: config.PenaltyStopWorkspaceAndBlockUser},
+			}},
+			Snapshot:    miner,
+			Expectation: []rules.Result{{Rule: "miner", Matched: true, Penalty: config.PenaltyStopWorkspaceAndBlockUser, DryRun: true}},
+		},
+		{
+			Name: "per-rule dry run",
+			Rules: config.Rules{Rules: []config.Rule{
+				{Name: "miner", Expression: `process.name == "xmrig"`, Penalty: config.PenaltyLimitCPU, DryRun: true},
+				{Name: "bash", Expression: `process.name == "bash"`, Penalty: config.PenaltyLimitCPU},
+			}},
+			Snapshot: miner,
+			Expectation: []rules.Result{
+				{Rule: "miner", Matched: true, Penalty: config.PenaltyLimitCPU, DryRun: true},
+				{Rule: "bash", Penalty: config.PenaltyLimitCPU},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			engine, err := rules.NewEngine(test.Rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			act := engine.Evaluate(test.Snapshot)
+			if diff := cmp.Diff(test.Expectation, act, cmpopts.IgnoreFields(rules.Result{}, "Err")); diff != "" {
+				t.Errorf("unexpected results (-want +got):\n%s", diff)
+			}
+			for _, r := range act {
+				if r.Err != nil {
+					t.Errorf("unexpected error: %v", r.Err)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateError(t *testing.T) {
+	engine, err := rules.NewEngine(config.Rules{Rules: []config.Rule{
+		{Name: "missing-env", Expression: `process.env.FOO == "bar"`},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	act := engine.Evaluate(&rules.Snapshot{})
+	if len(act) != 1 || act[0].Matched || act[0].Err == nil {
+		t.Errorf("expected evaluation error, got %+v", act)
+	}
+}
+
+func TestNewEngine(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Rules   config.Rules
+		Invalid bool
+	}{
+		{Name: "empty"},
+		{Name: "valid", Rules: config.Rules{Rules: []config.Rule{{Name: "a", Expression: `process.name == "xmrig"`, Penalty: config.PenaltyStopWorkspace}}}},
+		{Name: "missing name", Rules: config.Rules{Rules: []config.Rule{{Expression: "true"}}}, Invalid: true},
+		{Name: "duplicate name", Rules: config.Rules{Rules: []config.Rule{{Name: "a", Expression: "true"}, {Name: "a", Expression: "false"}}}, Invalid: true},
+		{Name: "missing expression", Rules: config.Rules{Rules: []config.Rule{{Name: "a"}}}, Invalid: true},
+		{Name: "unknown penalty", Rules: config.Rules{Rules: []config.Rule{{Name: "a", Expression: "true", Penalty: "self-destruct"}}}, Invalid: true},
+		{Name: "syntax error", Rules: config.Rules{Rules: []config.Rule{{Name: "a", Expression: `process.name ==`}}}, Invalid: true},
+		{Name: "unknown variable", Rules: config.Rules{Rules: []config.Rule{{Name: "a", Expression: `node.name == "foo"`}}}, Invalid: true},
+		{Name: "not a bool", Rules: config.Rules{Rules: []config.Rule{{Name: "a", Expression: `"foo"`}}}, Invalid: true},
+		{Name: "negative watch period", Rules: config.Rules{WatchMinutes: -1}, Invalid: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := rules.NewEngine(test.Rules)
+			if act := err != nil; act != test.Invalid {
+				t.Errorf("unexpected result: %v", err)
+			}
+		})
+	}
+}
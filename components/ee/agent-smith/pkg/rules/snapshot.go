@@ -0,0 +1,203 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package rules
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/prometheus/procfs"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/common"
+)
+
+// Snapshot captures the state of a process and its workspace at a point in time.
+// Rules are evaluated against snapshots, which can be serialised to test rules offline.
+type Snapshot struct {
+	// Time is when the snapshot was taken
+	Time      time.Time         `json:"time"`
+	Process   ProcessSnapshot   `json:"process"`
+	Workspace WorkspaceSnapshot `json:"workspace"`
+}
+
+// ProcessSnapshot describes a process
+type ProcessSnapshot struct {
+	PID         int               `json:"pid"`
+	Executable  string            `json:"executable"`
+	CommandLine []string          `json:"cmdline"`
+	Environment map[string]string `json:"env,omitempty"`
+	StartedAt   time.Time         `json:"startedAt"`
+	// CPUSeconds is the user and system CPU time the process has used
+	CPUSeconds float64 `json:"cpuSeconds"`
+
+	Parent *ProcessSnapshot `json:"parent,omitempty"`
+}
+
+// WorkspaceSnapshot describes the workspace a process runs in
+type WorkspaceSnapshot struct {
+	OwnerID     string    `json:"ownerId"`
+	WorkspaceID string    `json:"workspaceId"`
+	InstanceID  string    `json:"instanceId"`
+	GitURL      string    `json:"gitURL,omitempty"`
+	StartedAt   time.Time `json:"startedAt"`
+}
+
+// Capture takes a snapshot of a process which belongs to the workspace. The workspace PID
+// is used to determine the age of the workspace.
+func Capture(fs procfs.FS, pid int, ws *common.Workspace, now time.Time) (*Snapshot, error) {
+	proc, err := captureProcess(fs, pid, true)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Snapshot{
+		Time:    now,
+		Process: *proc,
+	}
+	if ws == nil {
+		return res, nil
+	}
+
+	res.Workspace = WorkspaceSnapshot{
+		OwnerID:     ws.OwnerID,
+		WorkspaceID: ws.WorkspaceID,
+		InstanceID:  ws.InstanceID,
+		GitURL:      ws.GitURL,
+	}
+	wsproc, err := fs.Proc(ws.PID)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot find workspace process %d: %w", ws.PID, err)
+	}
+	wsstat, err := wsproc.Stat()
+	if err != nil {
+		return nil, xerrors.Errorf("cannot stat workspace process %d: %w", ws.PID, err)
+	}
+	res.Workspace.StartedAt, err = startTime(wsstat)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func captureProcess(fs procfs.FS, pid int, withParent bool) (*ProcessSnapshot, error) {
+	proc, err := fs.Proc(pid)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := proc.Stat()
+	if err != nil {
+		return nil, err
+	}
+	cmdline, err := proc.CmdLine()
+	if err != nil {
+		return nil, err
+	}
+	started, err := startTime(stat)
+	if err != nil {
+		return nil, err
+	}
+	res := &ProcessSnapshot{
+		PID:         pid,
+		CommandLine: cmdline,
+		StartedAt:   started,
+		CPUSeconds:  stat.CPUTime(),
+	}
+	// The executable lives in the mount namespace of the workspace, hence the resolved
+	// path is only meaningful as a name. Processes of other users cannot be resolved at all.
+	res.Executable, _ = proc.Executable()
+	if res.Executable == "" && len(cmdline) > 0 {
+		res.Executable = cmdline[0]
+	}
+
+	env, err := proc.Environ()
+	if err == nil {
+		res.Environment = make(map[string]string, len(env))
+		for _, e := range env {
+			k, v, _ := strings.Cut(e, "=")
+			res.Environment[k] = v
+		}
+	}
+
+	if withParent && stat.PPID > 1 {
+		res.Parent, err = captureProcess(fs, stat.PPID, false)
+		if err != nil {
+			// the parent is gone already
+			res.Parent = nil
+		}
+	}
+
+	return res, nil
+}
+
+func startTime(stat procfs.ProcStat) (time.Time, error) {
+	st, err := stat.StartTime()
+	if err != nil {
+		return time.Time{}, xerrors.Errorf("cannot determine start time of process %d: %w", stat.PID, err)
+	}
+	return time.Unix(0, int64(st*float64(time.Second))), nil
+}
+
+func (s *Snapshot) activation() map[string]interface{} {
+	var wsAge time.Duration
+	if !s.Workspace.StartedAt.IsZero() {
+		wsAge = s.Time.Sub(s.Workspace.StartedAt)
+	}
+
+	proc := processActivation(&s.Process, s.Time)
+	proc["parent"] = processActivation(s.Process.Parent, s.Time)
+
+	return map[string]interface{}{
+		"process": proc,
+		"workspace": map[string]interface{}{
+			"ownerId":     s.Workspace.OwnerID,
+			"workspaceId": s.Workspace.WorkspaceID,
+			"instanceId":  s.Workspace.InstanceID,
+			"repo":        s.Workspace.GitURL,
+			"age":         wsAge,
+		},
+	}
+}
+
+func processActivation(p *ProcessSnapshot, now time.Time) map[string]interface{} {
+	if p == nil {
+		p = &ProcessSnapshot{}
+	}
+
+	var (
+		runtime time.Duration
+		cpu     float64
+	)
+	if !p.StartedAt.IsZero() {
+		runtime = now.Sub(p.StartedAt)
+	}
+	if runtime > 0 {
+		cpu = p.CPUSeconds / runtime.Seconds()
+	}
+	cmdline := p.CommandLine
+	if cmdline == nil {
+		cmdline = []string{}
+	}
+	env := p.Environment
+	if env == nil {
+		env = map[string]string{}
+	}
+
+	res := map[string]interface{}{
+		"pid":        p.PID,
+		"executable": p.Executable,
+		"name":       filepath.Base(p.Executable),
+		"cmdline":    cmdline,
+		"env":        env,
+		"runtime":    runtime,
+		"cpu":        cpu,
+	}
+	if p.Executable == "" {
+		res["name"] = ""
+	}
+	return res
+}
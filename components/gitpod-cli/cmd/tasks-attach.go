@@ -7,6 +7,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
 	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/utils"
@@ -21,6 +22,9 @@ import (
 var attachTaskCmdOpts struct {
 	Interactive bool
 	ForceResize bool
+	Replay      bool
+	Speed       float64
+	MaxIdle     time.Duration
 }
 
 // attachTaskCmd represents the attach task command
@@ -40,7 +44,23 @@ var attachTaskCmd = &cobra.Command{
 		if len(args) > 0 {
 			terminalAlias = args[0]
 		} else {
-			tasks, err := client.GetTasksListByState(cmd.Context(), api.TaskState_running)
+			var (
+				tasks []*api.TaskStatus
+				err   error
+			)
+			if attachTaskCmdOpts.Replay {
+				// closed tasks can be replayed, too
+				tasks, err = client.GetTasksList(cmd.Context())
+				filtered := tasks[:0]
+				for _, task := range tasks {
+					if task.Terminal != "" {
+						filtered = append(filtered, task)
+					}
+				}
+				tasks = filtered
+			} else {
+				tasks, err = client.GetTasksListByState(cmd.Context(), api.TaskState_running)
+			}
 			if err != nil {
 				return xerrors.Errorf("cannot get task list: %w", err)
 			}
@@ -85,6 +105,18 @@ var attachTaskCmd = &cobra.Command{
 			terminalAlias = tasks[taskIndex].Terminal
 		}
 
+		if attachTaskCmdOpts.Replay {
+			err := client.ReplayTerminal(cmd.Context(), terminalAlias, os.Stdout, supervisor.ReplayTerminalOpts{
+				Speed:   attachTaskCmdOpts.Speed,
+				MaxIdle: attachTaskCmdOpts.MaxIdle,
+			})
+			if e, ok := status.FromError(err); ok && e.Code() == codes.NotFound {
+				fmt.Println("There is no recording of terminal:", terminalAlias)
+				return nil
+			}
+			return err
+		}
+
 		terminal, err := client.Terminal.Get(cmd.Context(), &api.GetTerminalRequest{Alias: terminalAlias})
 		if err != nil {
 			if e, ok := status.FromError(err); ok {
@@ -125,4 +157,7 @@ func init() {
 
 	attachTaskCmd.Flags().BoolVarP(&attachTaskCmdOpts.Interactive, "interactive", "i", true, "assume control over the terminal")
 	attachTaskCmd.Flags().BoolVarP(&attachTaskCmdOpts.ForceResize, "force-resize", "r", true, "force this terminal's size irregardless of other clients")
+	attachTaskCmd.Flags().BoolVar(&attachTaskCmdOpts.Replay, "replay", false, "replay the recorded output of the task instead of attaching to it")
+	attachTaskCmd.Flags().Float64Var(&attachTaskCmdOpts.Speed, "speed", 1, "playback speed of --replay")
+	attachTaskCmd.Flags().DurationVar(&attachTaskCmdOpts.MaxIdle, "max-idle", 2*time.Second, "limit pauses during --replay to this duration, 0 for no limit")
 }
@@ -52,6 +52,7 @@ var listTasksCmd = &cobra.Command{
 			0: tablewriter.FgHiGreenColor,
 			1: tablewriter.FgHiGreenColor,
 			2: tablewriter.FgHiBlackColor,
+			3: tablewriter.FgHiYellowColor,
 		}
 
 		mapCurrentToColor := map[bool]int{
@@ -0,0 +1,93 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/utils"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+// restartTaskCmd represents the restart task command
+var restartTaskCmd = &cobra.Command{
+	Use:   "restart <id>",
+	Short: "Restart a workspace task",
+	Long:  "Restarts a workspace task. A running task is stopped first. Init commands are not run again.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := supervisor.New(cmd.Context())
+		if err != nil {
+			return xerrors.Errorf("cannot get task list: %w", err)
+		}
+		defer client.Close()
+
+		listCtx, cancelList := context.WithTimeout(cmd.Context(), 5*time.Second)
+		defer cancelList()
+		tasks, err := client.GetTasksList(listCtx)
+		if err != nil {
+			return xerrors.Errorf("cannot get task list: %w", err)
+		}
+		if len(tasks) == 0 {
+			fmt.Println("There are no tasks")
+			return nil
+		}
+
+		var taskID string
+		if len(args) > 0 {
+			for _, task := range tasks {
+				if task.Id == args[0] || (task.Terminal != "" && task.Terminal == args[0]) {
+					taskID = task.Id
+					break
+				}
+			}
+			if taskID == "" {
+				msg := fmt.Sprintf("The selected task was not found: %s.\nMake sure to use the correct task ID.\nUse 'gp tasks list' to obtain the task id or run 'gp tasks restart' to select the desired task\n", args[0])
+				return GpError{Err: xerrors.Errorf("task %s not found", args[0]), Message: msg, OutCome: utils.Outcome_UserErr}
+			}
+		} else if len(tasks) == 1 {
+			taskID = tasks[0].Id
+		} else {
+			var taskNames []string
+			for _, task := range tasks {
+				taskNames = append(taskNames, task.Presentation.Name)
+			}
+
+			prompt := promptui.Select{
+				Label:        "What task do you want to restart?",
+				Items:        taskNames,
+				HideSelected: true,
+			}
+
+			selectedIndex, selectedValue, err := prompt.Run()
+			if selectedValue == "" {
+				return nil
+			}
+			if err != nil {
+				return xerrors.Errorf("error occurred with the input prompt: %w", err)
+			}
+			taskID = tasks[selectedIndex].Id
+		}
+
+		// the timeout starts only once the task is selected, as users may take their time to do so
+		ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+		defer cancel()
+		_, err = client.Control.RestartTask(ctx, &api.RestartTaskRequest{Id: taskID})
+		if err != nil {
+			return xerrors.Errorf("cannot restart task: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	tasksCmd.AddCommand(restartTaskCmd)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"golang.org/x/xerrors"
+)
+
+type ReplayTerminalOpts struct {
+	// Speed scales the playback speed. Use 0 for real time.
+	Speed float64
+	// MaxIdle caps the pause between two outputs. Use 0 for no limit.
+	MaxIdle time.Duration
+	// Follow keeps replaying until the terminal is closed
+	Follow bool
+}
+
+// ReplayTerminal replays the recorded output of a terminal to out
+func (client *SupervisorClient) ReplayTerminal(ctx context.Context, alias string, out io.Writer, opts ReplayTerminalOpts) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	recording, err := client.Terminal.GetRecording(ctx, &api.GetTerminalRecordingRequest{
+		Alias:  alias,
+		Follow: opts.Follow,
+	})
+	if err != nil {
+		return xerrors.Errorf("cannot get terminal recording: %w", err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		for {
+			resp, err := recording.Recv()
+			if err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+				pw.CloseWithError(err)
+				return
+			}
+			_, err = pw.Write(resp.Data)
+			if err != nil {
+				return
+			}
+		}
+	}()
+	defer pr.Close()
+
+	return ReplayRecording(ctx, pr, out, opts)
+}
+
+// ReplayRecording writes the output of an asciicast v2 recording to out, retaining its timing
+func ReplayRecording(ctx context.Context, recording io.Reader, out io.Writer, opts ReplayTerminalOpts) error {
+	speed := opts.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+
+	rd := bufio.NewReader(recording)
+	line, err := rd.ReadBytes('\n')
+	if err != nil && (err != io.EOF || len(line) == 0) {
+		return xerrors.Errorf("cannot read recording header: %w", err)
+	}
+	var hdr struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(line, &hdr); err != nil {
+		return xerrors.Errorf("cannot parse recording header: %w", err)
+	}
+	if hdr.Version != 2 {
+		return xerrors.Errorf("unsupported recording version: %d", hdr.Version)
+	}
+
+	var last float64
+	for {
+		line, err := rd.ReadBytes('\n')
+		if len(line) > 0 {
+			var (
+				evt  []json.RawMessage
+				ts   float64
+				code string
+				data string
+			)
+			perr := json.Unmarshal(line, &evt)
+			if perr == nil && len(evt) != 3 {
+				perr = xerrors.Errorf("expected 3 fields, got %d", len(evt))
+			}
+			if perr == nil {
+				perr = json.Unmarshal(evt[0], &ts)
+			}
+			if perr == nil {
+				perr = json.Unmarshal(evt[1], &code)
+			}
+			if perr == nil {
+				perr = json.Unmarshal(evt[2], &data)
+			}
+			if perr != nil {
+				return xerrors.Errorf("cannot parse recording event: %w", perr)
+			}
+			if code != "o" {
+				continue
+			}
+
+			delay := time.Duration((ts - last) / speed * float64(time.Second))
+			if opts.MaxIdle > 0 && delay > opts.MaxIdle {
+				delay = opts.MaxIdle
+			}
+			last = ts
+			if delay > 0 {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(delay):
+				}
+			}
+			if _, werr := io.WriteString(out, data); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditLog records a call of a mutating API.
+type AuditLog struct {
+	ID             uuid.UUID `gorm:"primary_key;column:id;type:char;size:36;" json:"id"`
+	Timestamp      time.Time `gorm:"column:timestamp;type:timestamp;" json:"timestamp"`
+	OrganizationID string    `gorm:"column:organizationId;type:char;size:36;" json:"organizationId"`
+	ActorID        string    `gorm:"column:actorId;type:char;size:36;" json:"actorId"`
+	Action         string    `gorm:"column:action;type:varchar;size:255;" json:"action"`
+	TargetType     string    `gorm:"column:targetType;type:varchar;size:64;" json:"targetType"`
+	TargetID       string    `gorm:"column:targetId;type:varchar;size:255;" json:"targetId"`
+	Details        string    `gorm:"column:details;type:text;size:65535;" json:"details"`
+	Origin         string    `gorm:"column:origin;type:varchar;size:255;" json:"origin"`
+	Status         string    `gorm:"column:status;type:varchar;size:64;" json:"status"`
+
+	LastModified time.Time `gorm:"column:_lastModified;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"_lastModified"`
+}
+
+// TableName sets the insert table name for this struct type
+func (a *AuditLog) TableName() string {
+	return "d_b_audit_log"
+}
+
+func CreateAuditLog(ctx context.Context, conn *gorm.DB, entry AuditLog) (AuditLog, error) {
+	if entry.ID == uuid.Nil {
+		return AuditLog{}, errors.New("id must be set")
+	}
+
+	if entry.Action == "" {
+		return AuditLog{}, errors.New("action must be set")
+	}
+
+	if entry.Timestamp.IsZero() {
+		return AuditLog{}, errors.New("timestamp must be set")
+	}
+
+	tx := conn.
+		WithContext(ctx).
+		Create(&entry)
+	if tx.Error != nil {
+		return AuditLog{}, fmt.Errorf("failed to create audit log: %w", tx.Error)
+	}
+
+	return entry, nil
+}
+
+type ListAuditLogsOpts struct {
+	// OrganizationID restricts the results to audit logs of this organization, if set.
+	OrganizationID string
+	// ActorID restricts the results to audit logs of this actor, if set.
+	// Audit logs of actions which do not belong to an organization can only be listed by actor.
+	ActorID string
+	// From restricts the results to audit logs at or after this time, if set.
+	From time.Time
+	// To restricts the results to audit logs before this time, if set.
+	To time.Time
+}
+
+// ListAuditLogs lists the audit logs of an organization or of an actor, most recent first.
+func ListAuditLogs(ctx context.Context, conn *gorm.DB, opts ListAuditLogsOpts, pagination Pagination) (*PaginatedResult[AuditLog], error) {
+	if opts.OrganizationID == "" && opts.ActorID == "" {
+		return nil, fmt.Errorf("organization ID or actor ID is a required argument to list audit logs, got empty")
+	}
+
+	query := func() *gorm.DB {
+		q := conn.
+			WithContext(ctx).
+			Table((&AuditLog{}).TableName())
+		if opts.OrganizationID != "" {
+			q = q.Where("organizationId = ?", opts.OrganizationID)
+		}
+		if opts.ActorID != "" {
+			q = q.Where("actorId = ?", opts.ActorID)
+		}
+		if !opts.From.IsZero() {
+			q = q.Where("timestamp >= ?", opts.From)
+		}
+		if !opts.To.IsZero() {
+			q = q.Where("timestamp < ?", opts.To)
+		}
+		return q
+	}
+
+	var results []AuditLog
+	tx := query().
+		Order("timestamp DESC").
+		Order("id").
+		Scopes(Paginate(pagination)).
+		Find(&results)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", tx.Error)
+	}
+
+	var count int64
+	tx = query().Count(&count)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to count total number of audit logs: %w", tx.Error)
+	}
+
+	return &PaginatedResult[AuditLog]{
+		Results: results,
+		Total:   count,
+	}, nil
+}
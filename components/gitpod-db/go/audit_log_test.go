@@ -0,0 +1,78 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAuditLogs(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+
+	orgID, actorID := uuid.NewString(), uuid.NewString()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	entries := dbtest.CreateAuditLogs(t, conn,
+		db.AuditLog{OrganizationID: orgID, ActorID: actorID, Timestamp: now.Add(-2 * time.Hour)},
+		db.AuditLog{OrganizationID: orgID, ActorID: actorID, Timestamp: now.Add(-1 * time.Hour)},
+		db.AuditLog{OrganizationID: orgID, Timestamp: now},
+		db.AuditLog{},
+	)
+
+	// actions which do not belong to an organization are recorded with an empty organization
+	withoutOrganization := dbtest.NewAuditLog(t, db.AuditLog{ActorID: actorID, Timestamp: now.Add(-3 * time.Hour)})
+	withoutOrganization.OrganizationID = ""
+	_, err := db.CreateAuditLog(ctx, conn, withoutOrganization)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		dbtest.HardDeleteAuditLogs(t, withoutOrganization.ID.String())
+	})
+
+	t.Run("lists audit logs of the organization, most recent first", func(t *testing.T) {
+		result, err := db.ListAuditLogs(ctx, conn, db.ListAuditLogsOpts{OrganizationID: orgID}, db.Pagination{PageSize: 2, Page: 1})
+		require.NoError(t, err)
+		require.EqualValues(t, 3, result.Total)
+		require.Len(t, result.Results, 2)
+		require.Equal(t, entries[2].ID, result.Results[0].ID)
+		require.Equal(t, entries[1].ID, result.Results[1].ID)
+	})
+
+	t.Run("filters by actor", func(t *testing.T) {
+		result, err := db.ListAuditLogs(ctx, conn, db.ListAuditLogsOpts{OrganizationID: orgID, ActorID: actorID}, db.Pagination{PageSize: 10, Page: 1})
+		require.NoError(t, err)
+		require.EqualValues(t, 2, result.Total)
+	})
+
+	t.Run("lists audit logs of the actor, including those without an organization", func(t *testing.T) {
+		result, err := db.ListAuditLogs(ctx, conn, db.ListAuditLogsOpts{ActorID: actorID}, db.Pagination{PageSize: 10, Page: 1})
+		require.NoError(t, err)
+		require.EqualValues(t, 3, result.Total)
+		require.Equal(t, withoutOrganization.ID, result.Results[2].ID)
+	})
+
+	t.Run("requires an organization or actor", func(t *testing.T) {
+		_, err := db.ListAuditLogs(ctx, conn, db.ListAuditLogsOpts{}, db.Pagination{PageSize: 10, Page: 1})
+		require.Error(t, err)
+	})
+
+	t.Run("filters by time", func(t *testing.T) {
+		result, err := db.ListAuditLogs(ctx, conn, db.ListAuditLogsOpts{
+			OrganizationID: orgID,
+			From:           now.Add(-90 * time.Minute),
+			To:             now,
+		}, db.Pagination{PageSize: 10, Page: 1})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, result.Total)
+		require.Equal(t, entries[1].ID, result.Results[0].ID)
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package dbtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func NewAuditLog(t *testing.T, record db.AuditLog) db.AuditLog {
+	t.Helper()
+
+	result := db.AuditLog{
+		ID:             uuid.New(),
+		Timestamp:      time.Now().UTC().Truncate(time.Millisecond),
+		OrganizationID: uuid.NewString(),
+		ActorID:        uuid.NewString(),
+		Action:         "gitpod.experimental.v1.WorkspacesService/UpdatePort",
+		TargetType:     "workspace",
+		TargetID:       "gitpodio-gitpod-abcdefghijk",
+		Origin:         "https://gitpod.io",
+		Status:         "ok",
+	}
+
+	if record.ID != uuid.Nil {
+		result.ID = record.ID
+	}
+
+	if !record.Timestamp.IsZero() {
+		result.Timestamp = record.Timestamp
+	}
+
+	if record.OrganizationID != "" {
+		result.OrganizationID = record.OrganizationID
+	}
+
+	if record.ActorID != "" {
+		result.ActorID = record.ActorID
+	}
+
+	if record.Action != "" {
+		result.Action = record.Action
+	}
+
+	if record.TargetType != "" {
+		result.TargetType = record.TargetType
+	}
+
+	if record.TargetID != "" {
+		result.TargetID = record.TargetID
+	}
+
+	if record.Details != "" {
+		result.Details = record.Details
+	}
+
+	return result
+}
+
+func CreateAuditLogs(t *testing.T, conn *gorm.DB, entries ...db.AuditLog) []db.AuditLog {
+	t.Helper()
+
+	var records []db.AuditLog
+	var ids []string
+	for _, entry := range entries {
+		record := NewAuditLog(t, entry)
+		records = append(records, record)
+		ids = append(ids, record.ID.String())
+
+		_, err := db.CreateAuditLog(context.Background(), conn, record)
+		require.NoError(t, err)
+	}
+
+	t.Cleanup(func() {
+		HardDeleteAuditLogs(t, ids...)
+	})
+
+	return records
+}
+
+func HardDeleteAuditLogs(t *testing.T, ids ...string) {
+	if len(ids) > 0 {
+		require.NoError(t, conn.Where(ids).Delete(&db.AuditLog{}).Error)
+	}
+}
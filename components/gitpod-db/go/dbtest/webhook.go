@@ -0,0 +1,166 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package dbtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func NewWebhook(t *testing.T, record db.Webhook) db.Webhook {
+	t.Helper()
+
+	cipher, _ := GetTestCipher(t)
+	secret, err := db.EncryptJSON(cipher, db.WebhookSecret{Secret: "some-secret"})
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	result := db.Webhook{
+		ID:             uuid.New(),
+		OrganizationID: uuid.New(),
+		URL:            "https://example.com/hook",
+		Events:         db.WebhookEvents{db.WebhookEventWorkspaceStarted},
+		Secret:         secret,
+		CreatedByID:    uuid.New(),
+		CreatedAt:      now,
+		LastModified:   now,
+	}
+
+	if record.ID != uuid.Nil {
+		result.ID = record.ID
+	}
+
+	if record.OrganizationID != uuid.Nil {
+		result.OrganizationID = record.OrganizationID
+	}
+
+	if record.URL != "" {
+		result.URL = record.URL
+	}
+
+	if len(record.Events) != 0 {
+		result.Events = record.Events
+	}
+
+	if record.Secret != nil {
+		result.Secret = record.Secret
+	}
+
+	if record.CreatedByID != uuid.Nil {
+		result.CreatedByID = record.CreatedByID
+	}
+
+	return result
+}
+
+func CreateWebhooks(t *testing.T, conn *gorm.DB, entries ...db.Webhook) []db.Webhook {
+	t.Helper()
+
+	var records []db.Webhook
+	var ids []string
+	for _, entry := range entries {
+		record := NewWebhook(t, entry)
+		records = append(records, record)
+		ids = append(ids, record.ID.String())
+
+		_, err := db.CreateWebhook(context.Background(), conn, record)
+		require.NoError(t, err)
+	}
+
+	t.Cleanup(func() {
+		HardDeleteWebhooks(t, ids...)
+	})
+
+	return records
+}
+
+func HardDeleteWebhooks(t *testing.T, ids ...string) {
+	if len(ids) > 0 {
+		require.NoError(t, conn.Where(ids).Delete(&db.Webhook{}).Error)
+	}
+}
+
+func NewWebhookDelivery(t *testing.T, record db.WebhookDelivery) db.WebhookDelivery {
+	t.Helper()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	result := db.WebhookDelivery{
+		ID:              uuid.New(),
+		WebhookID:       uuid.New(),
+		Event:           db.WebhookEventWorkspaceStarted,
+		Payload:         "{}",
+		Status:          db.WebhookDeliveryStatusPending,
+		NextAttemptTime: now,
+		CreatedAt:       now,
+		LastModified:    now,
+	}
+
+	if record.ID != uuid.Nil {
+		result.ID = record.ID
+	}
+
+	if record.WebhookID != uuid.Nil {
+		result.WebhookID = record.WebhookID
+	}
+
+	if record.Event != "" {
+		result.Event = record.Event
+	}
+
+	if record.Payload != "" {
+		result.Payload = record.Payload
+	}
+
+	if record.Status != "" {
+		result.Status = record.Status
+	}
+
+	if record.Attempts != 0 {
+		result.Attempts = record.Attempts
+	}
+
+	if !record.NextAttemptTime.IsZero() {
+		result.NextAttemptTime = record.NextAttemptTime
+	}
+
+	if !record.CreatedAt.IsZero() {
+		result.CreatedAt = record.CreatedAt
+	}
+
+	return result
+}
+
+func CreateWebhookDeliveries(t *testing.T, conn *gorm.DB, entries ...db.WebhookDelivery) []db.WebhookDelivery {
+	t.Helper()
+
+	var records []db.WebhookDelivery
+	var ids []string
+	for _, entry := range entries {
+		record := NewWebhookDelivery(t, entry)
+		records = append(records, record)
+		ids = append(ids, record.ID.String())
+
+		_, err := db.CreateWebhookDelivery(context.Background(), conn, record)
+		require.NoError(t, err)
+	}
+
+	t.Cleanup(func() {
+		HardDeleteWebhookDeliveries(t, ids...)
+	})
+
+	return records
+}
+
+func HardDeleteWebhookDeliveries(t *testing.T, ids ...string) {
+	if len(ids) > 0 {
+		require.NoError(t, conn.Where(ids).Delete(&db.WebhookDelivery{}).Error)
+	}
+}
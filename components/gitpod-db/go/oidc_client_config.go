@@ -48,6 +48,35 @@ type OIDCSpec struct {
 
 	// Scope specifies optional requested permissions.
 	Scopes []string `json:"scopes"`
+
+	// PreviousClientSecret is the secret which ClientSecret replaced when it was last rotated.
+	// It remains valid until PreviousClientSecretExpiresAt, such that sign-ins succeed while the secret is rotated at the identity provider.
+	PreviousClientSecret          string    `json:"previousClientSecret,omitempty"`
+	PreviousClientSecretExpiresAt time.Time `json:"previousClientSecretExpiresAt"`
+
+	// TeamMappings are applied to the claims of users signing in, to determine the teams they are members of.
+	TeamMappings []ClaimToTeamMapping `json:"teamMappings,omitempty"`
+}
+
+// ClientSecrets returns the secrets which are valid at the given time, the current secret first.
+func (s OIDCSpec) ClientSecrets(now time.Time) []string {
+	secrets := []string{s.ClientSecret}
+	if s.PreviousClientSecret != "" && now.Before(s.PreviousClientSecretExpiresAt) {
+		secrets = append(secrets, s.PreviousClientSecret)
+	}
+	return secrets
+}
+
+// ClaimToTeamMapping maps users with a claim of the given value to a team.
+type ClaimToTeamMapping struct {
+	// Claim is the name of the claim, e.g. groups. Claims with a list of values match if any of them equals Value.
+	Claim string `json:"claim"`
+
+	Value string `json:"value"`
+
+	TeamID string `json:"teamId"`
+
+	Role TeamMembershipRole `json:"role"`
 }
 
 func CreateOIDCCLientConfig(ctx context.Context, conn *gorm.DB, cfg OIDCClientConfig) (OIDCClientConfig, error) {
@@ -139,6 +168,41 @@ func ListOIDCClientConfigsForOrganization(ctx context.Context, conn *gorm.DB, or
 	return results, nil
 }
 
+// UpdateOIDCClientConfig updates the issuer and data of an existing config, and returns the updated config.
+func UpdateOIDCClientConfig(ctx context.Context, conn *gorm.DB, cfg OIDCClientConfig) (OIDCClientConfig, error) {
+	if cfg.ID == uuid.Nil {
+		return OIDCClientConfig{}, errors.New("id must be set")
+	}
+
+	if cfg.OrganizationID == uuid.Nil {
+		return OIDCClientConfig{}, errors.New("organization id must be set")
+	}
+
+	if cfg.Issuer == "" {
+		return OIDCClientConfig{}, errors.New("issuer must be set")
+	}
+
+	// Ensure the config exists, MySQL does not count rows which are unchanged by an update.
+	_, err := GetOIDCClientConfigForOrganization(ctx, conn, cfg.ID, cfg.OrganizationID)
+	if err != nil {
+		return OIDCClientConfig{}, err
+	}
+
+	tx := conn.
+		WithContext(ctx).
+		Table((&OIDCClientConfig{}).TableName()).
+		Where("id = ?", cfg.ID).
+		Where("organizationId = ?", cfg.OrganizationID).
+		Where("deleted = ?", 0).
+		Select("issuer", "data").
+		Updates(cfg)
+	if tx.Error != nil {
+		return OIDCClientConfig{}, fmt.Errorf("failed to update oidc client config (ID: %s): %w", cfg.ID.String(), tx.Error)
+	}
+
+	return GetOIDCClientConfigForOrganization(ctx, conn, cfg.ID, cfg.OrganizationID)
+}
+
 func DeleteOIDCClientConfig(ctx context.Context, conn *gorm.DB, id, organizationID uuid.UUID) error {
 	if id == uuid.Nil {
 		return fmt.Errorf("id is a required argument")
@@ -189,3 +253,26 @@ func GetOIDCClientConfigByOrgSlug(ctx context.Context, conn *gorm.DB, slug strin
 
 	return config, nil
 }
+
+// ListOIDCClientConfigsByOrgSlug lists the configs of the organization with the given slug.
+func ListOIDCClientConfigsByOrgSlug(ctx context.Context, conn *gorm.DB, slug string) ([]OIDCClientConfig, error) {
+	if slug == "" {
+		return nil, fmt.Errorf("slug is a required argument")
+	}
+
+	var results []OIDCClientConfig
+
+	tx := conn.
+		WithContext(ctx).
+		Table((&OIDCClientConfig{}).TableName()).
+		Joins("JOIN d_b_team team ON team.id = d_b_oidc_client_config.organizationId").
+		Where("team.slug = ?", slug).
+		Where("d_b_oidc_client_config.deleted = ?", 0).
+		Order("d_b_oidc_client_config.id").
+		Find(&results)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list oidc client configs by org slug (slug: %s): %w", slug, tx.Error)
+	}
+
+	return results, nil
+}
@@ -7,6 +7,7 @@ package db_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
 	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
@@ -110,3 +111,59 @@ func TestGetOIDCClientConfigForOrganization(t *testing.T) {
 	})
 
 }
+
+func TestUpdateOIDCClientConfig(t *testing.T) {
+
+	t.Run("not found when config does not exist", func(t *testing.T) {
+		conn := dbtest.ConnectForTests(t)
+
+		_, err := db.UpdateOIDCClientConfig(context.Background(), conn, db.OIDCClientConfig{
+			ID:             uuid.New(),
+			OrganizationID: uuid.New(),
+			Issuer:         "https://accounts.google.com",
+		})
+		require.Error(t, err)
+		require.ErrorIs(t, err, db.ErrorNotFound)
+	})
+
+	t.Run("updates issuer and data", func(t *testing.T) {
+		conn := dbtest.ConnectForTests(t)
+		cipher, _ := dbtest.GetTestCipher(t)
+
+		created := dbtest.CreateOIDCClientConfigs(t, conn, db.OIDCClientConfig{
+			OrganizationID: uuid.New(),
+		})[0]
+
+		data, err := db.EncryptJSON(cipher, db.OIDCSpec{ClientID: "client", ClientSecret: "new-secret", PreviousClientSecret: "old-secret"})
+		require.NoError(t, err)
+
+		updated, err := db.UpdateOIDCClientConfig(context.Background(), conn, db.OIDCClientConfig{
+			ID:             created.ID,
+			OrganizationID: created.OrganizationID,
+			Issuer:         "https://accounts.google.com",
+			Data:           data,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "https://accounts.google.com", updated.Issuer)
+
+		spec, err := updated.Data.Decrypt(cipher)
+		require.NoError(t, err)
+		require.Equal(t, "new-secret", spec.ClientSecret)
+		require.Equal(t, "old-secret", spec.PreviousClientSecret)
+	})
+
+}
+
+func TestOIDCSpec_ClientSecrets(t *testing.T) {
+	now := time.Now()
+
+	require.Equal(t, []string{"secret"}, db.OIDCSpec{ClientSecret: "secret"}.ClientSecrets(now))
+
+	rotated := db.OIDCSpec{
+		ClientSecret:                  "new-secret",
+		PreviousClientSecret:          "old-secret",
+		PreviousClientSecretExpiresAt: now.Add(time.Hour),
+	}
+	require.Equal(t, []string{"new-secret", "old-secret"}, rotated.ClientSecrets(now))
+	require.Equal(t, []string{"new-secret"}, rotated.ClientSecrets(now.Add(2*time.Hour)))
+}
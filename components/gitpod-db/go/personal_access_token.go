@@ -62,6 +62,29 @@ func GetPersonalAccessTokenForUser(ctx context.Context, conn *gorm.DB, tokenID u
 	return token, nil
 }
 
+// GetPersonalAccessTokenByHash retrieves a token by the hash of its value, the hash is unique across all tokens.
+func GetPersonalAccessTokenByHash(ctx context.Context, conn *gorm.DB, hash string) (PersonalAccessToken, error) {
+	var token PersonalAccessToken
+
+	if hash == "" {
+		return PersonalAccessToken{}, fmt.Errorf("Token hash is a required argument to get personal access token")
+	}
+
+	tx := conn.
+		WithContext(ctx).
+		Where("hash = ?", hash).
+		Where("deleted = ?", 0).
+		First(&token)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return PersonalAccessToken{}, fmt.Errorf("Token with hash does not exist: %w", ErrorNotFound)
+		}
+		return PersonalAccessToken{}, fmt.Errorf("Failed to retrieve token: %v", tx.Error)
+	}
+
+	return token, nil
+}
+
 func CreatePersonalAccessToken(ctx context.Context, conn *gorm.DB, req PersonalAccessToken) (PersonalAccessToken, error) {
 	if req.UserID == uuid.Nil {
 		return PersonalAccessToken{}, fmt.Errorf("Invalid or empty userID")
@@ -63,6 +63,30 @@ func TestPersonalAccessToken_Get(t *testing.T) {
 
 }
 
+func TestPersonalAccessToken_GetByHash(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+
+	token := dbtest.NewPersonalAccessToken(t, db.PersonalAccessToken{Hash: uuid.NewString()})
+	dbtest.CreatePersonalAccessTokenRecords(t, conn, token)
+
+	t.Run("empty hash is rejected", func(t *testing.T) {
+		_, err := db.GetPersonalAccessTokenByHash(context.Background(), conn, "")
+		require.Error(t, err)
+	})
+
+	t.Run("not matching hash", func(t *testing.T) {
+		_, err := db.GetPersonalAccessTokenByHash(context.Background(), conn, uuid.NewString())
+		require.ErrorIs(t, err, db.ErrorNotFound)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		returned, err := db.GetPersonalAccessTokenByHash(context.Background(), conn, token.Hash)
+		require.NoError(t, err)
+		require.Equal(t, token.ID, returned.ID)
+		require.Equal(t, token.UserID, returned.UserID)
+	})
+}
+
 func TestPersonalAccessToken_Create(t *testing.T) {
 	conn := dbtest.ConnectForTests(t)
 
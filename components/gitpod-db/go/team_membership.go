@@ -56,3 +56,45 @@ func ListTeamMembershipsForUserIDs(ctx context.Context, conn *gorm.DB, userIDs [
 
 	return memberships, nil
 }
+
+// EnsureTeamMembership adds the user to the team with the given role, unless the user is a member already.
+// Existing members are promoted to owners if the role is owner, but never demoted, as roles may have been changed in Gitpod.
+func EnsureTeamMembership(ctx context.Context, conn *gorm.DB, teamID, userID uuid.UUID, role TeamMembershipRole) (TeamMembership, error) {
+	var existing TeamMembership
+	tx := conn.WithContext(ctx).
+		Where("teamId = ?", teamID).
+		Where("userId = ?", userID).
+		Where("deleted = ?", 0).
+		Limit(1).
+		Find(&existing)
+	if tx.Error != nil {
+		return TeamMembership{}, fmt.Errorf("failed to get team membership of user %s in team %s: %w", userID.String(), teamID.String(), tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		membership := TeamMembership{
+			ID:           uuid.New(),
+			TeamID:       teamID,
+			UserID:       userID,
+			Role:         role,
+			CreationTime: NewVarCharTime(time.Now()),
+		}
+		tx = conn.WithContext(ctx).Create(&membership)
+		if tx.Error != nil {
+			return TeamMembership{}, fmt.Errorf("failed to add user %s to team %s: %w", userID.String(), teamID.String(), tx.Error)
+		}
+		return membership, nil
+	}
+
+	if role == TeamMembershipRole_Owner && existing.Role != TeamMembershipRole_Owner {
+		tx = conn.WithContext(ctx).
+			Model(&existing).
+			Update("role", TeamMembershipRole_Owner)
+		if tx.Error != nil {
+			return TeamMembership{}, fmt.Errorf("failed to promote user %s to owner of team %s: %w", userID.String(), teamID.String(), tx.Error)
+		}
+		existing.Role = TeamMembershipRole_Owner
+	}
+
+	return existing, nil
+}
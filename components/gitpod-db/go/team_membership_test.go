@@ -100,3 +100,29 @@ func TestListTeamMembershipsForUserIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestEnsureTeamMembership(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+	teamID, userID := uuid.New(), uuid.New()
+	t.Cleanup(func() {
+		require.NoError(t, conn.Where("teamId = ?", teamID).Delete(&db.TeamMembership{}).Error)
+	})
+
+	added, err := db.EnsureTeamMembership(context.Background(), conn, teamID, userID, db.TeamMembershipRole_Member)
+	require.NoError(t, err)
+	require.Equal(t, db.TeamMembershipRole_Member, added.Role)
+
+	promoted, err := db.EnsureTeamMembership(context.Background(), conn, teamID, userID, db.TeamMembershipRole_Owner)
+	require.NoError(t, err)
+	require.Equal(t, added.ID, promoted.ID)
+	require.Equal(t, db.TeamMembershipRole_Owner, promoted.Role)
+
+	// owners are not demoted
+	unchanged, err := db.EnsureTeamMembership(context.Background(), conn, teamID, userID, db.TeamMembershipRole_Member)
+	require.NoError(t, err)
+	require.Equal(t, db.TeamMembershipRole_Owner, unchanged.Role)
+
+	memberships, err := db.ListTeamMembershipsForUserIDs(context.Background(), conn, []uuid.UUID{userID})
+	require.NoError(t, err)
+	require.Len(t, memberships, 1)
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Webhook is an HTTPS endpoint an organization registered to receive events.
+type Webhook struct {
+	ID             uuid.UUID     `gorm:"primary_key;column:id;type:char;size:36;" json:"id"`
+	OrganizationID uuid.UUID     `gorm:"column:organizationId;type:char;size:36;" json:"organizationId"`
+	URL            string        `gorm:"column:url;type:varchar;size:1024;" json:"url"`
+	Events         WebhookEvents `gorm:"column:events;type:varchar;size:255;" json:"events"`
+	// Secret is used to sign the payloads of deliveries.
+	Secret      EncryptedJSON[WebhookSecret] `gorm:"column:secret;type:text;size:65535;" json:"secret"`
+	CreatedByID uuid.UUID                    `gorm:"column:createdById;type:char;size:36;" json:"createdById"`
+
+	CreatedAt    time.Time `gorm:"column:createdAt;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"createdAt"`
+	LastModified time.Time `gorm:"column:_lastModified;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"_lastModified"`
+
+	// deleted is reserved for use by periodic deleter.
+	_ bool `gorm:"column:deleted;type:tinyint;default:0;" json:"deleted"`
+}
+
+// TableName sets the insert table name for this struct type
+func (w *Webhook) TableName() string {
+	return "d_b_webhook"
+}
+
+type WebhookSecret struct {
+	Secret string `json:"secret"`
+}
+
+const (
+	WebhookEventWorkspaceStarted = "workspace.started"
+	WebhookEventWorkspaceStopped = "workspace.stopped"
+	WebhookEventWorkspaceFailed  = "workspace.failed"
+	WebhookEventPrebuildFinished = "prebuild.finished"
+	WebhookEventPortPublic       = "port.public"
+)
+
+type WebhookEvents []string
+
+// Scan() and Value() allow having a list of strings as a type for WebhookEvents
+func (e *WebhookEvents) Scan(src any) error {
+	return (*Scopes)(e).Scan(src)
+}
+
+func (e WebhookEvents) Value() (driver.Value, error) {
+	return Scopes(e).Value()
+}
+
+// Contains returns true if the event is part of the events.
+func (e WebhookEvents) Contains(event string) bool {
+	for _, ev := range e {
+		if ev == event {
+			return true
+		}
+	}
+	return false
+}
+
+func CreateWebhook(ctx context.Context, conn *gorm.DB, webhook Webhook) (Webhook, error) {
+	if webhook.ID == uuid.Nil {
+		return Webhook{}, errors.New("id must be set")
+	}
+
+	if webhook.OrganizationID == uuid.Nil {
+		return Webhook{}, errors.New("organization id must be set")
+	}
+
+	if webhook.URL == "" {
+		return Webhook{}, errors.New("url must be set")
+	}
+
+	if len(webhook.Events) == 0 {
+		return Webhook{}, errors.New("events must be set")
+	}
+
+	tx := conn.
+		WithContext(ctx).
+		Create(&webhook)
+	if tx.Error != nil {
+		return Webhook{}, fmt.Errorf("failed to create webhook: %w", tx.Error)
+	}
+
+	return webhook, nil
+}
+
+func GetWebhook(ctx context.Context, conn *gorm.DB, id uuid.UUID) (Webhook, error) {
+	var webhook Webhook
+
+	if id == uuid.Nil {
+		return Webhook{}, fmt.Errorf("Webhook ID is a required argument")
+	}
+
+	tx := conn.
+		WithContext(ctx).
+		Where("id = ?", id).
+		Where("deleted = ?", 0).
+		First(&webhook)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return Webhook{}, fmt.Errorf("Webhook with ID %s does not exist: %w", id.String(), ErrorNotFound)
+		}
+
+		return Webhook{}, fmt.Errorf("Failed to retrieve webhook %s: %v", id.String(), tx.Error)
+	}
+
+	return webhook, nil
+}
+
+func GetWebhookForOrganization(ctx context.Context, conn *gorm.DB, id, organizationID uuid.UUID) (Webhook, error) {
+	var webhook Webhook
+
+	if id == uuid.Nil {
+		return Webhook{}, fmt.Errorf("Webhook ID is a required argument")
+	}
+
+	if organizationID == uuid.Nil {
+		return Webhook{}, fmt.Errorf("organization id is a required argument")
+	}
+
+	tx := conn.
+		WithContext(ctx).
+		Where("id = ?", id).
+		Where("organizationId = ?", organizationID).
+		Where("deleted = ?", 0).
+		First(&webhook)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return Webhook{}, fmt.Errorf("Webhook with ID %s for Organization ID %s does not exist: %w", id.String(), organizationID.String(), ErrorNotFound)
+		}
+
+		return Webhook{}, fmt.Errorf("Failed to retrieve webhook %s for Organization ID %s: %v", id.String(), organizationID.String(), tx.Error)
+	}
+
+	return webhook, nil
+}
+
+func ListWebhooksForOrganization(ctx context.Context, conn *gorm.DB, organizationID uuid.UUID) ([]Webhook, error) {
+	if organizationID == uuid.Nil {
+		return nil, errors.New("organization ID is a required argument")
+	}
+
+	var results []Webhook
+
+	tx := conn.
+		WithContext(ctx).
+		Where("organizationId = ?", organizationID.String()).
+		Where("deleted = ?", 0).
+		Order("createdAt").
+		Find(&results)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list webhooks for organization %s: %w", organizationID.String(), tx.Error)
+	}
+
+	return results, nil
+}
+
+// DeleteWebhookForOrganization deletes the webhook and discards its pending deliveries.
+func DeleteWebhookForOrganization(ctx context.Context, conn *gorm.DB, id, organizationID uuid.UUID) error {
+	if id == uuid.Nil {
+		return fmt.Errorf("Webhook ID is a required argument")
+	}
+
+	if organizationID == uuid.Nil {
+		return fmt.Errorf("organization id is a required argument")
+	}
+
+	return conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.
+			Table((&Webhook{}).TableName()).
+			Where("id = ?", id).
+			Where("organizationId = ?", organizationID).
+			Where("deleted = ?", 0).
+			Update("deleted", 1)
+		if res.Error != nil {
+			return fmt.Errorf("failed to delete webhook (ID: %s): %v", id.String(), res.Error)
+		}
+
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("webhook (ID: %s) for organization (ID: %s) does not exist: %w", id, organizationID, ErrorNotFound)
+		}
+
+		res = tx.
+			Table((&WebhookDelivery{}).TableName()).
+			Where("webhookId = ?", id).
+			Where("status = ?", WebhookDeliveryStatusPending).
+			Where("deleted = ?", 0).
+			Update("deleted", 1)
+		if res.Error != nil {
+			return fmt.Errorf("failed to discard pending deliveries of webhook (ID: %s): %v", id.String(), res.Error)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending    WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded  WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusDeadLetter WebhookDeliveryStatus = "dead_letter"
+)
+
+// WebhookDelivery is the delivery of a single event to a webhook.
+type WebhookDelivery struct {
+	ID        uuid.UUID `gorm:"primary_key;column:id;type:char;size:36;" json:"id"`
+	WebhookID uuid.UUID `gorm:"column:webhookId;type:char;size:36;" json:"webhookId"`
+	Event     string    `gorm:"column:event;type:varchar;size:64;" json:"event"`
+	Payload   string    `gorm:"column:payload;type:text;size:65535;" json:"payload"`
+
+	Status           WebhookDeliveryStatus `gorm:"column:status;type:varchar;size:32;" json:"status"`
+	Attempts         int32                 `gorm:"column:attempts;type:int;" json:"attempts"`
+	LastResponseCode int32                 `gorm:"column:lastResponseCode;type:int;" json:"lastResponseCode"`
+	LastError        string                `gorm:"column:lastError;type:text;size:65535;" json:"lastError"`
+	NextAttemptTime  time.Time             `gorm:"column:nextAttemptTime;type:timestamp;" json:"nextAttemptTime"`
+	DeliveredTime    sql.NullTime          `gorm:"column:deliveredTime;type:timestamp;" json:"deliveredTime"`
+
+	CreatedAt    time.Time `gorm:"column:createdAt;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"createdAt"`
+	LastModified time.Time `gorm:"column:_lastModified;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"_lastModified"`
+
+	// deleted is reserved for use by periodic deleter.
+	_ bool `gorm:"column:deleted;type:tinyint;default:0;" json:"deleted"`
+}
+
+// TableName sets the insert table name for this struct type
+func (d *WebhookDelivery) TableName() string {
+	return "d_b_webhook_delivery"
+}
+
+// CreateWebhookDelivery stores a new delivery. Creating a delivery with an ID which already exists is a no-op,
+// which allows deriving the ID from the event to deliver each event only once.
+func CreateWebhookDelivery(ctx context.Context, conn *gorm.DB, delivery WebhookDelivery) (WebhookDelivery, error) {
+	if delivery.ID == uuid.Nil {
+		return WebhookDelivery{}, errors.New("id must be set")
+	}
+
+	if delivery.WebhookID == uuid.Nil {
+		return WebhookDelivery{}, errors.New("webhook id must be set")
+	}
+
+	if delivery.Status == "" {
+		return WebhookDelivery{}, errors.New("status must be set")
+	}
+
+	tx := conn.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&delivery)
+	if tx.Error != nil {
+		return WebhookDelivery{}, fmt.Errorf("failed to create webhook delivery: %w", tx.Error)
+	}
+
+	return delivery, nil
+}
+
+func GetWebhookDelivery(ctx context.Context, conn *gorm.DB, id, webhookID uuid.UUID) (WebhookDelivery, error) {
+	var delivery WebhookDelivery
+
+	if id == uuid.Nil {
+		return WebhookDelivery{}, fmt.Errorf("Webhook Delivery ID is a required argument")
+	}
+
+	if webhookID == uuid.Nil {
+		return WebhookDelivery{}, fmt.Errorf("Webhook ID is a required argument")
+	}
+
+	tx := conn.
+		WithContext(ctx).
+		Where("id = ?", id).
+		Where("webhookId = ?", webhookID).
+		Where("deleted = ?", 0).
+		First(&delivery)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return WebhookDelivery{}, fmt.Errorf("Webhook Delivery with ID %s for Webhook %s does not exist: %w", id.String(), webhookID.String(), ErrorNotFound)
+		}
+
+		return WebhookDelivery{}, fmt.Errorf("Failed to retrieve webhook delivery %s: %v", id.String(), tx.Error)
+	}
+
+	return delivery, nil
+}
+
+type ListWebhookDeliveriesOpts struct {
+	WebhookID uuid.UUID
+	// Status restricts the results to deliveries with this status, if set.
+	Status WebhookDeliveryStatus
+}
+
+// ListWebhookDeliveries lists the deliveries of a webhook, most recent first.
+func ListWebhookDeliveries(ctx context.Context, conn *gorm.DB, opts ListWebhookDeliveriesOpts, pagination Pagination) (*PaginatedResult[WebhookDelivery], error) {
+	if opts.WebhookID == uuid.Nil {
+		return nil, fmt.Errorf("webhook ID is a required argument to list webhook deliveries, got nil")
+	}
+
+	query := func() *gorm.DB {
+		q := conn.
+			WithContext(ctx).
+			Table((&WebhookDelivery{}).TableName()).
+			Where("webhookId = ?", opts.WebhookID).
+			Where("deleted = ?", 0)
+		if opts.Status != "" {
+			q = q.Where("status = ?", opts.Status)
+		}
+		return q
+	}
+
+	var results []WebhookDelivery
+	tx := query().
+		Order("createdAt DESC").
+		Scopes(Paginate(pagination)).
+		Find(&results)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list deliveries for webhook %s: %w", opts.WebhookID.String(), tx.Error)
+	}
+
+	var count int64
+	tx = query().Count(&count)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to count total number of deliveries for webhook %s: %w", opts.WebhookID.String(), tx.Error)
+	}
+
+	return &PaginatedResult[WebhookDelivery]{
+		Results: results,
+		Total:   count,
+	}, nil
+}
+
+// ListDueWebhookDeliveries lists pending deliveries whose next attempt is due, oldest first.
+func ListDueWebhookDeliveries(ctx context.Context, conn *gorm.DB, now time.Time, limit int) ([]WebhookDelivery, error) {
+	var results []WebhookDelivery
+
+	tx := conn.
+		WithContext(ctx).
+		Where("status = ?", WebhookDeliveryStatusPending).
+		Where("nextAttemptTime <= ?", now).
+		Where("deleted = ?", 0).
+		Order("nextAttemptTime").
+		Limit(limit).
+		Find(&results)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list due webhook deliveries: %w", tx.Error)
+	}
+
+	return results, nil
+}
+
+// ClaimWebhookDelivery moves the next attempt of a pending delivery to leaseUntil, unless it was changed since it was read.
+// It returns false if another process claimed the delivery first.
+func ClaimWebhookDelivery(ctx context.Context, conn *gorm.DB, delivery WebhookDelivery, leaseUntil time.Time) (bool, error) {
+	tx := conn.
+		WithContext(ctx).
+		Table((&WebhookDelivery{}).TableName()).
+		Where("id = ?", delivery.ID).
+		Where("status = ?", WebhookDeliveryStatusPending).
+		Where("attempts = ?", delivery.Attempts).
+		Where("nextAttemptTime = ?", delivery.NextAttemptTime).
+		Where("deleted = ?", 0).
+		Update("nextAttemptTime", leaseUntil)
+	if tx.Error != nil {
+		return false, fmt.Errorf("failed to claim webhook delivery %s: %w", delivery.ID.String(), tx.Error)
+	}
+
+	return tx.RowsAffected == 1, nil
+}
+
+// UpdateWebhookDeliveryStatus stores the outcome of a delivery attempt.
+func UpdateWebhookDeliveryStatus(ctx context.Context, conn *gorm.DB, delivery WebhookDelivery) (WebhookDelivery, error) {
+	if delivery.ID == uuid.Nil {
+		return WebhookDelivery{}, errors.New("id must be set")
+	}
+
+	tx := conn.
+		WithContext(ctx).
+		Table((&WebhookDelivery{}).TableName()).
+		Where("id = ?", delivery.ID).
+		Where("deleted = ?", 0).
+		Select("status", "attempts", "lastResponseCode", "lastError", "nextAttemptTime", "deliveredTime").
+		Updates(delivery)
+	if tx.Error != nil {
+		return WebhookDelivery{}, fmt.Errorf("failed to update webhook delivery %s: %w", delivery.ID.String(), tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return WebhookDelivery{}, fmt.Errorf("webhook delivery %s does not exist: %w", delivery.ID.String(), ErrorNotFound)
+	}
+
+	return GetWebhookDelivery(ctx, conn, delivery.ID, delivery.WebhookID)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWebhookDelivery_IsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+
+	delivery := dbtest.CreateWebhookDeliveries(t, conn, db.WebhookDelivery{})[0]
+
+	_, err := db.CreateWebhookDelivery(ctx, conn, delivery)
+	require.NoError(t, err)
+
+	result, err := db.ListWebhookDeliveries(ctx, conn, db.ListWebhookDeliveriesOpts{WebhookID: delivery.WebhookID}, db.Pagination{PageSize: 10, Page: 1})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, result.Total)
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+
+	webhookID := uuid.New()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	dbtest.CreateWebhookDeliveries(t, conn,
+		db.WebhookDelivery{WebhookID: webhookID, CreatedAt: now.Add(-2 * time.Minute)},
+		db.WebhookDelivery{WebhookID: webhookID, CreatedAt: now.Add(-1 * time.Minute), Status: db.WebhookDeliveryStatusDeadLetter},
+		db.WebhookDelivery{WebhookID: webhookID, CreatedAt: now, Status: db.WebhookDeliveryStatusSucceeded},
+		db.WebhookDelivery{},
+	)
+
+	all, err := db.ListWebhookDeliveries(ctx, conn, db.ListWebhookDeliveriesOpts{WebhookID: webhookID}, db.Pagination{PageSize: 2, Page: 1})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, all.Total)
+	require.Len(t, all.Results, 2)
+	require.Equal(t, db.WebhookDeliveryStatusSucceeded, all.Results[0].Status)
+
+	deadLetters, err := db.ListWebhookDeliveries(ctx, conn, db.ListWebhookDeliveriesOpts{WebhookID: webhookID, Status: db.WebhookDeliveryStatusDeadLetter}, db.Pagination{PageSize: 10, Page: 1})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, deadLetters.Total)
+	require.Len(t, deadLetters.Results, 1)
+}
+
+func TestClaimWebhookDelivery(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	delivery := dbtest.CreateWebhookDeliveries(t, conn, db.WebhookDelivery{NextAttemptTime: now.Add(-time.Second)})[0]
+
+	due, err := db.ListDueWebhookDeliveries(ctx, conn, now, 100)
+	require.NoError(t, err)
+	require.Contains(t, deliveryIDs(due), delivery.ID)
+
+	claimed, err := db.ClaimWebhookDelivery(ctx, conn, delivery, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	// a second claim based on the stale record must fail
+	claimed, err = db.ClaimWebhookDelivery(ctx, conn, delivery, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.False(t, claimed)
+
+	due, err = db.ListDueWebhookDeliveries(ctx, conn, now, 100)
+	require.NoError(t, err)
+	require.NotContains(t, deliveryIDs(due), delivery.ID)
+}
+
+func TestUpdateWebhookDeliveryStatus(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	delivery := dbtest.CreateWebhookDeliveries(t, conn, db.WebhookDelivery{})[0]
+
+	delivery.Status = db.WebhookDeliveryStatusSucceeded
+	delivery.Attempts = 1
+	delivery.LastResponseCode = 200
+	delivery.DeliveredTime = sql.NullTime{Time: now, Valid: true}
+
+	updated, err := db.UpdateWebhookDeliveryStatus(ctx, conn, delivery)
+	require.NoError(t, err)
+	require.Equal(t, db.WebhookDeliveryStatusSucceeded, updated.Status)
+	require.EqualValues(t, 1, updated.Attempts)
+	require.EqualValues(t, 200, updated.LastResponseCode)
+	require.True(t, updated.DeliveredTime.Valid)
+
+	_, err = db.UpdateWebhookDeliveryStatus(ctx, conn, db.WebhookDelivery{ID: uuid.New(), WebhookID: uuid.New()})
+	require.ErrorIs(t, err, db.ErrorNotFound)
+}
+
+func deliveryIDs(deliveries []db.WebhookDelivery) []uuid.UUID {
+	var result []uuid.UUID
+	for _, d := range deliveries {
+		result = append(result, d.ID)
+	}
+	return result
+}
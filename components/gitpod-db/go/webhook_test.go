@@ -0,0 +1,87 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_CreateAndGet(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+	created := dbtest.CreateWebhooks(t, conn, db.Webhook{
+		Events: db.WebhookEvents{db.WebhookEventWorkspaceStarted, db.WebhookEventPortPublic},
+	})[0]
+
+	retrieved, err := db.GetWebhookForOrganization(context.Background(), conn, created.ID, created.OrganizationID)
+	require.NoError(t, err)
+	require.Equal(t, created, retrieved)
+
+	_, err = db.GetWebhookForOrganization(context.Background(), conn, created.ID, uuid.New())
+	require.ErrorIs(t, err, db.ErrorNotFound)
+
+	retrieved, err = db.GetWebhook(context.Background(), conn, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, created, retrieved)
+}
+
+func TestListWebhooksForOrganization(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+
+	orgA, orgB := uuid.New(), uuid.New()
+
+	dbtest.CreateWebhooks(t, conn,
+		db.Webhook{OrganizationID: orgA},
+		db.Webhook{OrganizationID: orgA},
+		db.Webhook{OrganizationID: orgB},
+	)
+
+	webhooksForOrgA, err := db.ListWebhooksForOrganization(ctx, conn, orgA)
+	require.NoError(t, err)
+	require.Len(t, webhooksForOrgA, 2)
+
+	webhooksForOrgB, err := db.ListWebhooksForOrganization(ctx, conn, orgB)
+	require.NoError(t, err)
+	require.Len(t, webhooksForOrgB, 1)
+}
+
+func TestDeleteWebhookForOrganization(t *testing.T) {
+
+	t.Run("returns not found, when record does not exist", func(t *testing.T) {
+		conn := dbtest.ConnectForTests(t)
+
+		err := db.DeleteWebhookForOrganization(context.Background(), conn, uuid.New(), uuid.New())
+		require.ErrorIs(t, err, db.ErrorNotFound)
+	})
+
+	t.Run("marks record deleted and discards pending deliveries", func(t *testing.T) {
+		ctx := context.Background()
+		conn := dbtest.ConnectForTests(t)
+
+		webhook := dbtest.CreateWebhooks(t, conn, db.Webhook{})[0]
+		deliveries := dbtest.CreateWebhookDeliveries(t, conn,
+			db.WebhookDelivery{WebhookID: webhook.ID, Status: db.WebhookDeliveryStatusPending},
+			db.WebhookDelivery{WebhookID: webhook.ID, Status: db.WebhookDeliveryStatusSucceeded},
+		)
+
+		err := db.DeleteWebhookForOrganization(ctx, conn, webhook.ID, webhook.OrganizationID)
+		require.NoError(t, err)
+
+		_, err = db.GetWebhookForOrganization(ctx, conn, webhook.ID, webhook.OrganizationID)
+		require.ErrorIs(t, err, db.ErrorNotFound)
+
+		_, err = db.GetWebhookDelivery(ctx, conn, deliveries[0].ID, webhook.ID)
+		require.ErrorIs(t, err, db.ErrorNotFound)
+
+		_, err = db.GetWebhookDelivery(ctx, conn, deliveries[1].ID, webhook.ID)
+		require.NoError(t, err)
+	})
+}
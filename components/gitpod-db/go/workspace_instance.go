@@ -271,3 +271,46 @@ func ListWorkspaceInstanceIDsWithPhaseStoppedButNoStoppingTime(ctx context.Conte
 	}
 	return ids, nil
 }
+
+// WorkspaceInstanceUpdate is the phase of a workspace instance, together with the workspace it belongs to.
+type WorkspaceInstanceUpdate struct {
+	ID             uuid.UUID      `gorm:"column:id;type:char;size:36;" json:"id"`
+	WorkspaceID    string         `gorm:"column:workspaceId;type:char;size:36;" json:"workspaceId"`
+	OrganizationID uuid.UUID      `gorm:"column:organizationId;type:char;size:36;" json:"organizationId"`
+	OwnerID        uuid.UUID      `gorm:"column:ownerId;type:char;size:36;" json:"ownerId"`
+	ContextURL     string         `gorm:"column:contextUrl;type:text;size:65535;" json:"contextUrl"`
+	Type           WorkspaceType  `gorm:"column:workspaceType;type:char;size:16;" json:"workspaceType"`
+	Phase          string         `gorm:"column:phase;type:char;size:32;" json:"phase"`
+	Failed         sql.NullString `gorm:"column:failed;type:text;" json:"failed"`
+	LastModified   time.Time      `gorm:"column:lastModified;type:timestamp;" json:"lastModified"`
+}
+
+// ListWorkspaceInstanceUpdates lists instances of workspaces which belong to an organization, with one of the phases,
+// which were modified at or after the given time. Instances are ordered by the time they were modified.
+func ListWorkspaceInstanceUpdates(ctx context.Context, conn *gorm.DB, since time.Time, phases []string, limit int) ([]WorkspaceInstanceUpdate, error) {
+	var updates []WorkspaceInstanceUpdate
+	tx := conn.WithContext(ctx).
+		Table(fmt.Sprintf("%s as wsi", (&WorkspaceInstance{}).TableName())).
+		Select("wsi.id as id, "+
+			"wsi.workspaceId as workspaceId, "+
+			"ws.organizationId as organizationId, "+
+			"ws.ownerId as ownerId, "+
+			"ws.contextURL as contextUrl, "+
+			"ws.type as workspaceType, "+
+			"wsi.phasePersisted as phase, "+
+			"JSON_UNQUOTE(JSON_EXTRACT(wsi.status, '$.conditions.failed')) as failed, "+
+			"wsi._lastModified as lastModified",
+		).
+		Joins(fmt.Sprintf("INNER JOIN %s AS ws ON wsi.workspaceId = ws.id", (&Workspace{}).TableName())).
+		Where("wsi._lastModified >= ?", since).
+		Where("wsi.phasePersisted IN ?", phases).
+		Where("ws.organizationId IS NOT NULL").
+		Order("wsi._lastModified, wsi.id").
+		Limit(limit).
+		Scan(&updates)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list workspace instance updates: %w", tx.Error)
+	}
+
+	return updates, nil
+}
@@ -226,3 +226,28 @@ func TestListWorkspaceInstanceIDsWithPhaseStoppedButNoStoppingTime(t *testing.T)
 	require.NoError(t, err)
 	require.Equal(t, []uuid.UUID{instances[1].ID}, detectedIDs)
 }
+
+func TestListWorkspaceInstanceUpdates(t *testing.T) {
+	dbconn := dbtest.ConnectForTests(t)
+	orgID := uuid.New()
+	since := time.Now().Add(-time.Minute)
+
+	workspaces := dbtest.CreateWorkspaces(t, dbconn,
+		dbtest.NewWorkspace(t, db.Workspace{OrganizationId: &orgID}),
+		// workspaces without organization have no webhooks, hence are ignored
+		dbtest.NewWorkspace(t, db.Workspace{}),
+	)
+	instances := dbtest.CreateWorkspaceInstances(t, dbconn,
+		dbtest.NewWorkspaceInstance(t, db.WorkspaceInstance{WorkspaceID: workspaces[0].ID, PhasePersisted: "running"}),
+		dbtest.NewWorkspaceInstance(t, db.WorkspaceInstance{WorkspaceID: workspaces[0].ID, PhasePersisted: "pending"}),
+		dbtest.NewWorkspaceInstance(t, db.WorkspaceInstance{WorkspaceID: workspaces[1].ID, PhasePersisted: "running"}),
+	)
+
+	updates, err := db.ListWorkspaceInstanceUpdates(context.Background(), dbconn, since, []string{"running", "stopped"}, 10)
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	require.Equal(t, instances[0].ID, updates[0].ID)
+	require.Equal(t, orgID, updates[0].OrganizationID)
+	require.Equal(t, workspaces[0].OwnerID, updates[0].OwnerID)
+	require.Equal(t, "running", updates[0].Phase)
+}
@@ -0,0 +1,31 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+import { MigrationInterface, QueryRunner } from "typeorm";
+import { tableExists } from "./helper/helper";
+
+const WEBHOOK_TABLE = "d_b_webhook";
+const DELIVERY_TABLE = "d_b_webhook_delivery";
+
+export class CreateWebhookTables1680780000000 implements MigrationInterface {
+    public async up(queryRunner: QueryRunner): Promise<void> {
+        await queryRunner.query(
+            `CREATE TABLE IF NOT EXISTS ${WEBHOOK_TABLE} (id char(36) NOT NULL, organizationId char(36) NOT NULL, url varchar(1024) NOT NULL, events varchar(255) NOT NULL, secret text NOT NULL, createdById char(36) NOT NULL, createdAt timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6), _lastModified timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6), deleted tinyint(4) NOT NULL DEFAULT '0', PRIMARY KEY (id), KEY ind_organizationId (organizationId), KEY ind_lastModified (_lastModified)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;`,
+        );
+        await queryRunner.query(
+            `CREATE TABLE IF NOT EXISTS ${DELIVERY_TABLE} (id char(36) NOT NULL, webhookId char(36) NOT NULL, event varchar(64) NOT NULL, payload text NOT NULL, status varchar(32) NOT NULL, attempts int NOT NULL DEFAULT '0', lastResponseCode int NOT NULL DEFAULT '0', lastError text NOT NULL, nextAttemptTime timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6), deliveredTime timestamp(6) NULL, createdAt timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6), _lastModified timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6), deleted tinyint(4) NOT NULL DEFAULT '0', PRIMARY KEY (id), KEY ind_webhookId_createdAt (webhookId, createdAt), KEY ind_status_nextAttemptTime (status, nextAttemptTime), KEY ind_lastModified (_lastModified)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;`,
+        );
+    }
+
+    public async down(queryRunner: QueryRunner): Promise<void> {
+        if (await tableExists(queryRunner, DELIVERY_TABLE)) {
+            await queryRunner.query(`DROP TABLE ${DELIVERY_TABLE}`);
+        }
+        if (await tableExists(queryRunner, WEBHOOK_TABLE)) {
+            await queryRunner.query(`DROP TABLE ${WEBHOOK_TABLE}`);
+        }
+    }
+}
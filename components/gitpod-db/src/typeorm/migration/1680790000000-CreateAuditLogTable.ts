@@ -0,0 +1,24 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+import { MigrationInterface, QueryRunner } from "typeorm";
+import { tableExists } from "./helper/helper";
+
+const TABLE_NAME = "d_b_audit_log";
+
+export class CreateAuditLogTable1680790000000 implements MigrationInterface {
+    public async up(queryRunner: QueryRunner): Promise<void> {
+        await queryRunner.query(
+            `CREATE TABLE IF NOT EXISTS ${TABLE_NAME} (id char(36) NOT NULL, timestamp timestamp(6) NOT NULL, organizationId char(36) NOT NULL DEFAULT '', actorId char(36) NOT NULL DEFAULT '', action varchar(255) NOT NULL, targetType varchar(64) NOT NULL DEFAULT '', targetId varchar(255) NOT NULL DEFAULT '', details text NOT NULL, origin varchar(255) NOT NULL DEFAULT '', status varchar(64) NOT NULL DEFAULT '', _lastModified timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6), PRIMARY KEY (id), KEY ind_organizationId_timestamp (organizationId, timestamp), KEY ind_actorId_timestamp (actorId, timestamp), KEY ind_lastModified (_lastModified)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;`,
+        );
+    }
+
+    public async down(queryRunner: QueryRunner): Promise<void> {
+        if (await tableExists(queryRunner, TABLE_NAME)) {
+            await queryRunner.query(`DROP TABLE ${TABLE_NAME}`);
+        }
+    }
+}
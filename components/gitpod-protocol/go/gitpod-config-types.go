@@ -81,6 +81,16 @@ type GitpodConfig struct {
 	WorkspaceLocation string `yaml:"workspaceLocation,omitempty" json:"workspaceLocation,omitempty"`
 }
 
+// Http The task is ready once an HTTP GET request returns a 2xx or 3xx status code.
+type Http struct {
+
+	// The path to request. Defaults to '/'.
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+
+	// The port to send the request to.
+	Port float64 `yaml:"port" json:"port"`
+}
+
 // Image_object The Docker image to run your workspace in.
 type Image_object struct {
 
@@ -189,6 +199,35 @@ type Prebuilds_object struct {
 	PullRequestsFromForks bool `yaml:"pullRequestsFromForks,omitempty" json:"pullRequestsFromForks,omitempty"`
 }
 
+// Readiness Determines when the task is ready, i.e. when tasks depending on it can start. All configured checks must succeed.
+type Readiness struct {
+
+	// The task is ready once this shell command succeeds.
+	Exec string `yaml:"exec,omitempty" json:"exec,omitempty"`
+
+	// The task is ready once an HTTP GET request returns a 2xx or 3xx status code.
+	Http *Http `yaml:"http,omitempty" json:"http,omitempty"`
+
+	// The task is ready once this port accepts TCP connections.
+	Port float64 `yaml:"port,omitempty" json:"port,omitempty"`
+
+	// Maximum time in seconds to wait for the task to become ready. If the task does not become ready in time, tasks depending on it fail. Waits indefinitely by default.
+	Timeout float64 `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+// Restart Determines whether the `command` is restarted once it terminated. Restarts do not apply to prebuilds.
+type Restart struct {
+
+	// Time in seconds to wait before the first restart. The time doubles with every consecutive restart, up to 5 minutes. Defaults to 1.
+	Backoff float64 `yaml:"backoff,omitempty" json:"backoff,omitempty"`
+
+	// Maximum number of restarts. Restarts indefinitely by default.
+	MaxRetries float64 `yaml:"maxRetries,omitempty" json:"maxRetries,omitempty"`
+
+	// When to restart the command. 'never' (default) does not restart the command. 'on-failure' restarts the command if it exits with a non-zero exit code. 'always' restarts the command whenever it exits.
+	Policy string `yaml:"policy,omitempty" json:"policy,omitempty"`
+}
+
 // TasksItems
 type TasksItems struct {
 
@@ -198,6 +237,9 @@ type TasksItems struct {
 	// The main shell command to run after `before` and `init`. This command is executed last on every start and doesn't have to terminate.
 	Command string `yaml:"command,omitempty" json:"command,omitempty"`
 
+	// Names of tasks which must be ready before this task starts. A task without a `readiness` probe is ready once it terminated successfully.
+	DependsOn []string `yaml:"dependsOn,omitempty" json:"dependsOn,omitempty"`
+
 	// Environment variables to set.
 	Env *Env `yaml:"env,omitempty" json:"env,omitempty"`
 
@@ -215,6 +257,12 @@ type TasksItems struct {
 
 	// A shell command to run after `before`. This command is executed only on during workspace prebuilds. This command is expected to terminate. If it fails, the workspace build fails.
 	Prebuild string `yaml:"prebuild,omitempty" json:"prebuild,omitempty"`
+
+	// Determines when the task is ready, i.e. when tasks depending on it can start. All configured checks must succeed.
+	Readiness *Readiness `yaml:"readiness,omitempty" json:"readiness,omitempty"`
+
+	// Determines whether the `command` is restarted once it terminated. Restarts do not apply to prebuilds.
+	Restart *Restart `yaml:"restart,omitempty" json:"restart,omitempty"`
 }
 
 // Vscode Configure VS Code integration
@@ -1876,6 +1876,7 @@ type WorkspaceInstanceConditions struct {
 	NeededImageBuild  bool   `json:"neededImageBuild,omitempty"`
 	PullingImages     bool   `json:"pullingImages,omitempty"`
 	Timeout           string `json:"timeout,omitempty"`
+	TimeoutWarning    string `json:"timeoutWarning,omitempty"`
 }
 
 // WorkspaceInstanceConfiguration is the WorkspaceInstanceConfiguration message type
@@ -1060,6 +1060,25 @@ export interface TaskConfig {
     env?: { [env: string]: any };
     openIn?: "bottom" | "main" | "left" | "right";
     openMode?: "split-top" | "split-left" | "split-right" | "split-bottom" | "tab-before" | "tab-after";
+    dependsOn?: string[];
+    readiness?: TaskReadiness;
+    restart?: TaskRestart;
+}
+
+export interface TaskReadiness {
+    port?: number;
+    http?: {
+        port: number;
+        path?: string;
+    };
+    exec?: string;
+    timeout?: number;
+}
+
+export interface TaskRestart {
+    policy?: "never" | "on-failure" | "always";
+    maxRetries?: number;
+    backoff?: number;
 }
 
 export namespace TaskConfig {
@@ -178,6 +178,9 @@ export interface WorkspaceInstanceConditions {
 
     // stopped_by_request is true if the workspace was stopped using a StopWorkspace call
     stoppedByRequest?: boolean;
+
+    // ISO8601 timestamp when the workspace is expected to time out. Only set shortly before a running workspace times out.
+    timeoutWarning?: string;
 }
 
 // AdmissionLevel describes who can access a workspace instance and its ports.
@@ -0,0 +1,256 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	connect "github.com/bufbuild/connect-go"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+const exportPageSize = 100
+
+func NewAuditLogsService(connPool proxy.ServerConnectionPool, dbConn *gorm.DB) *AuditLogsService {
+	return &AuditLogsService{
+		connectionPool: connPool,
+		dbConn:         dbConn,
+	}
+}
+
+type AuditLogsService struct {
+	connectionPool proxy.ServerConnectionPool
+
+	dbConn *gorm.DB
+
+	v1connect.UnimplementedAuditLogsServiceHandler
+}
+
+func (s *AuditLogsService) ListAuditLogs(ctx context.Context, req *connect.Request[v1.ListAuditLogsRequest]) (*connect.Response[v1.ListAuditLogsResponse], error) {
+	opts, err := s.authorizeListAuditLogs(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := db.ListAuditLogs(ctx, s.dbConn, opts, paginationToDB(req.Msg.GetPagination()))
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to list audit logs.")
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to list audit logs."))
+	}
+
+	var logs []*v1.AuditLog
+	for _, entry := range result.Results {
+		logs = append(logs, auditLogToAPI(entry))
+	}
+
+	return connect.NewResponse(&v1.ListAuditLogsResponse{
+		AuditLogs:    logs,
+		TotalResults: result.Total,
+	}), nil
+}
+
+// ExportHandler serves all audit logs of an organization, or of the caller without an organization, as newline delimited JSON.
+// It accepts the same filters as ListAuditLogs as query parameters: organization_id, actor_id, and from and to in RFC 3339 format.
+func (s *AuditLogsService) ExportHandler(scopes *auth.ScopesInterceptor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Only GET requests are supported.", http.StatusMethodNotAllowed)
+			return
+		}
+
+		token, err := auth.TokenFromHeaders(r.Header)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		ctx := auth.TokenToContext(r.Context(), token)
+
+		msg, err := listAuditLogsRequestFromQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = scopes.Authorize(ctx, "/"+v1connect.AuditLogsServiceName+"/ListAuditLogs", msg)
+		if err != nil {
+			http.Error(w, err.Error(), httpStatusFromCode(connect.CodeOf(err)))
+			return
+		}
+
+		opts, err := s.authorizeListAuditLogs(ctx, msg)
+		if err != nil {
+			http.Error(w, err.Error(), httpStatusFromCode(connect.CodeOf(err)))
+			return
+		}
+		if opts.To.IsZero() {
+			// Audit logs recorded while the export is in progress would shift the pages, hence the export ends at its start.
+			opts.To = time.Now().UTC()
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		name := opts.OrganizationID
+		if name == "" {
+			name = "user-" + opts.ActorID
+		}
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"audit-logs-%s.ndjson\"", name))
+
+		err = s.export(ctx, w, opts)
+		if err != nil {
+			log.Extract(ctx).WithError(err).Error("Failed to export audit logs.")
+		}
+	})
+}
+
+func (s *AuditLogsService) export(ctx context.Context, w http.ResponseWriter, opts db.ListAuditLogsOpts) error {
+	out := bufio.NewWriter(w)
+	defer out.Flush()
+
+	for page := 1; ; page++ {
+		result, err := db.ListAuditLogs(ctx, s.dbConn, opts, db.Pagination{Page: page, PageSize: exportPageSize})
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range result.Results {
+			line, err := protojson.Marshal(auditLogToAPI(entry))
+			if err != nil {
+				return err
+			}
+			_, err = out.Write(append(line, '\n'))
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(result.Results) < exportPageSize {
+			return nil
+		}
+	}
+}
+
+// authorizeListAuditLogs validates the request and asserts that the caller is an owner of the organization.
+// Only owners are permitted to read the audit logs of an organization. Without an organization, callers may only read their own
+// audit logs, which include those of actions which do not belong to an organization, e.g. managing Personal Access Tokens.
+func (s *AuditLogsService) authorizeListAuditLogs(ctx context.Context, msg *v1.ListAuditLogsRequest) (db.ListAuditLogsOpts, error) {
+	opts := db.ListAuditLogsOpts{
+		ActorID: msg.GetActorId(),
+	}
+	if msg.GetFrom() != nil {
+		opts.From = msg.GetFrom().AsTime()
+	}
+	if msg.GetTo() != nil {
+		opts.To = msg.GetTo().AsTime()
+	}
+	if !opts.From.IsZero() && !opts.To.IsZero() && !opts.From.Before(opts.To) {
+		return db.ListAuditLogsOpts{}, connect.NewError(connect.CodeInvalidArgument, errors.New("From must be before To."))
+	}
+
+	if msg.GetOrganizationId() == "" {
+		userID, err := s.authenticatedUserID(ctx)
+		if err != nil {
+			return db.ListAuditLogsOpts{}, err
+		}
+		if opts.ActorID != "" && opts.ActorID != userID {
+			return db.ListAuditLogsOpts{}, connect.NewError(connect.CodePermissionDenied, errors.New("Audit logs of other users can only be listed for an Organization."))
+		}
+		opts.ActorID = userID
+		return opts, nil
+	}
+
+	organizationID, err := validateOrganizationID(ctx, msg.GetOrganizationId())
+	if err != nil {
+		return db.ListAuditLogsOpts{}, err
+	}
+	opts.OrganizationID = organizationID.String()
+	_, err = assertTeamOwner(ctx, s.connectionPool, organizationID)
+	if err != nil {
+		return db.ListAuditLogsOpts{}, err
+	}
+
+	return opts, nil
+}
+
+func (s *AuditLogsService) authenticatedUserID(ctx context.Context) (string, error) {
+	conn, err := getConnection(ctx, s.connectionPool)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := conn.GetLoggedInUser(ctx)
+	if err != nil {
+		return "", proxy.ConvertError(err)
+	}
+
+	log.AddFields(ctx, log.UserID(user.ID))
+
+	return user.ID, nil
+}
+
+func listAuditLogsRequestFromQuery(r *http.Request) (*v1.ListAuditLogsRequest, error) {
+	query := r.URL.Query()
+
+	msg := &v1.ListAuditLogsRequest{
+		OrganizationId: query.Get("organization_id"),
+		ActorId:        query.Get("actor_id"),
+	}
+
+	for param, target := range map[string]**timestamppb.Timestamp{"from": &msg.From, "to": &msg.To} {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("Query parameter %s must be a timestamp in RFC 3339 format.", param)
+		}
+		*target = timestamppb.New(t)
+	}
+
+	return msg, nil
+}
+
+func httpStatusFromCode(code connect.Code) int {
+	switch code {
+	case connect.CodeInvalidArgument:
+		return http.StatusBadRequest
+	case connect.CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case connect.CodePermissionDenied:
+		return http.StatusForbidden
+	case connect.CodeNotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func auditLogToAPI(entry db.AuditLog) *v1.AuditLog {
+	return &v1.AuditLog{
+		Id:             entry.ID.String(),
+		OrganizationId: entry.OrganizationID,
+		ActorId:        entry.ActorID,
+		Action:         entry.Action,
+		TargetType:     entry.TargetType,
+		TargetId:       entry.TargetID,
+		Details:        entry.Details,
+		Origin:         entry.Origin,
+		Status:         entry.Status,
+		CreatedAt:      timestamppb.New(entry.Timestamp),
+	}
+}
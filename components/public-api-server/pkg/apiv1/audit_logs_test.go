@@ -0,0 +1,190 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	connect "github.com/bufbuild/connect-go"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+func TestAuditLogsService_ListAuditLogs(t *testing.T) {
+	organizationID := uuid.New()
+
+	t.Run("invalid argument when organization ID is not a UUID", func(t *testing.T) {
+		_, client, _, _ := setupAuditLogsService(t)
+
+		_, err := client.ListAuditLogs(context.Background(), connect.NewRequest(&v1.ListAuditLogsRequest{
+			OrganizationId: "some-id",
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("permission denied when user is not an owner", func(t *testing.T) {
+		serverMock, client, _, _ := setupAuditLogsService(t)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+		serverMock.EXPECT().GetTeamMembers(gomock.Any(), organizationID.String()).Return([]*protocol.TeamMemberInfo{
+			newTeamMember(&protocol.TeamMemberInfo{UserId: user.ID, Role: protocol.TeamMember_Member}),
+		}, nil)
+
+		_, err := client.ListAuditLogs(context.Background(), connect.NewRequest(&v1.ListAuditLogsRequest{
+			OrganizationId: organizationID.String(),
+		}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("lists audit logs of the organization, most recent first", func(t *testing.T) {
+		serverMock, client, dbConn, _ := setupAuditLogsService(t)
+		expectTeamOwner(serverMock, organizationID, 2)
+
+		now := time.Now().UTC().Truncate(time.Second)
+		logs := dbtest.CreateAuditLogs(t, dbConn,
+			db.AuditLog{OrganizationID: organizationID.String(), ActorID: user.ID, Timestamp: now.Add(-2 * time.Minute)},
+			db.AuditLog{OrganizationID: organizationID.String(), Timestamp: now.Add(-1 * time.Minute)},
+			db.AuditLog{},
+		)
+
+		response, err := client.ListAuditLogs(context.Background(), connect.NewRequest(&v1.ListAuditLogsRequest{
+			OrganizationId: organizationID.String(),
+		}))
+		require.NoError(t, err)
+		require.EqualValues(t, 2, response.Msg.GetTotalResults())
+		require.Equal(t, logs[1].ID.String(), response.Msg.GetAuditLogs()[0].GetId())
+		require.Equal(t, logs[0].ID.String(), response.Msg.GetAuditLogs()[1].GetId())
+
+		filtered, err := client.ListAuditLogs(context.Background(), connect.NewRequest(&v1.ListAuditLogsRequest{
+			OrganizationId: organizationID.String(),
+			ActorId:        user.ID,
+			From:           timestamppb.New(now.Add(-time.Hour)),
+		}))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, filtered.Msg.GetTotalResults())
+		require.Equal(t, logs[0].ID.String(), filtered.Msg.GetAuditLogs()[0].GetId())
+	})
+
+	t.Run("lists audit logs of the caller without an organization", func(t *testing.T) {
+		serverMock, client, dbConn, _ := setupAuditLogsService(t)
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		dbtest.CreateAuditLogs(t, dbConn, db.AuditLog{})
+		withoutOrganization := dbtest.NewAuditLog(t, db.AuditLog{ActorID: user.ID, Action: "gitpod.experimental.v1.TokensService/DeletePersonalAccessToken"})
+		withoutOrganization.OrganizationID = ""
+		_, err := db.CreateAuditLog(context.Background(), dbConn, withoutOrganization)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			dbtest.HardDeleteAuditLogs(t, withoutOrganization.ID.String())
+		})
+
+		response, err := client.ListAuditLogs(context.Background(), connect.NewRequest(&v1.ListAuditLogsRequest{}))
+		require.NoError(t, err)
+		require.NotEmpty(t, response.Msg.GetAuditLogs())
+		var found bool
+		for _, entry := range response.Msg.GetAuditLogs() {
+			require.Equal(t, user.ID, entry.GetActorId())
+			found = found || entry.GetId() == withoutOrganization.ID.String()
+		}
+		require.True(t, found, "audit log without an organization is not listed")
+	})
+
+	t.Run("permission denied for audit logs of another user without an organization", func(t *testing.T) {
+		serverMock, client, _, _ := setupAuditLogsService(t)
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.ListAuditLogs(context.Background(), connect.NewRequest(&v1.ListAuditLogsRequest{
+			ActorId: uuid.NewString(),
+		}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
+func TestAuditLogsService_ExportHandler(t *testing.T) {
+	organizationID := uuid.New()
+
+	t.Run("unauthenticated without a token", func(t *testing.T) {
+		_, _, _, exportURL := setupAuditLogsService(t)
+
+		resp, err := http.Get(exportURL + "?organization_id=" + organizationID.String())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("exports audit logs as newline delimited JSON", func(t *testing.T) {
+		serverMock, _, dbConn, exportURL := setupAuditLogsService(t)
+		expectTeamOwner(serverMock, organizationID, 1)
+
+		dbtest.CreateAuditLogs(t, dbConn,
+			db.AuditLog{OrganizationID: organizationID.String()},
+			db.AuditLog{OrganizationID: organizationID.String()},
+		)
+
+		req, err := http.NewRequest(http.MethodGet, exportURL+"?organization_id="+organizationID.String(), nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer auth-token")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+		var lines int
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			var entry v1.AuditLog
+			require.NoError(t, protojson.Unmarshal(scanner.Bytes(), &entry))
+			require.Equal(t, organizationID.String(), entry.GetOrganizationId())
+			lines++
+		}
+		require.NoError(t, scanner.Err())
+		require.Equal(t, 2, lines)
+	})
+}
+
+func setupAuditLogsService(t *testing.T) (*protocol.MockAPIInterface, v1connect.AuditLogsServiceClient, *gorm.DB, string) {
+	t.Helper()
+
+	dbConn := dbtest.ConnectForTests(t)
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	serverMock := protocol.NewMockAPIInterface(ctrl)
+
+	svc := NewAuditLogsService(&FakeServerConnPool{api: serverMock}, dbConn)
+
+	route, handler := v1connect.NewAuditLogsServiceHandler(svc, connect.WithInterceptors(auth.NewServerInterceptor()))
+
+	mux := http.NewServeMux()
+	mux.Handle(route, handler)
+	mux.Handle("/audit-logs/export", svc.ExportHandler(auth.NewScopesInterceptor(nil, dbConn)))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client := v1connect.NewAuditLogsServiceClient(http.DefaultClient, srv.URL, connect.WithInterceptors(
+		auth.NewClientInterceptor("auth-token"),
+	))
+
+	return serverMock, client, dbConn, srv.URL + "/audit-logs/export"
+}
@@ -25,9 +25,18 @@ import (
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"gorm.io/gorm"
 )
 
+const (
+	redactedClientSecret = "REDACTED"
+
+	defaultSecretRotationGracePeriod = 24 * time.Hour
+	maxSecretRotationGracePeriod     = 30 * 24 * time.Hour
+)
+
 func NewOIDCService(connPool proxy.ServerConnectionPool, expClient experiments.Client, dbConn *gorm.DB, cipher db.Cipher) *OIDCService {
 	return &OIDCService{
 		connectionPool: connPool,
@@ -68,10 +77,18 @@ func (s *OIDCService) CreateClientConfig(ctx context.Context, req *connect.Reque
 		return nil, err
 	}
 
+	teamMappings, err := s.validateTeamMappings(ctx, req.Msg.GetConfig().GetTeamMappings())
+	if err != nil {
+		return nil, err
+	}
+
 	oauth2Config := req.Msg.GetConfig().GetOauth2Config()
 	oidcConfig := req.Msg.GetConfig().GetOidcConfig()
 
-	data, err := db.EncryptJSON(s.cipher, toDbOIDCSpec(oauth2Config, oidcConfig))
+	spec := toDbOIDCSpec(oauth2Config, oidcConfig)
+	spec.TeamMappings = teamMappings
+
+	data, err := db.EncryptJSON(s.cipher, spec)
 	if err != nil {
 		log.Extract(ctx).WithError(err).Error("Failed to encrypt oidc client config.")
 		return nil, status.Errorf(codes.Internal, "Failed to store OIDC client config.")
@@ -176,6 +193,46 @@ func (s *OIDCService) ListClientConfigs(ctx context.Context, req *connect.Reques
 }
 
 func (s *OIDCService) UpdateClientConfig(ctx context.Context, req *connect.Request[v1.UpdateClientConfigRequest]) (*connect.Response[v1.UpdateClientConfigResponse], error) {
+	const (
+		issuerField                = "oidc_config.issuer"
+		clientIDField              = "oauth2_config.client_id"
+		clientSecretField          = "oauth2_config.client_secret"
+		authorizationEndpointField = "oauth2_config.authorization_endpoint"
+		scopesField                = "oauth2_config.scopes"
+		teamMappingsField          = "team_mappings"
+	)
+	var (
+		updatableMask = fieldmaskpb.FieldMask{Paths: []string{issuerField, clientIDField, clientSecretField, authorizationEndpointField, scopesField, teamMappingsField}}
+	)
+
+	config := req.Msg.GetConfig()
+
+	organizationID, err := validateOrganizationID(ctx, config.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	clientConfigID, err := validateOIDCClientConfigID(ctx, config.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	mask, err := validateFieldMask(req.Msg.GetUpdateMask(), config)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no mask fields are specified, we treat the request as updating all updatable fields
+	updateAll := len(mask.GetPaths()) == 0
+	if updateAll {
+		mask = &updatableMask
+	}
+
+	gracePeriod, err := validateSecretRotationGracePeriod(req.Msg.GetSecretRotationGracePeriod())
+	if err != nil {
+		return nil, err
+	}
+
 	conn, err := s.getConnection(ctx)
 	if err != nil {
 		return nil, err
@@ -186,7 +243,123 @@ func (s *OIDCService) UpdateClientConfig(ctx context.Context, req *connect.Reque
 		return nil, err
 	}
 
-	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gitpod.experimental.v1.OIDCService.UpdateClientConfig is not implemented"))
+	record, err := db.GetOIDCClientConfigForOrganization(ctx, s.dbConn, clientConfigID, organizationID)
+	if err != nil {
+		if errors.Is(err, db.ErrorNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("OIDC Client Config %s for Organization %s does not exist", clientConfigID.String(), organizationID.String()))
+		}
+
+		log.Extract(ctx).WithError(err).Error("Failed to retrieve OIDC Client config.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to retrieve OIDC Client Config %s for Organization %s", clientConfigID.String(), organizationID.String()))
+	}
+
+	spec, err := record.Data.Decrypt(s.cipher)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to decrypt oidc client config.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to decrypt OIDC Client Config %s", clientConfigID.String()))
+	}
+
+	oauth2Config := config.GetOauth2Config()
+	for _, path := range fieldmaskpb.Intersect(mask, &updatableMask).GetPaths() {
+		switch path {
+		case issuerField:
+			issuer := config.GetOidcConfig().GetIssuer()
+			if issuer == "" {
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("Issuer must not be empty."))
+			}
+			err = assertIssuerIsReachable(issuer)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			}
+			record.Issuer = issuer
+		case clientIDField:
+			if oauth2Config.GetClientId() == "" {
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("Client ID must not be empty."))
+			}
+			spec.ClientID = oauth2Config.GetClientId()
+		case clientSecretField:
+			secret := oauth2Config.GetClientSecret()
+			if updateAll && (secret == "" || secret == redactedClientSecret) {
+				// Configs are read with a redacted secret, an update of all fields keeps the secret.
+				continue
+			}
+			if secret == "" {
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("Client secret must not be empty."))
+			}
+			spec = rotateClientSecret(spec, secret, time.Now().UTC().Add(gracePeriod))
+		case authorizationEndpointField:
+			spec.RedirectURL = oauth2Config.GetAuthorizationEndpoint()
+		case scopesField:
+			spec.Scopes = withDefaultScopes(oauth2Config.GetScopes())
+		case teamMappingsField:
+			spec.TeamMappings, err = s.validateTeamMappings(ctx, config.GetTeamMappings())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	record.Data, err = db.EncryptJSON(s.cipher, spec)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to encrypt oidc client config.")
+		return nil, status.Errorf(codes.Internal, "Failed to store OIDC client config.")
+	}
+
+	updated, err := db.UpdateOIDCClientConfig(ctx, s.dbConn, record)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to update OIDC Client config.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to update OIDC Client Config %s for Organization %s", clientConfigID.String(), organizationID.String()))
+	}
+
+	converted, err := dbOIDCClientConfigToAPI(updated, s.cipher)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to convert OIDC Client config to response.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to convert OIDC Client Config %s for Organization %s to API response", clientConfigID.String(), organizationID.String()))
+	}
+
+	return connect.NewResponse(&v1.UpdateClientConfigResponse{
+		Config: converted,
+	}), nil
+}
+
+// validateTeamMappings converts the mappings, and asserts that the caller is an owner of every mapped team.
+// Otherwise, owners of one organization could grant access to any other team.
+func (s *OIDCService) validateTeamMappings(ctx context.Context, mappings []*v1.ClaimToTeamMapping) ([]db.ClaimToTeamMapping, error) {
+	var result []db.ClaimToTeamMapping
+	teams := make(map[uuid.UUID]struct{})
+
+	for _, mapping := range mappings {
+		if mapping.GetClaim() == "" || mapping.GetValue() == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("Team mappings must specify a claim and a value."))
+		}
+
+		teamID, err := validateUUID(mapping.GetTeamId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("Team ID of team mappings must be a valid UUID."))
+		}
+		teams[teamID] = struct{}{}
+
+		role := db.TeamMembershipRole_Member
+		if mapping.GetRole() == v1.TeamRole_TEAM_ROLE_OWNER {
+			role = db.TeamMembershipRole_Owner
+		}
+
+		result = append(result, db.ClaimToTeamMapping{
+			Claim:  mapping.GetClaim(),
+			Value:  mapping.GetValue(),
+			TeamID: teamID.String(),
+			Role:   role,
+		})
+	}
+
+	for teamID := range teams {
+		_, err := assertTeamOwner(ctx, s.connectionPool, teamID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
 }
 
 func (s *OIDCService) DeleteClientConfig(ctx context.Context, req *connect.Request[v1.DeleteClientConfigRequest]) (*connect.Response[v1.DeleteClientConfigResponse], error) {
@@ -281,6 +454,30 @@ func (s *OIDCService) isFeatureEnabled(ctx context.Context, conn protocol.APIInt
 	return false
 }
 
+// rotateClientSecret replaces the secret of the spec. The replaced secret remains valid until the given time.
+func rotateClientSecret(spec db.OIDCSpec, secret string, previousValidUntil time.Time) db.OIDCSpec {
+	if secret == spec.ClientSecret {
+		return spec
+	}
+
+	spec.PreviousClientSecret = spec.ClientSecret
+	spec.PreviousClientSecretExpiresAt = previousValidUntil
+	spec.ClientSecret = secret
+	return spec
+}
+
+func validateSecretRotationGracePeriod(d *durationpb.Duration) (time.Duration, error) {
+	if d == nil {
+		return defaultSecretRotationGracePeriod, nil
+	}
+
+	if err := d.CheckValid(); err != nil || d.AsDuration() < 0 || d.AsDuration() > maxSecretRotationGracePeriod {
+		return 0, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Secret rotation grace period must be between 0 and %s.", maxSecretRotationGracePeriod))
+	}
+
+	return d.AsDuration(), nil
+}
+
 func dbOIDCClientConfigToAPI(config db.OIDCClientConfig, decryptor db.Decryptor) (*v1.OIDCClientConfig, error) {
 	decrypted, err := config.Data.Decrypt(decryptor)
 	if err != nil {
@@ -292,16 +489,35 @@ func dbOIDCClientConfigToAPI(config db.OIDCClientConfig, decryptor db.Decryptor)
 		OrganizationId: config.OrganizationID.String(),
 		Oauth2Config: &v1.OAuth2Config{
 			ClientId:              decrypted.ClientID,
-			ClientSecret:          "REDACTED",
+			ClientSecret:          redactedClientSecret,
 			AuthorizationEndpoint: decrypted.RedirectURL,
 			Scopes:                decrypted.Scopes,
 		},
 		OidcConfig: &v1.OIDCConfig{
 			Issuer: config.Issuer,
 		},
+		TeamMappings: teamMappingsToAPI(decrypted.TeamMappings),
 	}, nil
 }
 
+func teamMappingsToAPI(mappings []db.ClaimToTeamMapping) []*v1.ClaimToTeamMapping {
+	var result []*v1.ClaimToTeamMapping
+	for _, mapping := range mappings {
+		role := v1.TeamRole_TEAM_ROLE_MEMBER
+		if mapping.Role == db.TeamMembershipRole_Owner {
+			role = v1.TeamRole_TEAM_ROLE_OWNER
+		}
+
+		result = append(result, &v1.ClaimToTeamMapping{
+			Claim:  mapping.Claim,
+			Value:  mapping.Value,
+			TeamId: mapping.TeamID,
+			Role:   role,
+		})
+	}
+	return result
+}
+
 func dbOIDCClientConfigsToAPI(configs []db.OIDCClientConfig, decryptor db.Decryptor) ([]*v1.OIDCClientConfig, error) {
 	var results []*v1.OIDCClientConfig
 
@@ -322,8 +538,22 @@ func toDbOIDCSpec(oauth2Config *v1.OAuth2Config, oidcConfig *v1.OIDCConfig) db.O
 		ClientID:     oauth2Config.GetClientId(),
 		ClientSecret: oauth2Config.GetClientSecret(),
 		RedirectURL:  oauth2Config.GetAuthorizationEndpoint(),
-		Scopes:       append([]string{goidc.ScopeOpenID, "profile", "email"}, oauth2Config.GetScopes()...),
+		Scopes:       withDefaultScopes(oauth2Config.GetScopes()),
+	}
+}
+
+// withDefaultScopes returns the scopes, including the ones required to sign in, without duplicates.
+func withDefaultScopes(scopes []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, scope := range append([]string{goidc.ScopeOpenID, "profile", "email"}, scopes...) {
+		if seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		result = append(result, scope)
 	}
+	return result
 }
 
 func assertIssuerIsReachable(host string) error {
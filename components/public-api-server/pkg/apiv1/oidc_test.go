@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -27,6 +28,8 @@ import (
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 var (
@@ -305,20 +308,124 @@ func TestOIDCService_UpdateClientConfig(t *testing.T) {
 		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
 		serverMock.EXPECT().GetTeams(gomock.Any()).Return(teams, nil)
 
-		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{}))
+		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				Id:             uuid.NewString(),
+				OrganizationId: uuid.NewString(),
+			},
+		}))
 		require.Error(t, err)
 		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
 	})
 
-	t.Run("feature flag enabled returns unimplemented", func(t *testing.T) {
+	t.Run("invalid argument when ID not specified", func(t *testing.T) {
+		_, client, _, _ := setupOIDCService(t, withOIDCFeatureEnabled)
+
+		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				OrganizationId: uuid.NewString(),
+			},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("not found when record does not exist", func(t *testing.T) {
 		serverMock, client, _, _ := setupOIDCService(t, withOIDCFeatureEnabled)
 
 		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
 
-		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{}))
+		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				Id:             uuid.NewString(),
+				OrganizationId: uuid.NewString(),
+			},
+		}))
 		require.Error(t, err)
-		require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
 	})
+
+	t.Run("rotates client secret and keeps the previous secret for the grace period", func(t *testing.T) {
+		serverMock, client, dbConn, issuer := setupOIDCService(t, withOIDCFeatureEnabled)
+
+		cipher := dbtest.CipherSet(t)
+		data, err := db.EncryptJSON(cipher, db.OIDCSpec{ClientID: "test-id", ClientSecret: "old-secret"})
+		require.NoError(t, err)
+
+		created := dbtest.CreateOIDCClientConfigs(t, dbConn, db.OIDCClientConfig{
+			OrganizationID: uuid.New(),
+			Issuer:         issuer,
+			Data:           data,
+		})[0]
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		response, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				Id:             created.ID.String(),
+				OrganizationId: created.OrganizationID.String(),
+				Oauth2Config:   &v1.OAuth2Config{ClientSecret: "new-secret"},
+			},
+			UpdateMask:                &fieldmaskpb.FieldMask{Paths: []string{"oauth2_config.client_secret"}},
+			SecretRotationGracePeriod: durationpb.New(time.Hour),
+		}))
+		require.NoError(t, err)
+		require.Equal(t, "test-id", response.Msg.GetConfig().GetOauth2Config().GetClientId())
+		require.Equal(t, "REDACTED", response.Msg.GetConfig().GetOauth2Config().GetClientSecret())
+
+		retrieved, err := db.GetOIDCClientConfig(context.Background(), dbConn, created.ID)
+		require.NoError(t, err)
+
+		spec, err := retrieved.Data.Decrypt(cipher)
+		require.NoError(t, err)
+		require.Equal(t, []string{"new-secret", "old-secret"}, spec.ClientSecrets(time.Now()))
+		require.Equal(t, []string{"new-secret"}, spec.ClientSecrets(time.Now().Add(2*time.Hour)))
+	})
+
+	t.Run("permission denied when mapping to a team the user does not own", func(t *testing.T) {
+		serverMock, client, dbConn, issuer := setupOIDCService(t, withOIDCFeatureEnabled)
+
+		created := dbtest.CreateOIDCClientConfigs(t, dbConn, db.OIDCClientConfig{
+			OrganizationID: uuid.New(),
+			Issuer:         issuer,
+		})[0]
+		otherTeamID := uuid.New()
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil).Times(2)
+		serverMock.EXPECT().GetTeamMembers(gomock.Any(), otherTeamID.String()).Return([]*protocol.TeamMemberInfo{
+			newTeamMember(&protocol.TeamMemberInfo{UserId: user.ID, Role: protocol.TeamMember_Member}),
+		}, nil)
+
+		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				Id:             created.ID.String(),
+				OrganizationId: created.OrganizationID.String(),
+				TeamMappings: []*v1.ClaimToTeamMapping{
+					{Claim: "groups", Value: "engineering", TeamId: otherTeamID.String()},
+				},
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"team_mappings"}},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
+func TestRotateClientSecret(t *testing.T) {
+	validUntil := time.Now().Add(time.Hour)
+	spec := db.OIDCSpec{ClientSecret: "old-secret"}
+
+	rotated := rotateClientSecret(spec, "new-secret", validUntil)
+	require.Equal(t, "new-secret", rotated.ClientSecret)
+	require.Equal(t, "old-secret", rotated.PreviousClientSecret)
+	require.Equal(t, validUntil, rotated.PreviousClientSecretExpiresAt)
+
+	require.Equal(t, rotated, rotateClientSecret(rotated, "new-secret", validUntil.Add(time.Hour)), "same secret does not rotate")
+}
+
+func TestWithDefaultScopes(t *testing.T) {
+	require.Equal(t, []string{"openid", "profile", "email"}, withDefaultScopes(nil))
+	require.Equal(t, []string{"openid", "profile", "email", "groups"}, withDefaultScopes([]string{"openid", "profile", "email", "groups"}))
 }
 
 func TestOIDCService_DeleteClientConfig_WithFeatureFlagDisabled(t *testing.T) {
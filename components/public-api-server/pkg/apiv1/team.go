@@ -6,6 +6,7 @@ package apiv1
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
 	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
+	"github.com/google/uuid"
 )
 
 func NewTeamsService(pool proxy.ServerConnectionPool) *TeamService {
@@ -329,3 +331,36 @@ func teamInviteToAPIResponse(invite *protocol.TeamMembershipInvite) *v1.TeamInvi
 		Id: invite.ID,
 	}
 }
+
+// assertTeamOwner returns the ID of the caller, if they are an owner of the team.
+func assertTeamOwner(ctx context.Context, pool proxy.ServerConnectionPool, teamID uuid.UUID) (uuid.UUID, error) {
+	conn, err := getConnection(ctx, pool)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	user, err := conn.GetLoggedInUser(ctx)
+	if err != nil {
+		return uuid.Nil, proxy.ConvertError(err)
+	}
+
+	log.AddFields(ctx, log.UserID(user.ID))
+
+	userID, err := uuid.Parse(user.ID)
+	if err != nil {
+		return uuid.Nil, connect.NewError(connect.CodeInternal, errors.New("Failed to parse user ID as UUID. Please contact support."))
+	}
+
+	members, err := conn.GetTeamMembers(ctx, teamID.String())
+	if err != nil {
+		return uuid.Nil, proxy.ConvertError(err)
+	}
+
+	for _, member := range members {
+		if member.UserId == user.ID && member.Role == protocol.TeamMember_Owner {
+			return userID, nil
+		}
+	}
+
+	return uuid.Nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Only owners of Team %s are permitted to perform this action.", teamID.String()))
+}
@@ -64,6 +64,11 @@ func (s *TokensService) CreatePersonalAccessToken(ctx context.Context, req *conn
 		return nil, err
 	}
 
+	err = authorizeScopes(ctx, scopes)
+	if err != nil {
+		return nil, err
+	}
+
 	conn, err := getConnection(ctx, s.connectionPool)
 	if err != nil {
 		return nil, err
@@ -168,6 +173,25 @@ func (s *TokensService) RegeneratePersonalAccessToken(ctx context.Context, req *
 	if err != nil {
 		return nil, err
 	}
+
+	if _, ok := auth.ScopesFromContext(ctx); ok {
+		existing, err := db.GetPersonalAccessTokenForUser(ctx, s.dbConn, tokenID, userID)
+		if err != nil {
+			if errors.Is(err, db.ErrorNotFound) {
+				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Personal Access Token with ID %s for User %s does not exist", tokenID.String(), userID.String()))
+			}
+
+			log.Extract(ctx).WithError(err).Errorf("Failed to retrieve personal access token for user %s", userID.String())
+			return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to retrieve personal access token."))
+		}
+
+		// Regenerating a token reveals its new value, hence it is subject to the same restrictions as creating it.
+		err = authorizeScopes(ctx, existing.Scopes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	pat, err := auth.GeneratePersonalAccessToken(s.signer)
 	if err != nil {
 		log.Extract(ctx).WithError(err).Errorf("Failed to regenerate personal access token for user %s", userID.String())
@@ -246,6 +270,10 @@ func (s *TokensService) UpdatePersonalAccessToken(ctx context.Context, req *conn
 			if err != nil {
 				return nil, err
 			}
+			err = authorizeScopes(ctx, scopes)
+			if err != nil {
+				return nil, err
+			}
 			dbScopes := db.Scopes(scopes)
 			updateOpts.Scopes = &dbScopes
 		}
@@ -368,7 +396,7 @@ func personalAccessTokenToAPI(t db.PersonalAccessToken, value string) *v1.Person
 		// value is only present when the token is first created, or regenerated. It's empty for all subsequent requests.
 		Value:          value,
 		Name:           t.Name,
-		Scopes:         t.Scopes,
+		Scopes:         scopesToAPI(t.Scopes),
 		ExpirationTime: timestamppb.New(t.ExpirationTime),
 		CreatedAt:      timestamppb.New(t.CreatedAt),
 	}
@@ -393,15 +421,16 @@ func validatePersonalAccessTokenName(name string) (string, error) {
 }
 
 const (
-	allFunctionsScope    = "function:*"
-	defaultResourceScope = "resource:default"
+	allFunctionsScope    = auth.AllFunctionsScope
+	defaultResourceScope = auth.DefaultResourceScope
 )
 
 func validateScopes(scopes []string) ([]string, error) {
-	// Currently we do not have support for fine grained permissions, and therefore do not support fine-grained scopes.
-	// Therefore, for now we operate in one of the following modes:
+	// Tokens operate in one of the following modes:
 	// * Token has no scopes - represented as the empty list of scopes
 	// * Token explicitly has access to everything the user has access to, represented as ["function:*", "resource:default"]
+	// * Token has fine-grained scopes, e.g. ["workspaces:read", "teams:admin:<team-id>"]. We store them together with
+	//   the server scopes needed to serve the procedures they grant access to, as requests are proxied to server with the token.
 	if len(scopes) == 0 {
 		return nil, nil
 	}
@@ -413,5 +442,51 @@ func validateScopes(scopes []string) ([]string, error) {
 		return scopes, nil
 	}
 
-	return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Tokens can currently only have no scopes (empty), or all scopes represented as [%s, %s]", allFunctionsScope, defaultResourceScope))
+	for _, s := range scopes {
+		if auth.IsServerScope(s) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Tokens can only have all scopes represented as [%s, %s], or fine-grained scopes, but got %s.", allFunctionsScope, defaultResourceScope, s))
+		}
+	}
+
+	parsed, err := auth.ParseScopes(scopes)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid scopes: %w. Scopes must take the shape <family>:<read|write|admin>[:<resource-id>].", err))
+	}
+
+	return append(parsed.Strings(), parsed.ServerScopes()...), nil
+}
+
+// authorizeScopes rejects scopes which the Personal Access Token authenticating the request does not hold itself,
+// as tokens must not be able to obtain tokens with more access. Requests authenticated otherwise may grant any scopes.
+func authorizeScopes(ctx context.Context, scopes []string) error {
+	callerScopes, ok := auth.ScopesFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	requested, err := auth.ParseScopes(scopes)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid scopes: %w.", err))
+	}
+
+	if !callerScopes.Includes(requested) {
+		return connect.NewError(connect.CodePermissionDenied, errors.New("Personal Access Tokens can only grant scopes which they hold themselves."))
+	}
+
+	return nil
+}
+
+// scopesToAPI hides the server scopes we store for tokens with fine-grained scopes.
+func scopesToAPI(scopes []string) []string {
+	var res []string
+	for _, s := range scopes {
+		if !auth.IsServerScope(s) {
+			res = append(res, s)
+		}
+	}
+
+	if len(res) == 0 {
+		return scopes
+	}
+	return res
 }
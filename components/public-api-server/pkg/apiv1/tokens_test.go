@@ -816,6 +816,25 @@ func TestValidateScopes(t *testing.T) {
 			RequestedScopes: []string{"unknown", "function:*", "resource:default"},
 			Error:           true,
 		},
+		{
+			Name:            "fine-grained scopes are permitted",
+			RequestedScopes: []string{"workspaces:read", "teams:admin:" + uuid.NewString()},
+		},
+		{
+			Name:            "fine-grained scopes with unknown level are rejected",
+			RequestedScopes: []string{"workspaces:owner"},
+			Error:           true,
+		},
+		{
+			Name:            "fine-grained scopes, with all scopes, are rejected",
+			RequestedScopes: []string{"workspaces:read", "function:*", "resource:default"},
+			Error:           true,
+		},
+		{
+			Name:            "server scopes are rejected",
+			RequestedScopes: []string{"function:getWorkspace", "resource:default"},
+			Error:           true,
+		},
 	} {
 		t.Run(s.Name, func(t *testing.T) {
 			_, err := validateScopes(s.RequestedScopes)
@@ -830,6 +849,102 @@ func TestValidateScopes(t *testing.T) {
 	}
 }
 
+func TestValidateScopes_StoresServerScopes(t *testing.T) {
+	scopes, err := validateScopes([]string{"workspaces:read", "workspaces:read"})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"workspaces:read",
+		"function:getLoggedInUser",
+		"function:getWorkspace",
+		"function:getWorkspaces",
+		"resource:default",
+	}, scopes)
+
+	require.Equal(t, []string{"workspaces:read"}, scopesToAPI(scopes))
+	require.Equal(t, []string{allFunctionsScope, defaultResourceScope}, scopesToAPI([]string{allFunctionsScope, defaultResourceScope}))
+}
+
+func TestAuthorizeScopes(t *testing.T) {
+	tokensAdmin, err := auth.ParseScopes([]string{"tokens:admin", "workspaces:read"})
+	require.NoError(t, err)
+
+	for _, s := range []struct {
+		Name            string
+		CallerScopes    *auth.Scopes
+		RequestedScopes []string
+		ExpectedCode    connect.Code
+	}{
+		{
+			Name:            "requests without personal access token may grant any scopes",
+			RequestedScopes: []string{allFunctionsScope, defaultResourceScope},
+		},
+		{
+			Name:            "token may grant scopes it holds",
+			CallerScopes:    &tokensAdmin,
+			RequestedScopes: []string{"tokens:read", "workspaces:read"},
+		},
+		{
+			Name:            "token may grant no scopes",
+			CallerScopes:    &tokensAdmin,
+			RequestedScopes: nil,
+		},
+		{
+			Name:            "token cannot grant scopes of other families",
+			CallerScopes:    &tokensAdmin,
+			RequestedScopes: []string{"teams:admin"},
+			ExpectedCode:    connect.CodePermissionDenied,
+		},
+		{
+			Name:            "token cannot grant higher levels",
+			CallerScopes:    &tokensAdmin,
+			RequestedScopes: []string{"workspaces:write"},
+			ExpectedCode:    connect.CodePermissionDenied,
+		},
+		{
+			Name:            "token cannot grant all scopes",
+			CallerScopes:    &tokensAdmin,
+			RequestedScopes: []string{allFunctionsScope, defaultResourceScope},
+			ExpectedCode:    connect.CodePermissionDenied,
+		},
+	} {
+		t.Run(s.Name, func(t *testing.T) {
+			ctx := context.Background()
+			if s.CallerScopes != nil {
+				ctx = auth.ScopesToContext(ctx, *s.CallerScopes)
+			}
+
+			err := authorizeScopes(ctx, s.RequestedScopes)
+			if s.ExpectedCode == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, s.ExpectedCode, connect.CodeOf(err))
+		})
+	}
+}
+
+func TestTokensService_CreatePersonalAccessTokenDoesNotEscalateScopes(t *testing.T) {
+	tokensAdmin, err := auth.ParseScopes([]string{"tokens:admin"})
+	require.NoError(t, err)
+	ctx := auth.ScopesToContext(context.Background(), tokensAdmin)
+
+	svc := NewTokensService(&FakeServerConnPool{}, withTokenFeatureEnabled, nil, signer)
+	for _, scopes := range [][]string{
+		{"workspaces:write"},
+		{"tokens:admin", "teams:admin"},
+		{allFunctionsScope, defaultResourceScope},
+	} {
+		_, err := svc.CreatePersonalAccessToken(ctx, connect.NewRequest(&v1.CreatePersonalAccessTokenRequest{
+			Token: &v1.PersonalAccessToken{
+				Name:           "my-token",
+				ExpirationTime: timestamppb.Now(),
+				Scopes:         scopes,
+			},
+		}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "token with tokens:admin must not create a token with scopes %v", scopes)
+	}
+}
+
 func setupTokensService(t *testing.T, expClient experiments.Client) (*protocol.MockAPIInterface, *gorm.DB, v1connect.TokensServiceClient) {
 	t.Helper()
 
@@ -6,11 +6,15 @@ package apiv1
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/bufbuild/connect-go"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
 	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
 	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
 	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
@@ -18,6 +22,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func TestUserService_GetAuthenticatedUser(t *testing.T) {
@@ -38,6 +43,63 @@ func TestUserService_GetAuthenticatedUser(t *testing.T) {
 	})
 }
 
+func TestUserService_PersonalAccessTokenExpiry(t *testing.T) {
+	dbConn := dbtest.ConnectForTests(t)
+	userID := uuid.New()
+
+	setup := func(t *testing.T, expiration time.Time) (*protocol.MockAPIInterface, v1connect.UserServiceClient) {
+		t.Helper()
+
+		pat, err := auth.GeneratePersonalAccessToken(signer)
+		require.NoError(t, err)
+		dbtest.CreatePersonalAccessTokenRecords(t, dbConn, dbtest.NewPersonalAccessToken(t, db.PersonalAccessToken{
+			UserID:         userID,
+			Hash:           pat.ValueHash(),
+			Scopes:         []string{"user:read"},
+			ExpirationTime: expiration,
+		}))
+
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+		serverMock := protocol.NewMockAPIInterface(ctrl)
+
+		svc := NewUserService(&serverAuthenticatingConnPool{api: serverMock, conn: dbConn})
+		_, handler := v1connect.NewUserServiceHandler(svc, connect.WithInterceptors(
+			auth.NewServerInterceptor(),
+			auth.NewScopesInterceptor(signer, dbConn),
+		))
+
+		srv := httptest.NewServer(handler)
+		t.Cleanup(srv.Close)
+
+		client := v1connect.NewUserServiceClient(http.DefaultClient, srv.URL, connect.WithInterceptors(
+			auth.NewClientInterceptor(pat.String()),
+		))
+		return serverMock, client
+	}
+
+	t.Run("valid token reaches server", func(t *testing.T) {
+		serverMock, client := setup(t, time.Now().Add(time.Hour))
+
+		user := newUser(&protocol.User{ID: userID.String()})
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		retrieved, err := client.GetAuthenticatedUser(context.Background(), connect.NewRequest(&v1.GetAuthenticatedUserRequest{}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.GetAuthenticatedUserResponse{
+			User: userToAPIResponse(user),
+		}, retrieved.Msg)
+	})
+
+	t.Run("expired token is rejected before reaching server", func(t *testing.T) {
+		// the server mock has no expectations, hence any call to server fails the test
+		_, client := setup(t, time.Now().Add(-time.Hour))
+
+		_, err := client.GetAuthenticatedUser(context.Background(), connect.NewRequest(&v1.GetAuthenticatedUserRequest{}))
+		requireErrorCode(t, connect.CodeUnauthenticated, err)
+	})
+}
+
 func TestUserService_ListSSHKeys(t *testing.T) {
 	t.Run("proxies request to server", func(t *testing.T) {
 		serverMock, client := setupUserService(t)
@@ -181,3 +243,25 @@ func newGitToken(t *protocol.Token) *protocol.Token {
 
 	return result
 }
+
+// serverAuthenticatingConnPool authenticates Personal Access Tokens the way server does, i.e. it only
+// hands out a connection for tokens which exist and have not expired.
+type serverAuthenticatingConnPool struct {
+	api  protocol.APIInterface
+	conn *gorm.DB
+}
+
+func (p *serverAuthenticatingConnPool) Get(ctx context.Context, token auth.Token) (protocol.APIInterface, error) {
+	pat, err := auth.ParsePersonalAccessToken(token.Value, signer)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := db.GetPersonalAccessTokenByHash(ctx, p.conn, pat.ValueHash())
+	if err != nil {
+		return nil, err
+	}
+	if !stored.ExpirationTime.After(time.Now()) {
+		return nil, errors.New("personal access token has expired")
+	}
+	return p.api, nil
+}
@@ -100,6 +100,26 @@ func validateOIDCClientConfigID(ctx context.Context, id string) (uuid.UUID, erro
 	return oidcClientConfigID, nil
 }
 
+func validateWebhookID(ctx context.Context, id string) (uuid.UUID, error) {
+	log.AddFields(ctx, log.WebhookID(id))
+	webhookID, err := validateUUID(id)
+	if err != nil {
+		return uuid.Nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Webhook ID must be a valid UUID"))
+	}
+
+	return webhookID, nil
+}
+
+func validateWebhookDeliveryID(ctx context.Context, id string) (uuid.UUID, error) {
+	log.AddFields(ctx, log.WebhookDeliveryID(id))
+	deliveryID, err := validateUUID(id)
+	if err != nil {
+		return uuid.Nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Webhook Delivery ID must be a valid UUID"))
+	}
+
+	return deliveryID, nil
+}
+
 func validateFieldMask(mask *fieldmaskpb.FieldMask, message proto.Message) (*fieldmaskpb.FieldMask, error) {
 	if mask == nil {
 		return &fieldmaskpb.FieldMask{}, nil
@@ -0,0 +1,410 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	connect "github.com/bufbuild/connect-go"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/webhooks"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+func NewWebhooksService(connPool proxy.ServerConnectionPool, dbConn *gorm.DB, cipher db.Cipher) *WebhooksService {
+	return &WebhooksService{
+		connectionPool: connPool,
+		dbConn:         dbConn,
+		cipher:         cipher,
+	}
+}
+
+type WebhooksService struct {
+	connectionPool proxy.ServerConnectionPool
+
+	dbConn *gorm.DB
+	cipher db.Cipher
+
+	v1connect.UnimplementedWebhooksServiceHandler
+}
+
+func (s *WebhooksService) CreateWebhook(ctx context.Context, req *connect.Request[v1.CreateWebhookRequest]) (*connect.Response[v1.CreateWebhookResponse], error) {
+	teamID, err := validateTeamID(ctx, req.Msg.GetTeamId())
+	if err != nil {
+		return nil, err
+	}
+
+	webhookURL, err := validateWebhookURL(req.Msg.GetUrl())
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := validateWebhookEvents(req.Msg.GetEvents())
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := assertTeamOwner(ctx, s.connectionPool, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to generate webhook secret.")
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to generate webhook secret."))
+	}
+
+	encrypted, err := db.EncryptJSON(s.cipher, db.WebhookSecret{Secret: secret})
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to encrypt webhook secret.")
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to store webhook."))
+	}
+
+	created, err := db.CreateWebhook(ctx, s.dbConn, db.Webhook{
+		ID:             uuid.New(),
+		OrganizationID: teamID,
+		URL:            webhookURL,
+		Events:         events,
+		Secret:         encrypted,
+		CreatedByID:    userID,
+		CreatedAt:      time.Now().UTC(),
+	})
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to store webhook in the database.")
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to store webhook."))
+	}
+
+	log.AddFields(ctx, log.WebhookID(created.ID.String()))
+
+	return connect.NewResponse(&v1.CreateWebhookResponse{
+		// secret is only returned when the webhook is created.
+		Webhook: webhookToAPI(created, secret),
+	}), nil
+}
+
+func (s *WebhooksService) GetWebhook(ctx context.Context, req *connect.Request[v1.GetWebhookRequest]) (*connect.Response[v1.GetWebhookResponse], error) {
+	teamID, err := validateTeamID(ctx, req.Msg.GetTeamId())
+	if err != nil {
+		return nil, err
+	}
+
+	webhookID, err := validateWebhookID(ctx, req.Msg.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = assertTeamOwner(ctx, s.connectionPool, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.getWebhook(ctx, webhookID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&v1.GetWebhookResponse{
+		Webhook: webhookToAPI(webhook, ""),
+	}), nil
+}
+
+func (s *WebhooksService) ListWebhooks(ctx context.Context, req *connect.Request[v1.ListWebhooksRequest]) (*connect.Response[v1.ListWebhooksResponse], error) {
+	teamID, err := validateTeamID(ctx, req.Msg.GetTeamId())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = assertTeamOwner(ctx, s.connectionPool, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := db.ListWebhooksForOrganization(ctx, s.dbConn, teamID)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to list webhooks.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to list webhooks for Team %s", teamID.String()))
+	}
+
+	var results []*v1.Webhook
+	for _, webhook := range webhooks {
+		results = append(results, webhookToAPI(webhook, ""))
+	}
+
+	return connect.NewResponse(&v1.ListWebhooksResponse{
+		Webhooks: results,
+	}), nil
+}
+
+func (s *WebhooksService) DeleteWebhook(ctx context.Context, req *connect.Request[v1.DeleteWebhookRequest]) (*connect.Response[v1.DeleteWebhookResponse], error) {
+	teamID, err := validateTeamID(ctx, req.Msg.GetTeamId())
+	if err != nil {
+		return nil, err
+	}
+
+	webhookID, err := validateWebhookID(ctx, req.Msg.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = assertTeamOwner(ctx, s.connectionPool, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.DeleteWebhookForOrganization(ctx, s.dbConn, webhookID, teamID)
+	if err != nil {
+		if errors.Is(err, db.ErrorNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Webhook %s for Team %s does not exist", webhookID.String(), teamID.String()))
+		}
+
+		log.Extract(ctx).WithError(err).Error("Failed to delete webhook.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to delete Webhook %s for Team %s", webhookID.String(), teamID.String()))
+	}
+
+	return connect.NewResponse(&v1.DeleteWebhookResponse{}), nil
+}
+
+func (s *WebhooksService) ListWebhookDeliveries(ctx context.Context, req *connect.Request[v1.ListWebhookDeliveriesRequest]) (*connect.Response[v1.ListWebhookDeliveriesResponse], error) {
+	teamID, err := validateTeamID(ctx, req.Msg.GetTeamId())
+	if err != nil {
+		return nil, err
+	}
+
+	webhookID, err := validateWebhookID(ctx, req.Msg.GetWebhookId())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = assertTeamOwner(ctx, s.connectionPool, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.getWebhook(ctx, webhookID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := db.ListWebhookDeliveriesOpts{WebhookID: webhookID}
+	if req.Msg.GetDeadLetterOnly() {
+		opts.Status = db.WebhookDeliveryStatusDeadLetter
+	}
+
+	result, err := db.ListWebhookDeliveries(ctx, s.dbConn, opts, paginationToDB(req.Msg.GetPagination()))
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to list webhook deliveries.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to list deliveries for Webhook %s", webhookID.String()))
+	}
+
+	var deliveries []*v1.WebhookDelivery
+	for _, delivery := range result.Results {
+		deliveries = append(deliveries, webhookDeliveryToAPI(delivery))
+	}
+
+	return connect.NewResponse(&v1.ListWebhookDeliveriesResponse{
+		Deliveries:   deliveries,
+		TotalResults: result.Total,
+	}), nil
+}
+
+func (s *WebhooksService) RetryWebhookDelivery(ctx context.Context, req *connect.Request[v1.RetryWebhookDeliveryRequest]) (*connect.Response[v1.RetryWebhookDeliveryResponse], error) {
+	teamID, err := validateTeamID(ctx, req.Msg.GetTeamId())
+	if err != nil {
+		return nil, err
+	}
+
+	webhookID, err := validateWebhookID(ctx, req.Msg.GetWebhookId())
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryID, err := validateWebhookDeliveryID(ctx, req.Msg.GetDeliveryId())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = assertTeamOwner(ctx, s.connectionPool, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.getWebhook(ctx, webhookID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := db.GetWebhookDelivery(ctx, s.dbConn, deliveryID, webhookID)
+	if err != nil {
+		if errors.Is(err, db.ErrorNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Delivery %s for Webhook %s does not exist", deliveryID.String(), webhookID.String()))
+		}
+
+		log.Extract(ctx).WithError(err).Error("Failed to get webhook delivery.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to get Delivery %s for Webhook %s", deliveryID.String(), webhookID.String()))
+	}
+
+	if delivery.Status != db.WebhookDeliveryStatusDeadLetter {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Only deliveries on the dead-letter list can be retried, delivery %s is %s", deliveryID.String(), delivery.Status))
+	}
+
+	delivery.Status = db.WebhookDeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptTime = time.Now().UTC()
+
+	updated, err := db.UpdateWebhookDeliveryStatus(ctx, s.dbConn, delivery)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to schedule webhook delivery.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to retry Delivery %s for Webhook %s", deliveryID.String(), webhookID.String()))
+	}
+
+	return connect.NewResponse(&v1.RetryWebhookDeliveryResponse{
+		Delivery: webhookDeliveryToAPI(updated),
+	}), nil
+}
+
+func (s *WebhooksService) getWebhook(ctx context.Context, webhookID, teamID uuid.UUID) (db.Webhook, error) {
+	webhook, err := db.GetWebhookForOrganization(ctx, s.dbConn, webhookID, teamID)
+	if err != nil {
+		if errors.Is(err, db.ErrorNotFound) {
+			return db.Webhook{}, connect.NewError(connect.CodeNotFound, fmt.Errorf("Webhook %s for Team %s does not exist", webhookID.String(), teamID.String()))
+		}
+
+		log.Extract(ctx).WithError(err).Error("Failed to get webhook.")
+		return db.Webhook{}, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to get Webhook %s for Team %s", webhookID.String(), teamID.String()))
+	}
+
+	return webhook, nil
+}
+
+func validateWebhookURL(raw string) (string, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Webhook URL must be a valid https URL, got: %q", raw))
+	}
+
+	if len(raw) > 1024 {
+		return "", connect.NewError(connect.CodeInvalidArgument, errors.New("Webhook URL must not be longer than 1024 characters."))
+	}
+
+	// Addresses the host resolves to are checked on every delivery, as they may change after the webhook is created.
+	err = webhooks.CheckHost(parsed.Hostname())
+	if err != nil {
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Webhook URL must point to a public host: %w.", err))
+	}
+
+	return parsed.String(), nil
+}
+
+func validateWebhookEvents(events []v1.WebhookEvent) (db.WebhookEvents, error) {
+	if len(events) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("At least one webhook event is required."))
+	}
+
+	var result db.WebhookEvents
+	for _, event := range events {
+		converted, ok := webhookEventsFromAPI[event]
+		if !ok {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unknown webhook event %s.", event.String()))
+		}
+
+		if !result.Contains(converted) {
+			result = append(result, converted)
+		}
+	}
+
+	return result, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+var webhookEventsFromAPI = map[v1.WebhookEvent]string{
+	v1.WebhookEvent_WEBHOOK_EVENT_WORKSPACE_STARTED: db.WebhookEventWorkspaceStarted,
+	v1.WebhookEvent_WEBHOOK_EVENT_WORKSPACE_STOPPED: db.WebhookEventWorkspaceStopped,
+	v1.WebhookEvent_WEBHOOK_EVENT_WORKSPACE_FAILED:  db.WebhookEventWorkspaceFailed,
+	v1.WebhookEvent_WEBHOOK_EVENT_PREBUILD_FINISHED: db.WebhookEventPrebuildFinished,
+	v1.WebhookEvent_WEBHOOK_EVENT_PORT_PUBLIC:       db.WebhookEventPortPublic,
+}
+
+func webhookEventToAPI(event string) v1.WebhookEvent {
+	for apiEvent, dbEvent := range webhookEventsFromAPI {
+		if dbEvent == event {
+			return apiEvent
+		}
+	}
+	return v1.WebhookEvent_WEBHOOK_EVENT_UNSPECIFIED
+}
+
+func webhookToAPI(webhook db.Webhook, secret string) *v1.Webhook {
+	var events []v1.WebhookEvent
+	for _, event := range webhook.Events {
+		events = append(events, webhookEventToAPI(event))
+	}
+
+	return &v1.Webhook{
+		Id:     webhook.ID.String(),
+		TeamId: webhook.OrganizationID.String(),
+		Url:    webhook.URL,
+		Events: events,
+		// secret is only present when the webhook is first created. It's empty for all subsequent requests.
+		Secret:    secret,
+		CreatedAt: timestamppb.New(webhook.CreatedAt),
+	}
+}
+
+func webhookDeliveryToAPI(delivery db.WebhookDelivery) *v1.WebhookDelivery {
+	result := &v1.WebhookDelivery{
+		Id:               delivery.ID.String(),
+		WebhookId:        delivery.WebhookID.String(),
+		Event:            webhookEventToAPI(delivery.Event),
+		Status:           webhookDeliveryStatusToAPI(delivery.Status),
+		Payload:          delivery.Payload,
+		Attempts:         delivery.Attempts,
+		LastResponseCode: delivery.LastResponseCode,
+		LastError:        delivery.LastError,
+		CreatedAt:        timestamppb.New(delivery.CreatedAt),
+	}
+
+	if delivery.Status == db.WebhookDeliveryStatusPending {
+		result.NextAttemptAt = timestamppb.New(delivery.NextAttemptTime)
+	}
+
+	if delivery.DeliveredTime.Valid {
+		result.DeliveredAt = timestamppb.New(delivery.DeliveredTime.Time)
+	}
+
+	return result
+}
+
+func webhookDeliveryStatusToAPI(status db.WebhookDeliveryStatus) v1.WebhookDeliveryStatus {
+	switch status {
+	case db.WebhookDeliveryStatusPending:
+		return v1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING
+	case db.WebhookDeliveryStatusSucceeded:
+		return v1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED
+	case db.WebhookDeliveryStatusDeadLetter:
+		return v1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD_LETTER
+	default:
+		return v1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED
+	}
+}
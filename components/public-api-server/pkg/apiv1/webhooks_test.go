@@ -0,0 +1,188 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	connect "github.com/bufbuild/connect-go"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestValidateWebhookURL(t *testing.T) {
+	for _, valid := range []string{"https://example.com/hook", "https://example.com:8443/hook?x=y"} {
+		_, err := validateWebhookURL(valid)
+		require.NoError(t, err, valid)
+	}
+
+	for _, invalid := range []string{"", "http://example.com/hook", "https://", "example.com/hook", "ftp://example.com",
+		"https://localhost/hook", "https://127.0.0.1/hook", "https://10.0.0.1/hook", "https://169.254.169.254/latest/meta-data", "https://[::1]/hook", "https://[fd00:ec2::254]/hook"} {
+		_, err := validateWebhookURL(invalid)
+		require.Error(t, err, invalid)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	}
+}
+
+func TestValidateWebhookEvents(t *testing.T) {
+	events, err := validateWebhookEvents([]v1.WebhookEvent{
+		v1.WebhookEvent_WEBHOOK_EVENT_WORKSPACE_STARTED,
+		v1.WebhookEvent_WEBHOOK_EVENT_PORT_PUBLIC,
+		v1.WebhookEvent_WEBHOOK_EVENT_WORKSPACE_STARTED,
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.WebhookEvents{db.WebhookEventWorkspaceStarted, db.WebhookEventPortPublic}, events)
+
+	_, err = validateWebhookEvents(nil)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = validateWebhookEvents([]v1.WebhookEvent{v1.WebhookEvent_WEBHOOK_EVENT_UNSPECIFIED})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestWebhooksService_CreateWebhook(t *testing.T) {
+	teamID := uuid.New()
+
+	t.Run("permission denied when user is not a team owner", func(t *testing.T) {
+		serverMock, client, _ := setupWebhooksService(t)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+		serverMock.EXPECT().GetTeamMembers(gomock.Any(), teamID.String()).Return([]*protocol.TeamMemberInfo{
+			newTeamMember(&protocol.TeamMemberInfo{UserId: user.ID, Role: protocol.TeamMember_Member}),
+		}, nil)
+
+		_, err := client.CreateWebhook(context.Background(), connect.NewRequest(&v1.CreateWebhookRequest{
+			TeamId: teamID.String(),
+			Url:    "https://example.com/hook",
+			Events: []v1.WebhookEvent{v1.WebhookEvent_WEBHOOK_EVENT_WORKSPACE_STARTED},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("creates webhook and returns its secret once", func(t *testing.T) {
+		serverMock, client, dbConn := setupWebhooksService(t)
+		expectTeamOwner(serverMock, teamID, 2)
+
+		response, err := client.CreateWebhook(context.Background(), connect.NewRequest(&v1.CreateWebhookRequest{
+			TeamId: teamID.String(),
+			Url:    "https://example.com/hook",
+			Events: []v1.WebhookEvent{v1.WebhookEvent_WEBHOOK_EVENT_WORKSPACE_STARTED, v1.WebhookEvent_WEBHOOK_EVENT_PREBUILD_FINISHED},
+		}))
+		require.NoError(t, err)
+
+		created := response.Msg.GetWebhook()
+		t.Cleanup(func() {
+			require.NoError(t, dbConn.Where("id = ?", created.GetId()).Delete(&db.Webhook{}).Error)
+		})
+		require.Equal(t, teamID.String(), created.GetTeamId())
+		require.Equal(t, "https://example.com/hook", created.GetUrl())
+		require.Len(t, created.GetEvents(), 2)
+		require.NotEmpty(t, created.GetSecret())
+
+		retrieved, err := client.GetWebhook(context.Background(), connect.NewRequest(&v1.GetWebhookRequest{
+			TeamId: teamID.String(),
+			Id:     created.GetId(),
+		}))
+		require.NoError(t, err)
+		require.Empty(t, retrieved.Msg.GetWebhook().GetSecret())
+	})
+}
+
+func TestWebhooksService_DeleteWebhook(t *testing.T) {
+	serverMock, client, dbConn := setupWebhooksService(t)
+
+	webhook := dbtest.CreateWebhooks(t, dbConn, db.Webhook{})[0]
+	expectTeamOwner(serverMock, webhook.OrganizationID, 2)
+
+	_, err := client.DeleteWebhook(context.Background(), connect.NewRequest(&v1.DeleteWebhookRequest{
+		TeamId: webhook.OrganizationID.String(),
+		Id:     webhook.ID.String(),
+	}))
+	require.NoError(t, err)
+
+	_, err = client.DeleteWebhook(context.Background(), connect.NewRequest(&v1.DeleteWebhookRequest{
+		TeamId: webhook.OrganizationID.String(),
+		Id:     webhook.ID.String(),
+	}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestWebhooksService_RetryWebhookDelivery(t *testing.T) {
+	serverMock, client, dbConn := setupWebhooksService(t)
+
+	webhook := dbtest.CreateWebhooks(t, dbConn, db.Webhook{})[0]
+	deliveries := dbtest.CreateWebhookDeliveries(t, dbConn,
+		db.WebhookDelivery{WebhookID: webhook.ID, Status: db.WebhookDeliveryStatusSucceeded},
+		db.WebhookDelivery{WebhookID: webhook.ID, Status: db.WebhookDeliveryStatusDeadLetter, Attempts: 8},
+	)
+	expectTeamOwner(serverMock, webhook.OrganizationID, 3)
+
+	_, err := client.RetryWebhookDelivery(context.Background(), connect.NewRequest(&v1.RetryWebhookDeliveryRequest{
+		TeamId:     webhook.OrganizationID.String(),
+		WebhookId:  webhook.ID.String(),
+		DeliveryId: deliveries[0].ID.String(),
+	}))
+	require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	response, err := client.RetryWebhookDelivery(context.Background(), connect.NewRequest(&v1.RetryWebhookDeliveryRequest{
+		TeamId:     webhook.OrganizationID.String(),
+		WebhookId:  webhook.ID.String(),
+		DeliveryId: deliveries[1].ID.String(),
+	}))
+	require.NoError(t, err)
+	require.Equal(t, v1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING, response.Msg.GetDelivery().GetStatus())
+	require.Zero(t, response.Msg.GetDelivery().GetAttempts())
+
+	deadLetters, err := client.ListWebhookDeliveries(context.Background(), connect.NewRequest(&v1.ListWebhookDeliveriesRequest{
+		TeamId:         webhook.OrganizationID.String(),
+		WebhookId:      webhook.ID.String(),
+		DeadLetterOnly: true,
+	}))
+	require.NoError(t, err)
+	require.Zero(t, deadLetters.Msg.GetTotalResults())
+}
+
+func expectTeamOwner(serverMock *protocol.MockAPIInterface, teamID uuid.UUID, times int) {
+	serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil).Times(times)
+	serverMock.EXPECT().GetTeamMembers(gomock.Any(), teamID.String()).Return([]*protocol.TeamMemberInfo{
+		newTeamMember(&protocol.TeamMemberInfo{UserId: user.ID, Role: protocol.TeamMember_Owner}),
+	}, nil).Times(times)
+}
+
+func setupWebhooksService(t *testing.T) (*protocol.MockAPIInterface, v1connect.WebhooksServiceClient, *gorm.DB) {
+	t.Helper()
+
+	dbConn := dbtest.ConnectForTests(t)
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	serverMock := protocol.NewMockAPIInterface(ctrl)
+
+	svc := NewWebhooksService(&FakeServerConnPool{api: serverMock}, dbConn, dbtest.CipherSet(t))
+
+	_, handler := v1connect.NewWebhooksServiceHandler(svc, connect.WithInterceptors(auth.NewServerInterceptor()))
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := v1connect.NewWebhooksServiceClient(http.DefaultClient, srv.URL, connect.WithInterceptors(
+		auth.NewClientInterceptor("auth-token"),
+	))
+
+	return serverMock, client, dbConn
+}
@@ -10,21 +10,32 @@ import (
 
 	connect "github.com/bufbuild/connect-go"
 	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
 	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
 	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
 	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/webhooks"
+	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func NewWorkspaceService(serverConnPool proxy.ServerConnectionPool) *WorkspaceService {
+// WorkspaceEventPublisher publishes workspace events to the webhooks of the organization the workspace belongs to.
+type WorkspaceEventPublisher interface {
+	PublishForWorkspace(ctx context.Context, workspaceID string, event webhooks.Event) error
+}
+
+// NewWorkspaceService creates the workspace service. Workspace events are not published when publisher is nil.
+func NewWorkspaceService(serverConnPool proxy.ServerConnectionPool, publisher WorkspaceEventPublisher) *WorkspaceService {
 	return &WorkspaceService{
 		connectionPool: serverConnPool,
+		publisher:      publisher,
 	}
 }
 
 type WorkspaceService struct {
 	connectionPool proxy.ServerConnectionPool
+	publisher      WorkspaceEventPublisher
 
 	v1connect.UnimplementedWorkspacesServiceHandler
 }
@@ -206,6 +217,17 @@ func (s *WorkspaceService) UpdatePort(ctx context.Context, req *connect.Request[
 		return nil, proxy.ConvertError(err)
 	}
 
+	if req.Msg.GetPort().GetPolicy() == v1.PortPolicy_PORT_POLICY_PUBLIC {
+		s.publish(ctx, workspaceID, webhooks.Event{
+			ID:   uuid.NewString(),
+			Type: db.WebhookEventPortPublic,
+			Data: webhooks.PortEventData{
+				WorkspaceID: workspaceID,
+				Port:        req.Msg.GetPort().GetPort(),
+			},
+		})
+	}
+
 	return connect.NewResponse(
 		&v1.UpdatePortResponse{},
 	), nil
@@ -251,6 +273,17 @@ func (s *WorkspaceService) DeleteWorkspace(ctx context.Context, req *connect.Req
 	return connect.NewResponse(&v1.DeleteWorkspaceResponse{}), nil
 }
 
+func (s *WorkspaceService) publish(ctx context.Context, workspaceID string, event webhooks.Event) {
+	if s.publisher == nil {
+		return
+	}
+
+	err := s.publisher.PublishForWorkspace(ctx, workspaceID, event)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Warnf("Failed to publish %s event to webhooks.", event.Type)
+	}
+}
+
 func getLimitFromPagination(pagination *v1.Pagination) (int, error) {
 	const (
 		defaultLimit = 20
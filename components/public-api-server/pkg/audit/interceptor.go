@@ -0,0 +1,226 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/origin"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const StatusOK = "ok"
+
+// targets maps the log fields which identify the resource of a request to the target type recorded in audit logs.
+// Handlers add these fields when validating requests. The first field present determines the target.
+var targets = []struct {
+	Field string
+	Type  string
+}{
+	{Field: log.PersonalAccessTokenIDField, Type: "personal_access_token"},
+	{Field: log.OIDCClientConfigIDField, Type: "oidc_client_config"},
+	{Field: log.WebhookIDField, Type: "webhook"},
+	{Field: log.WorkspaceIDField, Type: "workspace"},
+	{Field: log.ProjectIDField, Type: "project"},
+	{Field: log.OrganizationIDField, Type: "team"},
+}
+
+// NewInterceptor creates an interceptor which records an audit log for every call of a mutating procedure.
+// It must be installed after the log interceptor, because it reads the actor and target of the call from the log fields.
+func NewInterceptor(dbConn *gorm.DB, connPool proxy.ServerConnectionPool) *Interceptor {
+	return &Interceptor{
+		dbConn:   dbConn,
+		connPool: connPool,
+		memberships: func(ctx context.Context, userID uuid.UUID) ([]db.TeamMembership, error) {
+			return db.ListTeamMembershipsForUserIDs(ctx, dbConn, []uuid.UUID{userID})
+		},
+		create: func(ctx context.Context, entry db.AuditLog) error {
+			_, err := db.CreateAuditLog(ctx, dbConn, entry)
+			return err
+		},
+		now: time.Now,
+	}
+}
+
+type Interceptor struct {
+	dbConn      *gorm.DB
+	connPool    proxy.ServerConnectionPool
+	memberships func(ctx context.Context, userID uuid.UUID) ([]db.TeamMembership, error)
+	create      func(ctx context.Context, entry db.AuditLog) error
+	now         func() time.Time
+}
+
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient || !IsAudited(req.Spec().Procedure) {
+			return next(ctx, req)
+		}
+
+		resp, err := next(ctx, req)
+		i.record(ctx, req.Spec().Procedure, req.Any(), err)
+		return resp, err
+	}
+}
+
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler does not record streaming calls, none of them mutate.
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// IsAudited returns true for procedures which mutate resources, i.e. which require at least write access.
+func IsAudited(procedure string) bool {
+	permission, ok := auth.PermissionForProcedure(procedure)
+	return ok && permission.Level >= auth.ScopeLevelWrite
+}
+
+func (i *Interceptor) record(ctx context.Context, procedure string, msg any, callErr error) {
+	fields := log.Extract(ctx).Data
+
+	entry := db.AuditLog{
+		ID:        uuid.New(),
+		Timestamp: i.now().UTC(),
+		ActorID:   stringValue(fields, log.UserIDField),
+		Action:    strings.TrimPrefix(procedure, "/"),
+		Details:   describe(msg),
+		Origin:    origin.FromContext(ctx),
+		Status:    status(callErr),
+	}
+	entry.TargetType, entry.TargetID = target(fields)
+
+	if entry.ActorID == "" {
+		entry.ActorID = i.resolveActor(ctx)
+	}
+	if entry.ActorID == "" && callErr != nil {
+		// Calls which fail without an actor, e.g. because they are not authenticated, cannot be attributed to anyone
+		// and have not mutated anything, hence recording them would only add noise.
+		log.Extract(ctx).Debugf("Not recording audit log for failed call of %s without an actor.", procedure)
+		return
+	}
+
+	organizationID, err := i.resolveOrganization(ctx, stringValue(fields, log.OrganizationIDField), stringValue(fields, log.WorkspaceIDField), entry.ActorID)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to resolve organization of audit log.")
+	}
+	entry.OrganizationID = organizationID
+
+	err = i.create(ctx, entry)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Errorf("Failed to record audit log for %s.", procedure)
+	}
+}
+
+// resolveActor returns the ID of the user who made the call, for handlers which do not add it to the log fields.
+func (i *Interceptor) resolveActor(ctx context.Context) string {
+	token, err := auth.TokenFromContext(ctx)
+	if err != nil {
+		return ""
+	}
+
+	conn, err := i.connPool.Get(ctx, token)
+	if err != nil {
+		return ""
+	}
+
+	user, err := conn.GetLoggedInUser(ctx)
+	if err != nil {
+		return ""
+	}
+
+	return user.ID
+}
+
+// resolveOrganization returns the organization an audit log is recorded for, i.e. the organization of the resource the call targeted.
+// The organization is only recorded if the actor is a member of it, as requests may name any organization, including ones the
+// actor has no access to. Audit logs of calls which do not target a resource of an organization the actor is a member of, e.g.
+// managing Personal Access Tokens, are recorded against the actor only, with an empty organization. Actors can list these by
+// listing audit logs without an organization.
+func (i *Interceptor) resolveOrganization(ctx context.Context, organizationID, workspaceID, actorID string) (string, error) {
+	if organizationID == "" && workspaceID != "" {
+		workspaces, err := db.ListWorkspacesByID(ctx, i.dbConn, []string{workspaceID})
+		if err != nil {
+			return "", err
+		}
+		if len(workspaces) == 1 && workspaces[0].OrganizationId != nil {
+			organizationID = workspaces[0].OrganizationId.String()
+		}
+	}
+	if organizationID == "" {
+		return "", nil
+	}
+
+	return i.authorizedOrganization(ctx, organizationID, actorID)
+}
+
+// authorizedOrganization returns the organization if the actor is a member of it, or empty otherwise.
+func (i *Interceptor) authorizedOrganization(ctx context.Context, organizationID, actorID string) (string, error) {
+	actor, err := uuid.Parse(actorID)
+	if err != nil {
+		return "", nil
+	}
+
+	memberships, err := i.memberships(ctx, actor)
+	if err != nil {
+		return "", err
+	}
+
+	for _, membership := range memberships {
+		if membership.TeamID.String() == organizationID {
+			return organizationID, nil
+		}
+	}
+	return "", nil
+}
+
+// target returns the type and ID of the resource a call targeted, derived from its log fields.
+func target(fields map[string]interface{}) (string, string) {
+	for _, t := range targets {
+		if id := stringValue(fields, t.Field); id != "" {
+			return t.Type, id
+		}
+	}
+	return "", ""
+}
+
+// describe returns details of requests whose target alone does not describe the action.
+func describe(msg any) string {
+	switch req := msg.(type) {
+	case *v1.UpdatePortRequest:
+		return fmt.Sprintf("port %d %s", req.GetPort().GetPort(), req.GetPort().GetPolicy().String())
+	case *v1.UpdateTeamMemberRequest:
+		return fmt.Sprintf("member %s %s", req.GetTeamMember().GetUserId(), req.GetTeamMember().GetRole().String())
+	case *v1.DeleteTeamMemberRequest:
+		return fmt.Sprintf("member %s", req.GetTeamMemberId())
+	default:
+		return ""
+	}
+}
+
+func status(err error) string {
+	if err == nil {
+		return StatusOK
+	}
+	return connect.CodeOf(err).String()
+}
+
+func stringValue(fields map[string]interface{}, key string) string {
+	if v, ok := fields[key].(string); ok {
+		return v
+	}
+	return ""
+}
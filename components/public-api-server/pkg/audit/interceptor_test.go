@@ -0,0 +1,139 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsAudited(t *testing.T) {
+	for procedure, expected := range map[string]bool{
+		"/" + v1connect.TokensServiceName + "/CreatePersonalAccessToken": true,
+		"/" + v1connect.TokensServiceName + "/ListPersonalAccessTokens":  false,
+		"/" + v1connect.TeamsServiceName + "/DeleteTeamMember":           true,
+		"/" + v1connect.WorkspacesServiceName + "/UpdatePort":            true,
+		"/" + v1connect.WorkspacesServiceName + "/GetWorkspace":          false,
+		"/" + v1connect.AuditLogsServiceName + "/ListAuditLogs":          false,
+		"/unknown.Service/Method":                                        false,
+	} {
+		require.Equal(t, expected, IsAudited(procedure), procedure)
+	}
+}
+
+func TestTarget(t *testing.T) {
+	targetType, targetID := target(map[string]interface{}{
+		log.OrganizationIDField:        "org-id",
+		log.PersonalAccessTokenIDField: "token-id",
+	})
+	require.Equal(t, "personal_access_token", targetType)
+	require.Equal(t, "token-id", targetID)
+
+	targetType, targetID = target(map[string]interface{}{
+		log.OrganizationIDField: "org-id",
+		log.UserIDField:         "user-id",
+	})
+	require.Equal(t, "team", targetType)
+	require.Equal(t, "org-id", targetID)
+
+	targetType, targetID = target(map[string]interface{}{})
+	require.Empty(t, targetType)
+	require.Empty(t, targetID)
+}
+
+func TestDescribe(t *testing.T) {
+	require.Equal(t, "port 8080 PORT_POLICY_PUBLIC", describe(&v1.UpdatePortRequest{
+		Port: &v1.PortSpec{Port: 8080, Policy: v1.PortPolicy_PORT_POLICY_PUBLIC},
+	}))
+	require.Equal(t, "member user-id TEAM_ROLE_OWNER", describe(&v1.UpdateTeamMemberRequest{
+		TeamMember: &v1.TeamMember{UserId: "user-id", Role: v1.TeamRole_TEAM_ROLE_OWNER},
+	}))
+	require.Empty(t, describe(&v1.DeleteTeamRequest{}))
+}
+
+func TestStatus(t *testing.T) {
+	require.Equal(t, StatusOK, status(nil))
+	require.Equal(t, "permission_denied", status(connect.NewError(connect.CodePermissionDenied, errors.New("denied"))))
+	require.Equal(t, "unknown", status(errors.New("plain error")))
+}
+
+func TestAuthorizedOrganization(t *testing.T) {
+	var (
+		actorID = uuid.New()
+		orgID   = uuid.New()
+	)
+	i := &Interceptor{
+		memberships: func(ctx context.Context, userID uuid.UUID) ([]db.TeamMembership, error) {
+			if userID != actorID {
+				return nil, nil
+			}
+			return []db.TeamMembership{{UserID: actorID, TeamID: orgID}}, nil
+		},
+	}
+
+	for _, s := range []struct {
+		Name           string
+		OrganizationID string
+		ActorID        string
+		Expected       string
+	}{
+		{Name: "organization of the actor", OrganizationID: orgID.String(), ActorID: actorID.String(), Expected: orgID.String()},
+		{Name: "organization the actor is not a member of", OrganizationID: uuid.NewString(), ActorID: actorID.String()},
+		{Name: "organization of another user", OrganizationID: orgID.String(), ActorID: uuid.NewString()},
+		{Name: "unknown actor", OrganizationID: orgID.String()},
+	} {
+		t.Run(s.Name, func(t *testing.T) {
+			organizationID, err := i.authorizedOrganization(context.Background(), s.OrganizationID, s.ActorID)
+			require.NoError(t, err)
+			require.Equal(t, s.Expected, organizationID)
+		})
+	}
+}
+
+func TestRecord(t *testing.T) {
+	procedure := "/" + v1connect.TokensServiceName + "/DeletePersonalAccessToken"
+	callErr := connect.NewError(connect.CodeUnauthenticated, errors.New("unauthenticated"))
+
+	for _, s := range []struct {
+		Name     string
+		ActorID  string
+		Err      error
+		Expected []string
+	}{
+		{Name: "successful call", ActorID: "user-id", Expected: []string{StatusOK}},
+		{Name: "failed call of an actor", ActorID: "user-id", Err: callErr, Expected: []string{"unauthenticated"}},
+		{Name: "failed call without an actor", Err: callErr},
+	} {
+		t.Run(s.Name, func(t *testing.T) {
+			var recorded []string
+			i := &Interceptor{
+				create: func(ctx context.Context, entry db.AuditLog) error {
+					require.Equal(t, s.ActorID, entry.ActorID)
+					require.Empty(t, entry.OrganizationID)
+					recorded = append(recorded, entry.Status)
+					return nil
+				},
+				now: time.Now,
+			}
+
+			ctx := log.ToContext(context.Background(), log.Log)
+			if s.ActorID != "" {
+				log.AddFields(ctx, log.UserID(s.ActorID))
+			}
+			i.record(ctx, procedure, &v1.DeletePersonalAccessTokenRequest{}, s.Err)
+			require.Equal(t, s.Expected, recorded)
+		})
+	}
+}
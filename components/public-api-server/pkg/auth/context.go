@@ -13,6 +13,7 @@ type contextKey int
 
 const (
 	authContextKey contextKey = iota
+	scopesContextKey
 )
 
 type TokenType int
@@ -52,3 +53,15 @@ func TokenFromContext(ctx context.Context) (Token, error) {
 
 	return Token{}, errors.New("no token present on context")
 }
+
+// ScopesToContext stores the scopes of the personal access token which authenticated the request.
+func ScopesToContext(ctx context.Context, scopes Scopes) context.Context {
+	return context.WithValue(ctx, scopesContextKey, scopes)
+}
+
+// ScopesFromContext returns the scopes of the personal access token which authenticated the request.
+// It returns false for requests authenticated with other credentials, which are not restricted by scopes.
+func ScopesFromContext(ctx context.Context) (Scopes, bool) {
+	scopes, ok := ctx.Value(scopesContextKey).(Scopes)
+	return scopes, ok
+}
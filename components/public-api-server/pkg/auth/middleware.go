@@ -7,6 +7,7 @@ package auth
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/bufbuild/connect-go"
 )
@@ -58,30 +59,21 @@ func NewServerInterceptor() connect.Interceptor {
 }
 
 func tokenFromRequest(ctx context.Context, req connect.AnyRequest) (Token, error) {
-	headers := req.Header()
-
-	bearerToken, err := BearerTokenFromHeaders(headers)
-	if err == nil {
-		return NewAccessToken(bearerToken), nil
-	}
-
-	cookie := req.Header().Get("Cookie")
-	if cookie != "" {
-		return NewCookieToken(cookie), nil
-	}
-
-	return Token{}, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("No access token or cookie credentials available on request."))
+	return TokenFromHeaders(req.Header())
 }
 
 func tokenFromConn(ctx context.Context, conn connect.StreamingHandlerConn) (Token, error) {
-	headers := conn.RequestHeader()
+	return TokenFromHeaders(conn.RequestHeader())
+}
 
+// TokenFromHeaders returns the Bearer token, or otherwise the cookie credentials present in the headers.
+func TokenFromHeaders(headers http.Header) (Token, error) {
 	bearerToken, err := BearerTokenFromHeaders(headers)
 	if err == nil {
 		return NewAccessToken(bearerToken), nil
 	}
 
-	cookie := conn.RequestHeader().Get("Cookie")
+	cookie := headers.Get("Cookie")
 	if cookie != "" {
 		return NewCookieToken(cookie), nil
 	}
@@ -0,0 +1,97 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package auth
+
+import (
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+)
+
+// Permission is the scope a personal access token requires to call a procedure.
+type Permission struct {
+	Family string
+	Level  ScopeLevel
+
+	// Functions are the server functions the procedure calls on behalf of the caller.
+	Functions []protocol.FunctionName
+}
+
+// baseServerFunction is called by most procedures to identify the caller, hence it's permitted for every token with scopes.
+const baseServerFunction = protocol.FunctionGetLoggedInUser
+
+// procedurePermissions lists the permission of every procedure served by the public API.
+// Personal access tokens are rejected for procedures which are not listed.
+var procedurePermissions = map[string]Permission{
+	procedure(v1connect.WorkspacesServiceName, "GetWorkspace"):            {Family: ScopeFamilyWorkspaces, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetWorkspace}},
+	procedure(v1connect.WorkspacesServiceName, "StreamWorkspaceStatus"):   {Family: ScopeFamilyWorkspaces, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetWorkspace}},
+	procedure(v1connect.WorkspacesServiceName, "ListWorkspaces"):          {Family: ScopeFamilyWorkspaces, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetWorkspaces}},
+	procedure(v1connect.WorkspacesServiceName, "GetOwnerToken"):           {Family: ScopeFamilyWorkspaces, Level: ScopeLevelWrite, Functions: []protocol.FunctionName{protocol.FunctionGetOwnerToken}},
+	procedure(v1connect.WorkspacesServiceName, "CreateAndStartWorkspace"): {Family: ScopeFamilyWorkspaces, Level: ScopeLevelWrite, Functions: []protocol.FunctionName{protocol.FunctionCreateWorkspace}},
+	procedure(v1connect.WorkspacesServiceName, "UpdatePort"):              {Family: ScopeFamilyWorkspaces, Level: ScopeLevelWrite, Functions: []protocol.FunctionName{protocol.FunctionOpenPort}},
+	procedure(v1connect.WorkspacesServiceName, "StopWorkspace"):           {Family: ScopeFamilyWorkspaces, Level: ScopeLevelWrite, Functions: []protocol.FunctionName{protocol.FunctionStopWorkspace}},
+	procedure(v1connect.WorkspacesServiceName, "DeleteWorkspace"):         {Family: ScopeFamilyWorkspaces, Level: ScopeLevelWrite, Functions: []protocol.FunctionName{protocol.FunctionDeleteWorkspace}},
+
+	procedure(v1connect.IDEClientServiceName, "SendHeartbeat"): {Family: ScopeFamilyWorkspaces, Level: ScopeLevelWrite, Functions: []protocol.FunctionName{protocol.FunctionGetWorkspace, protocol.FunctionSendHeartBeat}},
+	procedure(v1connect.IDEClientServiceName, "SendDidClose"):  {Family: ScopeFamilyWorkspaces, Level: ScopeLevelWrite, Functions: []protocol.FunctionName{protocol.FunctionGetWorkspace, protocol.FunctionSendHeartBeat}},
+
+	// ID tokens identify the workspace to third parties, hence they require write access.
+	procedure(v1connect.IdentityProviderServiceName, "GetIDToken"): {Family: ScopeFamilyWorkspaces, Level: ScopeLevelWrite, Functions: []protocol.FunctionName{protocol.FunctionGetIDToken, protocol.FunctionGetWorkspace}},
+
+	procedure(v1connect.TeamsServiceName, "GetTeam"):             {Family: ScopeFamilyTeams, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetTeam, protocol.FunctionGetTeamMembers, protocol.FunctionGetGenericInvite}},
+	procedure(v1connect.TeamsServiceName, "ListTeams"):           {Family: ScopeFamilyTeams, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetTeams, protocol.FunctionGetTeamMembers, protocol.FunctionGetGenericInvite}},
+	procedure(v1connect.TeamsServiceName, "CreateTeam"):          {Family: ScopeFamilyTeams, Level: ScopeLevelWrite, Functions: []protocol.FunctionName{protocol.FunctionCreateTeam, protocol.FunctionGetTeamMembers, protocol.FunctionGetGenericInvite}},
+	procedure(v1connect.TeamsServiceName, "JoinTeam"):            {Family: ScopeFamilyTeams, Level: ScopeLevelWrite, Functions: []protocol.FunctionName{protocol.FunctionJoinTeam, protocol.FunctionGetTeamMembers, protocol.FunctionGetGenericInvite}},
+	procedure(v1connect.TeamsServiceName, "DeleteTeam"):          {Family: ScopeFamilyTeams, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionDeleteTeam}},
+	procedure(v1connect.TeamsServiceName, "ResetTeamInvitation"): {Family: ScopeFamilyTeams, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionResetGenericInvite}},
+	procedure(v1connect.TeamsServiceName, "UpdateTeamMember"):    {Family: ScopeFamilyTeams, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionSetTeamMemberRole, protocol.FunctionGetTeamMembers}},
+	procedure(v1connect.TeamsServiceName, "DeleteTeamMember"):    {Family: ScopeFamilyTeams, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionRemoveTeamMember}},
+
+	procedure(v1connect.ProjectsServiceName, "GetProject"):    {Family: ScopeFamilyProjects, Level: ScopeLevelRead},
+	procedure(v1connect.ProjectsServiceName, "ListProjects"):  {Family: ScopeFamilyProjects, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetUserProjects, protocol.FunctionGetTeamProjects}},
+	procedure(v1connect.ProjectsServiceName, "CreateProject"): {Family: ScopeFamilyProjects, Level: ScopeLevelWrite, Functions: []protocol.FunctionName{protocol.FunctionCreateProject}},
+	procedure(v1connect.ProjectsServiceName, "DeleteProject"): {Family: ScopeFamilyProjects, Level: ScopeLevelWrite, Functions: []protocol.FunctionName{protocol.FunctionDeleteProject}},
+
+	procedure(v1connect.UserServiceName, "GetAuthenticatedUser"): {Family: ScopeFamilyUser, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetLoggedInUser}},
+	procedure(v1connect.UserServiceName, "ListSSHKeys"):          {Family: ScopeFamilyUser, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetSSHPublicKeys}},
+	procedure(v1connect.UserServiceName, "GetSSHKey"):            {Family: ScopeFamilyUser, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetSSHPublicKeys}},
+	procedure(v1connect.UserServiceName, "CreateSSHKey"):         {Family: ScopeFamilyUser, Level: ScopeLevelWrite, Functions: []protocol.FunctionName{protocol.FunctionAddSSHPublicKey}},
+	procedure(v1connect.UserServiceName, "DeleteSSHKey"):         {Family: ScopeFamilyUser, Level: ScopeLevelWrite, Functions: []protocol.FunctionName{protocol.FunctionDeleteSSHPublicKey}},
+	// Git tokens are credentials for the user's SCM accounts, hence they require admin access.
+	procedure(v1connect.UserServiceName, "GetGitToken"): {Family: ScopeFamilyUser, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionGetToken}},
+	procedure(v1connect.UserServiceName, "BlockUser"):   {Family: ScopeFamilyUser, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionAdminBlockUser}},
+
+	// Tokens can be created with any scope the caller holds, hence managing tokens requires admin access.
+	procedure(v1connect.TokensServiceName, "GetPersonalAccessToken"):        {Family: ScopeFamilyTokens, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetTeams}},
+	procedure(v1connect.TokensServiceName, "ListPersonalAccessTokens"):      {Family: ScopeFamilyTokens, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetTeams}},
+	procedure(v1connect.TokensServiceName, "CreatePersonalAccessToken"):     {Family: ScopeFamilyTokens, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionGetTeams}},
+	procedure(v1connect.TokensServiceName, "RegeneratePersonalAccessToken"): {Family: ScopeFamilyTokens, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionGetTeams}},
+	procedure(v1connect.TokensServiceName, "UpdatePersonalAccessToken"):     {Family: ScopeFamilyTokens, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionGetTeams}},
+	procedure(v1connect.TokensServiceName, "DeletePersonalAccessToken"):     {Family: ScopeFamilyTokens, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionGetTeams}},
+
+	procedure(v1connect.OIDCServiceName, "GetClientConfig"):    {Family: ScopeFamilyOIDC, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetTeams}},
+	procedure(v1connect.OIDCServiceName, "ListClientConfigs"):  {Family: ScopeFamilyOIDC, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetTeams}},
+	procedure(v1connect.OIDCServiceName, "CreateClientConfig"): {Family: ScopeFamilyOIDC, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionGetTeams, protocol.FunctionGetTeamMembers}},
+	procedure(v1connect.OIDCServiceName, "UpdateClientConfig"): {Family: ScopeFamilyOIDC, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionGetTeams, protocol.FunctionGetTeamMembers}},
+	procedure(v1connect.OIDCServiceName, "DeleteClientConfig"): {Family: ScopeFamilyOIDC, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionGetTeams}},
+
+	procedure(v1connect.AuditLogsServiceName, "ListAuditLogs"): {Family: ScopeFamilyAuditLogs, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetTeamMembers}},
+
+	procedure(v1connect.WebhooksServiceName, "GetWebhook"):            {Family: ScopeFamilyTeams, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetTeamMembers}},
+	procedure(v1connect.WebhooksServiceName, "ListWebhooks"):          {Family: ScopeFamilyTeams, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetTeamMembers}},
+	procedure(v1connect.WebhooksServiceName, "ListWebhookDeliveries"): {Family: ScopeFamilyTeams, Level: ScopeLevelRead, Functions: []protocol.FunctionName{protocol.FunctionGetTeamMembers}},
+	procedure(v1connect.WebhooksServiceName, "CreateWebhook"):         {Family: ScopeFamilyTeams, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionGetTeamMembers}},
+	procedure(v1connect.WebhooksServiceName, "DeleteWebhook"):         {Family: ScopeFamilyTeams, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionGetTeamMembers}},
+	procedure(v1connect.WebhooksServiceName, "RetryWebhookDelivery"):  {Family: ScopeFamilyTeams, Level: ScopeLevelAdmin, Functions: []protocol.FunctionName{protocol.FunctionGetTeamMembers}},
+}
+
+// PermissionForProcedure returns the permission required to call the procedure, e.g. /gitpod.experimental.v1.TeamsService/GetTeam
+func PermissionForProcedure(procedure string) (Permission, bool) {
+	p, ok := procedurePermissions[procedure]
+	return p, ok
+}
+
+func procedure(service, method string) string {
+	return "/" + service + "/" + method
+}
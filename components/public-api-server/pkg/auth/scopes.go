@@ -0,0 +1,254 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package auth
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// AllFunctionsScope together with DefaultResourceScope grants a token access to everything the user has access to.
+	AllFunctionsScope    = "function:*"
+	DefaultResourceScope = "resource:default"
+
+	serverFunctionScopePrefix = "function:"
+	serverResourceScopePrefix = "resource:"
+)
+
+// ScopeLevel is the level of access a scope grants on a family of procedures. Higher levels include all lower levels.
+type ScopeLevel int
+
+const (
+	ScopeLevelRead ScopeLevel = iota + 1
+	ScopeLevelWrite
+	ScopeLevelAdmin
+)
+
+func (l ScopeLevel) String() string {
+	switch l {
+	case ScopeLevelRead:
+		return "read"
+	case ScopeLevelWrite:
+		return "write"
+	case ScopeLevelAdmin:
+		return "admin"
+	default:
+		return fmt.Sprintf("ScopeLevel(%d)", int(l))
+	}
+}
+
+func parseScopeLevel(s string) (ScopeLevel, error) {
+	for _, l := range []ScopeLevel{ScopeLevelRead, ScopeLevelWrite, ScopeLevelAdmin} {
+		if l.String() == s {
+			return l, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown level %q", s)
+}
+
+const (
+	ScopeFamilyWorkspaces = "workspaces"
+	ScopeFamilyProjects   = "projects"
+	ScopeFamilyTeams      = "teams"
+	ScopeFamilyUser       = "user"
+	ScopeFamilyTokens     = "tokens"
+	ScopeFamilyOIDC       = "oidc"
+	ScopeFamilyAuditLogs  = "audit_logs"
+)
+
+// scopeFamilies maps all known families to the request field which identifies a single resource of the family.
+// Scopes of families without a resource field cannot be restricted to a single resource.
+var scopeFamilies = map[string]string{
+	ScopeFamilyWorkspaces: "workspace_id",
+	ScopeFamilyProjects:   "project_id",
+	ScopeFamilyTeams:      "team_id",
+	ScopeFamilyUser:       "",
+	ScopeFamilyTokens:     "",
+	ScopeFamilyOIDC:       "",
+	ScopeFamilyAuditLogs:  "organization_id",
+}
+
+// Scope grants access to a family of procedures, optionally restricted to a single resource.
+// In string form, a scope takes the shape <family>:<level>[:<resource>], e.g. `workspaces:read` or `teams:admin:<team-id>`.
+type Scope struct {
+	Family string
+	Level  ScopeLevel
+	// Resource restricts the scope to requests for the resource with this ID. Empty for all resources.
+	Resource string
+}
+
+func ParseScope(s string) (Scope, error) {
+	segments := strings.SplitN(s, ":", 3)
+	if len(segments) < 2 {
+		return Scope{}, fmt.Errorf("scope %q does not have the shape <family>:<level>[:<resource>]", s)
+	}
+
+	family := segments[0]
+	resourceField, ok := scopeFamilies[family]
+	if !ok {
+		return Scope{}, fmt.Errorf("scope %q has unknown family %q", s, family)
+	}
+
+	level, err := parseScopeLevel(segments[1])
+	if err != nil {
+		return Scope{}, fmt.Errorf("scope %q has %w", s, err)
+	}
+
+	res := Scope{Family: family, Level: level}
+	if len(segments) == 3 {
+		if resourceField == "" {
+			return Scope{}, fmt.Errorf("scope %q cannot be restricted to a resource", s)
+		}
+		if segments[2] == "" {
+			return Scope{}, fmt.Errorf("scope %q has an empty resource", s)
+		}
+		res.Resource = segments[2]
+	}
+
+	return res, nil
+}
+
+func (s Scope) String() string {
+	if s.Resource == "" {
+		return fmt.Sprintf("%s:%s", s.Family, s.Level)
+	}
+	return fmt.Sprintf("%s:%s:%s", s.Family, s.Level, s.Resource)
+}
+
+func (s Scope) grants(p Permission, resource string) bool {
+	if s.Family != p.Family || s.Level < p.Level {
+		return false
+	}
+	return s.Resource == "" || s.Resource == resource
+}
+
+// IsServerScope returns true for scopes which are interpreted by server, rather than by the public API.
+func IsServerScope(s string) bool {
+	return strings.HasPrefix(s, serverFunctionScopePrefix) || strings.HasPrefix(s, serverResourceScopePrefix)
+}
+
+// Scopes are the scopes of a personal access token.
+type Scopes struct {
+	// all is set for tokens with access to everything the user has access to
+	all    bool
+	scopes []Scope
+}
+
+// ParseScopes parses the scopes of a personal access token. Server scopes are ignored,
+// except for AllFunctionsScope and DefaultResourceScope which together grant access to everything.
+func ParseScopes(scopes []string) (Scopes, error) {
+	var (
+		res                  Scopes
+		allFunctions         bool
+		defaultResourceScope bool
+	)
+	for _, s := range scopes {
+		switch {
+		case s == AllFunctionsScope:
+			allFunctions = true
+		case s == DefaultResourceScope:
+			defaultResourceScope = true
+		case IsServerScope(s):
+			continue
+		default:
+			scope, err := ParseScope(s)
+			if err != nil {
+				return Scopes{}, err
+			}
+			res.scopes = append(res.scopes, scope)
+		}
+	}
+	res.all = allFunctions && defaultResourceScope
+
+	return res, nil
+}
+
+// Grants returns true if the scopes permit a request for the resource. Resource is empty
+// for requests which do not refer to a single resource of the permission's family.
+func (s Scopes) Grants(p Permission, resource string) bool {
+	if s.all {
+		return true
+	}
+	for _, scope := range s.scopes {
+		if scope.grants(p, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// Includes returns true if the scopes grant at least the access granted by other, i.e. a token with
+// these scopes may create a token with the other scopes without escalating its privileges.
+func (s Scopes) Includes(other Scopes) bool {
+	if other.all {
+		return s.all
+	}
+	for _, scope := range other.scopes {
+		if !s.Grants(Permission{Family: scope.Family, Level: scope.Level}, scope.Resource) {
+			return false
+		}
+	}
+	return true
+}
+
+// Strings returns the scopes in their canonical, sorted string form.
+func (s Scopes) Strings() []string {
+	if s.all {
+		return []string{AllFunctionsScope, DefaultResourceScope}
+	}
+
+	seen := make(map[string]struct{}, len(s.scopes))
+	var res []string
+	for _, scope := range s.scopes {
+		str := scope.String()
+		if _, ok := seen[str]; ok {
+			continue
+		}
+		seen[str] = struct{}{}
+		res = append(res, str)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// ServerScopes returns the server scopes required to serve all procedures the scopes grant access to.
+// Requests are proxied to server using the personal access token, hence server must permit the functions
+// the procedures call. Restrictions to a single resource are enforced by the public API only.
+func (s Scopes) ServerScopes() []string {
+	if s.all {
+		return []string{AllFunctionsScope, DefaultResourceScope}
+	}
+	if len(s.scopes) == 0 {
+		return nil
+	}
+
+	functions := map[string]struct{}{
+		string(baseServerFunction): {},
+	}
+	for _, p := range procedurePermissions {
+		var granted bool
+		for _, scope := range s.scopes {
+			if scope.Family == p.Family && scope.Level >= p.Level {
+				granted = true
+				break
+			}
+		}
+		if !granted {
+			continue
+		}
+		for _, f := range p.Functions {
+			functions[string(f)] = struct{}{}
+		}
+	}
+
+	res := []string{DefaultResourceScope}
+	for f := range functions {
+		res = append(res, serverFunctionScopePrefix+f)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gorm.io/gorm"
+)
+
+type personalAccessTokenLookup func(ctx context.Context, hash string) (db.PersonalAccessToken, error)
+
+// ScopesInterceptor enforces the scopes of personal access tokens. Requests authenticated with other credentials are not affected.
+// It relies on the token being present on the context, hence it must be installed after the server interceptor.
+type ScopesInterceptor struct {
+	signer Signer
+	lookup personalAccessTokenLookup
+	now    func() time.Time
+}
+
+// NewScopesInterceptor creates a server-side interceptor which rejects requests with personal access tokens lacking the required scope.
+func NewScopesInterceptor(signer Signer, conn *gorm.DB) *ScopesInterceptor {
+	return &ScopesInterceptor{
+		signer: signer,
+		lookup: func(ctx context.Context, hash string) (db.PersonalAccessToken, error) {
+			return db.GetPersonalAccessTokenByHash(ctx, conn, hash)
+		},
+		now: time.Now,
+	}
+}
+
+func (i *ScopesInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+
+		scopes, ok, err := i.authorize(ctx, req.Spec().Procedure, req.Any())
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ctx = ScopesToContext(ctx, scopes)
+		}
+
+		return next(ctx, req)
+	})
+}
+
+func (i *ScopesInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *ScopesInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		// The request message is only available once received, hence we authorize every received message.
+		return next(ctx, &authorizingConn{
+			StreamingHandlerConn: conn,
+			authorize: func(msg any) error {
+				return i.Authorize(ctx, conn.Spec().Procedure, msg)
+			},
+		})
+	}
+}
+
+type authorizingConn struct {
+	connect.StreamingHandlerConn
+	authorize func(msg any) error
+}
+
+func (c *authorizingConn) Receive(msg any) error {
+	err := c.StreamingHandlerConn.Receive(msg)
+	if err != nil {
+		return err
+	}
+
+	return c.authorize(msg)
+}
+
+// Authorize checks that the Personal Access Token on the context, if any, has the scopes required to call the procedure with msg.
+// Requests authenticated otherwise are not restricted by scopes.
+func (i *ScopesInterceptor) Authorize(ctx context.Context, procedure string, msg any) error {
+	_, _, err := i.authorize(ctx, procedure, msg)
+	return err
+}
+
+// authorize returns the scopes of the Personal Access Token on the context once they have been checked, or false if the
+// request is authenticated otherwise.
+func (i *ScopesInterceptor) authorize(ctx context.Context, procedure string, msg any) (Scopes, bool, error) {
+	token, err := TokenFromContext(ctx)
+	if err != nil || token.Type != AccessTokenType || !strings.HasPrefix(token.Value, PersonalAccessTokenPrefix) {
+		return Scopes{}, false, nil
+	}
+
+	permission, ok := PermissionForProcedure(procedure)
+	if !ok {
+		return Scopes{}, false, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Personal Access Tokens are not permitted to call %s.", procedure))
+	}
+
+	scopes, err := i.scopesFromToken(ctx, token.Value)
+	if err != nil {
+		return Scopes{}, false, err
+	}
+
+	var resource string
+	if field := scopeFamilies[permission.Family]; field != "" {
+		resource = stringField(msg, field)
+	}
+
+	if !scopes.Grants(permission, resource) {
+		required := Scope{Family: permission.Family, Level: permission.Level}
+		return Scopes{}, false, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Personal Access Token does not have the %s scope required to call %s.", required, procedure))
+	}
+
+	return scopes, true, nil
+}
+
+func (i *ScopesInterceptor) scopesFromToken(ctx context.Context, value string) (Scopes, error) {
+	if i.signer == nil {
+		return Scopes{}, connect.NewError(connect.CodeUnauthenticated, errors.New("Personal Access Tokens are not enabled."))
+	}
+
+	pat, err := ParsePersonalAccessToken(value, i.signer)
+	if err != nil {
+		return Scopes{}, connect.NewError(connect.CodeUnauthenticated, errors.New("Invalid Personal Access Token."))
+	}
+
+	stored, err := i.lookup(ctx, pat.ValueHash())
+	if err != nil {
+		if errors.Is(err, db.ErrorNotFound) {
+			return Scopes{}, connect.NewError(connect.CodeUnauthenticated, errors.New("Invalid Personal Access Token."))
+		}
+
+		log.Extract(ctx).WithError(err).Error("Failed to retrieve personal access token.")
+		return Scopes{}, connect.NewError(connect.CodeInternal, errors.New("Failed to retrieve Personal Access Token."))
+	}
+
+	log.AddFields(ctx, log.UserID(stored.UserID.String()))
+
+	scopes, err := ParseScopes(stored.Scopes)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Errorf("Personal access token %s has invalid scopes.", stored.ID.String())
+		return Scopes{}, connect.NewError(connect.CodePermissionDenied, errors.New("Personal Access Token has invalid scopes."))
+	}
+
+	// server rejects expired tokens, hence we must not let them pass either
+	if !i.now().Before(stored.ExpirationTime) {
+		return Scopes{}, connect.NewError(connect.CodeUnauthenticated, errors.New("Personal Access Token has expired."))
+	}
+
+	return scopes, nil
+}
+
+// stringField returns the value of the top-level string field of a request message, or empty if the message has no such field.
+func stringField(msg any, name string) string {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return ""
+	}
+
+	r := m.ProtoReflect()
+	field := r.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if field == nil || field.Kind() != protoreflect.StringKind || field.IsList() {
+		return ""
+	}
+
+	return r.Get(field).String()
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopesInterceptor(t *testing.T) {
+	signer := NewHS256Signer([]byte("my-secret"))
+	pat, err := GeneratePersonalAccessToken(signer)
+	require.NoError(t, err)
+
+	now := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	handler := connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return connect.NewResponse(&v1.GetTeamResponse{}), nil
+	})
+
+	for _, s := range []struct {
+		Name           string
+		Token          Token
+		Scopes         []string
+		ExpirationTime time.Time
+		Request        connect.AnyRequest
+		Procedure      string
+		ExpectedCode   connect.Code
+	}{
+		{
+			Name:      "cookie tokens are not affected",
+			Token:     NewCookieToken("cookie"),
+			Request:   connect.NewRequest(&v1.DeleteTeamRequest{TeamId: "team-id"}),
+			Procedure: procedure(v1connect.TeamsServiceName, "DeleteTeam"),
+		},
+		{
+			Name:      "access tokens are not affected",
+			Token:     NewAccessToken("access-token"),
+			Request:   connect.NewRequest(&v1.DeleteTeamRequest{TeamId: "team-id"}),
+			Procedure: procedure(v1connect.TeamsServiceName, "DeleteTeam"),
+		},
+		{
+			Name:         "invalid personal access token",
+			Token:        NewAccessToken(PersonalAccessTokenPrefix + "foo.bar"),
+			Request:      connect.NewRequest(&v1.GetTeamRequest{TeamId: "team-id"}),
+			Procedure:    procedure(v1connect.TeamsServiceName, "GetTeam"),
+			ExpectedCode: connect.CodeUnauthenticated,
+		},
+		{
+			Name:           "all scopes",
+			Token:          NewAccessToken(pat.String()),
+			Scopes:         []string{AllFunctionsScope, DefaultResourceScope},
+			ExpirationTime: now.Add(time.Hour),
+			Request:        connect.NewRequest(&v1.DeleteTeamRequest{TeamId: "team-id"}),
+			Procedure:      procedure(v1connect.TeamsServiceName, "DeleteTeam"),
+		},
+		{
+			Name:           "no scopes",
+			Token:          NewAccessToken(pat.String()),
+			ExpirationTime: now.Add(time.Hour),
+			Request:        connect.NewRequest(&v1.GetTeamRequest{TeamId: "team-id"}),
+			Procedure:      procedure(v1connect.TeamsServiceName, "GetTeam"),
+			ExpectedCode:   connect.CodePermissionDenied,
+		},
+		{
+			Name:           "unknown procedure",
+			Token:          NewAccessToken(pat.String()),
+			Scopes:         []string{AllFunctionsScope, DefaultResourceScope},
+			ExpirationTime: now.Add(time.Hour),
+			Request:        connect.NewRequest(&v1.GetTeamRequest{TeamId: "team-id"}),
+			Procedure:      procedure(v1connect.TeamsServiceName, "Unknown"),
+			ExpectedCode:   connect.CodePermissionDenied,
+		},
+		{
+			Name:           "scope grants procedure",
+			Token:          NewAccessToken(pat.String()),
+			Scopes:         []string{"teams:read"},
+			ExpirationTime: now.Add(time.Hour),
+			Request:        connect.NewRequest(&v1.GetTeamRequest{TeamId: "team-id"}),
+			Procedure:      procedure(v1connect.TeamsServiceName, "GetTeam"),
+		},
+		{
+			Name:           "scope level too low",
+			Token:          NewAccessToken(pat.String()),
+			Scopes:         []string{"teams:read"},
+			ExpirationTime: now.Add(time.Hour),
+			Request:        connect.NewRequest(&v1.DeleteTeamRequest{TeamId: "team-id"}),
+			Procedure:      procedure(v1connect.TeamsServiceName, "DeleteTeam"),
+			ExpectedCode:   connect.CodePermissionDenied,
+		},
+		{
+			Name:           "scope for resource grants procedure",
+			Token:          NewAccessToken(pat.String()),
+			Scopes:         []string{"teams:admin:team-id"},
+			ExpirationTime: now.Add(time.Hour),
+			Request:        connect.NewRequest(&v1.DeleteTeamRequest{TeamId: "team-id"}),
+			Procedure:      procedure(v1connect.TeamsServiceName, "DeleteTeam"),
+		},
+		{
+			Name:           "scope for other resource",
+			Token:          NewAccessToken(pat.String()),
+			Scopes:         []string{"teams:admin:team-id"},
+			ExpirationTime: now.Add(time.Hour),
+			Request:        connect.NewRequest(&v1.DeleteTeamRequest{TeamId: "other-team-id"}),
+			Procedure:      procedure(v1connect.TeamsServiceName, "DeleteTeam"),
+			ExpectedCode:   connect.CodePermissionDenied,
+		},
+		{
+			Name:           "expired token",
+			Token:          NewAccessToken(pat.String()),
+			Scopes:         []string{"teams:admin"},
+			ExpirationTime: now.Add(-time.Hour),
+			Request:        connect.NewRequest(&v1.GetTeamRequest{TeamId: "team-id"}),
+			Procedure:      procedure(v1connect.TeamsServiceName, "GetTeam"),
+			ExpectedCode:   connect.CodeUnauthenticated,
+		},
+		{
+			Name:           "token expiring right now",
+			Token:          NewAccessToken(pat.String()),
+			Scopes:         []string{"teams:admin"},
+			ExpirationTime: now,
+			Request:        connect.NewRequest(&v1.GetTeamRequest{TeamId: "team-id"}),
+			Procedure:      procedure(v1connect.TeamsServiceName, "GetTeam"),
+			ExpectedCode:   connect.CodeUnauthenticated,
+		},
+	} {
+		t.Run(s.Name, func(t *testing.T) {
+			interceptor := &ScopesInterceptor{
+				signer: signer,
+				lookup: func(ctx context.Context, hash string) (db.PersonalAccessToken, error) {
+					if hash != pat.ValueHash() {
+						return db.PersonalAccessToken{}, fmt.Errorf("token does not exist: %w", db.ErrorNotFound)
+					}
+					return db.PersonalAccessToken{Scopes: s.Scopes, ExpirationTime: s.ExpirationTime}, nil
+				},
+				now: func() time.Time { return now },
+			}
+
+			ctx := TokenToContext(context.Background(), s.Token)
+			_, err := interceptor.WrapUnary(handler)(ctx, &procedureRequest{AnyRequest: s.Request, procedure: s.Procedure})
+			if s.ExpectedCode == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, s.ExpectedCode, connect.CodeOf(err))
+		})
+	}
+}
+
+func TestScopesInterceptor_ScopesOnContext(t *testing.T) {
+	signer := NewHS256Signer([]byte("my-secret"))
+	pat, err := GeneratePersonalAccessToken(signer)
+	require.NoError(t, err)
+
+	interceptor := &ScopesInterceptor{
+		signer: signer,
+		lookup: func(ctx context.Context, hash string) (db.PersonalAccessToken, error) {
+			return db.PersonalAccessToken{Scopes: []string{"tokens:admin"}, ExpirationTime: time.Now().Add(time.Hour)}, nil
+		},
+		now: time.Now,
+	}
+
+	var (
+		scopes Scopes
+		ok     bool
+	)
+	handler := connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		scopes, ok = ScopesFromContext(ctx)
+		return connect.NewResponse(&v1.ListPersonalAccessTokensResponse{}), nil
+	})
+	req := &procedureRequest{AnyRequest: connect.NewRequest(&v1.ListPersonalAccessTokensRequest{}), procedure: procedure(v1connect.TokensServiceName, "ListPersonalAccessTokens")}
+
+	_, err = interceptor.WrapUnary(handler)(TokenToContext(context.Background(), NewAccessToken(pat.String())), req)
+	require.NoError(t, err)
+	require.True(t, ok, "scopes of personal access tokens must be on the context")
+	require.Equal(t, []string{"tokens:admin"}, scopes.Strings())
+
+	_, err = interceptor.WrapUnary(handler)(TokenToContext(context.Background(), NewCookieToken("cookie")), req)
+	require.NoError(t, err)
+	require.False(t, ok, "requests authenticated otherwise must not have scopes on the context")
+}
+
+// procedureRequest overrides the spec of a request, which is only populated by connect for requests it handles.
+type procedureRequest struct {
+	connect.AnyRequest
+	procedure string
+}
+
+func (r *procedureRequest) Spec() connect.Spec {
+	return connect.Spec{Procedure: r.procedure}
+}
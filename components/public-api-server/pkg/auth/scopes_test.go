@@ -0,0 +1,145 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScope(t *testing.T) {
+	for _, s := range []struct {
+		Scope    string
+		Expected Scope
+		Error    bool
+	}{
+		{Scope: "workspaces:read", Expected: Scope{Family: ScopeFamilyWorkspaces, Level: ScopeLevelRead}},
+		{Scope: "teams:admin:team-id", Expected: Scope{Family: ScopeFamilyTeams, Level: ScopeLevelAdmin, Resource: "team-id"}},
+		{Scope: "projects:write:project-id", Expected: Scope{Family: ScopeFamilyProjects, Level: ScopeLevelWrite, Resource: "project-id"}},
+		{Scope: "workspaces", Error: true},
+		{Scope: "unknown:read", Error: true},
+		{Scope: "workspaces:owner", Error: true},
+		{Scope: "teams:admin:", Error: true},
+		{Scope: "tokens:admin:token-id", Error: true},
+	} {
+		t.Run(s.Scope, func(t *testing.T) {
+			scope, err := ParseScope(s.Scope)
+			if s.Error {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, s.Expected, scope)
+			require.Equal(t, s.Scope, scope.String())
+		})
+	}
+}
+
+func TestScopes_Grants(t *testing.T) {
+	var (
+		readWorkspaces  = Permission{Family: ScopeFamilyWorkspaces, Level: ScopeLevelRead}
+		writeWorkspaces = Permission{Family: ScopeFamilyWorkspaces, Level: ScopeLevelWrite}
+		readTeams       = Permission{Family: ScopeFamilyTeams, Level: ScopeLevelRead}
+		adminTeams      = Permission{Family: ScopeFamilyTeams, Level: ScopeLevelAdmin}
+	)
+
+	for _, s := range []struct {
+		Name       string
+		Scopes     []string
+		Permission Permission
+		Resource   string
+		Expected   bool
+	}{
+		{Name: "no scopes", Permission: readWorkspaces},
+		{Name: "all scopes", Scopes: []string{AllFunctionsScope, DefaultResourceScope}, Permission: adminTeams, Expected: true},
+		{Name: "all functions without default resource", Scopes: []string{AllFunctionsScope}, Permission: readWorkspaces},
+		{Name: "matching level", Scopes: []string{"workspaces:read"}, Permission: readWorkspaces, Expected: true},
+		{Name: "higher level includes lower", Scopes: []string{"workspaces:write"}, Permission: readWorkspaces, Expected: true},
+		{Name: "lower level", Scopes: []string{"workspaces:read"}, Permission: writeWorkspaces},
+		{Name: "other family", Scopes: []string{"workspaces:write"}, Permission: readTeams},
+		{Name: "matching resource", Scopes: []string{"teams:admin:team-id"}, Permission: adminTeams, Resource: "team-id", Expected: true},
+		{Name: "other resource", Scopes: []string{"teams:admin:team-id"}, Permission: adminTeams, Resource: "other-team-id"},
+		{Name: "request without resource", Scopes: []string{"teams:read:team-id"}, Permission: readTeams},
+		{Name: "server scopes are ignored", Scopes: []string{"workspaces:read", DefaultResourceScope, "function:getWorkspace"}, Permission: readWorkspaces, Expected: true},
+	} {
+		t.Run(s.Name, func(t *testing.T) {
+			scopes, err := ParseScopes(s.Scopes)
+			require.NoError(t, err)
+
+			require.Equal(t, s.Expected, scopes.Grants(s.Permission, s.Resource))
+		})
+	}
+}
+
+func TestScopes_Includes(t *testing.T) {
+	all := []string{AllFunctionsScope, DefaultResourceScope}
+
+	for _, s := range []struct {
+		Name     string
+		Scopes   []string
+		Other    []string
+		Expected bool
+	}{
+		{Name: "no scopes include no scopes", Expected: true},
+		{Name: "all scopes include everything", Scopes: all, Other: []string{"teams:admin", "tokens:admin"}, Expected: true},
+		{Name: "all scopes include all scopes", Scopes: all, Other: all, Expected: true},
+		{Name: "fine-grained scopes do not include all scopes", Scopes: []string{"tokens:admin"}, Other: all},
+		{Name: "other family", Scopes: []string{"tokens:admin"}, Other: []string{"workspaces:write"}},
+		{Name: "lower level", Scopes: []string{"tokens:admin", "workspaces:write"}, Other: []string{"tokens:read", "workspaces:read"}, Expected: true},
+		{Name: "higher level", Scopes: []string{"tokens:admin", "workspaces:read"}, Other: []string{"workspaces:write"}},
+		{Name: "resource of unrestricted scope", Scopes: []string{"teams:admin"}, Other: []string{"teams:read:team-id"}, Expected: true},
+		{Name: "other resource", Scopes: []string{"teams:admin:team-id"}, Other: []string{"teams:read:other-team-id"}},
+		{Name: "unrestricted scope of resource", Scopes: []string{"teams:admin:team-id"}, Other: []string{"teams:read"}},
+	} {
+		t.Run(s.Name, func(t *testing.T) {
+			scopes, err := ParseScopes(s.Scopes)
+			require.NoError(t, err)
+			other, err := ParseScopes(s.Other)
+			require.NoError(t, err)
+
+			require.Equal(t, s.Expected, scopes.Includes(other))
+		})
+	}
+}
+
+func TestScopes_ServerScopes(t *testing.T) {
+	all, err := ParseScopes([]string{DefaultResourceScope, AllFunctionsScope})
+	require.NoError(t, err)
+	require.Equal(t, []string{AllFunctionsScope, DefaultResourceScope}, all.ServerScopes())
+
+	none, err := ParseScopes(nil)
+	require.NoError(t, err)
+	require.Nil(t, none.ServerScopes())
+
+	scopes, err := ParseScopes([]string{"workspaces:read", "teams:read:team-id"})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"function:getGenericInvite",
+		"function:getLoggedInUser",
+		"function:getTeam",
+		"function:getTeamMembers",
+		"function:getTeams",
+		"function:getWorkspace",
+		"function:getWorkspaces",
+		DefaultResourceScope,
+	}, scopes.ServerScopes())
+}
+
+func TestPermissionForProcedure(t *testing.T) {
+	permission, ok := PermissionForProcedure("/gitpod.experimental.v1.TeamsService/DeleteTeam")
+	require.True(t, ok)
+	require.Equal(t, ScopeFamilyTeams, permission.Family)
+	require.Equal(t, ScopeLevelAdmin, permission.Level)
+
+	_, ok = PermissionForProcedure("/gitpod.experimental.v1.TeamsService/Unknown")
+	require.False(t, ok)
+
+	for procedure, permission := range procedurePermissions {
+		_, ok := scopeFamilies[permission.Family]
+		require.True(t, ok, "procedure %s has unknown family %s", procedure, permission.Family)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package oidc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	goidc "github.com/coreos/go-oidc/v3/oidc"
+)
+
+const discoveryCacheTTL = 1 * time.Hour
+
+type discoverFunc func(ctx context.Context, issuer string) (*goidc.Provider, error)
+
+// providerCache caches the discovered configuration of issuers, such that sign-ins do not each require a discovery request.
+// Failed discoveries are not cached.
+type providerCache struct {
+	ttl      time.Duration
+	discover discoverFunc
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cachedProvider
+}
+
+type cachedProvider struct {
+	provider     *goidc.Provider
+	discoveredAt time.Time
+}
+
+func newProviderCache(ttl time.Duration, discover discoverFunc) *providerCache {
+	return &providerCache{
+		ttl:      ttl,
+		discover: discover,
+		now:      time.Now,
+		entries:  make(map[string]cachedProvider),
+	}
+}
+
+// Get returns the provider of the issuer, discovering it if it is not cached or the cached entry has expired.
+func (c *providerCache) Get(ctx context.Context, issuer string) (*goidc.Provider, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[issuer]
+	c.mu.Unlock()
+	if ok && c.now().Sub(entry.discoveredAt) < c.ttl {
+		return entry.provider, nil
+	}
+
+	provider, err := c.discover(ctx, issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[issuer] = cachedProvider{provider: provider, discoveredAt: c.now()}
+	c.mu.Unlock()
+
+	return provider, nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package oidc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	goidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderCache(t *testing.T) {
+	now := time.Now()
+	discoveries := map[string]int{}
+	cache := newProviderCache(time.Hour, func(ctx context.Context, issuer string) (*goidc.Provider, error) {
+		discoveries[issuer]++
+		if issuer == "https://unreachable.example.com" {
+			return nil, errors.New("unreachable")
+		}
+		return &goidc.Provider{}, nil
+	})
+	cache.now = func() time.Time { return now }
+
+	first, err := cache.Get(context.Background(), "https://accounts.google.com")
+	require.NoError(t, err)
+	second, err := cache.Get(context.Background(), "https://accounts.google.com")
+	require.NoError(t, err)
+	require.Same(t, first, second)
+	require.Equal(t, 1, discoveries["https://accounts.google.com"], "cached providers are not discovered again")
+
+	now = now.Add(2 * time.Hour)
+	_, err = cache.Get(context.Background(), "https://accounts.google.com")
+	require.NoError(t, err)
+	require.Equal(t, 2, discoveries["https://accounts.google.com"], "expired providers are discovered again")
+
+	for i := 0; i < 2; i++ {
+		_, err = cache.Get(context.Background(), "https://unreachable.example.com")
+		require.Error(t, err)
+	}
+	require.Equal(t, 2, discoveries["https://unreachable.example.com"], "failed discoveries are not cached")
+}
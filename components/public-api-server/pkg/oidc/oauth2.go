@@ -6,7 +6,9 @@ package oidc
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"golang.org/x/oauth2"
@@ -79,7 +81,7 @@ func (s *Service) OAuth2Middleware(next http.Handler) http.Handler {
 		}
 
 		config.OAuth2Config.RedirectURL = getCallbackURL(r.Host)
-		oauth2Token, err := config.OAuth2Config.Exchange(r.Context(), code)
+		oauth2Token, err := exchange(r.Context(), config, code)
 		if err != nil {
 			http.Error(rw, "failed to exchange token: "+err.Error(), http.StatusInternalServerError)
 			return
@@ -93,3 +95,36 @@ func (s *Service) OAuth2Middleware(next http.Handler) http.Handler {
 		next.ServeHTTP(rw, r.WithContext(ctx))
 	})
 }
+
+// exchange exchanges the code for a token. While the client secret is rotated, the identity provider may not yet
+// accept the current secret, hence a rejected client is retried with the previous secret.
+func exchange(ctx context.Context, config *ClientConfig, code string) (*oauth2.Token, error) {
+	token, err := config.OAuth2Config.Exchange(ctx, code)
+	if err == nil || config.PreviousClientSecret == "" || !isInvalidClient(err) {
+		return token, err
+	}
+
+	previous := *config.OAuth2Config
+	previous.ClientSecret = config.PreviousClientSecret
+
+	token, retryErr := previous.Exchange(ctx, code)
+	if retryErr != nil {
+		return nil, err
+	}
+
+	log.WithFields(log.OIDCClientConfigID(config.ID)).Info("Exchanged OIDC token with the previous client secret.")
+	return token, nil
+}
+
+func isInvalidClient(err error) bool {
+	var retrieveErr *oauth2.RetrieveError
+	if !errors.As(err, &retrieveErr) {
+		return false
+	}
+
+	// Identity providers respond with 401 or 400 and error code invalid_client to failed client authentication.
+	if retrieveErr.Response != nil && retrieveErr.Response.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+	return strings.Contains(string(retrieveErr.Body), "invalid_client")
+}
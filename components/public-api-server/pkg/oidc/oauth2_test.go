@@ -0,0 +1,54 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestExchange(t *testing.T) {
+	// The fake IdP only accepts the old secret, as if the rotation was not applied there yet.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, secret, _ := r.BasicAuth()
+		if secret != "old-secret" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "invalid_client"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "token", "token_type": "Bearer"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	newConfig := func(previousSecret string) *ClientConfig {
+		return &ClientConfig{
+			ID: "config-id",
+			OAuth2Config: &oauth2.Config{
+				ClientID:     "client-id",
+				ClientSecret: "new-secret",
+				Endpoint:     oauth2.Endpoint{TokenURL: srv.URL, AuthStyle: oauth2.AuthStyleInHeader},
+			},
+			PreviousClientSecret: previousSecret,
+		}
+	}
+
+	t.Run("retries with previous secret during grace period", func(t *testing.T) {
+		token, err := exchange(context.Background(), newConfig("old-secret"), "code")
+		require.NoError(t, err)
+		require.Equal(t, "token", token.AccessToken)
+	})
+
+	t.Run("fails without previous secret", func(t *testing.T) {
+		_, err := exchange(context.Background(), newConfig(""), "code")
+		require.Error(t, err)
+	})
+}
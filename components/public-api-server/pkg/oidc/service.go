@@ -14,8 +14,8 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 
-	"github.com/coreos/go-oidc/v3/oidc"
 	goidc "github.com/coreos/go-oidc/v3/oidc"
 	"github.com/gitpod-io/gitpod/common-go/log"
 	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
@@ -31,7 +31,8 @@ type Service struct {
 	cipher   db.Cipher
 	stateJWT *StateJWT
 
-	// verifierByIssuer      map[string]*goidc.IDTokenVerifier
+	providers *providerCache
+
 	sessionServiceAddress string
 
 	// TODO(at) remove by enhancing test setups
@@ -44,6 +45,12 @@ type ClientConfig struct {
 	Issuer         string
 	OAuth2Config   *oauth2.Config
 	VerifierConfig *goidc.Config
+
+	// PreviousClientSecret is set while the secret replaced by the current one is in its grace period.
+	// Token exchanges rejected for the current secret are retried with it.
+	PreviousClientSecret string
+
+	TeamMappings []db.ClaimToTeamMapping
 }
 
 type StartParams struct {
@@ -55,6 +62,10 @@ type StartParams struct {
 type AuthFlowResult struct {
 	IDToken *goidc.IDToken         `json:"idToken"`
 	Claims  map[string]interface{} `json:"claims"`
+
+	// TeamMemberships are derived from the claims by the team mappings of the client config.
+	// They are applied once the session is created, hence they are not sent to server.
+	TeamMemberships []TeamMembership `json:"-"`
 }
 
 func NewService(sessionServiceAddress string, dbConn *gorm.DB, cipher db.Cipher, stateJWT *StateJWT) *Service {
@@ -65,6 +76,8 @@ func NewService(sessionServiceAddress string, dbConn *gorm.DB, cipher db.Cipher,
 		cipher: cipher,
 
 		stateJWT: stateJWT,
+
+		providers: newProviderCache(discoveryCacheTTL, goidc.NewProvider),
 	}
 }
 
@@ -126,8 +139,9 @@ func randString(size int) (string, error) {
 
 func (s *Service) GetClientConfigFromStartRequest(r *http.Request) (*ClientConfig, error) {
 	orgSlug := r.URL.Query().Get("orgSlug")
+	idParam := r.URL.Query().Get("id")
 	if orgSlug != "" {
-		dbEntry, err := db.GetOIDCClientConfigByOrgSlug(r.Context(), s.dbConn, orgSlug)
+		dbEntry, err := s.selectConfigOfOrganization(r.Context(), orgSlug, idParam)
 		if err != nil {
 			return nil, fmt.Errorf("Failed to find OIDC clients: %w", err)
 		}
@@ -140,7 +154,6 @@ func (s *Service) GetClientConfigFromStartRequest(r *http.Request) (*ClientConfi
 		return &config, nil
 	}
 
-	idParam := r.URL.Query().Get("id")
 	if idParam == "" {
 		return nil, fmt.Errorf("missing id parameter")
 	}
@@ -156,6 +169,33 @@ func (s *Service) GetClientConfigFromStartRequest(r *http.Request) (*ClientConfi
 	return nil, fmt.Errorf("failed to find OIDC config")
 }
 
+// selectConfigOfOrganization returns the config of the organization with the given ID.
+// If no ID is given, the organization must have a single config.
+func (s *Service) selectConfigOfOrganization(ctx context.Context, orgSlug, id string) (db.OIDCClientConfig, error) {
+	configs, err := db.ListOIDCClientConfigsByOrgSlug(ctx, s.dbConn, orgSlug)
+	if err != nil {
+		return db.OIDCClientConfig{}, err
+	}
+
+	if id != "" {
+		for _, config := range configs {
+			if config.ID.String() == id {
+				return config, nil
+			}
+		}
+		return db.OIDCClientConfig{}, fmt.Errorf("no config with ID %s for organization %s", id, orgSlug)
+	}
+
+	switch len(configs) {
+	case 0:
+		return db.OIDCClientConfig{}, fmt.Errorf("no config for organization %s", orgSlug)
+	case 1:
+		return configs[0], nil
+	default:
+		return db.OIDCClientConfig{}, fmt.Errorf("organization %s has %d configs, the id parameter is required to select one", orgSlug, len(configs))
+	}
+}
+
 func (s *Service) GetClientConfigFromCallbackRequest(r *http.Request) (*ClientConfig, error) {
 	stateParam := r.URL.Query().Get("state")
 	if stateParam == "" {
@@ -199,12 +239,12 @@ func (s *Service) convertClientConfig(ctx context.Context, dbEntry db.OIDCClient
 		return ClientConfig{}, status.Errorf(codes.Internal, "Failed to decrypt OIDC client config.")
 	}
 
-	provider, err := oidc.NewProvider(ctx, dbEntry.Issuer)
+	provider, err := s.providers.Get(ctx, dbEntry.Issuer)
 	if err != nil {
 		return ClientConfig{}, err
 	}
 
-	return ClientConfig{
+	config := ClientConfig{
 		ID:             dbEntry.ID.String(),
 		OrganizationID: dbEntry.OrganizationID.String(),
 		Issuer:         dbEntry.Issuer,
@@ -217,7 +257,13 @@ func (s *Service) convertClientConfig(ctx context.Context, dbEntry db.OIDCClient
 		VerifierConfig: &goidc.Config{
 			ClientID: spec.ClientID,
 		},
-	}, nil
+		TeamMappings: spec.TeamMappings,
+	}
+	if secrets := spec.ClientSecrets(time.Now()); len(secrets) > 1 {
+		config.PreviousClientSecret = secrets[1]
+	}
+
+	return config, nil
 }
 
 type AuthenticateParams struct {
@@ -232,7 +278,7 @@ func (s *Service) Authenticate(ctx context.Context, params AuthenticateParams) (
 		return nil, fmt.Errorf("id_token not found")
 	}
 
-	provider, err := oidc.NewProvider(ctx, params.Config.Issuer)
+	provider, err := s.providers.Get(ctx, params.Config.Issuer)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to initialize provider.")
 	}
@@ -253,8 +299,9 @@ func (s *Service) Authenticate(ctx context.Context, params AuthenticateParams) (
 		return nil, fmt.Errorf("nonce mismatch")
 	}
 	return &AuthFlowResult{
-		IDToken: idToken,
-		Claims:  claims,
+		IDToken:         idToken,
+		Claims:          claims,
+		TeamMemberships: MapClaimsToTeams(params.Config.TeamMappings, claims),
 	}, nil
 }
 
@@ -277,15 +324,53 @@ func (s *Service) CreateSession(ctx context.Context, flowResult *AuthFlowResult,
 	if err != nil {
 		return nil, err
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode == http.StatusOK {
 		cookies := res.Cookies()
-		if len(cookies) == 1 {
-			return cookies[0], nil
+		if len(cookies) != 1 {
+			return nil, fmt.Errorf("unexpected count of cookies: %v", len(cookies))
+		}
+
+		var session struct {
+			UserID string `json:"userId"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&session)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode session: %w", err)
 		}
-		return nil, fmt.Errorf("unexpected count of cookies: %v", len(cookies))
+		s.applyTeamMemberships(ctx, session.UserID, flowResult.TeamMemberships)
+
+		return cookies[0], nil
 	}
 	message, _ := ioutil.ReadAll(res.Body)
 	log.WithField("create-session-error", message).Error("Failed to create session (via server)")
 	return nil, fmt.Errorf("unexpected status code: %v", res.StatusCode)
 }
+
+// applyTeamMemberships adds the user to the teams mapped from their claims. Failures are logged, but do not fail the login,
+// as the user can still be added to teams manually.
+func (s *Service) applyTeamMemberships(ctx context.Context, userID string, memberships []TeamMembership) {
+	if len(memberships) == 0 {
+		return
+	}
+
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		log.WithError(err).WithField("userId", userID).Error("Failed to apply team memberships, session has no valid user ID.")
+		return
+	}
+
+	for _, membership := range memberships {
+		team, err := uuid.Parse(membership.TeamID)
+		if err != nil {
+			log.WithError(err).WithField("teamId", membership.TeamID).Error("Failed to apply team membership, mapping has no valid team ID.")
+			continue
+		}
+
+		_, err = db.EnsureTeamMembership(ctx, s.dbConn, team, user, membership.Role)
+		if err != nil {
+			log.WithError(err).WithField("teamId", membership.TeamID).WithField("userId", userID).Error("Failed to apply team membership.")
+		}
+	}
+}
@@ -121,6 +121,33 @@ func TestGetClientConfigFromStartRequest(t *testing.T) {
 	})
 }
 
+func TestGetClientConfigFromStartRequest_MultipleConfigs(t *testing.T) {
+	issuer := newFakeIdP(t)
+	service, dbConn := setupOIDCServiceForTests(t)
+	config, team := createConfig(t, dbConn, &ClientConfig{
+		Issuer:         issuer,
+		VerifierConfig: &oidc.Config{},
+		OAuth2Config:   &oauth2.Config{},
+	})
+	second := dbtest.CreateOIDCClientConfigs(t, dbConn, db.OIDCClientConfig{
+		OrganizationID: config.OrganizationID,
+		Issuer:         issuer,
+	})[0]
+	t.Cleanup(func() {
+		require.NoError(t, dbConn.Where("slug = ?", team.Slug).Delete(&db.Team{}).Error)
+	})
+
+	_, err := service.GetClientConfigFromStartRequest(httptest.NewRequest(http.MethodGet, "/start?orgSlug="+team.Slug, nil))
+	require.Error(t, err, "ambiguous without id")
+
+	selected, err := service.GetClientConfigFromStartRequest(httptest.NewRequest(http.MethodGet, "/start?orgSlug="+team.Slug+"&id="+second.ID.String(), nil))
+	require.NoError(t, err)
+	require.Equal(t, second.ID.String(), selected.ID)
+
+	_, err = service.GetClientConfigFromStartRequest(httptest.NewRequest(http.MethodGet, "/start?orgSlug="+team.Slug+"&id="+uuid.NewString(), nil))
+	require.Error(t, err, "id of another organization")
+}
+
 func TestGetClientConfigFromCallbackRequest(t *testing.T) {
 	issuer := newFakeIdP(t)
 	service, dbConn := setupOIDCServiceForTests(t)
@@ -218,6 +245,45 @@ func TestAuthenticate_nonce_check(t *testing.T) {
 	require.NotNil(t, result)
 }
 
+func TestCreateSession_AppliesTeamMemberships(t *testing.T) {
+	service, dbConn := setupOIDCServiceForTests(t)
+	_, team := createConfig(t, dbConn, &ClientConfig{
+		Issuer:       "https://issuer.example.com",
+		OAuth2Config: &oauth2.Config{},
+	})
+	otherTeam, err := db.CreateTeam(context.Background(), dbConn, db.Team{ID: uuid.New(), Name: "Org 2", Slug: uuid.NewString()})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, dbConn.Where("userId = ?", fakeSessionUserID).Delete(&db.TeamMembership{}).Error)
+	})
+
+	mappings := []db.ClaimToTeamMapping{
+		{Claim: "groups", Value: "admins", TeamID: team.ID.String(), Role: db.TeamMembershipRole_Owner},
+		{Claim: "groups", Value: "developers", TeamID: otherTeam.ID.String(), Role: db.TeamMembershipRole_Member},
+		{Claim: "groups", Value: "contractors", TeamID: uuid.NewString(), Role: db.TeamMembershipRole_Member},
+	}
+	claims := map[string]interface{}{"groups": []interface{}{"admins", "developers"}}
+	result := &AuthFlowResult{
+		Claims:          claims,
+		TeamMemberships: MapClaimsToTeams(mappings, claims),
+	}
+
+	cookie, err := service.CreateSession(context.Background(), result, team.ID.String())
+	require.NoError(t, err)
+	require.Equal(t, "test-cookie", cookie.Name)
+
+	memberships, err := db.ListTeamMembershipsForUserIDs(context.Background(), dbConn, []uuid.UUID{fakeSessionUserID})
+	require.NoError(t, err)
+	roles := make(map[uuid.UUID]db.TeamMembershipRole)
+	for _, m := range memberships {
+		roles[m.TeamID] = m.Role
+	}
+	require.Equal(t, map[uuid.UUID]db.TeamMembershipRole{
+		team.ID:      db.TeamMembershipRole_Owner,
+		otherTeam.ID: db.TeamMembershipRole_Member,
+	}, roles)
+}
+
 func setupOIDCServiceForTests(t *testing.T) (*Service, *gorm.DB) {
 	t.Helper()
 
@@ -259,6 +325,9 @@ func createConfig(t *testing.T, dbConn *gorm.DB, config *ClientConfig) (db.OIDCC
 	return created, team
 }
 
+// fakeSessionUserID is the user the fake session server creates sessions for.
+var fakeSessionUserID = uuid.New()
+
 func newFakeSessionServer(t *testing.T) string {
 	router := chi.NewRouter()
 	ts := httptest.NewServer(router)
@@ -276,7 +345,9 @@ func newFakeSessionServer(t *testing.T) string {
 			HttpOnly: true,
 			Expires:  time.Now().AddDate(0, 0, 1),
 		})
+		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"sessionId":"session-id","userId":"` + fakeSessionUserID.String() + `"}`))
 	})
 
 	t.Cleanup(ts.Close)
@@ -0,0 +1,65 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package oidc
+
+import (
+	"fmt"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+)
+
+type TeamMembership struct {
+	TeamID string                `json:"teamId"`
+	Role   db.TeamMembershipRole `json:"role"`
+}
+
+// MapClaimsToTeams returns the team memberships of a user with the given claims.
+// A user matched by several rules for the same team is given the highest role.
+func MapClaimsToTeams(mappings []db.ClaimToTeamMapping, claims map[string]interface{}) []TeamMembership {
+	var result []TeamMembership
+	index := make(map[string]int)
+
+	for _, mapping := range mappings {
+		if !claimHasValue(claims[mapping.Claim], mapping.Value) {
+			continue
+		}
+
+		role := mapping.Role
+		if role != db.TeamMembershipRole_Owner {
+			role = db.TeamMembershipRole_Member
+		}
+
+		if i, ok := index[mapping.TeamID]; ok {
+			if role == db.TeamMembershipRole_Owner {
+				result[i].Role = db.TeamMembershipRole_Owner
+			}
+			continue
+		}
+
+		index[mapping.TeamID] = len(result)
+		result = append(result, TeamMembership{TeamID: mapping.TeamID, Role: role})
+	}
+
+	return result
+}
+
+func claimHasValue(claim interface{}, value string) bool {
+	switch c := claim.(type) {
+	case nil:
+		return false
+	case string:
+		return c == value
+	case []interface{}:
+		for _, v := range c {
+			if claimHasValue(v, value) {
+				return true
+			}
+		}
+		return false
+	default:
+		// Numbers and booleans are matched by their string representation.
+		return fmt.Sprint(c) == value
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package oidc
+
+import (
+	"testing"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapClaimsToTeams(t *testing.T) {
+	mappings := []db.ClaimToTeamMapping{
+		{Claim: "groups", Value: "engineering", TeamID: "team-a", Role: db.TeamMembershipRole_Member},
+		{Claim: "groups", Value: "admins", TeamID: "team-a", Role: db.TeamMembershipRole_Owner},
+		{Claim: "department", Value: "sales", TeamID: "team-b"},
+		{Claim: "email_verified", Value: "true", TeamID: "team-c"},
+	}
+
+	testCases := []struct {
+		Name     string
+		Claims   map[string]interface{}
+		Expected []TeamMembership
+	}{
+		{
+			Name:     "no matching claims",
+			Claims:   map[string]interface{}{"groups": []interface{}{"marketing"}},
+			Expected: nil,
+		},
+		{
+			Name:     "list claim matches any value",
+			Claims:   map[string]interface{}{"groups": []interface{}{"marketing", "engineering"}},
+			Expected: []TeamMembership{{TeamID: "team-a", Role: db.TeamMembershipRole_Member}},
+		},
+		{
+			Name:     "highest role of several matching rules",
+			Claims:   map[string]interface{}{"groups": []interface{}{"admins", "engineering"}},
+			Expected: []TeamMembership{{TeamID: "team-a", Role: db.TeamMembershipRole_Owner}},
+		},
+		{
+			Name:   "string and boolean claims, role defaults to member",
+			Claims: map[string]interface{}{"department": "sales", "email_verified": true},
+			Expected: []TeamMembership{
+				{TeamID: "team-b", Role: db.TeamMembershipRole_Member},
+				{TeamID: "team-c", Role: db.TeamMembershipRole_Member},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			require.Equal(t, tc.Expected, MapClaimsToTeams(mappings, tc.Claims))
+		})
+	}
+}
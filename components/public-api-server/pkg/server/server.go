@@ -30,6 +30,7 @@ import (
 	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
 	"github.com/gitpod-io/gitpod/public-api-server/middleware"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/apiv1"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/audit"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/billingservice"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/identityprovider"
@@ -138,14 +139,21 @@ func Start(logger *logrus.Entry, version string, cfg *config.Configuration) erro
 		return err
 	}
 
+	webhookDispatcher := webhooks.NewDispatcher(dbConn, cipherSet)
+	dispatcherCtx, cancelDispatcher := context.WithCancel(context.Background())
+	defer cancelDispatcher()
+	go webhookDispatcher.Start(dispatcherCtx)
+	go webhookDispatcher.WatchInstances(dispatcherCtx)
+
 	if registerErr := register(srv, &registerDependencies{
-		connPool:    connPool,
-		expClient:   expClient,
-		dbConn:      dbConn,
-		signer:      signer,
-		cipher:      cipherSet,
-		oidcService: oidcService,
-		idpService:  idpService,
+		connPool:          connPool,
+		expClient:         expClient,
+		dbConn:            dbConn,
+		signer:            signer,
+		cipher:            cipherSet,
+		oidcService:       oidcService,
+		idpService:        idpService,
+		webhookDispatcher: webhookDispatcher,
 	}); registerErr != nil {
 		return fmt.Errorf("failed to register services: %w", registerErr)
 	}
@@ -165,6 +173,8 @@ type registerDependencies struct {
 	cipher      db.Cipher
 	oidcService *oidc.Service
 	idpService  *identityprovider.Service
+
+	webhookDispatcher *webhooks.Dispatcher
 }
 
 func register(srv *baseserver.Server, deps *registerDependencies) error {
@@ -180,22 +190,31 @@ func register(srv *baseserver.Server, deps *registerDependencies) error {
 	rootHandler.Use(chi_middleware.Recoverer)
 	rootHandler.Use(middleware.NewLoggingMiddleware())
 
+	scopesInterceptor := auth.NewScopesInterceptor(deps.signer, deps.dbConn)
+
 	handlerOptions := []connect.HandlerOption{
 		connect.WithInterceptors(
 			NewMetricsInterceptor(connectMetrics),
 			NewLogInterceptor(log.Log),
 			auth.NewServerInterceptor(),
+			scopesInterceptor,
 			origin.NewInterceptor(),
+			audit.NewInterceptor(deps.dbConn, deps.connPool),
 		),
 	}
 
-	rootHandler.Mount(v1connect.NewWorkspacesServiceHandler(apiv1.NewWorkspaceService(deps.connPool), handlerOptions...))
+	rootHandler.Mount(v1connect.NewWorkspacesServiceHandler(apiv1.NewWorkspaceService(deps.connPool, deps.webhookDispatcher), handlerOptions...))
 	rootHandler.Mount(v1connect.NewTeamsServiceHandler(apiv1.NewTeamsService(deps.connPool), handlerOptions...))
 	rootHandler.Mount(v1connect.NewUserServiceHandler(apiv1.NewUserService(deps.connPool), handlerOptions...))
 	rootHandler.Mount(v1connect.NewIDEClientServiceHandler(apiv1.NewIDEClientService(deps.connPool), handlerOptions...))
 	rootHandler.Mount(v1connect.NewProjectsServiceHandler(apiv1.NewProjectsService(deps.connPool), handlerOptions...))
 	rootHandler.Mount(v1connect.NewOIDCServiceHandler(apiv1.NewOIDCService(deps.connPool, deps.expClient, deps.dbConn, deps.cipher), handlerOptions...))
 	rootHandler.Mount(v1connect.NewIdentityProviderServiceHandler(apiv1.NewIdentityProviderService(deps.connPool, deps.idpService), handlerOptions...))
+	rootHandler.Mount(v1connect.NewWebhooksServiceHandler(apiv1.NewWebhooksService(deps.connPool, deps.dbConn, deps.cipher), handlerOptions...))
+
+	auditLogsService := apiv1.NewAuditLogsService(deps.connPool, deps.dbConn)
+	rootHandler.Mount(v1connect.NewAuditLogsServiceHandler(auditLogsService, handlerOptions...))
+	rootHandler.Handle("/audit-logs/export", auditLogsService.ExportHandler(scopesInterceptor))
 
 	if deps.signer != nil {
 		rootHandler.Mount(v1connect.NewTokensServiceHandler(apiv1.NewTokensService(deps.connPool, deps.expClient, deps.dbConn, deps.signer), handlerOptions...))
@@ -0,0 +1,107 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package webhooks
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// blockedNetworks are not routable on the internet, but may reach services inside the cluster or its cloud provider,
+// e.g. the metadata service at 169.254.169.254 or fd00:ec2::254.
+var blockedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",     // this network
+	"100.64.0.0/10", // carrier-grade NAT, also used for metadata services
+	"192.0.0.0/24",  // IETF protocol assignments
+	"198.18.0.0/15", // benchmarking
+	"64:ff9b::/96",  // NAT64, embeds IPv4 addresses
+	"2002::/16",     // 6to4, embeds IPv4 addresses
+)
+
+// CheckAddress returns an error if webhooks must not be delivered to the IP address, because it is not a public unicast address.
+func CheckAddress(ip net.IP) error {
+	if ip == nil {
+		return fmt.Errorf("invalid IP address")
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("address %s is not a public address", ip)
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return fmt.Errorf("address %s is not a public address", ip)
+		}
+	}
+
+	return nil
+}
+
+// CheckHost returns an error if the host of a webhook URL is an IP address or name which webhooks must not be delivered to.
+// Other names are only checked once they are resolved by the dialer of the dispatcher, as their addresses may change.
+func CheckHost(host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		return CheckAddress(ip)
+	}
+
+	name := strings.ToLower(strings.TrimSuffix(host, "."))
+	if name == "localhost" || strings.HasSuffix(name, ".localhost") || name == "metadata.google.internal" {
+		return fmt.Errorf("host %s is not a public host", host)
+	}
+
+	return nil
+}
+
+// newDialer returns a dialer which refuses to connect to addresses rejected by CheckAddress. Addresses are checked
+// after they have been resolved, right before connecting, such that names cannot be rebound to other addresses in between.
+func newDialer() *net.Dialer {
+	return &net.Dialer{
+		Timeout: deliveryTimeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			err = CheckAddress(net.ParseIP(host))
+			if err != nil {
+				return fmt.Errorf("refusing to deliver webhook: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// newTransport returns a transport which connects to public addresses only. Proxies are not used,
+// as the addresses of endpoints could not be checked otherwise.
+func newTransport() *http.Transport {
+	return &http.Transport{
+		Proxy:                 nil,
+		DialContext:           newDialer().DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   deliveryTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	var res []*net.IPNet
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		res = append(res, network)
+	}
+	return res
+}
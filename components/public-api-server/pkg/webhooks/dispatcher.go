@@ -0,0 +1,249 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxAttempts is the number of attempts after which a delivery is moved to the dead-letter list.
+	MaxAttempts = 8
+
+	initialBackoff = 30 * time.Second
+	maxBackoff     = 6 * time.Hour
+
+	deliveryTimeout = 10 * time.Second
+	pollInterval    = 5 * time.Second
+	batchSize       = 50
+
+	maxErrorLength = 1024
+)
+
+// Backoff returns the delay before the next attempt, after the given number of failed attempts.
+func Backoff(attempts int32) time.Duration {
+	backoff := initialBackoff
+	for i := int32(1); i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return backoff
+}
+
+func NewDispatcher(dbConn *gorm.DB, cipher db.Cipher) *Dispatcher {
+	return &Dispatcher{
+		dbConn: dbConn,
+		cipher: cipher,
+		client: &http.Client{
+			Timeout:   deliveryTimeout,
+			Transport: newTransport(),
+			// Redirects are not followed, endpoints must respond with a 2xx status code.
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// Dispatcher stores events as deliveries for all webhooks subscribed to them, and delivers them.
+// Deliveries are claimed in the database before they are attempted, such that multiple replicas can run a Dispatcher.
+type Dispatcher struct {
+	dbConn *gorm.DB
+	cipher db.Cipher
+	client *http.Client
+	now    func() time.Time
+}
+
+// PublishForWorkspace publishes an event to the webhooks of the organization the workspace belongs to.
+// Workspaces which do not belong to an organization have no webhooks.
+func (d *Dispatcher) PublishForWorkspace(ctx context.Context, workspaceID string, event Event) error {
+	workspaces, err := db.ListWorkspacesByID(ctx, d.dbConn, []string{workspaceID})
+	if err != nil {
+		return fmt.Errorf("failed to resolve organization of workspace %s: %w", workspaceID, err)
+	}
+	if len(workspaces) == 0 || workspaces[0].OrganizationId == nil {
+		return nil
+	}
+
+	return d.Publish(ctx, *workspaces[0].OrganizationId, event)
+}
+
+// Publish stores a delivery of the event for each webhook of the organization which is subscribed to it.
+func (d *Dispatcher) Publish(ctx context.Context, organizationID uuid.UUID, event Event) error {
+	webhooks, err := db.ListWebhooksForOrganization(ctx, d.dbConn, organizationID)
+	if err != nil {
+		return err
+	}
+
+	event.OrganizationID = organizationID.String()
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = d.now().UTC()
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", event.Type, err)
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.Events.Contains(event.Type) {
+			continue
+		}
+
+		_, err := db.CreateWebhookDelivery(ctx, d.dbConn, db.WebhookDelivery{
+			// The ID is derived from the event, such that publishing the same event again does not deliver it twice.
+			ID:              uuid.NewSHA1(webhook.ID, []byte(event.ID)),
+			WebhookID:       webhook.ID,
+			Event:           event.Type,
+			Payload:         string(payload),
+			Status:          db.WebhookDeliveryStatusPending,
+			NextAttemptTime: event.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Start delivers due deliveries until the context is cancelled.
+func (d *Dispatcher) Start(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		err := d.DeliverDue(ctx)
+		if err != nil {
+			log.WithError(err).Error("Failed to deliver webhooks.")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue attempts all pending deliveries whose next attempt is due.
+func (d *Dispatcher) DeliverDue(ctx context.Context) error {
+	due, err := db.ListDueWebhookDeliveries(ctx, d.dbConn, d.now(), batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range due {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err := d.deliver(ctx, delivery)
+		if err != nil {
+			log.WithError(err).WithFields(log.WebhookDeliveryID(delivery.ID.String())).Error("Failed to deliver webhook.")
+		}
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery db.WebhookDelivery) error {
+	// Claim the delivery for long enough to attempt it, other replicas will pick it up once the claim expires.
+	claimed, err := db.ClaimWebhookDelivery(ctx, d.dbConn, delivery, d.now().Add(2*deliveryTimeout))
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	webhook, err := db.GetWebhook(ctx, d.dbConn, delivery.WebhookID)
+	if err != nil {
+		if errors.Is(err, db.ErrorNotFound) {
+			// The webhook was deleted after the delivery was listed.
+			return nil
+		}
+		return err
+	}
+
+	secret, err := webhook.Secret.Decrypt(d.cipher)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt secret of webhook %s: %w", webhook.ID.String(), err)
+	}
+
+	code, postErr := post(ctx, d.client, webhook.URL, secret.Secret, delivery)
+
+	_, err = db.UpdateWebhookDeliveryStatus(ctx, d.dbConn, recordAttempt(delivery, code, postErr, d.now()))
+	return err
+}
+
+// post sends the delivery to the URL and returns the response status code.
+func post(ctx context.Context, client *http.Client, url, secret string, delivery db.WebhookDelivery) (int, error) {
+	payload := []byte(delivery.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Gitpod-Webhooks")
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, delivery.ID.String())
+	req.Header.Set(SignatureHeader, Sign(secret, payload))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// recordAttempt returns the delivery updated with the outcome of an attempt.
+func recordAttempt(delivery db.WebhookDelivery, code int, err error, now time.Time) db.WebhookDelivery {
+	delivery.Attempts++
+	delivery.LastResponseCode = int32(code)
+
+	if err == nil {
+		delivery.Status = db.WebhookDeliveryStatusSucceeded
+		delivery.LastError = ""
+		delivery.DeliveredTime = sql.NullTime{Time: now, Valid: true}
+		return delivery
+	}
+
+	delivery.LastError = err.Error()
+	if len(delivery.LastError) > maxErrorLength {
+		delivery.LastError = delivery.LastError[:maxErrorLength]
+	}
+
+	if delivery.Attempts >= MaxAttempts {
+		delivery.Status = db.WebhookDeliveryStatusDeadLetter
+		return delivery
+	}
+
+	delivery.NextAttemptTime = now.Add(Backoff(delivery.Attempts))
+	return delivery
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	payload := []byte(`{"type":"workspace.started"}`)
+
+	signature := Sign("secret", payload)
+	require.Equal(t, "sha256=", signature[:7])
+	require.True(t, VerifySignature("secret", payload, signature))
+	require.False(t, VerifySignature("other-secret", payload, signature))
+	require.False(t, VerifySignature("secret", []byte(`{}`), signature))
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, 30*time.Second, Backoff(1))
+	require.Equal(t, 60*time.Second, Backoff(2))
+	require.Equal(t, 4*time.Minute, Backoff(4))
+	require.Equal(t, 6*time.Hour, Backoff(100))
+}
+
+func TestPost(t *testing.T) {
+	delivery := db.WebhookDelivery{
+		ID:      uuid.New(),
+		Event:   db.WebhookEventWorkspaceStarted,
+		Payload: `{"type":"workspace.started"}`,
+	}
+
+	t.Run("signs the payload", func(t *testing.T) {
+		var received *http.Request
+		var body []byte
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		t.Cleanup(srv.Close)
+
+		code, err := post(context.Background(), srv.Client(), srv.URL, "secret", delivery)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, code)
+
+		require.Equal(t, http.MethodPost, received.Method)
+		require.Equal(t, delivery.Payload, string(body))
+		require.Equal(t, db.WebhookEventWorkspaceStarted, received.Header.Get(EventHeader))
+		require.Equal(t, delivery.ID.String(), received.Header.Get(DeliveryHeader))
+		require.True(t, VerifySignature("secret", body, received.Header.Get(SignatureHeader)))
+	})
+
+	t.Run("fails on non-2xx status codes", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		t.Cleanup(srv.Close)
+
+		code, err := post(context.Background(), srv.Client(), srv.URL, "secret", delivery)
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadGateway, code)
+	})
+}
+
+func TestRecordAttempt(t *testing.T) {
+	now := time.Now().UTC()
+	pending := db.WebhookDelivery{
+		ID:              uuid.New(),
+		Status:          db.WebhookDeliveryStatusPending,
+		NextAttemptTime: now,
+	}
+
+	t.Run("success marks the delivery succeeded", func(t *testing.T) {
+		result := recordAttempt(pending, http.StatusOK, nil, now)
+		require.Equal(t, db.WebhookDeliveryStatusSucceeded, result.Status)
+		require.EqualValues(t, 1, result.Attempts)
+		require.EqualValues(t, http.StatusOK, result.LastResponseCode)
+		require.True(t, result.DeliveredTime.Valid)
+	})
+
+	t.Run("failure schedules the next attempt", func(t *testing.T) {
+		result := recordAttempt(pending, http.StatusInternalServerError, errors.New("boom"), now)
+		require.Equal(t, db.WebhookDeliveryStatusPending, result.Status)
+		require.EqualValues(t, 1, result.Attempts)
+		require.Equal(t, "boom", result.LastError)
+		require.Equal(t, now.Add(Backoff(1)), result.NextAttemptTime)
+	})
+
+	t.Run("last failure moves the delivery to the dead-letter list", func(t *testing.T) {
+		delivery := pending
+		delivery.Attempts = MaxAttempts - 1
+
+		result := recordAttempt(delivery, 0, errors.New("connection refused"), now)
+		require.Equal(t, db.WebhookDeliveryStatusDeadLetter, result.Status)
+		require.EqualValues(t, MaxAttempts, result.Attempts)
+	})
+}
+
+func TestCheckAddress(t *testing.T) {
+	for _, public := range []string{"1.1.1.1", "140.82.112.3", "2606:4700:4700::1111"} {
+		require.NoError(t, CheckAddress(net.ParseIP(public)), public)
+	}
+
+	for _, blocked := range []string{
+		"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.100.100.200", "0.0.0.0",
+		"::1", "::", "fe80::1", "fd00:ec2::254", "::ffff:127.0.0.1", "::ffff:169.254.169.254", "224.0.0.1",
+	} {
+		require.Error(t, CheckAddress(net.ParseIP(blocked)), blocked)
+	}
+}
+
+func TestDispatcherRefusesPrivateAddresses(t *testing.T) {
+	var called bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	t.Cleanup(srv.Close)
+
+	// The test server listens on the loopback address, the dispatcher must not connect to it.
+	d := NewDispatcher(nil, nil)
+	_, err := post(context.Background(), d.client, srv.URL, "secret", db.WebhookDelivery{ID: uuid.New(), Payload: `{}`})
+	require.Error(t, err)
+	require.False(t, called)
+}
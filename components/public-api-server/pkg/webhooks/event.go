@@ -0,0 +1,60 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of the payload, keyed with the webhook secret.
+	SignatureHeader = "X-Gitpod-Signature-256"
+	EventHeader     = "X-Gitpod-Event"
+	DeliveryHeader  = "X-Gitpod-Delivery"
+
+	signaturePrefix = "sha256="
+)
+
+// Event is the payload delivered to webhooks.
+type Event struct {
+	// ID identifies the occurrence of the event. Publishing an event with the same ID more than once delivers it only once.
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	CreatedAt      time.Time `json:"createdAt"`
+	OrganizationID string    `json:"organizationId"`
+	Data           any       `json:"data"`
+}
+
+// WorkspaceEventData is the data of workspace and prebuild events.
+type WorkspaceEventData struct {
+	WorkspaceID   string `json:"workspaceId"`
+	InstanceID    string `json:"instanceId"`
+	OwnerID       string `json:"ownerId"`
+	ContextURL    string `json:"contextUrl"`
+	WorkspaceType string `json:"workspaceType"`
+	Phase         string `json:"phase"`
+	Failed        string `json:"failed,omitempty"`
+}
+
+// PortEventData is the data of port events.
+type PortEventData struct {
+	WorkspaceID string `json:"workspaceId"`
+	Port        uint64 `json:"port"`
+}
+
+// Sign computes the value of the signature header for a payload.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks the value of a signature header against the payload.
+func VerifySignature(secret string, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, payload)), []byte(signature))
+}
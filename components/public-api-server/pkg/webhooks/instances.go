@@ -0,0 +1,136 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+)
+
+const (
+	// instanceUpdateOverlap is how far back each poll for instance updates reaches before the last update seen,
+	// such that updates which are committed late with an earlier modification time are not missed.
+	instanceUpdateOverlap = 30 * time.Second
+)
+
+// instancePhases are the phases of workspace instances which correspond to events.
+var instancePhases = []string{"running", "stopped"}
+
+// InstanceEvents returns the events which correspond to the phase of the instance.
+// Events are identified by the instance, such that each is delivered only once no matter how often the instance is observed.
+func InstanceEvents(instance db.WorkspaceInstanceUpdate) []Event {
+	var types []string
+	switch instance.Phase {
+	case "running":
+		types = append(types, db.WebhookEventWorkspaceStarted)
+	case "stopped":
+		types = append(types, db.WebhookEventWorkspaceStopped)
+		if instance.Failed.String != "" {
+			types = append(types, db.WebhookEventWorkspaceFailed)
+		}
+		if instance.Type == db.WorkspaceType_Prebuild {
+			types = append(types, db.WebhookEventPrebuildFinished)
+		}
+	}
+
+	data := WorkspaceEventData{
+		WorkspaceID:   instance.WorkspaceID,
+		InstanceID:    instance.ID.String(),
+		OwnerID:       instance.OwnerID.String(),
+		ContextURL:    instance.ContextURL,
+		WorkspaceType: string(instance.Type),
+		Phase:         instance.Phase,
+		Failed:        instance.Failed.String,
+	}
+
+	var events []Event
+	for _, t := range types {
+		events = append(events, Event{
+			ID:   instance.ID.String() + "/" + t,
+			Type: t,
+			Data: data,
+		})
+	}
+	return events
+}
+
+// WatchInstances publishes the events of workspace instances as they change phase, until the context is cancelled.
+// Instances are observed in the database, independently of any client watching them. Every replica may watch instances,
+// as events are delivered only once.
+func (d *Dispatcher) WatchInstances(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	w := &instanceWatcher{
+		dispatcher: d,
+		since:      d.now(),
+		published:  make(map[string]time.Time),
+	}
+	for {
+		err := w.poll(ctx)
+		if err != nil {
+			log.WithError(err).Error("Failed to publish workspace instance events.")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+type instanceWatcher struct {
+	dispatcher *Dispatcher
+
+	// since is the modification time of the last update seen
+	since time.Time
+	// published are the IDs of events published within the overlap, mapped to the modification time of their instance
+	published map[string]time.Time
+}
+
+func (w *instanceWatcher) poll(ctx context.Context) error {
+	cursor := w.since.Add(-instanceUpdateOverlap)
+	for {
+		updates, err := db.ListWorkspaceInstanceUpdates(ctx, w.dispatcher.dbConn, cursor, instancePhases, batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, update := range updates {
+			for _, event := range InstanceEvents(update) {
+				if _, ok := w.published[event.ID]; ok {
+					continue
+				}
+
+				err := w.dispatcher.Publish(ctx, update.OrganizationID, event)
+				if err != nil {
+					return err
+				}
+				w.published[event.ID] = update.LastModified
+			}
+			if update.LastModified.After(w.since) {
+				w.since = update.LastModified
+			}
+		}
+
+		// A full batch may be followed by more updates, unless all of them were modified at the same time.
+		if len(updates) < batchSize || !updates[len(updates)-1].LastModified.After(cursor) {
+			break
+		}
+		cursor = updates[len(updates)-1].LastModified
+	}
+
+	for id, modified := range w.published {
+		if modified.Before(w.since.Add(-instanceUpdateOverlap)) {
+			delete(w.published, id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package webhooks
+
+import (
+	"database/sql"
+	"testing"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstanceEvents(t *testing.T) {
+	instanceID := uuid.New()
+	instance := func(workspaceType db.WorkspaceType, phase, failed string) db.WorkspaceInstanceUpdate {
+		return db.WorkspaceInstanceUpdate{
+			ID:          instanceID,
+			WorkspaceID: "workspace-id",
+			Type:        workspaceType,
+			Phase:       phase,
+			Failed:      sql.NullString{String: failed, Valid: failed != ""},
+		}
+	}
+
+	for _, test := range []struct {
+		Name     string
+		Instance db.WorkspaceInstanceUpdate
+		Expected []string
+	}{
+		{Name: "pending", Instance: instance(db.WorkspaceType_Regular, "pending", "")},
+		{Name: "running", Instance: instance(db.WorkspaceType_Regular, "running", ""), Expected: []string{"workspace.started"}},
+		{Name: "stopped", Instance: instance(db.WorkspaceType_Regular, "stopped", ""), Expected: []string{"workspace.stopped"}},
+		{Name: "failed", Instance: instance(db.WorkspaceType_Regular, "stopped", "boom"), Expected: []string{"workspace.stopped", "workspace.failed"}},
+		{Name: "prebuild", Instance: instance(db.WorkspaceType_Prebuild, "stopped", ""), Expected: []string{"workspace.stopped", "prebuild.finished"}},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			var types []string
+			for _, e := range InstanceEvents(test.Instance) {
+				types = append(types, e.Type)
+				require.Equal(t, instanceID.String()+"/"+e.Type, e.ID)
+				require.Equal(t, "workspace-id", e.Data.(WorkspaceEventData).WorkspaceID)
+			}
+			require.Equal(t, test.Expected, types)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: gitpod/experimental/v1/audit_logs.proto
+
+package v1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// AuditLogsServiceName is the fully-qualified name of the AuditLogsService service.
+	AuditLogsServiceName = "gitpod.experimental.v1.AuditLogsService"
+)
+
+// AuditLogsServiceClient is a client for the gitpod.experimental.v1.AuditLogsService service.
+type AuditLogsServiceClient interface {
+	// ListAuditLogs returns the audit logs of an organization, most recent first.
+	// Without an organization, it returns the audit logs of the caller, including those of actions which do not
+	// belong to an organization, e.g. managing Personal Access Tokens.
+	// Audit logs can also be exported as newline delimited JSON by GET /audit-logs/export?organization_id=<id>
+	ListAuditLogs(context.Context, *connect_go.Request[v1.ListAuditLogsRequest]) (*connect_go.Response[v1.ListAuditLogsResponse], error)
+}
+
+// NewAuditLogsServiceClient constructs a client for the gitpod.experimental.v1.AuditLogsService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAuditLogsServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) AuditLogsServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &auditLogsServiceClient{
+		listAuditLogs: connect_go.NewClient[v1.ListAuditLogsRequest, v1.ListAuditLogsResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.AuditLogsService/ListAuditLogs",
+			opts...,
+		),
+	}
+}
+
+// auditLogsServiceClient implements AuditLogsServiceClient.
+type auditLogsServiceClient struct {
+	listAuditLogs *connect_go.Client[v1.ListAuditLogsRequest, v1.ListAuditLogsResponse]
+}
+
+// ListAuditLogs calls gitpod.experimental.v1.AuditLogsService.ListAuditLogs.
+func (c *auditLogsServiceClient) ListAuditLogs(ctx context.Context, req *connect_go.Request[v1.ListAuditLogsRequest]) (*connect_go.Response[v1.ListAuditLogsResponse], error) {
+	return c.listAuditLogs.CallUnary(ctx, req)
+}
+
+// AuditLogsServiceHandler is an implementation of the gitpod.experimental.v1.AuditLogsService
+// service.
+type AuditLogsServiceHandler interface {
+	// ListAuditLogs returns the audit logs of an organization, most recent first.
+	// Without an organization, it returns the audit logs of the caller, including those of actions which do not
+	// belong to an organization, e.g. managing Personal Access Tokens.
+	// Audit logs can also be exported as newline delimited JSON by GET /audit-logs/export?organization_id=<id>
+	ListAuditLogs(context.Context, *connect_go.Request[v1.ListAuditLogsRequest]) (*connect_go.Response[v1.ListAuditLogsResponse], error)
+}
+
+// NewAuditLogsServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAuditLogsServiceHandler(svc AuditLogsServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle("/gitpod.experimental.v1.AuditLogsService/ListAuditLogs", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.AuditLogsService/ListAuditLogs",
+		svc.ListAuditLogs,
+		opts...,
+	))
+	return "/gitpod.experimental.v1.AuditLogsService/", mux
+}
+
+// UnimplementedAuditLogsServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAuditLogsServiceHandler struct{}
+
+func (UnimplementedAuditLogsServiceHandler) ListAuditLogs(context.Context, *connect_go.Request[v1.ListAuditLogsRequest]) (*connect_go.Response[v1.ListAuditLogsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.AuditLogsService.ListAuditLogs is not implemented"))
+}
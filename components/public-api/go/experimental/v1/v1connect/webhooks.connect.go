@@ -0,0 +1,213 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: gitpod/experimental/v1/webhooks.proto
+
+package v1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// WebhooksServiceName is the fully-qualified name of the WebhooksService service.
+	WebhooksServiceName = "gitpod.experimental.v1.WebhooksService"
+)
+
+// WebhooksServiceClient is a client for the gitpod.experimental.v1.WebhooksService service.
+type WebhooksServiceClient interface {
+	// CreateWebhook registers a new webhook for a team.
+	CreateWebhook(context.Context, *connect_go.Request[v1.CreateWebhookRequest]) (*connect_go.Response[v1.CreateWebhookResponse], error)
+	// GetWebhook returns a webhook by ID.
+	GetWebhook(context.Context, *connect_go.Request[v1.GetWebhookRequest]) (*connect_go.Response[v1.GetWebhookResponse], error)
+	// ListWebhooks returns all webhooks of a team.
+	ListWebhooks(context.Context, *connect_go.Request[v1.ListWebhooksRequest]) (*connect_go.Response[v1.ListWebhooksResponse], error)
+	// DeleteWebhook removes a webhook by ID. Pending deliveries are discarded.
+	DeleteWebhook(context.Context, *connect_go.Request[v1.DeleteWebhookRequest]) (*connect_go.Response[v1.DeleteWebhookResponse], error)
+	// ListWebhookDeliveries returns the delivery history of a webhook, most recent first.
+	ListWebhookDeliveries(context.Context, *connect_go.Request[v1.ListWebhookDeliveriesRequest]) (*connect_go.Response[v1.ListWebhookDeliveriesResponse], error)
+	// RetryWebhookDelivery schedules a delivery from the dead-letter list to be attempted again.
+	RetryWebhookDelivery(context.Context, *connect_go.Request[v1.RetryWebhookDeliveryRequest]) (*connect_go.Response[v1.RetryWebhookDeliveryResponse], error)
+}
+
+// NewWebhooksServiceClient constructs a client for the gitpod.experimental.v1.WebhooksService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewWebhooksServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) WebhooksServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &webhooksServiceClient{
+		createWebhook: connect_go.NewClient[v1.CreateWebhookRequest, v1.CreateWebhookResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.WebhooksService/CreateWebhook",
+			opts...,
+		),
+		getWebhook: connect_go.NewClient[v1.GetWebhookRequest, v1.GetWebhookResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.WebhooksService/GetWebhook",
+			opts...,
+		),
+		listWebhooks: connect_go.NewClient[v1.ListWebhooksRequest, v1.ListWebhooksResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.WebhooksService/ListWebhooks",
+			opts...,
+		),
+		deleteWebhook: connect_go.NewClient[v1.DeleteWebhookRequest, v1.DeleteWebhookResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.WebhooksService/DeleteWebhook",
+			opts...,
+		),
+		listWebhookDeliveries: connect_go.NewClient[v1.ListWebhookDeliveriesRequest, v1.ListWebhookDeliveriesResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.WebhooksService/ListWebhookDeliveries",
+			opts...,
+		),
+		retryWebhookDelivery: connect_go.NewClient[v1.RetryWebhookDeliveryRequest, v1.RetryWebhookDeliveryResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.WebhooksService/RetryWebhookDelivery",
+			opts...,
+		),
+	}
+}
+
+// webhooksServiceClient implements WebhooksServiceClient.
+type webhooksServiceClient struct {
+	createWebhook         *connect_go.Client[v1.CreateWebhookRequest, v1.CreateWebhookResponse]
+	getWebhook            *connect_go.Client[v1.GetWebhookRequest, v1.GetWebhookResponse]
+	listWebhooks          *connect_go.Client[v1.ListWebhooksRequest, v1.ListWebhooksResponse]
+	deleteWebhook         *connect_go.Client[v1.DeleteWebhookRequest, v1.DeleteWebhookResponse]
+	listWebhookDeliveries *connect_go.Client[v1.ListWebhookDeliveriesRequest, v1.ListWebhookDeliveriesResponse]
+	retryWebhookDelivery  *connect_go.Client[v1.RetryWebhookDeliveryRequest, v1.RetryWebhookDeliveryResponse]
+}
+
+// CreateWebhook calls gitpod.experimental.v1.WebhooksService.CreateWebhook.
+func (c *webhooksServiceClient) CreateWebhook(ctx context.Context, req *connect_go.Request[v1.CreateWebhookRequest]) (*connect_go.Response[v1.CreateWebhookResponse], error) {
+	return c.createWebhook.CallUnary(ctx, req)
+}
+
+// GetWebhook calls gitpod.experimental.v1.WebhooksService.GetWebhook.
+func (c *webhooksServiceClient) GetWebhook(ctx context.Context, req *connect_go.Request[v1.GetWebhookRequest]) (*connect_go.Response[v1.GetWebhookResponse], error) {
+	return c.getWebhook.CallUnary(ctx, req)
+}
+
+// ListWebhooks calls gitpod.experimental.v1.WebhooksService.ListWebhooks.
+func (c *webhooksServiceClient) ListWebhooks(ctx context.Context, req *connect_go.Request[v1.ListWebhooksRequest]) (*connect_go.Response[v1.ListWebhooksResponse], error) {
+	return c.listWebhooks.CallUnary(ctx, req)
+}
+
+// DeleteWebhook calls gitpod.experimental.v1.WebhooksService.DeleteWebhook.
+func (c *webhooksServiceClient) DeleteWebhook(ctx context.Context, req *connect_go.Request[v1.DeleteWebhookRequest]) (*connect_go.Response[v1.DeleteWebhookResponse], error) {
+	return c.deleteWebhook.CallUnary(ctx, req)
+}
+
+// ListWebhookDeliveries calls gitpod.experimental.v1.WebhooksService.ListWebhookDeliveries.
+func (c *webhooksServiceClient) ListWebhookDeliveries(ctx context.Context, req *connect_go.Request[v1.ListWebhookDeliveriesRequest]) (*connect_go.Response[v1.ListWebhookDeliveriesResponse], error) {
+	return c.listWebhookDeliveries.CallUnary(ctx, req)
+}
+
+// RetryWebhookDelivery calls gitpod.experimental.v1.WebhooksService.RetryWebhookDelivery.
+func (c *webhooksServiceClient) RetryWebhookDelivery(ctx context.Context, req *connect_go.Request[v1.RetryWebhookDeliveryRequest]) (*connect_go.Response[v1.RetryWebhookDeliveryResponse], error) {
+	return c.retryWebhookDelivery.CallUnary(ctx, req)
+}
+
+// WebhooksServiceHandler is an implementation of the gitpod.experimental.v1.WebhooksService
+// service.
+type WebhooksServiceHandler interface {
+	// CreateWebhook registers a new webhook for a team.
+	CreateWebhook(context.Context, *connect_go.Request[v1.CreateWebhookRequest]) (*connect_go.Response[v1.CreateWebhookResponse], error)
+	// GetWebhook returns a webhook by ID.
+	GetWebhook(context.Context, *connect_go.Request[v1.GetWebhookRequest]) (*connect_go.Response[v1.GetWebhookResponse], error)
+	// ListWebhooks returns all webhooks of a team.
+	ListWebhooks(context.Context, *connect_go.Request[v1.ListWebhooksRequest]) (*connect_go.Response[v1.ListWebhooksResponse], error)
+	// DeleteWebhook removes a webhook by ID. Pending deliveries are discarded.
+	DeleteWebhook(context.Context, *connect_go.Request[v1.DeleteWebhookRequest]) (*connect_go.Response[v1.DeleteWebhookResponse], error)
+	// ListWebhookDeliveries returns the delivery history of a webhook, most recent first.
+	ListWebhookDeliveries(context.Context, *connect_go.Request[v1.ListWebhookDeliveriesRequest]) (*connect_go.Response[v1.ListWebhookDeliveriesResponse], error)
+	// RetryWebhookDelivery schedules a delivery from the dead-letter list to be attempted again.
+	RetryWebhookDelivery(context.Context, *connect_go.Request[v1.RetryWebhookDeliveryRequest]) (*connect_go.Response[v1.RetryWebhookDeliveryResponse], error)
+}
+
+// NewWebhooksServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewWebhooksServiceHandler(svc WebhooksServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle("/gitpod.experimental.v1.WebhooksService/CreateWebhook", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.WebhooksService/CreateWebhook",
+		svc.CreateWebhook,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.WebhooksService/GetWebhook", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.WebhooksService/GetWebhook",
+		svc.GetWebhook,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.WebhooksService/ListWebhooks", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.WebhooksService/ListWebhooks",
+		svc.ListWebhooks,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.WebhooksService/DeleteWebhook", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.WebhooksService/DeleteWebhook",
+		svc.DeleteWebhook,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.WebhooksService/ListWebhookDeliveries", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.WebhooksService/ListWebhookDeliveries",
+		svc.ListWebhookDeliveries,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.WebhooksService/RetryWebhookDelivery", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.WebhooksService/RetryWebhookDelivery",
+		svc.RetryWebhookDelivery,
+		opts...,
+	))
+	return "/gitpod.experimental.v1.WebhooksService/", mux
+}
+
+// UnimplementedWebhooksServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedWebhooksServiceHandler struct{}
+
+func (UnimplementedWebhooksServiceHandler) CreateWebhook(context.Context, *connect_go.Request[v1.CreateWebhookRequest]) (*connect_go.Response[v1.CreateWebhookResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.WebhooksService.CreateWebhook is not implemented"))
+}
+
+func (UnimplementedWebhooksServiceHandler) GetWebhook(context.Context, *connect_go.Request[v1.GetWebhookRequest]) (*connect_go.Response[v1.GetWebhookResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.WebhooksService.GetWebhook is not implemented"))
+}
+
+func (UnimplementedWebhooksServiceHandler) ListWebhooks(context.Context, *connect_go.Request[v1.ListWebhooksRequest]) (*connect_go.Response[v1.ListWebhooksResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.WebhooksService.ListWebhooks is not implemented"))
+}
+
+func (UnimplementedWebhooksServiceHandler) DeleteWebhook(context.Context, *connect_go.Request[v1.DeleteWebhookRequest]) (*connect_go.Response[v1.DeleteWebhookResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.WebhooksService.DeleteWebhook is not implemented"))
+}
+
+func (UnimplementedWebhooksServiceHandler) ListWebhookDeliveries(context.Context, *connect_go.Request[v1.ListWebhookDeliveriesRequest]) (*connect_go.Response[v1.ListWebhookDeliveriesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.WebhooksService.ListWebhookDeliveries is not implemented"))
+}
+
+func (UnimplementedWebhooksServiceHandler) RetryWebhookDelivery(context.Context, *connect_go.Request[v1.RetryWebhookDeliveryRequest]) (*connect_go.Response[v1.RetryWebhookDeliveryResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.WebhooksService.RetryWebhookDelivery is not implemented"))
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-proxy-gen. DO NOT EDIT.
+
+package v1connect
+
+import (
+	context "context"
+	connect_go "github.com/bufbuild/connect-go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+)
+
+var _ WebhooksServiceHandler = (*ProxyWebhooksServiceHandler)(nil)
+
+type ProxyWebhooksServiceHandler struct {
+	Client v1.WebhooksServiceClient
+	UnimplementedWebhooksServiceHandler
+}
+
+func (s *ProxyWebhooksServiceHandler) CreateWebhook(ctx context.Context, req *connect_go.Request[v1.CreateWebhookRequest]) (*connect_go.Response[v1.CreateWebhookResponse], error) {
+	resp, err := s.Client.CreateWebhook(ctx, req.Msg)
+	if err != nil {
+		// TODO(milan): Convert to correct status code
+		return nil, err
+	}
+
+	return connect_go.NewResponse(resp), nil
+}
+
+func (s *ProxyWebhooksServiceHandler) GetWebhook(ctx context.Context, req *connect_go.Request[v1.GetWebhookRequest]) (*connect_go.Response[v1.GetWebhookResponse], error) {
+	resp, err := s.Client.GetWebhook(ctx, req.Msg)
+	if err != nil {
+		// TODO(milan): Convert to correct status code
+		return nil, err
+	}
+
+	return connect_go.NewResponse(resp), nil
+}
+
+func (s *ProxyWebhooksServiceHandler) ListWebhooks(ctx context.Context, req *connect_go.Request[v1.ListWebhooksRequest]) (*connect_go.Response[v1.ListWebhooksResponse], error) {
+	resp, err := s.Client.ListWebhooks(ctx, req.Msg)
+	if err != nil {
+		// TODO(milan): Convert to correct status code
+		return nil, err
+	}
+
+	return connect_go.NewResponse(resp), nil
+}
+
+func (s *ProxyWebhooksServiceHandler) DeleteWebhook(ctx context.Context, req *connect_go.Request[v1.DeleteWebhookRequest]) (*connect_go.Response[v1.DeleteWebhookResponse], error) {
+	resp, err := s.Client.DeleteWebhook(ctx, req.Msg)
+	if err != nil {
+		// TODO(milan): Convert to correct status code
+		return nil, err
+	}
+
+	return connect_go.NewResponse(resp), nil
+}
+
+func (s *ProxyWebhooksServiceHandler) ListWebhookDeliveries(ctx context.Context, req *connect_go.Request[v1.ListWebhookDeliveriesRequest]) (*connect_go.Response[v1.ListWebhookDeliveriesResponse], error) {
+	resp, err := s.Client.ListWebhookDeliveries(ctx, req.Msg)
+	if err != nil {
+		// TODO(milan): Convert to correct status code
+		return nil, err
+	}
+
+	return connect_go.NewResponse(resp), nil
+}
+
+func (s *ProxyWebhooksServiceHandler) RetryWebhookDelivery(ctx context.Context, req *connect_go.Request[v1.RetryWebhookDeliveryRequest]) (*connect_go.Response[v1.RetryWebhookDeliveryResponse], error) {
+	resp, err := s.Client.RetryWebhookDelivery(ctx, req.Msg)
+	if err != nil {
+		// TODO(milan): Convert to correct status code
+		return nil, err
+	}
+
+	return connect_go.NewResponse(resp), nil
+}
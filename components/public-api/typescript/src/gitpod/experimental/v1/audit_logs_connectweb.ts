@@ -0,0 +1,37 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// @generated by protoc-gen-connect-web v0.2.1 with parameter "target=ts"
+// @generated from file gitpod/experimental/v1/audit_logs.proto (package gitpod.experimental.v1, syntax proto3)
+/* eslint-disable */
+/* @ts-nocheck */
+
+import {ListAuditLogsRequest, ListAuditLogsResponse} from "./audit_logs_pb.js";
+import {MethodKind} from "@bufbuild/protobuf";
+
+/**
+ * @generated from service gitpod.experimental.v1.AuditLogsService
+ */
+export const AuditLogsService = {
+  typeName: "gitpod.experimental.v1.AuditLogsService",
+  methods: {
+    /**
+     * ListAuditLogs returns the audit logs of an organization, most recent first.
+     * Without an organization, it returns the audit logs of the caller, including those of actions which do not
+     * belong to an organization, e.g. managing Personal Access Tokens.
+     * Audit logs can also be exported as newline delimited JSON by GET /audit-logs/export?organization_id=<id>
+     *
+     * @generated from rpc gitpod.experimental.v1.AuditLogsService.ListAuditLogs
+     */
+    listAuditLogs: {
+      name: "ListAuditLogs",
+      I: ListAuditLogsRequest,
+      O: ListAuditLogsResponse,
+      kind: MethodKind.Unary,
+    },
+  }
+} as const;
+
@@ -0,0 +1,239 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// @generated by protoc-gen-es v0.1.1 with parameter "target=ts"
+// @generated from file gitpod/experimental/v1/audit_logs.proto (package gitpod.experimental.v1, syntax proto3)
+/* eslint-disable */
+/* @ts-nocheck */
+
+import type {BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage} from "@bufbuild/protobuf";
+import {Message, proto3, protoInt64, Timestamp} from "@bufbuild/protobuf";
+import {Pagination} from "./pagination_pb.js";
+
+/**
+ * AuditLog records a call of a mutating RPC.
+ *
+ * @generated from message gitpod.experimental.v1.AuditLog
+ */
+export class AuditLog extends Message<AuditLog> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * organization_id is the ID of the organization the audit log belongs to.
+   *
+   * @generated from field: string organization_id = 2;
+   */
+  organizationId = "";
+
+  /**
+   * actor_id is the ID of the user who made the call.
+   *
+   * @generated from field: string actor_id = 3;
+   */
+  actorId = "";
+
+  /**
+   * action is the RPC which was called, e.g. gitpod.experimental.v1.WorkspacesService/UpdatePort
+   *
+   * @generated from field: string action = 4;
+   */
+  action = "";
+
+  /**
+   * target_type is the type of resource the action was performed on, e.g. workspace or personal_access_token
+   *
+   * @generated from field: string target_type = 5;
+   */
+  targetType = "";
+
+  /**
+   * target_id is the ID of the resource the action was performed on.
+   *
+   * @generated from field: string target_id = 6;
+   */
+  targetId = "";
+
+  /**
+   * details describes the action further, e.g. the port which was made public.
+   *
+   * @generated from field: string details = 7;
+   */
+  details = "";
+
+  /**
+   * origin is the Origin of the request.
+   *
+   * @generated from field: string origin = 8;
+   */
+  origin = "";
+
+  /**
+   * status is the code of the response, e.g. ok or permission_denied
+   *
+   * @generated from field: string status = 9;
+   */
+  status = "";
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 10;
+   */
+  createdAt?: Timestamp;
+
+  constructor(data?: PartialMessage<AuditLog>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.AuditLog";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "organization_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "actor_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "action", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "target_type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "target_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "details", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "origin", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 9, name: "status", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 10, name: "created_at", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AuditLog {
+    return new AuditLog().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AuditLog {
+    return new AuditLog().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AuditLog {
+    return new AuditLog().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AuditLog | PlainMessage<AuditLog> | undefined, b: AuditLog | PlainMessage<AuditLog> | undefined): boolean {
+    return proto3.util.equals(AuditLog, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ListAuditLogsRequest
+ */
+export class ListAuditLogsRequest extends Message<ListAuditLogsRequest> {
+  /**
+   * organization_id restricts the results to audit logs of this organization.
+   * If empty, the audit logs of the caller are returned instead.
+   *
+   * @generated from field: string organization_id = 1;
+   */
+  organizationId = "";
+
+  /**
+   * Page information
+   *
+   * @generated from field: gitpod.experimental.v1.Pagination pagination = 2;
+   */
+  pagination?: Pagination;
+
+  /**
+   * actor_id restricts the results to audit logs of this user, if set.
+   *
+   * @generated from field: string actor_id = 3;
+   */
+  actorId = "";
+
+  /**
+   * from restricts the results to audit logs created at or after this time, if set.
+   *
+   * @generated from field: google.protobuf.Timestamp from = 4;
+   */
+  from?: Timestamp;
+
+  /**
+   * to restricts the results to audit logs created before this time, if set.
+   *
+   * @generated from field: google.protobuf.Timestamp to = 5;
+   */
+  to?: Timestamp;
+
+  constructor(data?: PartialMessage<ListAuditLogsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ListAuditLogsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "organization_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "pagination", kind: "message", T: Pagination },
+    { no: 3, name: "actor_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "from", kind: "message", T: Timestamp },
+    { no: 5, name: "to", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListAuditLogsRequest {
+    return new ListAuditLogsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListAuditLogsRequest {
+    return new ListAuditLogsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListAuditLogsRequest {
+    return new ListAuditLogsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListAuditLogsRequest | PlainMessage<ListAuditLogsRequest> | undefined, b: ListAuditLogsRequest | PlainMessage<ListAuditLogsRequest> | undefined): boolean {
+    return proto3.util.equals(ListAuditLogsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ListAuditLogsResponse
+ */
+export class ListAuditLogsResponse extends Message<ListAuditLogsResponse> {
+  /**
+   * @generated from field: repeated gitpod.experimental.v1.AuditLog audit_logs = 1;
+   */
+  auditLogs: AuditLog[] = [];
+
+  /**
+   * @generated from field: int64 total_results = 2;
+   */
+  totalResults = protoInt64.zero;
+
+  constructor(data?: PartialMessage<ListAuditLogsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ListAuditLogsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "audit_logs", kind: "message", T: AuditLog, repeated: true },
+    { no: 2, name: "total_results", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListAuditLogsResponse {
+    return new ListAuditLogsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListAuditLogsResponse {
+    return new ListAuditLogsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListAuditLogsResponse {
+    return new ListAuditLogsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListAuditLogsResponse | PlainMessage<ListAuditLogsResponse> | undefined, b: ListAuditLogsResponse | PlainMessage<ListAuditLogsResponse> | undefined): boolean {
+    return proto3.util.equals(ListAuditLogsResponse, a, b);
+  }
+}
+
@@ -10,8 +10,9 @@
 /* @ts-nocheck */
 
 import type {BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage} from "@bufbuild/protobuf";
-import {Message, proto3, protoInt64, Timestamp} from "@bufbuild/protobuf";
+import {Duration, FieldMask, Message, proto3, protoInt64, Timestamp} from "@bufbuild/protobuf";
 import {Pagination} from "./pagination_pb.js";
+import {TeamRole} from "./teams_pb.js";
 
 /**
  * Configuration of an OpenID client.
@@ -78,6 +79,14 @@ export class OIDCClientConfig extends Message<OIDCClientConfig> {
    */
   status?: OIDCClientConfigStatus;
 
+  /**
+   * Rules applied to the claims of users signing in, to determine the teams they are members of.
+   * Optional.
+   *
+   * @generated from field: repeated gitpod.experimental.v1.ClaimToTeamMapping team_mappings = 9;
+   */
+  teamMappings: ClaimToTeamMapping[] = [];
+
   constructor(data?: PartialMessage<OIDCClientConfig>) {
     super();
     proto3.util.initPartial(data, this);
@@ -94,6 +103,7 @@ export class OIDCClientConfig extends Message<OIDCClientConfig> {
     { no: 6, name: "id_token_signing_alg_values_supported", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
     { no: 7, name: "creation_time", kind: "message", T: Timestamp },
     { no: 8, name: "status", kind: "message", T: OIDCClientConfigStatus },
+    { no: 9, name: "team_mappings", kind: "message", T: ClaimToTeamMapping, repeated: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OIDCClientConfig {
@@ -113,6 +123,73 @@ export class OIDCClientConfig extends Message<OIDCClientConfig> {
   }
 }
 
+/**
+ * Maps users whose ID token contains a claim with the given value to a team.
+ *
+ * @generated from message gitpod.experimental.v1.ClaimToTeamMapping
+ */
+export class ClaimToTeamMapping extends Message<ClaimToTeamMapping> {
+  /**
+   * Name of the claim, e.g. groups. Claims with a list of values match if any of them equals the value.
+   * Required.
+   *
+   * @generated from field: string claim = 1;
+   */
+  claim = "";
+
+  /**
+   * Required.
+   *
+   * @generated from field: string value = 2;
+   */
+  value = "";
+
+  /**
+   * Required.
+   *
+   * @generated from field: string team_id = 3;
+   */
+  teamId = "";
+
+  /**
+   * Role of matching users in the team.
+   * Optional, defaults to TEAM_ROLE_MEMBER.
+   *
+   * @generated from field: gitpod.experimental.v1.TeamRole role = 4;
+   */
+  role = TeamRole.UNSPECIFIED;
+
+  constructor(data?: PartialMessage<ClaimToTeamMapping>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ClaimToTeamMapping";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "claim", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "team_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "role", kind: "enum", T: proto3.getEnumType(TeamRole) },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ClaimToTeamMapping {
+    return new ClaimToTeamMapping().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ClaimToTeamMapping {
+    return new ClaimToTeamMapping().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ClaimToTeamMapping {
+    return new ClaimToTeamMapping().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ClaimToTeamMapping | PlainMessage<ClaimToTeamMapping> | undefined, b: ClaimToTeamMapping | PlainMessage<ClaimToTeamMapping> | undefined): boolean {
+    return proto3.util.equals(ClaimToTeamMapping, a, b);
+  }
+}
+
 /**
  * The OIDC specific part of the client configuration.
  *
@@ -740,6 +817,25 @@ export class UpdateClientConfigRequest extends Message<UpdateClientConfigRequest
    */
   config?: OIDCClientConfig;
 
+  /**
+   * Fields of the config to update. Supported paths are oidc_config.issuer,
+   * oauth2_config.client_id, oauth2_config.client_secret, oauth2_config.authorization_endpoint,
+   * oauth2_config.scopes and team_mappings.
+   * If empty, all supported fields are updated, except for an empty client_secret.
+   *
+   * @generated from field: google.protobuf.FieldMask update_mask = 2;
+   */
+  updateMask?: FieldMask;
+
+  /**
+   * When the client secret is rotated, the previous secret remains valid for this period,
+   * such that sign-ins succeed while the secret is rotated at the identity provider.
+   * Optional, defaults to 24 hours.
+   *
+   * @generated from field: google.protobuf.Duration secret_rotation_grace_period = 3;
+   */
+  secretRotationGracePeriod?: Duration;
+
   constructor(data?: PartialMessage<UpdateClientConfigRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -749,6 +845,8 @@ export class UpdateClientConfigRequest extends Message<UpdateClientConfigRequest
   static readonly typeName = "gitpod.experimental.v1.UpdateClientConfigRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "config", kind: "message", T: OIDCClientConfig },
+    { no: 2, name: "update_mask", kind: "message", T: FieldMask },
+    { no: 3, name: "secret_rotation_grace_period", kind: "message", T: Duration },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpdateClientConfigRequest {
@@ -772,6 +870,11 @@ export class UpdateClientConfigRequest extends Message<UpdateClientConfigRequest
  * @generated from message gitpod.experimental.v1.UpdateClientConfigResponse
  */
 export class UpdateClientConfigResponse extends Message<UpdateClientConfigResponse> {
+  /**
+   * @generated from field: gitpod.experimental.v1.OIDCClientConfig config = 1;
+   */
+  config?: OIDCClientConfig;
+
   constructor(data?: PartialMessage<UpdateClientConfigResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -780,6 +883,7 @@ export class UpdateClientConfigResponse extends Message<UpdateClientConfigRespon
   static readonly runtime = proto3;
   static readonly typeName = "gitpod.experimental.v1.UpdateClientConfigResponse";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "config", kind: "message", T: OIDCClientConfig },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpdateClientConfigResponse {
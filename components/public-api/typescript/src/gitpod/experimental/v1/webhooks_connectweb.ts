@@ -0,0 +1,89 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// @generated by protoc-gen-connect-web v0.2.1 with parameter "target=ts"
+// @generated from file gitpod/experimental/v1/webhooks.proto (package gitpod.experimental.v1, syntax proto3)
+/* eslint-disable */
+/* @ts-nocheck */
+
+import {CreateWebhookRequest, CreateWebhookResponse, DeleteWebhookRequest, DeleteWebhookResponse, GetWebhookRequest, GetWebhookResponse, ListWebhookDeliveriesRequest, ListWebhookDeliveriesResponse, ListWebhooksRequest, ListWebhooksResponse, RetryWebhookDeliveryRequest, RetryWebhookDeliveryResponse} from "./webhooks_pb.js";
+import {MethodKind} from "@bufbuild/protobuf";
+
+/**
+ * @generated from service gitpod.experimental.v1.WebhooksService
+ */
+export const WebhooksService = {
+  typeName: "gitpod.experimental.v1.WebhooksService",
+  methods: {
+    /**
+     * CreateWebhook registers a new webhook for a team.
+     *
+     * @generated from rpc gitpod.experimental.v1.WebhooksService.CreateWebhook
+     */
+    createWebhook: {
+      name: "CreateWebhook",
+      I: CreateWebhookRequest,
+      O: CreateWebhookResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetWebhook returns a webhook by ID.
+     *
+     * @generated from rpc gitpod.experimental.v1.WebhooksService.GetWebhook
+     */
+    getWebhook: {
+      name: "GetWebhook",
+      I: GetWebhookRequest,
+      O: GetWebhookResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ListWebhooks returns all webhooks of a team.
+     *
+     * @generated from rpc gitpod.experimental.v1.WebhooksService.ListWebhooks
+     */
+    listWebhooks: {
+      name: "ListWebhooks",
+      I: ListWebhooksRequest,
+      O: ListWebhooksResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * DeleteWebhook removes a webhook by ID. Pending deliveries are discarded.
+     *
+     * @generated from rpc gitpod.experimental.v1.WebhooksService.DeleteWebhook
+     */
+    deleteWebhook: {
+      name: "DeleteWebhook",
+      I: DeleteWebhookRequest,
+      O: DeleteWebhookResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ListWebhookDeliveries returns the delivery history of a webhook, most recent first.
+     *
+     * @generated from rpc gitpod.experimental.v1.WebhooksService.ListWebhookDeliveries
+     */
+    listWebhookDeliveries: {
+      name: "ListWebhookDeliveries",
+      I: ListWebhookDeliveriesRequest,
+      O: ListWebhookDeliveriesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RetryWebhookDelivery schedules a delivery from the dead-letter list to be attempted again.
+     *
+     * @generated from rpc gitpod.experimental.v1.WebhooksService.RetryWebhookDelivery
+     */
+    retryWebhookDelivery: {
+      name: "RetryWebhookDelivery",
+      I: RetryWebhookDeliveryRequest,
+      O: RetryWebhookDeliveryResponse,
+      kind: MethodKind.Unary,
+    },
+  }
+} as const;
+
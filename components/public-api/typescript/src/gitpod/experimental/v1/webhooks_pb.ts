@@ -0,0 +1,807 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// @generated by protoc-gen-es v0.1.1 with parameter "target=ts"
+// @generated from file gitpod/experimental/v1/webhooks.proto (package gitpod.experimental.v1, syntax proto3)
+/* eslint-disable */
+/* @ts-nocheck */
+
+import type {BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage} from "@bufbuild/protobuf";
+import {Message, proto3, protoInt64, Timestamp} from "@bufbuild/protobuf";
+import {Pagination} from "./pagination_pb.js";
+
+/**
+ * Webhook is an HTTPS endpoint registered by a team to receive events.
+ *
+ * @generated from message gitpod.experimental.v1.Webhook
+ */
+export class Webhook extends Message<Webhook> {
+  /**
+   * id is the unique identifier of this webhook
+   * Read only.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * team_id is the ID of the team whose events are delivered to this webhook
+   *
+   * @generated from field: string team_id = 2;
+   */
+  teamId = "";
+
+  /**
+   * url is the HTTPS endpoint events are delivered to with a POST request.
+   *
+   * @generated from field: string url = 3;
+   */
+  url = "";
+
+  /**
+   * events are the events delivered to this webhook. At least one event is required.
+   *
+   * @generated from field: repeated gitpod.experimental.v1.WebhookEvent events = 4;
+   */
+  events: WebhookEvent[] = [];
+
+  /**
+   * secret is used to sign payloads. Every delivery carries the hex encoded HMAC-SHA256
+   * of the payload in the X-Gitpod-Signature-256 header, prefixed with `sha256=`.
+   * The secret property is only populated when the Webhook is first created, and never again.
+   * Read only.
+   *
+   * @generated from field: string secret = 5;
+   */
+  secret = "";
+
+  /**
+   * created_at is the time when the webhook was created.
+   * Read only.
+   *
+   * @generated from field: google.protobuf.Timestamp created_at = 6;
+   */
+  createdAt?: Timestamp;
+
+  constructor(data?: PartialMessage<Webhook>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.Webhook";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "team_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "events", kind: "enum", T: proto3.getEnumType(WebhookEvent), repeated: true },
+    { no: 5, name: "secret", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "created_at", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Webhook {
+    return new Webhook().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Webhook {
+    return new Webhook().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Webhook {
+    return new Webhook().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Webhook | PlainMessage<Webhook> | undefined, b: Webhook | PlainMessage<Webhook> | undefined): boolean {
+    return proto3.util.equals(Webhook, a, b);
+  }
+}
+
+/**
+ * @generated from enum gitpod.experimental.v1.WebhookEvent
+ */
+export enum WebhookEvent {
+  /**
+   * @generated from enum value: WEBHOOK_EVENT_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * A workspace instance started running.
+   *
+   * @generated from enum value: WEBHOOK_EVENT_WORKSPACE_STARTED = 1;
+   */
+  WORKSPACE_STARTED = 1,
+
+  /**
+   * A workspace instance stopped.
+   *
+   * @generated from enum value: WEBHOOK_EVENT_WORKSPACE_STOPPED = 2;
+   */
+  WORKSPACE_STOPPED = 2,
+
+  /**
+   * A workspace instance stopped because of a failure.
+   *
+   * @generated from enum value: WEBHOOK_EVENT_WORKSPACE_FAILED = 3;
+   */
+  WORKSPACE_FAILED = 3,
+
+  /**
+   * A prebuild finished, successfully or not.
+   *
+   * @generated from enum value: WEBHOOK_EVENT_PREBUILD_FINISHED = 4;
+   */
+  PREBUILD_FINISHED = 4,
+
+  /**
+   * A workspace port was made public.
+   *
+   * @generated from enum value: WEBHOOK_EVENT_PORT_PUBLIC = 5;
+   */
+  PORT_PUBLIC = 5,
+}
+// Retrieve enum metadata with: proto3.getEnumType(WebhookEvent)
+proto3.util.setEnumType(WebhookEvent, "gitpod.experimental.v1.WebhookEvent", [
+  { no: 0, name: "WEBHOOK_EVENT_UNSPECIFIED" },
+  { no: 1, name: "WEBHOOK_EVENT_WORKSPACE_STARTED" },
+  { no: 2, name: "WEBHOOK_EVENT_WORKSPACE_STOPPED" },
+  { no: 3, name: "WEBHOOK_EVENT_WORKSPACE_FAILED" },
+  { no: 4, name: "WEBHOOK_EVENT_PREBUILD_FINISHED" },
+  { no: 5, name: "WEBHOOK_EVENT_PORT_PUBLIC" },
+]);
+
+/**
+ * WebhookDelivery is the delivery of a single event to a webhook.
+ *
+ * @generated from message gitpod.experimental.v1.WebhookDelivery
+ */
+export class WebhookDelivery extends Message<WebhookDelivery> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string webhook_id = 2;
+   */
+  webhookId = "";
+
+  /**
+   * @generated from field: gitpod.experimental.v1.WebhookEvent event = 3;
+   */
+  event = WebhookEvent.UNSPECIFIED;
+
+  /**
+   * @generated from field: gitpod.experimental.v1.WebhookDeliveryStatus status = 4;
+   */
+  status = WebhookDeliveryStatus.UNSPECIFIED;
+
+  /**
+   * payload is the JSON payload of the event.
+   *
+   * @generated from field: string payload = 5;
+   */
+  payload = "";
+
+  /**
+   * attempts is the number of times delivery was attempted.
+   *
+   * @generated from field: int32 attempts = 6;
+   */
+  attempts = 0;
+
+  /**
+   * last_response_code is the HTTP status code the endpoint responded with on the last attempt.
+   * It's 0 if no response was received.
+   *
+   * @generated from field: int32 last_response_code = 7;
+   */
+  lastResponseCode = 0;
+
+  /**
+   * last_error describes why the last attempt failed.
+   *
+   * @generated from field: string last_error = 8;
+   */
+  lastError = "";
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 9;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * next_attempt_at is the time of the next attempt of pending deliveries.
+   *
+   * @generated from field: google.protobuf.Timestamp next_attempt_at = 10;
+   */
+  nextAttemptAt?: Timestamp;
+
+  /**
+   * delivered_at is the time of the successful attempt of succeeded deliveries.
+   *
+   * @generated from field: google.protobuf.Timestamp delivered_at = 11;
+   */
+  deliveredAt?: Timestamp;
+
+  constructor(data?: PartialMessage<WebhookDelivery>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.WebhookDelivery";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "webhook_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "event", kind: "enum", T: proto3.getEnumType(WebhookEvent) },
+    { no: 4, name: "status", kind: "enum", T: proto3.getEnumType(WebhookDeliveryStatus) },
+    { no: 5, name: "payload", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "attempts", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 7, name: "last_response_code", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 8, name: "last_error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 9, name: "created_at", kind: "message", T: Timestamp },
+    { no: 10, name: "next_attempt_at", kind: "message", T: Timestamp },
+    { no: 11, name: "delivered_at", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): WebhookDelivery {
+    return new WebhookDelivery().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): WebhookDelivery {
+    return new WebhookDelivery().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): WebhookDelivery {
+    return new WebhookDelivery().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: WebhookDelivery | PlainMessage<WebhookDelivery> | undefined, b: WebhookDelivery | PlainMessage<WebhookDelivery> | undefined): boolean {
+    return proto3.util.equals(WebhookDelivery, a, b);
+  }
+}
+
+/**
+ * @generated from enum gitpod.experimental.v1.WebhookDeliveryStatus
+ */
+export enum WebhookDeliveryStatus {
+  /**
+   * @generated from enum value: WEBHOOK_DELIVERY_STATUS_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Delivery has not succeeded yet and will be attempted (again).
+   *
+   * @generated from enum value: WEBHOOK_DELIVERY_STATUS_PENDING = 1;
+   */
+  PENDING = 1,
+
+  /**
+   * @generated from enum value: WEBHOOK_DELIVERY_STATUS_SUCCEEDED = 2;
+   */
+  SUCCEEDED = 2,
+
+  /**
+   * All attempts failed, the delivery is on the dead-letter list and won't be attempted again unless retried.
+   *
+   * @generated from enum value: WEBHOOK_DELIVERY_STATUS_DEAD_LETTER = 3;
+   */
+  DEAD_LETTER = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(WebhookDeliveryStatus)
+proto3.util.setEnumType(WebhookDeliveryStatus, "gitpod.experimental.v1.WebhookDeliveryStatus", [
+  { no: 0, name: "WEBHOOK_DELIVERY_STATUS_UNSPECIFIED" },
+  { no: 1, name: "WEBHOOK_DELIVERY_STATUS_PENDING" },
+  { no: 2, name: "WEBHOOK_DELIVERY_STATUS_SUCCEEDED" },
+  { no: 3, name: "WEBHOOK_DELIVERY_STATUS_DEAD_LETTER" },
+]);
+
+/**
+ * @generated from message gitpod.experimental.v1.CreateWebhookRequest
+ */
+export class CreateWebhookRequest extends Message<CreateWebhookRequest> {
+  /**
+   * @generated from field: string team_id = 1;
+   */
+  teamId = "";
+
+  /**
+   * url must be an https URL.
+   *
+   * @generated from field: string url = 2;
+   */
+  url = "";
+
+  /**
+   * @generated from field: repeated gitpod.experimental.v1.WebhookEvent events = 3;
+   */
+  events: WebhookEvent[] = [];
+
+  constructor(data?: PartialMessage<CreateWebhookRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.CreateWebhookRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "team_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "events", kind: "enum", T: proto3.getEnumType(WebhookEvent), repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateWebhookRequest {
+    return new CreateWebhookRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateWebhookRequest {
+    return new CreateWebhookRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateWebhookRequest {
+    return new CreateWebhookRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateWebhookRequest | PlainMessage<CreateWebhookRequest> | undefined, b: CreateWebhookRequest | PlainMessage<CreateWebhookRequest> | undefined): boolean {
+    return proto3.util.equals(CreateWebhookRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.CreateWebhookResponse
+ */
+export class CreateWebhookResponse extends Message<CreateWebhookResponse> {
+  /**
+   * @generated from field: gitpod.experimental.v1.Webhook webhook = 1;
+   */
+  webhook?: Webhook;
+
+  constructor(data?: PartialMessage<CreateWebhookResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.CreateWebhookResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "webhook", kind: "message", T: Webhook },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateWebhookResponse {
+    return new CreateWebhookResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateWebhookResponse {
+    return new CreateWebhookResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateWebhookResponse {
+    return new CreateWebhookResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateWebhookResponse | PlainMessage<CreateWebhookResponse> | undefined, b: CreateWebhookResponse | PlainMessage<CreateWebhookResponse> | undefined): boolean {
+    return proto3.util.equals(CreateWebhookResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.GetWebhookRequest
+ */
+export class GetWebhookRequest extends Message<GetWebhookRequest> {
+  /**
+   * @generated from field: string team_id = 1;
+   */
+  teamId = "";
+
+  /**
+   * @generated from field: string id = 2;
+   */
+  id = "";
+
+  constructor(data?: PartialMessage<GetWebhookRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.GetWebhookRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "team_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetWebhookRequest {
+    return new GetWebhookRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetWebhookRequest {
+    return new GetWebhookRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetWebhookRequest {
+    return new GetWebhookRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetWebhookRequest | PlainMessage<GetWebhookRequest> | undefined, b: GetWebhookRequest | PlainMessage<GetWebhookRequest> | undefined): boolean {
+    return proto3.util.equals(GetWebhookRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.GetWebhookResponse
+ */
+export class GetWebhookResponse extends Message<GetWebhookResponse> {
+  /**
+   * @generated from field: gitpod.experimental.v1.Webhook webhook = 1;
+   */
+  webhook?: Webhook;
+
+  constructor(data?: PartialMessage<GetWebhookResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.GetWebhookResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "webhook", kind: "message", T: Webhook },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetWebhookResponse {
+    return new GetWebhookResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetWebhookResponse {
+    return new GetWebhookResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetWebhookResponse {
+    return new GetWebhookResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetWebhookResponse | PlainMessage<GetWebhookResponse> | undefined, b: GetWebhookResponse | PlainMessage<GetWebhookResponse> | undefined): boolean {
+    return proto3.util.equals(GetWebhookResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ListWebhooksRequest
+ */
+export class ListWebhooksRequest extends Message<ListWebhooksRequest> {
+  /**
+   * @generated from field: string team_id = 1;
+   */
+  teamId = "";
+
+  constructor(data?: PartialMessage<ListWebhooksRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ListWebhooksRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "team_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListWebhooksRequest {
+    return new ListWebhooksRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListWebhooksRequest {
+    return new ListWebhooksRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListWebhooksRequest {
+    return new ListWebhooksRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListWebhooksRequest | PlainMessage<ListWebhooksRequest> | undefined, b: ListWebhooksRequest | PlainMessage<ListWebhooksRequest> | undefined): boolean {
+    return proto3.util.equals(ListWebhooksRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ListWebhooksResponse
+ */
+export class ListWebhooksResponse extends Message<ListWebhooksResponse> {
+  /**
+   * @generated from field: repeated gitpod.experimental.v1.Webhook webhooks = 1;
+   */
+  webhooks: Webhook[] = [];
+
+  constructor(data?: PartialMessage<ListWebhooksResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ListWebhooksResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "webhooks", kind: "message", T: Webhook, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListWebhooksResponse {
+    return new ListWebhooksResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListWebhooksResponse {
+    return new ListWebhooksResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListWebhooksResponse {
+    return new ListWebhooksResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListWebhooksResponse | PlainMessage<ListWebhooksResponse> | undefined, b: ListWebhooksResponse | PlainMessage<ListWebhooksResponse> | undefined): boolean {
+    return proto3.util.equals(ListWebhooksResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.DeleteWebhookRequest
+ */
+export class DeleteWebhookRequest extends Message<DeleteWebhookRequest> {
+  /**
+   * @generated from field: string team_id = 1;
+   */
+  teamId = "";
+
+  /**
+   * @generated from field: string id = 2;
+   */
+  id = "";
+
+  constructor(data?: PartialMessage<DeleteWebhookRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.DeleteWebhookRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "team_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteWebhookRequest {
+    return new DeleteWebhookRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteWebhookRequest {
+    return new DeleteWebhookRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteWebhookRequest {
+    return new DeleteWebhookRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteWebhookRequest | PlainMessage<DeleteWebhookRequest> | undefined, b: DeleteWebhookRequest | PlainMessage<DeleteWebhookRequest> | undefined): boolean {
+    return proto3.util.equals(DeleteWebhookRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.DeleteWebhookResponse
+ */
+export class DeleteWebhookResponse extends Message<DeleteWebhookResponse> {
+  constructor(data?: PartialMessage<DeleteWebhookResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.DeleteWebhookResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteWebhookResponse {
+    return new DeleteWebhookResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteWebhookResponse {
+    return new DeleteWebhookResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteWebhookResponse {
+    return new DeleteWebhookResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteWebhookResponse | PlainMessage<DeleteWebhookResponse> | undefined, b: DeleteWebhookResponse | PlainMessage<DeleteWebhookResponse> | undefined): boolean {
+    return proto3.util.equals(DeleteWebhookResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ListWebhookDeliveriesRequest
+ */
+export class ListWebhookDeliveriesRequest extends Message<ListWebhookDeliveriesRequest> {
+  /**
+   * @generated from field: string team_id = 1;
+   */
+  teamId = "";
+
+  /**
+   * @generated from field: string webhook_id = 2;
+   */
+  webhookId = "";
+
+  /**
+   * dead_letter_only restricts the results to deliveries on the dead-letter list.
+   *
+   * @generated from field: bool dead_letter_only = 3;
+   */
+  deadLetterOnly = false;
+
+  /**
+   * Page information
+   *
+   * @generated from field: gitpod.experimental.v1.Pagination pagination = 4;
+   */
+  pagination?: Pagination;
+
+  constructor(data?: PartialMessage<ListWebhookDeliveriesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ListWebhookDeliveriesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "team_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "webhook_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "dead_letter_only", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 4, name: "pagination", kind: "message", T: Pagination },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListWebhookDeliveriesRequest {
+    return new ListWebhookDeliveriesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListWebhookDeliveriesRequest {
+    return new ListWebhookDeliveriesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListWebhookDeliveriesRequest {
+    return new ListWebhookDeliveriesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListWebhookDeliveriesRequest | PlainMessage<ListWebhookDeliveriesRequest> | undefined, b: ListWebhookDeliveriesRequest | PlainMessage<ListWebhookDeliveriesRequest> | undefined): boolean {
+    return proto3.util.equals(ListWebhookDeliveriesRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ListWebhookDeliveriesResponse
+ */
+export class ListWebhookDeliveriesResponse extends Message<ListWebhookDeliveriesResponse> {
+  /**
+   * @generated from field: repeated gitpod.experimental.v1.WebhookDelivery deliveries = 1;
+   */
+  deliveries: WebhookDelivery[] = [];
+
+  /**
+   * @generated from field: int64 total_results = 2;
+   */
+  totalResults = protoInt64.zero;
+
+  constructor(data?: PartialMessage<ListWebhookDeliveriesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ListWebhookDeliveriesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "deliveries", kind: "message", T: WebhookDelivery, repeated: true },
+    { no: 2, name: "total_results", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListWebhookDeliveriesResponse {
+    return new ListWebhookDeliveriesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListWebhookDeliveriesResponse {
+    return new ListWebhookDeliveriesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListWebhookDeliveriesResponse {
+    return new ListWebhookDeliveriesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListWebhookDeliveriesResponse | PlainMessage<ListWebhookDeliveriesResponse> | undefined, b: ListWebhookDeliveriesResponse | PlainMessage<ListWebhookDeliveriesResponse> | undefined): boolean {
+    return proto3.util.equals(ListWebhookDeliveriesResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.RetryWebhookDeliveryRequest
+ */
+export class RetryWebhookDeliveryRequest extends Message<RetryWebhookDeliveryRequest> {
+  /**
+   * @generated from field: string team_id = 1;
+   */
+  teamId = "";
+
+  /**
+   * @generated from field: string webhook_id = 2;
+   */
+  webhookId = "";
+
+  /**
+   * @generated from field: string delivery_id = 3;
+   */
+  deliveryId = "";
+
+  constructor(data?: PartialMessage<RetryWebhookDeliveryRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.RetryWebhookDeliveryRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "team_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "webhook_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "delivery_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RetryWebhookDeliveryRequest {
+    return new RetryWebhookDeliveryRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RetryWebhookDeliveryRequest {
+    return new RetryWebhookDeliveryRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RetryWebhookDeliveryRequest {
+    return new RetryWebhookDeliveryRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RetryWebhookDeliveryRequest | PlainMessage<RetryWebhookDeliveryRequest> | undefined, b: RetryWebhookDeliveryRequest | PlainMessage<RetryWebhookDeliveryRequest> | undefined): boolean {
+    return proto3.util.equals(RetryWebhookDeliveryRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.RetryWebhookDeliveryResponse
+ */
+export class RetryWebhookDeliveryResponse extends Message<RetryWebhookDeliveryResponse> {
+  /**
+   * @generated from field: gitpod.experimental.v1.WebhookDelivery delivery = 1;
+   */
+  delivery?: WebhookDelivery;
+
+  constructor(data?: PartialMessage<RetryWebhookDeliveryResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.RetryWebhookDeliveryResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "delivery", kind: "message", T: WebhookDelivery },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RetryWebhookDeliveryResponse {
+    return new RetryWebhookDeliveryResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RetryWebhookDeliveryResponse {
+    return new RetryWebhookDeliveryResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RetryWebhookDeliveryResponse {
+    return new RetryWebhookDeliveryResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RetryWebhookDeliveryResponse | PlainMessage<RetryWebhookDeliveryResponse> | undefined, b: RetryWebhookDeliveryResponse | PlainMessage<RetryWebhookDeliveryResponse> | undefined): boolean {
+    return proto3.util.equals(RetryWebhookDeliveryResponse, a, b);
+  }
+}
+
@@ -73,6 +73,10 @@ type Config struct {
 	IPFSCache *IPFSCacheConfig `json:"ipfs,omitempty"`
 
 	RedisCache *RedisCacheConfig `json:"redis,omitempty"`
+
+	EStargz *EStargzConfig `json:"estargz,omitempty"`
+
+	Verification *VerificationConfig `json:"verification,omitempty"`
 }
 
 type RedisCacheConfig struct {
@@ -92,6 +96,49 @@ type IPFSCacheConfig struct {
 	IPFSAddr string `json:"ipfsAddr"`
 }
 
+// EStargzConfig configures the conversion of base and IDE layers to eStargz, which
+// allows snapshotters that support lazy pulling to start workspaces before the whole image is pulled.
+// Layers are converted in the background, and kept on disk until the store grows beyond its maximum size.
+type EStargzConfig struct {
+	Enabled bool `json:"enabled"`
+	// Store is the directory converted layers are kept in. Defaults to the estargz directory of the store.
+	Store string `json:"store,omitempty"`
+	// MaxStoreSize is the size in bytes beyond which the least recently used layers are removed. Defaults to 20 GiB.
+	MaxStoreSize int64 `json:"maxStoreSize,omitempty"`
+}
+
+// VerificationConfig configures the verification of cosign signatures and attestations of the base image,
+// the IDE images and the static layers. Images which do not match any trust policy are rejected.
+type VerificationConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Policies []TrustPolicy `json:"policies"`
+}
+
+// VerificationMode determines how a trust policy treats images which fail verification
+type VerificationMode string
+
+const (
+	// VerificationModeEnforce rejects images which fail verification
+	VerificationModeEnforce VerificationMode = "enforce"
+	// VerificationModeWarn logs images which fail verification but serves them anyways
+	VerificationModeWarn VerificationMode = "warn"
+	// VerificationModeSkip does not verify images
+	VerificationModeSkip VerificationMode = "skip"
+)
+
+// TrustPolicy configures which keys are trusted to sign the images of a registry or repository
+type TrustPolicy struct {
+	// Scope is the registry host or repository prefix this policy applies to, e.g. "eu.gcr.io/gitpod-core-dev".
+	// "*" matches all images. If multiple policies match an image, the most specific one applies.
+	Scope string           `json:"scope"`
+	Mode  VerificationMode `json:"mode"`
+	// PublicKeys are paths to PEM encoded public keys. Images must be signed by at least one of them.
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	// Attestations are in-toto predicate types, e.g. "https://slsa.dev/provenance/v0.2", which
+	// must be attested for an image by one of the public keys.
+	Attestations []string `json:"attestations,omitempty"`
+}
+
 // StaticLayerCfg configure statically added layer
 type StaticLayerCfg struct {
 	Ref  string `json:"ref"`
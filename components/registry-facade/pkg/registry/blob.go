@@ -11,6 +11,7 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"os"
 	"sync"
 	"syscall"
 	"time"
@@ -71,6 +72,7 @@ func (reg *Registry) handleBlob(ctx context.Context, r *http.Request) http.Handl
 		Resolver: reg.Resolver(),
 		Store:    reg.Store,
 		IPFS:     reg.IPFS,
+		EStargz:  reg.EStargz,
 		AdditionalSources: []BlobSource{
 			reg.LayerSource,
 		},
@@ -100,6 +102,7 @@ type blobHandler struct {
 	Resolver          remotes.Resolver
 	Store             BlobStore
 	IPFS              *IPFSBlobCache
+	EStargz           *EStargzConverter
 	AdditionalSources []BlobSource
 	ConfigModifier    ConfigModifier
 
@@ -146,9 +149,14 @@ func (bh *blobHandler) getBlob(w http.ResponseWriter, r *http.Request) {
 		// 3. upstream registry
 		srcs = append(srcs, proxyingBlobSource{Fetcher: fetcher, Blobs: manifest.Layers})
 
-		srcs = append(srcs, &configBlobSource{Fetcher: fetcher, Spec: bh.Spec, Manifest: manifest, ConfigModifier: bh.ConfigModifier})
+		srcs = append(srcs, &configBlobSource{Fetcher: fetcher, Spec: bh.Spec, Manifest: manifest, EStargz: bh.EStargz, ConfigModifier: bh.ConfigModifier})
 		srcs = append(srcs, bh.AdditionalSources...)
 
+		// 4. base layers converted to eStargz (if configured)
+		if bh.EStargz != nil {
+			srcs = append(srcs, estargzBlobSource{Store: bh.EStargz.Store})
+		}
+
 		w.Header().Set("Etag", bh.Digest.String())
 
 		var retrieved bool
@@ -227,22 +235,17 @@ func (bh *blobHandler) retrieveFromSource(ctx context.Context, src BlobSource, w
 
 	w.Header().Set("Content-Type", mediaType)
 
-	bp := bufPool.Get().(*[]byte)
-	defer bufPool.Put(bp)
-
 	var n int64
 	t0 := time.Now()
-	err = wait.ExponentialBackoffWithContext(ctx, backoffParams, func() (done bool, err error) {
-		n, err = io.CopyBuffer(w, rc, *bp)
-		if err == nil {
-			return true, nil
-		}
-		if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
-			log.WithField("blobSource", src.Name()).WithField("baseRef", bh.Spec.BaseRef).WithError(err).Warn("retry get blob because of error")
-			return false, nil
-		}
-		return true, err
-	})
+	if f, ok := rc.(*os.File); ok {
+		// blobs served from local files, e.g. converted eStargz layers, support range requests
+		// which are used for lazy pulling.
+		cw := &countingResponseWriter{ResponseWriter: w}
+		http.ServeContent(cw, r, "", time.Time{}, f)
+		n = cw.n
+	} else {
+		n, err = bh.copyBlob(ctx, src, w, rc)
+	}
 
 	if err != nil {
 		if bh.Metrics != nil {
@@ -260,6 +263,36 @@ func (bh *blobHandler) retrieveFromSource(ctx context.Context, src BlobSource, w
 	return true, dontCache, nil
 }
 
+func (bh *blobHandler) copyBlob(ctx context.Context, src BlobSource, w io.Writer, rc io.Reader) (n int64, err error) {
+	bp := bufPool.Get().(*[]byte)
+	defer bufPool.Put(bp)
+
+	err = wait.ExponentialBackoffWithContext(ctx, backoffParams, func() (done bool, err error) {
+		n, err = io.CopyBuffer(w, rc, *bp)
+		if err == nil {
+			return true, nil
+		}
+		if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+			log.WithField("blobSource", src.Name()).WithField("baseRef", bh.Spec.BaseRef).WithError(err).Warn("retry get blob because of error")
+			return false, nil
+		}
+		return true, err
+	})
+	return n, err
+}
+
+// countingResponseWriter counts the bytes written to the response body
+type countingResponseWriter struct {
+	http.ResponseWriter
+	n int64
+}
+
+func (w *countingResponseWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.n += int64(n)
+	return n, err
+}
+
 func (bh *blobHandler) downloadManifest(ctx context.Context, ref string) (res *ociv1.Manifest, fetcher remotes.Fetcher, err error) {
 	_, desc, err := bh.Resolver.Resolve(ctx, ref)
 	if err != nil {
@@ -369,6 +402,7 @@ type configBlobSource struct {
 	Fetcher        remotes.Fetcher
 	Spec           *api.ImageSpec
 	Manifest       *ociv1.Manifest
+	EStargz        *EStargzConverter
 	ConfigModifier ConfigModifier
 }
 
@@ -377,24 +411,21 @@ func (sbs configBlobSource) Name() string {
 }
 
 func (pbs *configBlobSource) HasBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) bool {
-	cfg, err := pbs.getConfig(ctx)
+	_, ok, err := pbs.findConfig(ctx, dgst)
 	if err != nil {
 		log.WithError(err).Error("cannot (re-)produce image config")
 		return false
 	}
-
-	cfgDgst := digest.FromBytes(cfg)
-	return cfgDgst == dgst
+	return ok
 }
 
 func (pbs *configBlobSource) GetBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) (dontCache bool, mediaType string, url string, data io.ReadCloser, err error) {
-	if !pbs.HasBlob(ctx, spec, dgst) {
-		err = distv2.ErrorCodeBlobUnknown
+	cfg, ok, err := pbs.findConfig(ctx, dgst)
+	if err != nil {
 		return
 	}
-
-	cfg, err := pbs.getConfig(ctx)
-	if err != nil {
+	if !ok {
+		err = distv2.ErrorCodeBlobUnknown
 		return
 	}
 	mediaType = pbs.Manifest.Config.MediaType
@@ -402,13 +433,40 @@ func (pbs *configBlobSource) GetBlob(ctx context.Context, spec *api.ImageSpec, d
 	return
 }
 
-func (pbs *configBlobSource) getConfig(ctx context.Context) (rawCfg []byte, err error) {
+// findConfig produces the config with the digest. While the layers are converted to eStargz, the manifest
+// which refers to the config may have been served with the original layers or with the converted ones,
+// hence we try both.
+func (pbs *configBlobSource) findConfig(ctx context.Context, dgst digest.Digest) (rawCfg []byte, ok bool, err error) {
+	variants := []bool{false}
+	if pbs.EStargz != nil {
+		variants = []bool{true, false}
+	}
+	for _, convert := range variants {
+		rawCfg, err = pbs.getConfig(ctx, convert)
+		if err != nil {
+			return nil, false, err
+		}
+		if digest.FromBytes(rawCfg) == dgst {
+			return rawCfg, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (pbs *configBlobSource) getConfig(ctx context.Context, convert bool) (rawCfg []byte, err error) {
 	manifest := *pbs.Manifest
 	cfg, err := DownloadConfig(ctx, AsFetcherFunc(pbs.Fetcher), "", manifest.Config)
 	if err != nil {
 		return
 	}
 
+	if convert {
+		err = pbs.EStargz.ConvertManifest(pbs.Fetcher, &manifest, cfg)
+		if err != nil {
+			return
+		}
+	}
+
 	_, err = pbs.ConfigModifier(ctx, pbs.Spec, cfg)
 	if err != nil {
 		return
@@ -0,0 +1,349 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/stargz-snapshotter/estargz"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/registry-facade/api"
+)
+
+const (
+	// estargzConversionTimeout is the time a layer may take to be fetched and converted
+	estargzConversionTimeout = 30 * time.Minute
+	// estargzConversionRetryInterval is the time after which the conversion of a layer is retried once it failed
+	estargzConversionRetryInterval = 5 * time.Minute
+	// estargzMaxConcurrentConversions limits the number of layers which are converted at the same time
+	estargzMaxConcurrentConversions = 2
+)
+
+// LayerFetcher fetches the content of a layer
+type LayerFetcher func(ctx context.Context) (io.ReadCloser, error)
+
+// EStargzConverter converts layers to eStargz and keeps the converted layers in a store.
+// The conversion is deterministic, hence registry-facade instances which do not share a store
+// produce the same manifests.
+//
+// Layers are converted in the background. Until all layers of an image are converted,
+// the image is served with its original layers.
+type EStargzConverter struct {
+	Store *EStargzStore
+
+	group singleflight.Group
+	// failed maps the digest of an original layer to the time its conversion failed
+	failed *lru.Cache
+	// slots limits the number of concurrent conversions
+	slots chan struct{}
+
+	mu      sync.Mutex
+	pending map[digest.Digest]struct{}
+	running sync.WaitGroup
+}
+
+// NewEStargzConverter creates a new eStargz converter
+func NewEStargzConverter(store *EStargzStore) (*EStargzConverter, error) {
+	failed, err := lru.New(1024)
+	if err != nil {
+		return nil, err
+	}
+	return &EStargzConverter{
+		Store:   store,
+		failed:  failed,
+		slots:   make(chan struct{}, estargzMaxConcurrentConversions),
+		pending: make(map[digest.Digest]struct{}),
+	}, nil
+}
+
+// Convert returns the eStargz version of a layer, converting it if it is not in the store yet. Layers which cannot be converted,
+// e.g. because they are eStargz already or are foreign layers, are returned as is.
+func (c *EStargzConverter) Convert(ctx context.Context, layer AddonLayer, fetch LayerFetcher) (AddonLayer, error) {
+	if !isConvertible(layer.Descriptor) {
+		return layer, nil
+	}
+	if res, ok := c.Store.Get(layer.Descriptor.Digest); ok {
+		return res, nil
+	}
+
+	res, err, _ := c.group.Do(layer.Descriptor.Digest.String(), func() (interface{}, error) {
+		return c.convert(ctx, layer, fetch)
+	})
+	if err != nil {
+		return AddonLayer{}, err
+	}
+	return res.(AddonLayer), nil
+}
+
+// ConvertLayers returns the eStargz versions of the layers if all of them have been converted already.
+// Otherwise the layers are returned as is, and the missing layers are converted in the background.
+// Images are never served with a mix of converted and original layers, such that the converted image
+// is the same on all registry-facade instances.
+func (c *EStargzConverter) ConvertLayers(layers []AddonLayer, fetch func(layer AddonLayer) LayerFetcher) []AddonLayer {
+	res := make([]AddonLayer, len(layers))
+	complete := true
+	for i, l := range layers {
+		if !isConvertible(l.Descriptor) {
+			res[i] = l
+			continue
+		}
+		converted, ok := c.Store.Get(l.Descriptor.Digest)
+		if !ok {
+			complete = false
+			c.convertInBackground(l, fetch(l))
+			continue
+		}
+		res[i] = converted
+	}
+	if !complete {
+		return layers
+	}
+	return res
+}
+
+// convertInBackground converts a layer unless it is being converted already, or its conversion failed recently
+func (c *EStargzConverter) convertInBackground(layer AddonLayer, fetch LayerFetcher) {
+	dgst := layer.Descriptor.Digest
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.pending[dgst]; ok {
+		return
+	}
+	if failedAt, ok := c.failed.Get(dgst); ok && time.Since(failedAt.(time.Time)) < estargzConversionRetryInterval {
+		return
+	}
+	c.pending[dgst] = struct{}{}
+
+	c.running.Add(1)
+	go func() {
+		defer c.running.Done()
+		defer func() {
+			c.mu.Lock()
+			delete(c.pending, dgst)
+			c.mu.Unlock()
+		}()
+
+		c.slots <- struct{}{}
+		defer func() { <-c.slots }()
+
+		// the conversion must not be bound to the request which caused it
+		ctx, cancel := context.WithTimeout(context.Background(), estargzConversionTimeout)
+		defer cancel()
+		_, err := c.Convert(ctx, layer, fetch)
+		if err != nil {
+			log.WithError(err).WithField("digest", dgst).Warn("cannot convert layer to eStargz")
+			c.failed.Add(dgst, time.Now())
+			return
+		}
+		c.failed.Remove(dgst)
+	}()
+}
+
+// Wait blocks until all conversions in the background are done
+func (c *EStargzConverter) Wait() {
+	c.running.Wait()
+}
+
+func (c *EStargzConverter) convert(ctx context.Context, layer AddonLayer, fetch LayerFetcher) (res AddonLayer, err error) {
+	logFields := log.WithField("digest", layer.Descriptor.Digest)
+	logFields.Debug("converting layer to eStargz")
+
+	rc, err := fetch(ctx)
+	if err != nil {
+		return AddonLayer{}, xerrors.Errorf("cannot fetch layer %s: %w", layer.Descriptor.Digest, err)
+	}
+	defer rc.Close()
+
+	// estargz.Build needs random access to the layer
+	src, err := c.Store.TempFile("estargz-src-*")
+	if err != nil {
+		return AddonLayer{}, err
+	}
+	defer os.Remove(src.Name())
+	defer src.Close()
+	size, err := io.Copy(src, rc)
+	if err != nil {
+		return AddonLayer{}, xerrors.Errorf("cannot fetch layer %s: %w", layer.Descriptor.Digest, err)
+	}
+
+	blob, err := estargz.Build(io.NewSectionReader(src, 0, size), estargz.WithContext(ctx))
+	if err != nil {
+		return AddonLayer{}, xerrors.Errorf("cannot convert layer %s: %w", layer.Descriptor.Digest, err)
+	}
+	defer blob.Close()
+
+	// we need the digest of the converted layer before we can place it in the store
+	dst, err := c.Store.TempFile("estargz-dst-*")
+	if err != nil {
+		return AddonLayer{}, err
+	}
+	defer os.Remove(dst.Name())
+	defer dst.Close()
+	digester := digest.Canonical.Digester()
+	convertedSize, err := io.Copy(io.MultiWriter(dst, digester.Hash()), blob)
+	if err != nil {
+		return AddonLayer{}, xerrors.Errorf("cannot convert layer %s: %w", layer.Descriptor.Digest, err)
+	}
+	// the DiffID is only valid after the blob was closed
+	err = blob.Close()
+	if err != nil {
+		return AddonLayer{}, xerrors.Errorf("cannot convert layer %s: %w", layer.Descriptor.Digest, err)
+	}
+	err = dst.Close()
+	if err != nil {
+		return AddonLayer{}, err
+	}
+
+	mediaType := ociv1.MediaTypeImageLayerGzip
+	if images.IsDockerType(layer.Descriptor.MediaType) {
+		mediaType = images.MediaTypeDockerSchema2LayerGzip
+	}
+	res = AddonLayer{
+		Descriptor: ociv1.Descriptor{
+			MediaType: mediaType,
+			Digest:    digester.Digest(),
+			Size:      convertedSize,
+			Annotations: map[string]string{
+				estargz.TOCJSONDigestAnnotation: blob.TOCDigest().String(),
+			},
+		},
+		DiffID: blob.DiffID(),
+	}
+
+	err = c.Store.Put(layer.Descriptor.Digest, res, dst.Name())
+	if err != nil {
+		return AddonLayer{}, xerrors.Errorf("cannot store converted layer %s: %w", layer.Descriptor.Digest, err)
+	}
+	logFields.WithField("estargzDigest", res.Descriptor.Digest).Info("converted layer to eStargz")
+
+	return res, nil
+}
+
+// ConvertManifest replaces the layers of an image with their eStargz versions and updates the diffIDs of its config accordingly,
+// once all layers have been converted. Until then, the image is left as is and its layers are converted in the background.
+func (c *EStargzConverter) ConvertManifest(fetcher remotes.Fetcher, manifest *ociv1.Manifest, cfg *ociv1.Image) error {
+	if len(manifest.Layers) != len(cfg.RootFS.DiffIDs) {
+		return xerrors.Errorf("manifest has %d layers but config has %d diffIDs", len(manifest.Layers), len(cfg.RootFS.DiffIDs))
+	}
+
+	layers := make([]AddonLayer, len(manifest.Layers))
+	for i, l := range manifest.Layers {
+		layers[i] = AddonLayer{Descriptor: l, DiffID: cfg.RootFS.DiffIDs[i]}
+	}
+	converted := c.ConvertLayers(layers, func(layer AddonLayer) LayerFetcher {
+		return func(ctx context.Context) (io.ReadCloser, error) {
+			return fetcher.Fetch(ctx, layer.Descriptor)
+		}
+	})
+
+	// the layers may be shared with the manifest we were given
+	descs := make([]ociv1.Descriptor, len(converted))
+	diffIDs := make([]digest.Digest, len(converted))
+	for i, l := range converted {
+		descs[i] = l.Descriptor
+		diffIDs[i] = l.DiffID
+	}
+	manifest.Layers = descs
+	cfg.RootFS.DiffIDs = diffIDs
+	return nil
+}
+
+func isConvertible(desc ociv1.Descriptor) bool {
+	if len(desc.URLs) > 0 {
+		// foreign layers are not served by us
+		return false
+	}
+	if _, ok := desc.Annotations[estargz.TOCJSONDigestAnnotation]; ok {
+		return false
+	}
+	switch desc.MediaType {
+	case ociv1.MediaTypeImageLayer, ociv1.MediaTypeImageLayerGzip,
+		images.MediaTypeDockerSchema2Layer, images.MediaTypeDockerSchema2LayerGzip:
+		return true
+	default:
+		return false
+	}
+}
+
+// EStargzLayerSource provides the layers of another layer source converted to eStargz
+type EStargzLayerSource struct {
+	LayerSource
+	Converter *EStargzConverter
+}
+
+// GetLayer returns the converted layers of the delegate, or its original layers while they are being converted
+func (src *EStargzLayerSource) GetLayer(ctx context.Context, spec *api.ImageSpec) ([]AddonLayer, error) {
+	layers, err := src.LayerSource.GetLayer(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return src.Converter.ConvertLayers(layers, func(layer AddonLayer) LayerFetcher {
+		dgst := layer.Descriptor.Digest
+		return func(ctx context.Context) (io.ReadCloser, error) {
+			_, _, _, rc, err := src.LayerSource.GetBlob(ctx, spec, dgst)
+			if err != nil {
+				return nil, err
+			}
+			if rc == nil {
+				return nil, xerrors.Errorf("layer %s has no content", dgst)
+			}
+			return rc, nil
+		}
+	}), nil
+}
+
+// HasBlob checks if a digest can be served by this blob source
+func (src *EStargzLayerSource) HasBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) bool {
+	return src.LayerSource.HasBlob(ctx, spec, dgst) || src.Converter.Store.Has(dgst)
+}
+
+// GetBlob provides access to a blob. If a ReadCloser is returned the receiver is expected to
+// call close on it eventually.
+func (src *EStargzLayerSource) GetBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) (dontCache bool, mediaType string, url string, data io.ReadCloser, err error) {
+	if src.LayerSource.HasBlob(ctx, spec, dgst) {
+		return src.LayerSource.GetBlob(ctx, spec, dgst)
+	}
+	return estargzBlobSource{Store: src.Converter.Store}.GetBlob(ctx, spec, dgst)
+}
+
+// estargzBlobSource provides converted layers. Layers are only served once they have been converted,
+// as manifests refer to converted layers only after all of them are in the store.
+type estargzBlobSource struct {
+	Store *EStargzStore
+}
+
+func (sbs estargzBlobSource) Name() string {
+	return "estargz"
+}
+
+func (sbs estargzBlobSource) HasBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) bool {
+	return sbs.Store.Has(dgst)
+}
+
+func (sbs estargzBlobSource) GetBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) (dontCache bool, mediaType string, url string, data io.ReadCloser, err error) {
+	f, err := sbs.Store.Open(dgst)
+	if os.IsNotExist(err) {
+		err = errdefs.ErrNotFound
+	}
+	if err != nil {
+		return
+	}
+	return false, "application/octet-stream", "", f, nil
+}
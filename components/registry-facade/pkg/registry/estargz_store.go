@@ -0,0 +1,296 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+const (
+	// DefaultEStargzStoreSize is the size of the eStargz store beyond which converted layers are removed
+	DefaultEStargzStoreSize = 20 * 1024 * 1024 * 1024
+
+	estargzStoreGCInterval = 10 * time.Minute
+	// estargzTempFileMaxAge is the age after which left-over temporary files are removed
+	estargzTempFileMaxAge = 2 * estargzConversionTimeout
+)
+
+// EStargzStore keeps converted layers on disk. Layers are indexed by the digest of the layer they were converted from.
+// Once the store grows beyond its maximum size, the least recently used layers are removed.
+//
+// The store is laid out as follows:
+//
+//	blobs/<algorithm>/<encoded>       converted layers
+//	index/<algorithm>/<encoded>.json  converted AddonLayer of the source layer with this digest
+//	tmp/                              layers in conversion
+type EStargzStore struct {
+	dir     string
+	maxSize int64
+}
+
+// NewEStargzStore creates a new eStargz store in dir. Left-over temporary files of previous runs are removed.
+func NewEStargzStore(dir string, maxSize int64) (*EStargzStore, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultEStargzStoreSize
+	}
+	s := &EStargzStore{dir: dir, maxSize: maxSize}
+
+	err := os.RemoveAll(s.tmpDir())
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range []string{filepath.Join(dir, "blobs"), filepath.Join(dir, "index"), s.tmpDir()} {
+		err := os.MkdirAll(d, 0755)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *EStargzStore) tmpDir() string {
+	return filepath.Join(s.dir, "tmp")
+}
+
+func (s *EStargzStore) blobPath(dgst digest.Digest) (string, error) {
+	if err := dgst.Validate(); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, "blobs", dgst.Algorithm().String(), dgst.Encoded()), nil
+}
+
+func (s *EStargzStore) indexPath(source digest.Digest) (string, error) {
+	if err := source.Validate(); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, "index", source.Algorithm().String(), source.Encoded()+".json"), nil
+}
+
+// TempFile creates a temporary file in the store, which is on the same filesystem as the converted layers
+func (s *EStargzStore) TempFile(pattern string) (*os.File, error) {
+	return os.CreateTemp(s.tmpDir(), pattern)
+}
+
+// Get returns the converted version of the source layer, if it is in the store
+func (s *EStargzStore) Get(source digest.Digest) (AddonLayer, bool) {
+	fn, err := s.indexPath(source)
+	if err != nil {
+		return AddonLayer{}, false
+	}
+	c, err := os.ReadFile(fn)
+	if err != nil {
+		return AddonLayer{}, false
+	}
+	var res AddonLayer
+	err = json.Unmarshal(c, &res)
+	if err != nil {
+		log.WithError(err).WithField("digest", source).Warn("cannot read eStargz index entry")
+		return AddonLayer{}, false
+	}
+	if !s.touch(res.Descriptor.Digest) {
+		// the converted layer was removed
+		return AddonLayer{}, false
+	}
+	return res, true
+}
+
+// Put moves the blob of the converted layer into the store and indexes it as the converted version of the source layer.
+// The blob must be a temporary file of the store.
+func (s *EStargzStore) Put(source digest.Digest, layer AddonLayer, blob string) error {
+	blobFN, err := s.blobPath(layer.Descriptor.Digest)
+	if err != nil {
+		return err
+	}
+	indexFN, err := s.indexPath(source)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(blobFN), 0755)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(blob, blobFN)
+	if err != nil {
+		return err
+	}
+
+	c, err := json.Marshal(layer)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(indexFN), 0755)
+	if err != nil {
+		return err
+	}
+	tmp, err := s.TempFile("index-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(c)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), indexFN)
+}
+
+// Has returns true if the converted layer with the digest is in the store
+func (s *EStargzStore) Has(dgst digest.Digest) bool {
+	fn, err := s.blobPath(dgst)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(fn)
+	return err == nil
+}
+
+// Open opens a converted layer. The receiver is expected to close the returned file.
+func (s *EStargzStore) Open(dgst digest.Digest) (*os.File, error) {
+	fn, err := s.blobPath(dgst)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	s.touch(dgst)
+	return f, nil
+}
+
+// touch marks a converted layer as used, and returns false if it is not in the store
+func (s *EStargzStore) touch(dgst digest.Digest) bool {
+	fn, err := s.blobPath(dgst)
+	if err != nil {
+		return false
+	}
+	now := time.Now()
+	return os.Chtimes(fn, now, now) == nil
+}
+
+// StartGC removes the least recently used layers from the store periodically, until the context is canceled
+func (s *EStargzStore) StartGC(ctx context.Context) {
+	t := time.NewTicker(estargzStoreGCInterval)
+	defer t.Stop()
+	for {
+		err := s.GC()
+		if err != nil {
+			log.WithError(err).Warn("cannot garbage collect eStargz store")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// GC removes the least recently used layers until the store is no larger than its maximum size.
+// Index entries of removed layers, and left-over temporary files are removed as well.
+func (s *EStargzStore) GC() error {
+	type blob struct {
+		Path    string
+		Size    int64
+		ModTime time.Time
+	}
+	var (
+		blobs []blob
+		total int64
+	)
+	err := filepath.WalkDir(filepath.Join(s.dir, "blobs"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		blobs = append(blobs, blob{Path: path, Size: info.Size(), ModTime: info.ModTime()})
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return xerrors.Errorf("cannot list converted layers: %w", err)
+	}
+
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].ModTime.Before(blobs[j].ModTime) })
+	for _, b := range blobs {
+		if total <= s.maxSize {
+			break
+		}
+		err := os.Remove(b.Path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		total -= b.Size
+		log.WithField("path", b.Path).Debug("removed converted layer from eStargz store")
+	}
+
+	err = filepath.WalkDir(filepath.Join(s.dir, "index"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		c, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		var layer AddonLayer
+		if json.Unmarshal(c, &layer) == nil && s.Has(layer.Descriptor.Digest) {
+			return nil
+		}
+		err = os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return xerrors.Errorf("cannot clean eStargz index: %w", err)
+	}
+
+	tmp, err := os.ReadDir(s.tmpDir())
+	if err != nil {
+		return err
+	}
+	for _, f := range tmp {
+		info, err := f.Info()
+		if err != nil || time.Since(info.ModTime()) < estargzTempFileMaxAge {
+			continue
+		}
+		_ = os.Remove(filepath.Join(s.tmpDir(), f.Name()))
+	}
+
+	return nil
+}
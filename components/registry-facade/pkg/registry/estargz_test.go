@@ -0,0 +1,363 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/stargz-snapshotter/estargz"
+	"github.com/google/go-cmp/cmp"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/xerrors"
+)
+
+func createTestLayer(t *testing.T, mediaType string, files map[string]string) (AddonLayer, []byte) {
+	var (
+		raw bytes.Buffer
+		buf bytes.Buffer
+	)
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(io.MultiWriter(gz, &raw))
+	for name, c := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(c)), Typeflag: tar.TypeReg})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tw.Write([]byte(c))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return AddonLayer{
+		Descriptor: ociv1.Descriptor{
+			MediaType: mediaType,
+			Digest:    digest.FromBytes(buf.Bytes()),
+			Size:      int64(buf.Len()),
+		},
+		DiffID: digest.FromBytes(raw.Bytes()),
+	}, buf.Bytes()
+}
+
+func newTestEStargzConverter(t *testing.T) *EStargzConverter {
+	store, err := NewEStargzStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv, err := NewEStargzConverter(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conv
+}
+
+func staticLayerFetcher(data []byte) LayerFetcher {
+	return func(ctx context.Context) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+}
+
+func TestEStargzConvert(t *testing.T) {
+	tests := []struct {
+		Name              string
+		MediaType         string
+		ExpectedMediaType string
+	}{
+		{Name: "docker layer", MediaType: images.MediaTypeDockerSchema2LayerGzip, ExpectedMediaType: images.MediaTypeDockerSchema2LayerGzip},
+		{Name: "oci layer", MediaType: ociv1.MediaTypeImageLayerGzip, ExpectedMediaType: ociv1.MediaTypeImageLayerGzip},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx := context.Background()
+			layer, data := createTestLayer(t, test.MediaType, map[string]string{"foo": "bar", "bin/baz": "qux"})
+
+			conv := newTestEStargzConverter(t)
+			res, err := conv.Convert(ctx, layer, staticLayerFetcher(data))
+			if err != nil {
+				t.Fatalf("cannot convert layer: %v", err)
+			}
+			if res.Descriptor.MediaType != test.ExpectedMediaType {
+				t.Errorf("unexpected media type: %s", res.Descriptor.MediaType)
+			}
+			if _, ok := res.Descriptor.Annotations[estargz.TOCJSONDigestAnnotation]; !ok {
+				t.Error("converted layer has no TOC digest annotation")
+			}
+			if res.Descriptor.Digest == layer.Descriptor.Digest || res.DiffID == layer.DiffID {
+				t.Error("converted layer has the digest or diffID of the original layer")
+			}
+			if !conv.Store.Has(res.Descriptor.Digest) {
+				t.Error("converted layer is not in the store")
+			}
+
+			rc, err := conv.Store.Open(res.Descriptor.Digest)
+			if err != nil {
+				t.Fatalf("converted layer is not in the store: %v", err)
+			}
+			blob, err := io.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if act := digest.FromBytes(blob); act != res.Descriptor.Digest {
+				t.Errorf("stored layer has digest %s, expected %s", act, res.Descriptor.Digest)
+			}
+
+			// converted layers are cached, hence we must not fetch the layer again
+			cached, err := conv.Convert(ctx, layer, func(ctx context.Context) (io.ReadCloser, error) {
+				return nil, xerrors.Errorf("layer was fetched again")
+			})
+			if err != nil {
+				t.Fatalf("cannot convert layer: %v", err)
+			}
+			if diff := cmp.Diff(res, cached); diff != "" {
+				t.Errorf("unexpected cached layer (-want +got):\n%s", diff)
+			}
+
+			// other registry-facade instances must produce the same layer
+			other, err := newTestEStargzConverter(t).Convert(ctx, layer, staticLayerFetcher(data))
+			if err != nil {
+				t.Fatalf("cannot convert layer: %v", err)
+			}
+			if diff := cmp.Diff(res, other); diff != "" {
+				t.Errorf("conversion is not deterministic (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEStargzConvertPassthrough(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Descriptor ociv1.Descriptor
+	}{
+		{
+			Name: "foreign layer",
+			Descriptor: ociv1.Descriptor{
+				MediaType: images.MediaTypeDockerSchema2LayerForeignGzip,
+				Digest:    digest.FromString("foreign"),
+				URLs:      []string{"https://example.com/layer.tar.gz"},
+			},
+		},
+		{
+			Name: "estargz layer",
+			Descriptor: ociv1.Descriptor{
+				MediaType:   ociv1.MediaTypeImageLayerGzip,
+				Digest:      digest.FromString("estargz"),
+				Annotations: map[string]string{estargz.TOCJSONDigestAnnotation: digest.FromString("toc").String()},
+			},
+		},
+		{
+			Name: "zstd layer",
+			Descriptor: ociv1.Descriptor{
+				MediaType: ociv1.MediaTypeImageLayerZstd,
+				Digest:    digest.FromString("zstd"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			layer := AddonLayer{Descriptor: test.Descriptor, DiffID: digest.FromString("diffID")}
+			res, err := newTestEStargzConverter(t).Convert(context.Background(), layer, func(ctx context.Context) (io.ReadCloser, error) {
+				return nil, xerrors.Errorf("layer must not be fetched")
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(layer, res); diff != "" {
+				t.Errorf("layer was modified (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEStargzConvertManifest(t *testing.T) {
+	layer, data := createTestLayer(t, ociv1.MediaTypeImageLayerGzip, map[string]string{"foo": "bar"})
+	foreign := ociv1.Descriptor{
+		MediaType: images.MediaTypeDockerSchema2LayerForeignGzip,
+		Digest:    digest.FromString("foreign"),
+		URLs:      []string{"https://example.com/layer.tar.gz"},
+	}
+	layers := []ociv1.Descriptor{layer.Descriptor, foreign}
+	manifest := &ociv1.Manifest{Layers: layers}
+	cfg := &ociv1.Image{RootFS: ociv1.RootFS{DiffIDs: []digest.Digest{layer.DiffID, digest.FromString("foreign-diffID")}}}
+
+	conv := newTestEStargzConverter(t)
+	fetcher := &fakeFetcher{Content: map[string][]byte{layer.Descriptor.Digest.Encoded(): data}}
+
+	// the manifest is served as is until all layers are converted
+	err := conv.ConvertManifest(fetcher, manifest, cfg)
+	if err != nil {
+		t.Fatalf("cannot convert manifest: %v", err)
+	}
+	if diff := cmp.Diff(layers, manifest.Layers); diff != "" {
+		t.Errorf("layers were converted synchronously (-want +got):\n%s", diff)
+	}
+	if cfg.RootFS.DiffIDs[0] != layer.DiffID {
+		t.Errorf("diffID of the first layer was updated before the layer was converted")
+	}
+
+	conv.Wait()
+	err = conv.ConvertManifest(fetcher, manifest, cfg)
+	if err != nil {
+		t.Fatalf("cannot convert manifest: %v", err)
+	}
+
+	if layers[0].Digest != layer.Descriptor.Digest {
+		t.Error("ConvertManifest modified the layers of the original manifest")
+	}
+	if manifest.Layers[0].Digest == layer.Descriptor.Digest || !conv.Store.Has(manifest.Layers[0].Digest) {
+		t.Errorf("first layer was not converted")
+	}
+	if cfg.RootFS.DiffIDs[0] == layer.DiffID {
+		t.Errorf("diffID of the first layer was not updated")
+	}
+	if diff := cmp.Diff(foreign, manifest.Layers[1]); diff != "" {
+		t.Errorf("foreign layer was modified (-want +got):\n%s", diff)
+	}
+
+	err = conv.ConvertManifest(&fakeFetcher{}, &ociv1.Manifest{Layers: layers}, &ociv1.Image{})
+	if err == nil {
+		t.Error("expected an error for a config with missing diffIDs")
+	}
+}
+
+func TestEStargzConvertLayersFailure(t *testing.T) {
+	layer, _ := createTestLayer(t, ociv1.MediaTypeImageLayerGzip, map[string]string{"foo": "bar"})
+	layers := []AddonLayer{layer}
+
+	conv := newTestEStargzConverter(t)
+	var fetches int
+	fetch := func(l AddonLayer) LayerFetcher {
+		return func(ctx context.Context) (io.ReadCloser, error) {
+			fetches++
+			return nil, xerrors.Errorf("registry is unavailable")
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		res := conv.ConvertLayers(layers, fetch)
+		if diff := cmp.Diff(layers, res); diff != "" {
+			t.Errorf("unexpected layers (-want +got):\n%s", diff)
+		}
+		conv.Wait()
+	}
+	if fetches != 1 {
+		t.Errorf("layer was fetched %d times, expected the failed conversion not to be retried right away", fetches)
+	}
+}
+
+func TestEStargzStoreGC(t *testing.T) {
+	store, err := NewEStargzStore(t.TempDir(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	put := func(name string, modTime time.Time) AddonLayer {
+		layer := AddonLayer{Descriptor: ociv1.Descriptor{Digest: digest.FromString(name + "-converted"), Size: 6}}
+		f, err := store.TempFile("test-*")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = f.WriteString("012345")
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = store.Put(digest.FromString(name), layer, f.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		fn, _ := store.blobPath(layer.Descriptor.Digest)
+		err = os.Chtimes(fn, modTime, modTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return layer
+	}
+	old := put("old", time.Now().Add(-time.Hour))
+	recent := put("recent", time.Now())
+
+	err = store.GC()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if store.Has(old.Descriptor.Digest) {
+		t.Error("least recently used layer was not removed")
+	}
+	if _, ok := store.Get(digest.FromString("old")); ok {
+		t.Error("index entry of the removed layer is still served")
+	}
+	if res, ok := store.Get(digest.FromString("recent")); !ok || res.Descriptor.Digest != recent.Descriptor.Digest {
+		t.Error("recently used layer was removed")
+	}
+}
+
+func TestEStargzBlobSourceRangeRequest(t *testing.T) {
+	store, err := NewEStargzStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := "0123456789"
+	layer := AddonLayer{Descriptor: ociv1.Descriptor{Digest: digest.FromString(content), Size: int64(len(content))}}
+	f, err := store.TempFile("test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.WriteString(content)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.Put(digest.FromString("source"), layer, f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bh := &blobHandler{
+		Context: context.Background(),
+		Digest:  layer.Descriptor.Digest,
+		Name:    "unittest",
+	}
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.Header.Set("Range", "bytes=2-5")
+	w := httptest.NewRecorder()
+
+	handled, _, err := bh.retrieveFromSource(context.Background(), estargzBlobSource{Store: store}, w, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !handled {
+		t.Fatal("blob was not handled")
+	}
+	if w.Code != http.StatusPartialContent {
+		t.Errorf("unexpected status code: %d", w.Code)
+	}
+	if body := w.Body.String(); body != "2345" {
+		t.Errorf("unexpected body: %q", body)
+	}
+	if cr := w.Header().Get("Content-Range"); cr != "bytes 2-5/10" {
+		t.Errorf("unexpected content range: %q", cr)
+	}
+}
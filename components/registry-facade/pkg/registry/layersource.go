@@ -172,6 +172,10 @@ type imagebackedLayer struct {
 
 // ImageLayerSource provides additional layers from another image
 type ImageLayerSource struct {
+	// Ref is the reference of the image, which was resolved to Digest
+	Ref    string
+	Digest digest.Digest
+
 	envs   []EnvModifier
 	layers []imagebackedLayer
 }
@@ -302,6 +306,8 @@ func NewStaticSourceFromImage(ctx context.Context, resolver remotes.Resolver, re
 	}
 
 	return &ImageLayerSource{
+		Ref:    ref,
+		Digest: desc.Digest,
 		layers: res,
 		envs:   envs,
 	}, nil
@@ -397,6 +403,8 @@ func NewSpecMappedImageSource(resolver ResolverProvider, refSource RefSource) (*
 type SpecMappedImagedSource struct {
 	RefSource RefSource
 	Resolver  ResolverProvider
+	// Verifier verifies the images before their layers are served, if set
+	Verifier *Verifier
 
 	// TODO: add ttl
 	cache *lru.Cache
@@ -498,10 +506,14 @@ func (src *SpecMappedImagedSource) getDelegate(ctx context.Context, spec *api.Im
 			layers[i] = s.(LayerSource)
 			continue
 		}
-		lsrc, err := NewStaticSourceFromImage(ctx, src.Resolver(), ref)
+		img, err := NewStaticSourceFromImage(ctx, src.Resolver(), ref)
 		if err != nil {
 			return nil, err
 		}
+		var lsrc LayerSource = img
+		if src.Verifier != nil {
+			lsrc = &VerifiedLayerSource{ImageLayerSource: img, Verifier: src.Verifier}
+		}
 		src.cache.Add(ref, lsrc)
 		layers[i] = lsrc
 	}
@@ -19,6 +19,7 @@ import (
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/remotes"
+	"github.com/docker/distribution/registry/api/errcode"
 	distv2 "github.com/docker/distribution/registry/api/v2"
 	"github.com/gorilla/handlers"
 	"github.com/opencontainers/go-digest"
@@ -68,6 +69,8 @@ func (reg *Registry) handleManifest(ctx context.Context, r *http.Request) http.H
 		Spec:           spec,
 		Resolver:       reg.Resolver(),
 		Store:          reg.Store,
+		EStargz:        reg.EStargz,
+		Verifier:       reg.Verifier,
 		ConfigModifier: reg.ConfigModifier,
 	}
 	reference := getReference(ctx)
@@ -100,6 +103,8 @@ type manifestHandler struct {
 	Spec           *api.ImageSpec
 	Resolver       remotes.Resolver
 	Store          BlobStore
+	EStargz        *EStargzConverter
+	Verifier       *Verifier
 	ConfigModifier ConfigModifier
 
 	Name   string
@@ -153,6 +158,20 @@ func (mh *manifestHandler) getManifest(w http.ResponseWriter, r *http.Request) {
 			return err
 		}
 
+		// the images we add layers from are verified by their layer sources, at the digests their layers are taken from
+		if mh.Verifier != nil {
+			err = mh.Verifier.Verify(ctx, ref, desc.Digest)
+			var verr *VerificationError
+			if xerrors.As(err, &verr) {
+				log.WithError(err).WithFields(logFields).Warn("image failed verification")
+				return errcode.ErrorCodeDenied.WithMessage(verr.Error())
+			}
+			if err != nil {
+				log.WithError(err).WithField("ref", ref).WithFields(logFields).Error("cannot verify image")
+				return err
+			}
+		}
+
 		var fcache remotes.Fetcher
 		fetch := func() (remotes.Fetcher, error) {
 			if fcache != nil {
@@ -184,8 +203,25 @@ func (mh *manifestHandler) getManifest(w http.ResponseWriter, r *http.Request) {
 				return err
 			}
 
+			if mh.EStargz != nil {
+				fetcher, err := fetch()
+				if err != nil {
+					return err
+				}
+				err = mh.EStargz.ConvertManifest(fetcher, manifest, cfg)
+				if err != nil {
+					log.WithError(err).WithFields(logFields).Error("cannot convert layers to eStargz")
+					return err
+				}
+			}
+
 			// modify config
 			addonLayer, err := mh.ConfigModifier(ctx, mh.Spec, cfg)
+			var verr *VerificationError
+			if xerrors.As(err, &verr) {
+				log.WithError(err).WithFields(logFields).Warn("image failed verification")
+				return errcode.ErrorCodeDenied.WithMessage(verr.Error())
+			}
 			if err != nil {
 				log.WithError(err).WithFields(logFields).Error("cannot modify config")
 				return err
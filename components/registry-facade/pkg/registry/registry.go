@@ -41,7 +41,7 @@ import (
 )
 
 // BuildStaticLayer builds a layer set from a static layer configuration
-func buildStaticLayer(ctx context.Context, cfg []config.StaticLayerCfg, newResolver ResolverProvider) (CompositeLayerSource, error) {
+func buildStaticLayer(ctx context.Context, cfg []config.StaticLayerCfg, newResolver ResolverProvider, estargz *EStargzConverter, verifier *Verifier) (CompositeLayerSource, error) {
 	var l CompositeLayerSource
 	for _, sl := range cfg {
 		switch sl.Type {
@@ -52,10 +52,18 @@ func buildStaticLayer(ctx context.Context, cfg []config.StaticLayerCfg, newResol
 			}
 			l = append(l, src)
 		case "image":
-			src, err := NewStaticSourceFromImage(ctx, newResolver(), sl.Ref)
+			img, err := NewStaticSourceFromImage(ctx, newResolver(), sl.Ref)
 			if err != nil {
 				return nil, xerrors.Errorf("cannot source layer from %s: %w", sl.Ref, err)
 			}
+			var src LayerSource = img
+			if verifier != nil {
+				src = &VerifiedLayerSource{ImageLayerSource: img, Verifier: verifier}
+			}
+			if estargz != nil {
+				l = append(l, &EStargzLayerSource{LayerSource: src, Converter: estargz})
+				continue
+			}
 			l = append(l, src)
 		default:
 			return nil, xerrors.Errorf("unknown static layer type: %s", sl.Type)
@@ -73,6 +81,8 @@ type Registry struct {
 	Resolver       ResolverProvider
 	Store          BlobStore
 	IPFS           *IPFSBlobCache
+	EStargz        *EStargzConverter
+	Verifier       *Verifier
 	LayerSource    LayerSource
 	ConfigModifier ConfigModifier
 	SpecProvider   map[string]ImageSpecProvider
@@ -129,6 +139,34 @@ func NewRegistry(cfg config.Config, newResolver ResolverProvider, reg prometheus
 		return nil, err
 	}
 
+	var estargz *EStargzConverter
+	if cfg.EStargz != nil && cfg.EStargz.Enabled {
+		storePath := cfg.EStargz.Store
+		if storePath == "" {
+			storePath = filepath.Join(cfg.Store, "estargz")
+		}
+		estargzStore, err := NewEStargzStore(storePath, cfg.EStargz.MaxStoreSize)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create eStargz store: %w", err)
+		}
+		go estargzStore.StartGC(context.Background())
+
+		estargz, err = NewEStargzConverter(estargzStore)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create eStargz converter: %w", err)
+		}
+		log.WithField("storePath", storePath).Info("converting base and IDE layers to eStargz")
+	}
+
+	var verifier *Verifier
+	if cfg.Verification != nil && cfg.Verification.Enabled {
+		verifier, err = NewVerifier(cfg.Verification, newResolver)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create image verifier: %w", err)
+		}
+		log.WithField("policies", len(cfg.Verification.Policies)).Info("verifying image signatures")
+	}
+
 	var layerSources []LayerSource
 
 	// static layers
@@ -136,7 +174,7 @@ func NewRegistry(cfg config.Config, newResolver ResolverProvider, reg prometheus
 	staticLayer := NewRevisioningLayerSource(CompositeLayerSource{})
 	layerSources = append(layerSources, staticLayer)
 	if len(cfg.StaticLayer) > 0 {
-		l, err := buildStaticLayer(ctx, cfg.StaticLayer, newResolver)
+		l, err := buildStaticLayer(ctx, cfg.StaticLayer, newResolver, estargz, verifier)
 		if err != nil {
 			return nil, err
 		}
@@ -153,7 +191,12 @@ func NewRegistry(cfg config.Config, newResolver ResolverProvider, reg prometheus
 	if err != nil {
 		return nil, err
 	}
-	layerSources = append(layerSources, ideLayerSource)
+	ideLayerSource.Verifier = verifier
+	if estargz != nil {
+		layerSources = append(layerSources, &EStargzLayerSource{LayerSource: ideLayerSource, Converter: estargz})
+	} else {
+		layerSources = append(layerSources, ideLayerSource)
+	}
 
 	// content layer
 	clsrc, err := NewContentLayerSource()
@@ -235,6 +278,8 @@ func NewRegistry(cfg config.Config, newResolver ResolverProvider, reg prometheus
 		Resolver:          newResolver,
 		Store:             mfStore,
 		IPFS:              ipfs,
+		EStargz:           estargz,
+		Verifier:          verifier,
 		SpecProvider:      specProvider,
 		LayerSource:       layerSource,
 		staticLayerSource: staticLayer,
@@ -307,7 +352,7 @@ func getRedisClient(cfg *config.RedisCacheConfig) (*redis.Client, error) {
 
 // UpdateStaticLayer updates the static layer a registry-facade adds
 func (reg *Registry) UpdateStaticLayer(ctx context.Context, cfg []config.StaticLayerCfg) error {
-	l, err := buildStaticLayer(ctx, cfg, reg.Resolver)
+	l, err := buildStaticLayer(ctx, cfg, reg.Resolver, reg.EStargz, reg.Verifier)
 	if err != nil {
 		return err
 	}
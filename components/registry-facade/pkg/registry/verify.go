@@ -0,0 +1,514 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/remotes"
+	"github.com/docker/distribution/reference"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/registry-facade/api"
+	"github.com/gitpod-io/gitpod/registry-facade/api/config"
+)
+
+const (
+	cosignSimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	cosignSignatureAnnotation    = "dev.cosignproject.cosign/signature"
+	cosignSignatureType          = "cosign container image signature"
+	dsseEnvelopeMediaType        = "application/vnd.dsse.envelope.v1+json"
+	inTotoPayloadType            = "application/vnd.in-toto+json"
+
+	// maxSignatureBlobSize limits the size of signature manifests and payloads we download
+	maxSignatureBlobSize = 4 * 1024 * 1024
+	// maxReferrers limits the number of referrers of an image we look at
+	maxReferrers = 32
+
+	verifiedTTL = 1 * time.Hour
+	failedTTL   = 1 * time.Minute
+	// verificationTimeout is the time the verification of an image may take
+	verificationTimeout = 1 * time.Minute
+)
+
+// VerificationError is returned when an image fails verification
+type VerificationError struct {
+	Ref    string
+	Digest digest.Digest
+	Reason string
+}
+
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("image %s@%s failed signature verification: %s", e.Ref, e.Digest, e.Reason)
+}
+
+type trustPolicy struct {
+	Scope        string
+	Mode         config.VerificationMode
+	Keys         []crypto.PublicKey
+	Attestations []string
+}
+
+func (p *trustPolicy) matches(name string) bool {
+	return p.Scope == "*" || name == p.Scope || strings.HasPrefix(name, p.Scope+"/")
+}
+
+type verificationResult struct {
+	Err     error
+	Expires time.Time
+}
+
+// Verifier verifies cosign signatures and in-toto attestations of images against trust policies.
+// Signatures and attestations are discovered using the OCI referrers tag schema (sha256-<digest>)
+// and the tags cosign uses (sha256-<digest>.sig and sha256-<digest>.att).
+type Verifier struct {
+	Resolver ResolverProvider
+
+	policies []*trustPolicy
+	// cache maps name@digest to a verificationResult
+	cache *lru.Cache
+	group singleflight.Group
+}
+
+// NewVerifier creates a new verifier and loads the public keys of the trust policies
+func NewVerifier(cfg *config.VerificationConfig, resolver ResolverProvider) (*Verifier, error) {
+	scopes := make(map[string]struct{}, len(cfg.Policies))
+	policies := make([]*trustPolicy, 0, len(cfg.Policies))
+	for _, p := range cfg.Policies {
+		if p.Scope == "" {
+			return nil, xerrors.Errorf("trust policy has no scope")
+		}
+		if _, exists := scopes[p.Scope]; exists {
+			return nil, xerrors.Errorf("duplicate trust policy for %s", p.Scope)
+		}
+		scopes[p.Scope] = struct{}{}
+
+		policy := &trustPolicy{
+			Scope:        strings.TrimSuffix(p.Scope, "/"),
+			Mode:         p.Mode,
+			Attestations: p.Attestations,
+		}
+		switch policy.Mode {
+		case "":
+			policy.Mode = config.VerificationModeEnforce
+		case config.VerificationModeEnforce, config.VerificationModeWarn, config.VerificationModeSkip:
+		default:
+			return nil, xerrors.Errorf("trust policy for %s has unknown mode: %s", p.Scope, p.Mode)
+		}
+		for _, fn := range p.PublicKeys {
+			key, err := loadPublicKey(fn)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot load public key for %s: %w", p.Scope, err)
+			}
+			policy.Keys = append(policy.Keys, key)
+		}
+		if policy.Mode != config.VerificationModeSkip && len(policy.Keys) == 0 {
+			return nil, xerrors.Errorf("trust policy for %s has no public keys", p.Scope)
+		}
+		policies = append(policies, policy)
+	}
+	// the most specific policy comes first, the catch-all policy last
+	sort.SliceStable(policies, func(i, j int) bool {
+		if policies[i].Scope == "*" || policies[j].Scope == "*" {
+			return policies[j].Scope == "*" && policies[i].Scope != "*"
+		}
+		return len(policies[i].Scope) > len(policies[j].Scope)
+	})
+
+	cache, err := lru.New(4096)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Verifier{
+		Resolver: resolver,
+		policies: policies,
+		cache:    cache,
+	}, nil
+}
+
+func loadPublicKey(fn string) (crypto.PublicKey, error) {
+	fc, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(fc)
+	if block == nil {
+		return nil, xerrors.Errorf("%s contains no PEM data", fn)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot parse %s: %w", fn, err)
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, xerrors.Errorf("%s contains an unsupported key type %T", fn, key)
+	}
+}
+
+func (v *Verifier) policyFor(name string) *trustPolicy {
+	for _, p := range v.policies {
+		if p.matches(name) {
+			return p
+		}
+	}
+	return nil
+}
+
+// Verify verifies the image ref which was resolved to dgst. Images that fail verification
+// produce a *VerificationError unless the applicable trust policy only warns.
+func (v *Verifier) Verify(ctx context.Context, ref string, dgst digest.Digest) error {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return xerrors.Errorf("cannot parse image reference %s: %w", ref, err)
+	}
+	name := named.Name()
+
+	policy := v.policyFor(name)
+	if policy == nil {
+		return &VerificationError{Ref: name, Digest: dgst, Reason: "no trust policy applies to this image"}
+	}
+	if policy.Mode == config.VerificationModeSkip {
+		return nil
+	}
+
+	key := name + "@" + dgst.String()
+	if res, ok := v.cache.Get(key); ok && time.Now().Before(res.(verificationResult).Expires) {
+		err = res.(verificationResult).Err
+	} else {
+		ch := v.group.DoChan(key, func() (interface{}, error) {
+			// the verification is shared by all callers, hence must not end when the first of them goes away
+			ctx, cancel := context.WithTimeout(context.Background(), verificationTimeout)
+			defer cancel()
+
+			err := v.verify(ctx, name, dgst, policy)
+			var verr *VerificationError
+			if err == nil {
+				v.cache.Add(key, verificationResult{Expires: time.Now().Add(verifiedTTL)})
+			} else if xerrors.As(err, &verr) {
+				v.cache.Add(key, verificationResult{Err: err, Expires: time.Now().Add(failedTTL)})
+			}
+			// other errors, e.g. failing to reach the registry, are not cached
+			return verificationResult{Err: err}, nil
+		})
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case res := <-ch:
+			err = res.Val.(verificationResult).Err
+		}
+	}
+
+	if err != nil && policy.Mode == config.VerificationModeWarn {
+		log.WithError(err).WithField("ref", ref).WithField("scope", policy.Scope).Warn("image failed verification - serving it anyways")
+		return nil
+	}
+	return err
+}
+
+// VerifiedLayerSource provides the layers of an image only if the image passes verification.
+// The image is verified at the digest its layers were taken from, rather than by re-resolving its reference,
+// such that the layers we serve are the ones that were verified.
+type VerifiedLayerSource struct {
+	*ImageLayerSource
+	Verifier *Verifier
+}
+
+// Envs returns the list of env modifiers of the verified image
+func (src *VerifiedLayerSource) Envs(ctx context.Context, spec *api.ImageSpec) ([]EnvModifier, error) {
+	err := src.Verifier.Verify(ctx, src.Ref, src.Digest)
+	if err != nil {
+		return nil, err
+	}
+	return src.ImageLayerSource.Envs(ctx, spec)
+}
+
+// GetLayer returns the layers of the verified image
+func (src *VerifiedLayerSource) GetLayer(ctx context.Context, spec *api.ImageSpec) ([]AddonLayer, error) {
+	err := src.Verifier.Verify(ctx, src.Ref, src.Digest)
+	if err != nil {
+		return nil, err
+	}
+	return src.ImageLayerSource.GetLayer(ctx, spec)
+}
+
+func (v *Verifier) verify(ctx context.Context, name string, dgst digest.Digest, policy *trustPolicy) error {
+	resolver := v.Resolver()
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return err
+	}
+	manifests, err := fetchSignatureManifests(ctx, resolver, fetcher, name, dgst)
+	if err != nil {
+		return xerrors.Errorf("cannot fetch signatures of %s@%s: %w", name, dgst, err)
+	}
+
+	var (
+		signed   bool
+		attested = make(map[string]struct{})
+	)
+	for _, mf := range manifests {
+		for _, l := range mf.Layers {
+			switch l.MediaType {
+			case cosignSimpleSigningMediaType:
+				if signed {
+					continue
+				}
+				payload, err := fetchSignatureBlob(ctx, fetcher, l)
+				if err != nil {
+					return err
+				}
+				err = verifySimpleSigning(policy.Keys, payload, l.Annotations[cosignSignatureAnnotation], dgst)
+				if err != nil {
+					log.WithError(err).WithField("ref", name).WithField("digest", dgst).WithField("signature", l.Digest).Debug("ignoring signature")
+					continue
+				}
+				signed = true
+			case dsseEnvelopeMediaType:
+				if len(policy.Attestations) == 0 {
+					continue
+				}
+				envelope, err := fetchSignatureBlob(ctx, fetcher, l)
+				if err != nil {
+					return err
+				}
+				predicateType, err := verifyAttestation(policy.Keys, envelope, dgst)
+				if err != nil {
+					log.WithError(err).WithField("ref", name).WithField("digest", dgst).WithField("attestation", l.Digest).Debug("ignoring attestation")
+					continue
+				}
+				attested[predicateType] = struct{}{}
+			}
+		}
+	}
+
+	if !signed {
+		return &VerificationError{Ref: name, Digest: dgst, Reason: "no valid signature by a trusted key"}
+	}
+	var missing []string
+	for _, p := range policy.Attestations {
+		if _, ok := attested[p]; !ok {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) > 0 {
+		return &VerificationError{Ref: name, Digest: dgst, Reason: fmt.Sprintf("no valid attestation by a trusted key for %s", strings.Join(missing, ", "))}
+	}
+
+	return nil
+}
+
+// fetchSignatureManifests downloads the manifests of all signatures and attestations of an image
+func fetchSignatureManifests(ctx context.Context, resolver remotes.Resolver, fetcher remotes.Fetcher, name string, dgst digest.Digest) ([]ociv1.Manifest, error) {
+	tag := dgst.Algorithm().String() + "-" + dgst.Encoded()
+
+	var res []ociv1.Manifest
+	for _, t := range []string{tag, tag + ".sig", tag + ".att"} {
+		_, desc, err := resolver.Resolve(ctx, name+":"+t)
+		if errdefs.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		buf, err := fetchSignatureBlob(ctx, fetcher, desc)
+		if err != nil {
+			return nil, err
+		}
+
+		switch desc.MediaType {
+		case images.MediaTypeDockerSchema2ManifestList, ociv1.MediaTypeImageIndex:
+			var index ociv1.Index
+			err = json.Unmarshal(buf, &index)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot unmarshal referrers of %s: %w", name, err)
+			}
+			for i, md := range index.Manifests {
+				if i >= maxReferrers {
+					log.WithField("ref", name).WithField("digest", dgst).Warn("image has too many referrers - ignoring the remainder")
+					break
+				}
+				buf, err := fetchSignatureBlob(ctx, fetcher, md)
+				if err != nil {
+					return nil, err
+				}
+				var mf ociv1.Manifest
+				err = json.Unmarshal(buf, &mf)
+				if err != nil {
+					return nil, xerrors.Errorf("cannot unmarshal referrer %s of %s: %w", md.Digest, name, err)
+				}
+				res = append(res, mf)
+			}
+		case images.MediaTypeDockerSchema2Manifest, ociv1.MediaTypeImageManifest:
+			var mf ociv1.Manifest
+			err = json.Unmarshal(buf, &mf)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot unmarshal %s:%s: %w", name, t, err)
+			}
+			res = append(res, mf)
+		default:
+			log.WithField("ref", name+":"+t).WithField("mediaType", desc.MediaType).Debug("ignoring signature tag with unsupported media type")
+		}
+	}
+	return res, nil
+}
+
+func fetchSignatureBlob(ctx context.Context, fetcher remotes.Fetcher, desc ociv1.Descriptor) ([]byte, error) {
+	if desc.Size > maxSignatureBlobSize {
+		return nil, xerrors.Errorf("%s is too large (%d bytes)", desc.Digest, desc.Size)
+	}
+
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot fetch %s: %w", desc.Digest, err)
+	}
+	defer rc.Close()
+
+	buf, err := io.ReadAll(io.LimitReader(rc, maxSignatureBlobSize+1))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot fetch %s: %w", desc.Digest, err)
+	}
+	if len(buf) > maxSignatureBlobSize {
+		return nil, xerrors.Errorf("%s is too large", desc.Digest)
+	}
+	if act := digest.FromBytes(buf); act != desc.Digest {
+		return nil, xerrors.Errorf("%s has digest %s", desc.Digest, act)
+	}
+	return buf, nil
+}
+
+// verifySimpleSigning verifies a cosign signature, i.e. a signed simple signing payload which refers to the image
+func verifySimpleSigning(keys []crypto.PublicKey, payload []byte, signature string, dgst digest.Digest) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return xerrors.Errorf("cannot decode signature: %w", err)
+	}
+	if !verifySignature(keys, payload, sig) {
+		return xerrors.Errorf("not signed by a trusted key")
+	}
+
+	var ss struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+			Type string `json:"type"`
+		} `json:"critical"`
+	}
+	err = json.Unmarshal(payload, &ss)
+	if err != nil {
+		return xerrors.Errorf("cannot unmarshal payload: %w", err)
+	}
+	if ss.Critical.Type != cosignSignatureType {
+		return xerrors.Errorf("unsupported signature type: %s", ss.Critical.Type)
+	}
+	if ss.Critical.Image.DockerManifestDigest != dgst.String() {
+		return xerrors.Errorf("signature is for %s", ss.Critical.Image.DockerManifestDigest)
+	}
+	return nil
+}
+
+// verifyAttestation verifies a DSSE envelope which contains an in-toto statement about the image
+// and returns the predicate type of the statement.
+func verifyAttestation(keys []crypto.PublicKey, envelope []byte, dgst digest.Digest) (predicateType string, err error) {
+	var env struct {
+		PayloadType string `json:"payloadType"`
+		Payload     string `json:"payload"`
+		Signatures  []struct {
+			Sig string `json:"sig"`
+		} `json:"signatures"`
+	}
+	err = json.Unmarshal(envelope, &env)
+	if err != nil {
+		return "", xerrors.Errorf("cannot unmarshal envelope: %w", err)
+	}
+	if env.PayloadType != inTotoPayloadType {
+		return "", xerrors.Errorf("unsupported payload type: %s", env.PayloadType)
+	}
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return "", xerrors.Errorf("cannot decode payload: %w", err)
+	}
+
+	// DSSE signs the pre-authentication encoding of the payload, not the payload itself
+	pae := []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(env.PayloadType), env.PayloadType, len(payload), payload))
+	var signed bool
+	for _, s := range env.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			continue
+		}
+		if verifySignature(keys, pae, sig) {
+			signed = true
+			break
+		}
+	}
+	if !signed {
+		return "", xerrors.Errorf("not signed by a trusted key")
+	}
+
+	var statement struct {
+		PredicateType string `json:"predicateType"`
+		Subject       []struct {
+			Digest map[string]string `json:"digest"`
+		} `json:"subject"`
+	}
+	err = json.Unmarshal(payload, &statement)
+	if err != nil {
+		return "", xerrors.Errorf("cannot unmarshal statement: %w", err)
+	}
+	for _, s := range statement.Subject {
+		if s.Digest[dgst.Algorithm().String()] == dgst.Encoded() {
+			return statement.PredicateType, nil
+		}
+	}
+	return "", xerrors.Errorf("statement is not about %s", dgst)
+}
+
+func verifySignature(keys []crypto.PublicKey, payload, sig []byte) bool {
+	hash := sha256.Sum256(payload)
+	for _, key := range keys {
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(k, hash[:], sig) {
+				return true
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], sig) == nil {
+				return true
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, payload, sig) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,397 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/registry-facade/api/config"
+)
+
+type testSigningKey struct {
+	Key *ecdsa.PrivateKey
+	Fn  string
+}
+
+func newTestSigningKey(t *testing.T) *testSigningKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := filepath.Join(t.TempDir(), "cosign.pub")
+	err = os.WriteFile(fn, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub}), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testSigningKey{Key: key, Fn: fn}
+}
+
+func (k *testSigningKey) Sign(t *testing.T, payload []byte) string {
+	hash := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, k.Key, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+// testSignatureRegistry is a resolver serving images and their signatures from memory
+type testSignatureRegistry struct {
+	Tags  map[string]ociv1.Descriptor
+	Blobs map[digest.Digest][]byte
+}
+
+func newTestSignatureRegistry() *testSignatureRegistry {
+	return &testSignatureRegistry{
+		Tags:  make(map[string]ociv1.Descriptor),
+		Blobs: make(map[digest.Digest][]byte),
+	}
+}
+
+func (r *testSignatureRegistry) Add(t *testing.T, mediaType string, obj interface{}) ociv1.Descriptor {
+	var (
+		buf []byte
+		err error
+	)
+	if b, ok := obj.([]byte); ok {
+		buf = b
+	} else {
+		buf, err = json.Marshal(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	desc := ociv1.Descriptor{MediaType: mediaType, Digest: digest.FromBytes(buf), Size: int64(len(buf))}
+	r.Blobs[desc.Digest] = buf
+	return desc
+}
+
+func (r *testSignatureRegistry) Resolve(ctx context.Context, ref string) (name string, desc ociv1.Descriptor, err error) {
+	desc, ok := r.Tags[ref]
+	if !ok {
+		return "", ociv1.Descriptor{}, xerrors.Errorf("%s: %w", ref, errdefs.ErrNotFound)
+	}
+	return ref, desc, nil
+}
+
+func (r *testSignatureRegistry) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	return r, nil
+}
+
+func (r *testSignatureRegistry) Pusher(ctx context.Context, ref string) (remotes.Pusher, error) {
+	return nil, xerrors.Errorf("not implemented")
+}
+
+func (r *testSignatureRegistry) Fetch(ctx context.Context, desc ociv1.Descriptor) (io.ReadCloser, error) {
+	buf, ok := r.Blobs[desc.Digest]
+	if !ok {
+		return nil, errdefs.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(buf)), nil
+}
+
+func (r *testSignatureRegistry) Sign(t *testing.T, key *testSigningKey, name string, dgst digest.Digest) ociv1.Descriptor {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"%s"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, name, dgst))
+	layer := r.Add(t, cosignSimpleSigningMediaType, payload)
+	layer.Annotations = map[string]string{cosignSignatureAnnotation: key.Sign(t, payload)}
+	return r.manifest(t, layer)
+}
+
+func (r *testSignatureRegistry) Attest(t *testing.T, key *testSigningKey, predicateType string, dgst digest.Digest) ociv1.Descriptor {
+	statement, _ := json.Marshal(map[string]interface{}{
+		"_type":         "https://in-toto.io/Statement/v0.1",
+		"predicateType": predicateType,
+		"subject": []map[string]interface{}{
+			{"name": "image", "digest": map[string]string{"sha256": dgst.Encoded()}},
+		},
+		"predicate": map[string]interface{}{},
+	})
+	pae := []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(inTotoPayloadType), inTotoPayloadType, len(statement), statement))
+	envelope, _ := json.Marshal(map[string]interface{}{
+		"payloadType": inTotoPayloadType,
+		"payload":     base64.StdEncoding.EncodeToString(statement),
+		"signatures":  []map[string]string{{"keyid": "", "sig": key.Sign(t, pae)}},
+	})
+	return r.manifest(t, r.Add(t, dsseEnvelopeMediaType, envelope))
+}
+
+func (r *testSignatureRegistry) manifest(t *testing.T, layers ...ociv1.Descriptor) ociv1.Descriptor {
+	return r.Add(t, ociv1.MediaTypeImageManifest, ociv1.Manifest{
+		MediaType: ociv1.MediaTypeImageManifest,
+		Config:    r.Add(t, "application/vnd.oci.image.config.v1+json", []byte("{}")),
+		Layers:    layers,
+	})
+}
+
+func TestVerifier(t *testing.T) {
+	const (
+		name       = "docker.io/gitpod/workspace-full"
+		provenance = "https://slsa.dev/provenance/v0.2"
+	)
+	var (
+		trusted   = newTestSigningKey(t)
+		untrusted = newTestSigningKey(t)
+		dgst      = digest.FromString("image")
+		tag       = name + ":sha256-" + dgst.Encoded()
+	)
+
+	type Expectation struct {
+		Error    string
+		Verified bool
+	}
+	tests := []struct {
+		Name        string
+		Policies    []config.TrustPolicy
+		Prep        func(t *testing.T, reg *testSignatureRegistry)
+		Ref         string
+		Expectation Expectation
+	}{
+		{
+			Name: "cosign signature",
+			Prep: func(t *testing.T, reg *testSignatureRegistry) {
+				reg.Tags[tag+".sig"] = reg.Sign(t, trusted, name, dgst)
+			},
+			Expectation: Expectation{Verified: true},
+		},
+		{
+			Name: "oci referrers",
+			Prep: func(t *testing.T, reg *testSignatureRegistry) {
+				reg.Tags[tag] = reg.Add(t, ociv1.MediaTypeImageIndex, ociv1.Index{
+					Manifests: []ociv1.Descriptor{reg.Sign(t, untrusted, name, dgst), reg.Sign(t, trusted, name, dgst)},
+				})
+			},
+			Expectation: Expectation{Verified: true},
+		},
+		{
+			Name:        "unsigned",
+			Expectation: Expectation{Error: "image docker.io/gitpod/workspace-full@" + dgst.String() + " failed signature verification: no valid signature by a trusted key"},
+		},
+		{
+			Name: "untrusted key",
+			Prep: func(t *testing.T, reg *testSignatureRegistry) {
+				reg.Tags[tag+".sig"] = reg.Sign(t, untrusted, name, dgst)
+			},
+			Expectation: Expectation{Error: "image docker.io/gitpod/workspace-full@" + dgst.String() + " failed signature verification: no valid signature by a trusted key"},
+		},
+		{
+			Name: "signature of other image",
+			Prep: func(t *testing.T, reg *testSignatureRegistry) {
+				reg.Tags[tag+".sig"] = reg.Sign(t, trusted, name, digest.FromString("other"))
+			},
+			Expectation: Expectation{Error: "image docker.io/gitpod/workspace-full@" + dgst.String() + " failed signature verification: no valid signature by a trusted key"},
+		},
+		{
+			Name:        "no policy",
+			Ref:         "eu.gcr.io/gitpod/workspace-full",
+			Expectation: Expectation{Error: "image eu.gcr.io/gitpod/workspace-full@" + dgst.String() + " failed signature verification: no trust policy applies to this image"},
+		},
+		{
+			Name: "warn",
+			Policies: []config.TrustPolicy{
+				{Scope: "docker.io", Mode: config.VerificationModeWarn, PublicKeys: []string{trusted.Fn}},
+			},
+			Expectation: Expectation{Verified: true},
+		},
+		{
+			Name: "most specific policy applies",
+			Policies: []config.TrustPolicy{
+				{Scope: "*", PublicKeys: []string{trusted.Fn}},
+				{Scope: "docker.io/gitpod", Mode: config.VerificationModeSkip},
+				{Scope: "docker.io", PublicKeys: []string{trusted.Fn}},
+			},
+			Expectation: Expectation{Verified: true},
+		},
+		{
+			Name: "scope matches path segments only",
+			Policies: []config.TrustPolicy{
+				{Scope: "docker.io/git", Mode: config.VerificationModeSkip},
+			},
+			Expectation: Expectation{Error: "image docker.io/gitpod/workspace-full@" + dgst.String() + " failed signature verification: no trust policy applies to this image"},
+		},
+		{
+			Name: "attestation",
+			Policies: []config.TrustPolicy{
+				{Scope: "docker.io", PublicKeys: []string{trusted.Fn}, Attestations: []string{provenance}},
+			},
+			Prep: func(t *testing.T, reg *testSignatureRegistry) {
+				reg.Tags[tag+".sig"] = reg.Sign(t, trusted, name, dgst)
+				reg.Tags[tag+".att"] = reg.Attest(t, trusted, provenance, dgst)
+			},
+			Expectation: Expectation{Verified: true},
+		},
+		{
+			Name: "missing attestation",
+			Policies: []config.TrustPolicy{
+				{Scope: "docker.io", PublicKeys: []string{trusted.Fn}, Attestations: []string{provenance}},
+			},
+			Prep: func(t *testing.T, reg *testSignatureRegistry) {
+				reg.Tags[tag+".sig"] = reg.Sign(t, trusted, name, dgst)
+				reg.Tags[tag+".att"] = reg.Attest(t, untrusted, provenance, dgst)
+			},
+			Expectation: Expectation{Error: "image docker.io/gitpod/workspace-full@" + dgst.String() + " failed signature verification: no valid attestation by a trusted key for " + provenance},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			reg := newTestSignatureRegistry()
+			if test.Prep != nil {
+				test.Prep(t, reg)
+			}
+			policies := test.Policies
+			if policies == nil {
+				policies = []config.TrustPolicy{{Scope: "docker.io", Mode: config.VerificationModeEnforce, PublicKeys: []string{trusted.Fn}}}
+			}
+			ref := test.Ref
+			if ref == "" {
+				ref = "gitpod/workspace-full:latest"
+			}
+
+			verifier, err := NewVerifier(&config.VerificationConfig{Enabled: true, Policies: policies}, func() remotes.Resolver { return reg })
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var act Expectation
+			err = verifier.Verify(context.Background(), ref, dgst)
+			if err != nil {
+				act.Error = err.Error()
+				var verr *VerificationError
+				if !xerrors.As(err, &verr) {
+					t.Errorf("expected a VerificationError, got %T", err)
+				}
+			} else {
+				act.Verified = true
+			}
+			if act != test.Expectation {
+				t.Errorf("unexpected result: want %+v, got %+v", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestVerifierCache(t *testing.T) {
+	key := newTestSigningKey(t)
+	reg := newTestSignatureRegistry()
+	dgst := digest.FromString("image")
+	reg.Tags["docker.io/library/alpine:sha256-"+dgst.Encoded()+".sig"] = reg.Sign(t, key, "docker.io/library/alpine", dgst)
+
+	verifier, err := NewVerifier(&config.VerificationConfig{
+		Enabled:  true,
+		Policies: []config.TrustPolicy{{Scope: "*", PublicKeys: []string{key.Fn}}},
+	}, func() remotes.Resolver { return reg })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = verifier.Verify(context.Background(), "alpine", dgst)
+	if err != nil {
+		t.Fatalf("cannot verify image: %v", err)
+	}
+
+	// the result is cached, hence removing the signature must not change the outcome
+	reg.Tags = map[string]ociv1.Descriptor{}
+	err = verifier.Verify(context.Background(), "alpine@"+dgst.String(), dgst)
+	if err != nil {
+		t.Errorf("verification result was not cached: %v", err)
+	}
+
+	// other images are not affected by the cache
+	err = verifier.Verify(context.Background(), "alpine", digest.FromString("other"))
+	if err == nil {
+		t.Errorf("expected an error for an unsigned image")
+	}
+}
+
+func TestVerifiedLayerSource(t *testing.T) {
+	key := newTestSigningKey(t)
+	reg := newTestSignatureRegistry()
+	signed := digest.FromString("signed")
+	reg.Tags["docker.io/library/alpine:sha256-"+signed.Encoded()+".sig"] = reg.Sign(t, key, "docker.io/library/alpine", signed)
+	// the tag points to the signed image, which must not vouch for layers taken from another digest
+	reg.Tags["docker.io/library/alpine:latest"] = ociv1.Descriptor{MediaType: ociv1.MediaTypeImageManifest, Digest: signed}
+
+	verifier, err := NewVerifier(&config.VerificationConfig{
+		Enabled:  true,
+		Policies: []config.TrustPolicy{{Scope: "*", PublicKeys: []string{key.Fn}}},
+	}, func() remotes.Resolver { return reg })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layers := []imagebackedLayer{{AddonLayer: AddonLayer{Descriptor: ociv1.Descriptor{Digest: digest.FromString("layer")}}}}
+	src := &VerifiedLayerSource{
+		ImageLayerSource: &ImageLayerSource{Ref: "alpine:latest", Digest: signed, layers: layers},
+		Verifier:         verifier,
+	}
+	res, err := src.GetLayer(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("cannot get layers of a signed image: %v", err)
+	}
+	if len(res) != 1 {
+		t.Errorf("expected one layer, got %d", len(res))
+	}
+
+	src.ImageLayerSource.Digest = digest.FromString("unsigned")
+	_, err = src.GetLayer(context.Background(), nil)
+	var verr *VerificationError
+	if !xerrors.As(err, &verr) {
+		t.Errorf("expected a verification error for the layers of an unsigned image, got %v", err)
+	}
+	_, err = src.Envs(context.Background(), nil)
+	if !xerrors.As(err, &verr) {
+		t.Errorf("expected a verification error for the envs of an unsigned image, got %v", err)
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	key := newTestSigningKey(t)
+
+	tests := []struct {
+		Name     string
+		Policies []config.TrustPolicy
+		Error    bool
+	}{
+		{Name: "valid", Policies: []config.TrustPolicy{{Scope: "*", PublicKeys: []string{key.Fn}}, {Scope: "docker.io", Mode: config.VerificationModeSkip}}},
+		{Name: "no keys", Policies: []config.TrustPolicy{{Scope: "*"}}, Error: true},
+		{Name: "missing key", Policies: []config.TrustPolicy{{Scope: "*", PublicKeys: []string{"/does/not/exist"}}}, Error: true},
+		{Name: "unknown mode", Policies: []config.TrustPolicy{{Scope: "*", Mode: "foo", PublicKeys: []string{key.Fn}}}, Error: true},
+		{Name: "no scope", Policies: []config.TrustPolicy{{PublicKeys: []string{key.Fn}}}, Error: true},
+		{Name: "duplicate scope", Policies: []config.TrustPolicy{{Scope: "*", PublicKeys: []string{key.Fn}}, {Scope: "*", PublicKeys: []string{key.Fn}}}, Error: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := NewVerifier(&config.VerificationConfig{Enabled: true, Policies: test.Policies}, nil)
+			if (err != nil) != test.Error {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
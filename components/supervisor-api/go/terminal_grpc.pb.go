@@ -45,6 +45,9 @@ type TerminalServiceClient interface {
 	SetTitle(ctx context.Context, in *SetTerminalTitleRequest, opts ...grpc.CallOption) (*SetTerminalTitleResponse, error)
 	// UpdateAnnotations updates the terminal's annotations
 	UpdateAnnotations(ctx context.Context, in *UpdateTerminalAnnotationsRequest, opts ...grpc.CallOption) (*UpdateTerminalAnnotationsResponse, error)
+	// GetRecording returns the asciicast v2 recording of a terminal's output.
+	// Recordings remain available after the terminal was closed.
+	GetRecording(ctx context.Context, in *GetTerminalRecordingRequest, opts ...grpc.CallOption) (TerminalService_GetRecordingClient, error)
 }
 
 type terminalServiceClient struct {
@@ -159,6 +162,38 @@ func (c *terminalServiceClient) UpdateAnnotations(ctx context.Context, in *Updat
 	return out, nil
 }
 
+func (c *terminalServiceClient) GetRecording(ctx context.Context, in *GetTerminalRecordingRequest, opts ...grpc.CallOption) (TerminalService_GetRecordingClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TerminalService_ServiceDesc.Streams[1], "/supervisor.TerminalService/GetRecording", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &terminalServiceGetRecordingClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TerminalService_GetRecordingClient interface {
+	Recv() (*GetTerminalRecordingResponse, error)
+	grpc.ClientStream
+}
+
+type terminalServiceGetRecordingClient struct {
+	grpc.ClientStream
+}
+
+func (x *terminalServiceGetRecordingClient) Recv() (*GetTerminalRecordingResponse, error) {
+	m := new(GetTerminalRecordingResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TerminalServiceServer is the server API for TerminalService service.
 // All implementations must embed UnimplementedTerminalServiceServer
 // for forward compatibility
@@ -182,6 +217,9 @@ type TerminalServiceServer interface {
 	SetTitle(context.Context, *SetTerminalTitleRequest) (*SetTerminalTitleResponse, error)
 	// UpdateAnnotations updates the terminal's annotations
 	UpdateAnnotations(context.Context, *UpdateTerminalAnnotationsRequest) (*UpdateTerminalAnnotationsResponse, error)
+	// GetRecording returns the asciicast v2 recording of a terminal's output.
+	// Recordings remain available after the terminal was closed.
+	GetRecording(*GetTerminalRecordingRequest, TerminalService_GetRecordingServer) error
 	mustEmbedUnimplementedTerminalServiceServer()
 }
 
@@ -216,6 +254,9 @@ func (UnimplementedTerminalServiceServer) SetTitle(context.Context, *SetTerminal
 func (UnimplementedTerminalServiceServer) UpdateAnnotations(context.Context, *UpdateTerminalAnnotationsRequest) (*UpdateTerminalAnnotationsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateAnnotations not implemented")
 }
+func (UnimplementedTerminalServiceServer) GetRecording(*GetTerminalRecordingRequest, TerminalService_GetRecordingServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetRecording not implemented")
+}
 func (UnimplementedTerminalServiceServer) mustEmbedUnimplementedTerminalServiceServer() {}
 
 // UnsafeTerminalServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -394,6 +435,27 @@ func _TerminalService_UpdateAnnotations_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TerminalService_GetRecording_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTerminalRecordingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TerminalServiceServer).GetRecording(m, &terminalServiceGetRecordingServer{stream})
+}
+
+type TerminalService_GetRecordingServer interface {
+	Send(*GetTerminalRecordingResponse) error
+	grpc.ServerStream
+}
+
+type terminalServiceGetRecordingServer struct {
+	grpc.ServerStream
+}
+
+func (x *terminalServiceGetRecordingServer) Send(m *GetTerminalRecordingResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // TerminalService_ServiceDesc is the grpc.ServiceDesc for TerminalService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -440,6 +502,11 @@ var TerminalService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TerminalService_Listen_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetRecording",
+			Handler:       _TerminalService_GetRecording_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "terminal.proto",
 }
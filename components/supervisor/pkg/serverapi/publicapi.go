@@ -33,6 +33,8 @@ type APIInterface interface {
 	GetToken(ctx context.Context, query *gitpod.GetTokenSearchOptions) (res *gitpod.Token, err error)
 	OpenPort(ctx context.Context, port *gitpod.WorkspaceInstancePort) (res *gitpod.WorkspaceInstancePort, err error)
 	InstanceUpdates(ctx context.Context) (<-chan *gitpod.WorkspaceInstance, error)
+	SetWorkspaceTimeout(ctx context.Context, duration time.Duration) (res *gitpod.SetWorkspaceTimeoutResult, err error)
+	SendHeartBeat(ctx context.Context) (err error)
 
 	// Metrics
 	RegisterMetrics(registry *prometheus.Registry) error
@@ -89,6 +91,8 @@ func NewServerApiService(ctx context.Context, cfg *ServiceConfig, tknsrv api.Tok
 			"function:openPort",
 			"function:trackEvent",
 			"function:getWorkspace",
+			"function:setWorkspaceTimeout",
+			"function:sendHeartBeat",
 		},
 	})
 	if err != nil {
@@ -267,6 +271,32 @@ func (s *Service) OpenPort(ctx context.Context, port *gitpod.WorkspaceInstancePo
 	return port, nil
 }
 
+// SetWorkspaceTimeout sets the timeout of the workspace
+func (s *Service) SetWorkspaceTimeout(ctx context.Context, duration time.Duration) (res *gitpod.SetWorkspaceTimeoutResult, err error) {
+	if s == nil {
+		return nil, errNotConnected
+	}
+	startTime := time.Now()
+	defer func() {
+		s.apiMetrics.ProcessMetrics(false, "SetWorkspaceTimeout", err, startTime)
+	}()
+	return s.gitpodService.SetWorkspaceTimeout(ctx, s.cfg.WorkspaceID, duration)
+}
+
+// SendHeartBeat marks the workspace instance as active
+func (s *Service) SendHeartBeat(ctx context.Context) (err error) {
+	if s == nil {
+		return errNotConnected
+	}
+	startTime := time.Now()
+	defer func() {
+		s.apiMetrics.ProcessMetrics(false, "SendHeartBeat", err, startTime)
+	}()
+	return s.gitpodService.SendHeartBeat(ctx, &gitpod.SendHeartBeatOptions{
+		InstanceID: s.cfg.InstanceID,
+	})
+}
+
 // onInstanceUpdates listen to server and public API instanceUpdates and publish to subscribers once Service created.
 func (s *Service) onInstanceUpdates(ctx context.Context) {
 	errChan := make(chan error)
@@ -281,6 +281,10 @@ type WorkspaceConfig struct {
 	// the in-workspace epxerience.
 	DotfileRepo string `env:"SUPERVISOR_DOTFILE_REPO"`
 
+	// TerminalRecording enables recording of all terminal output to the workspace in asciicast v2 format.
+	// Recordings are part of workspace backups, hence the oldest ones are removed once they grow too large.
+	TerminalRecording bool `env:"SUPERVISOR_TERMINAL_RECORDING"`
+
 	// EnvvarOTS points to a URL from which environment variables for child processes can be downloaded from.
 	// This provides a safer means to transport environment variables compared to shipping them on the Kubernetes pod.
 	//
@@ -319,6 +323,57 @@ type TaskConfig struct {
 	Env      *map[string]interface{} `json:"env,omitempty"`
 	OpenIn   *string                 `json:"openIn,omitempty"`
 	OpenMode *string                 `json:"openMode,omitempty"`
+
+	// DependsOn lists the names of tasks which must be ready before this task starts
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// Readiness determines when this task is ready. Without it, a task is ready once it terminated successfully.
+	Readiness *TaskReadiness `json:"readiness,omitempty"`
+	// Restart determines whether the command is restarted once it terminated
+	Restart *TaskRestart `json:"restart,omitempty"`
+}
+
+// TaskReadiness defines when a task is ready. All configured checks must succeed.
+type TaskReadiness struct {
+	// Port is ready once it accepts TCP connections
+	Port *int `json:"port,omitempty"`
+	// HTTP is ready once a GET request returns a 2xx or 3xx status code
+	HTTP *TaskReadinessHTTP `json:"http,omitempty"`
+	// Exec is ready once the shell command succeeds
+	Exec *string `json:"exec,omitempty"`
+	// Timeout is the time in seconds to wait for the task to become ready
+	Timeout *int `json:"timeout,omitempty"`
+}
+
+// TaskReadinessHTTP defines an HTTP readiness check.
+type TaskReadinessHTTP struct {
+	Port int    `json:"port"`
+	Path string `json:"path,omitempty"`
+}
+
+// TaskRestartPolicy determines when a task command is restarted.
+type TaskRestartPolicy string
+
+const (
+	// TaskRestartNever never restarts the command
+	TaskRestartNever TaskRestartPolicy = "never"
+	// TaskRestartOnFailure restarts the command if it exits with a non-zero exit code
+	TaskRestartOnFailure TaskRestartPolicy = "on-failure"
+	// TaskRestartAlways restarts the command whenever it exits
+	TaskRestartAlways TaskRestartPolicy = "always"
+)
+
+// TaskRestart defines the restart behaviour of a task.
+type TaskRestart struct {
+	Policy TaskRestartPolicy `json:"policy,omitempty"`
+	// MaxRetries limits the number of restarts
+	MaxRetries *int `json:"maxRetries,omitempty"`
+	// Backoff is the time in seconds to wait before the first restart
+	Backoff *int `json:"backoff,omitempty"`
+}
+
+// supervised returns true if the command is restarted in some cases
+func (r *TaskRestart) supervised() bool {
+	return r != nil && (r.Policy == TaskRestartOnFailure || r.Policy == TaskRestartAlways)
 }
 
 // Validate validates this configuration.
@@ -716,6 +716,7 @@ func (is *InfoService) WorkspaceInfo(context.Context, *api.WorkspaceInfoRequest)
 // ControlService implements the supervisor control service.
 type ControlService struct {
 	portsManager *ports.Manager
+	tasks        *tasksManager
 
 	privateKey string
 	publicKey  string
@@ -795,6 +796,21 @@ func (ss *ControlService) CreateSSHKeyPair(context.Context, *api.CreateSSHKeyPai
 	}, err
 }
 
+// RestartTask restarts a workspace task.
+func (c *ControlService) RestartTask(ctx context.Context, req *api.RestartTaskRequest) (*api.RestartTaskResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if c.tasks == nil {
+		return nil, status.Error(codes.Unavailable, "tasks are not available")
+	}
+	err := c.tasks.Restart(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &api.RestartTaskResponse{}, nil
+}
+
 // CreateDebugEnv creates a debug workspace envs
 func (c *ControlService) CreateDebugEnv(ctx context.Context, req *api.CreateDebugEnvRequest) (*api.CreateDebugEnvResponse, error) {
 	var envs []string
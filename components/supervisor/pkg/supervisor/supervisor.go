@@ -50,6 +50,7 @@ import (
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	"github.com/gitpod-io/gitpod/content-service/pkg/executor"
 	"github.com/gitpod-io/gitpod/content-service/pkg/git"
+	"github.com/gitpod-io/gitpod/content-service/pkg/logs"
 	gitpod "github.com/gitpod-io/gitpod/gitpod-protocol"
 	"github.com/gitpod-io/gitpod/supervisor/api"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/activation"
@@ -322,7 +323,11 @@ func Run(options ...RunOption) {
 		}
 	}
 
-	termMux := terminal.NewMux()
+	var termMuxOpts []terminal.MuxOption
+	if cfg.TerminalRecording {
+		termMuxOpts = append(termMuxOpts, terminal.WithRecorder(terminal.NewRecorder(filepath.Join(logs.TerminalStoreLocation, "recordings"), 0)))
+	}
+	termMux := terminal.NewMux(termMuxOpts...)
 	termMuxSrv := terminal.NewMuxTerminalService(termMux)
 	termMuxSrv.DefaultWorkdir = cfg.RepoRoot
 	if cfg.WorkspaceRoot != "" {
@@ -343,6 +348,10 @@ func Run(options ...RunOption) {
 		Gid: gitpodGID,
 	}
 
+	if !cfg.isHeadless() && !opts.RunGP {
+		go watchTimeoutWarnings(ctx, gitpodService, notificationService, termMux)
+	}
+
 	taskManager := newTasksManager(cfg, termMuxSrv, cstate, nil, ideReady, desktopIdeReady)
 
 	willShutdownCtx, fireWillShutdown := context.WithCancel(ctx)
@@ -360,7 +369,7 @@ func Run(options ...RunOption) {
 		RegistrableTokenService{Service: tokenService},
 		notificationService,
 		&InfoService{cfg: cfg, ContentState: cstate},
-		&ControlService{portsManager: portMgmt},
+		&ControlService{portsManager: portMgmt, tasks: taskManager},
 		&portService{portsManager: portMgmt},
 	}
 	apiServices = append(apiServices, additionalServices...)
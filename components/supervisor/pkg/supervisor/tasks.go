@@ -70,6 +70,46 @@ type task struct {
 	successChan chan taskSuccess
 	title       string
 	lastOutput  string
+
+	// deps are the tasks this task depends on
+	deps []*task
+	// readyChan is closed once it is known whether the task became ready
+	readyChan chan struct{}
+	readyOnce sync.Once
+	// readiness is the outcome of the task becoming ready, valid once readyChan is closed
+	readiness taskSuccess
+
+	// restartRequested is set if the task was asked to restart, guarded by tasksManager.mu
+	restartRequested bool
+	// consecutiveRestarts counts restarts of short-lived runs and determines the restart backoff
+	consecutiveRestarts int
+	startedAt           time.Time
+}
+
+// setReady records whether the task became ready. Only the first call has an effect.
+func (t *task) setReady(readiness taskSuccess) {
+	t.readyOnce.Do(func() {
+		t.readiness = readiness
+		close(t.readyChan)
+	})
+}
+
+// reportResult reports the outcome of the task unless an outcome was reported already
+func (t *task) reportResult(res taskSuccess) {
+	select {
+	case t.successChan <- res:
+	default:
+	}
+}
+
+// isReadyDecided returns true if it is known whether the task became ready
+func (t *task) isReadyDecided() bool {
+	select {
+	case <-t.readyChan:
+		return true
+	default:
+		return false
+	}
 }
 
 type headlessTaskProgressReporter interface {
@@ -109,6 +149,9 @@ type tasksManager struct {
 	reporter        headlessTaskProgressReporter
 	ideReady        *ideReadyState
 	desktopIdeReady *ideReadyState
+
+	// ctx is the context tasks run in, valid once ready is closed
+	ctx context.Context
 }
 
 func newTasksManager(config *Config, terminalService *terminal.MuxTerminalService, contentState ContentState, reporter headlessTaskProgressReporter, ideReady *ideReadyState, desktopIdeReady *ideReadyState) *tasksManager {
@@ -223,14 +266,18 @@ func (tm *tasksManager) init(ctx context.Context) {
 			config:      config,
 			successChan: make(chan taskSuccess, 1),
 			title:       presentation.Name,
+			readyChan:   make(chan struct{}),
 		}
 		task.command = getCommand(task, tm.config.isHeadless(), tm.config.isPrebuild(), tm.contentSource, tm.storeLocation)
 		if tm.config.isHeadless() && task.command == "exit" {
 			task.State = api.TaskState_closed
 			task.successChan <- taskSuccessful
+			task.setReady(taskSuccessful)
 		}
 		tm.tasks = append(tm.tasks, task)
 	}
+
+	tm.resolveDependencies()
 }
 
 func (tm *tasksManager) waitForIde(parent context.Context, timeout time.Duration) {
@@ -259,88 +306,18 @@ func (tm *tasksManager) Run(ctx context.Context, wg *sync.WaitGroup, successChan
 	defer wg.Done()
 	defer log.Debug("tasksManager shutdown")
 
+	tm.ctx = ctx
 	tm.init(ctx)
 
 	for _, t := range tm.tasks {
-		if t.State == api.TaskState_closed {
-			continue
-		}
-		taskLog := log.WithField("command", t.command)
-		taskLog.Info("starting a task terminal...")
-		openRequest := &api.OpenTerminalRequest{}
-		if t.config.Env != nil {
-			openRequest.Env = make(map[string]string, len(*t.config.Env))
-			for key, value := range *t.config.Env {
-				// Required check because a string is considered valid JSON (e.g. "hello")
-				// We don't want to marshall basic strings otherwise we get a double quoted environment variable
-				// See: https://github.com/gitpod-io/gitpod/issues/5887
-				if val, ok := value.(string); ok {
-					openRequest.Env[key] = val
-				} else {
-					v, err := json.Marshal(value)
-					if err != nil {
-						taskLog.WithError(err).WithField("key", key).Error("cannot marshal env var")
-					} else {
-						openRequest.Env[key] = string(v)
-					}
-				}
-			}
-		}
-		resp, err := tm.terminalService.OpenWithOptions(ctx, openRequest, terminal.TermOptions{
-			ReadTimeout: 5 * time.Second,
-			Title:       t.title,
-		})
-		if err != nil {
-			taskLog.WithError(err).Error("cannot open new task terminal")
-			t.successChan <- taskFailed("cannot open new task terminal")
-			tm.setTaskState(t, api.TaskState_closed)
+		switch t.State {
+		case api.TaskState_closed:
 			continue
-		}
-
-		taskLog = taskLog.WithField("terminal", resp.Terminal.Alias)
-		term, ok := tm.terminalService.Mux.Get(resp.Terminal.Alias)
-		if !ok {
-			taskLog.Error("cannot find a task terminal")
-			t.successChan <- taskFailed("cannot find a task terminal")
-			tm.setTaskState(t, api.TaskState_closed)
+		case api.TaskState_waiting:
+			go tm.startAfterDependencies(ctx, t)
 			continue
 		}
-
-		taskLog = taskLog.WithField("pid", term.Command.Process.Pid)
-		taskLog.Info("task terminal has been started")
-		tm.updateState(func() bool {
-			t.Terminal = resp.Terminal.Alias
-			t.State = api.TaskState_running
-			return true
-		})
-
-		go func(t *task, term *terminal.Term) {
-			state, err := term.Wait()
-			if state != nil {
-				if state.Success() {
-					t.successChan <- taskSuccessful
-				} else {
-					t.successChan <- taskFailed(state.String())
-				}
-			} else if err != nil {
-				t.successChan <- taskSuccessful
-			} else {
-				msg := "cannot wait for task"
-				if err != nil {
-					msg = err.Error()
-				}
-
-				t.successChan <- taskFailed(fmt.Sprintf("%s: %s", msg, t.lastOutput))
-			}
-			taskLog.Info("task terminal has been closed")
-			tm.setTaskState(t, api.TaskState_closed)
-		}(t, term)
-
-		tm.watch(t, term)
-
-		if t.command != "" {
-			term.PTY.Write([]byte(t.command + "\n"))
-		}
+		tm.startTask(ctx, t)
 	}
 
 	var success taskSuccess
@@ -361,6 +338,106 @@ func (tm *tasksManager) Run(ctx context.Context, wg *sync.WaitGroup, successChan
 	successChan <- success
 }
 
+// startTask opens the task terminal and runs the task command
+func (tm *tasksManager) startTask(ctx context.Context, t *task) {
+	// the command may be replaced once the task exits and restarts, hence we use the one we started with
+	command := t.command
+	taskLog := log.WithField("command", command)
+	taskLog.Info("starting a task terminal...")
+	openRequest := &api.OpenTerminalRequest{}
+	if t.config.Env != nil {
+		openRequest.Env = make(map[string]string, len(*t.config.Env))
+		for key, value := range *t.config.Env {
+			// Required check because a string is considered valid JSON (e.g. "hello")
+			// We don't want to marshall basic strings otherwise we get a double quoted environment variable
+			// See: https://github.com/gitpod-io/gitpod/issues/5887
+			if val, ok := value.(string); ok {
+				openRequest.Env[key] = val
+			} else {
+				v, err := json.Marshal(value)
+				if err != nil {
+					taskLog.WithError(err).WithField("key", key).Error("cannot marshal env var")
+				} else {
+					openRequest.Env[key] = string(v)
+				}
+			}
+		}
+	}
+	resp, err := tm.terminalService.OpenWithOptions(ctx, openRequest, terminal.TermOptions{
+		ReadTimeout: 5 * time.Second,
+		Title:       t.title,
+	})
+	if err != nil {
+		taskLog.WithError(err).Error("cannot open new task terminal")
+		t.reportResult(taskFailed("cannot open new task terminal"))
+		t.setReady(taskFailed("cannot open new task terminal"))
+		tm.setTaskState(t, api.TaskState_closed)
+		return
+	}
+
+	taskLog = taskLog.WithField("terminal", resp.Terminal.Alias)
+	term, ok := tm.terminalService.Mux.Get(resp.Terminal.Alias)
+	if !ok {
+		taskLog.Error("cannot find a task terminal")
+		t.reportResult(taskFailed("cannot find a task terminal"))
+		t.setReady(taskFailed("cannot find a task terminal"))
+		tm.setTaskState(t, api.TaskState_closed)
+		return
+	}
+
+	taskLog = taskLog.WithField("pid", term.Command.Process.Pid)
+	taskLog.Info("task terminal has been started")
+	tm.updateState(func() bool {
+		t.Terminal = resp.Terminal.Alias
+		t.State = api.TaskState_running
+		return true
+	})
+
+	t.startedAt = time.Now()
+	go func(t *task, term *terminal.Term) {
+		var res taskSuccess
+		state, err := term.Wait()
+		if state != nil {
+			if state.Success() {
+				res = taskSuccessful
+			} else {
+				res = taskFailed(state.String())
+			}
+			tm.updateState(func() bool {
+				t.LastExitCode = int32(state.ExitCode())
+				return true
+			})
+		} else if err != nil {
+			res = taskSuccessful
+		} else {
+			msg := "cannot wait for task"
+			if err != nil {
+				msg = err.Error()
+			}
+
+			res = taskFailed(fmt.Sprintf("%s: %s", msg, t.lastOutput))
+		}
+		taskLog.Info("task terminal has been closed")
+		if tm.restartAfterExit(ctx, t, res) {
+			return
+		}
+
+		// a task which was restarted manually might have reported its result already
+		t.reportResult(res)
+		tm.taskExited(t, res)
+		tm.setTaskState(t, api.TaskState_closed)
+	}(t, term)
+
+	tm.watch(t, term)
+	if t.config.Readiness != nil {
+		go tm.probeReadiness(ctx, t)
+	}
+
+	if command != "" {
+		term.PTY.Write([]byte(command + "\n"))
+	}
+}
+
 func getCommand(task *task, isHeadless bool, isPrebuild bool, contentSource csapi.WorkspaceInitSource, storeLocation string) string {
 	commands := getCommands(task, isPrebuild, contentSource, storeLocation)
 	command := composeCommand(composeCommandOptions{
@@ -382,6 +459,10 @@ func getCommand(task *task, isHeadless bool, isPrebuild bool, contentSource csap
 	if strings.TrimSpace(command) == "" {
 		return histfileCommand
 	}
+	if task.isSupervised() {
+		// supervised tasks close their terminal once the command exits, s.t. we can restart them
+		command += "; exit"
+	}
 	if histfileCommand == "" {
 		return command
 	}
@@ -0,0 +1,239 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+const (
+	// readinessProbeInterval is the time between two readiness probe attempts
+	readinessProbeInterval = 1 * time.Second
+	// readinessProbeTimeout is the time a single readiness probe attempt may take
+	readinessProbeTimeout = 5 * time.Second
+)
+
+// resolveDependencies links tasks to the tasks they depend on. Tasks with unknown or
+// cyclic dependencies fail right away, all other tasks with dependencies wait.
+// Callers are expected to not run the tasks yet.
+func (tm *tasksManager) resolveDependencies() {
+	byName := make(map[string][]*task, len(tm.tasks))
+	for _, t := range tm.tasks {
+		if t.config.Name != nil {
+			byName[*t.config.Name] = append(byName[*t.config.Name], t)
+		}
+	}
+
+	failed := make(map[*task]string)
+	for _, t := range tm.tasks {
+		for _, name := range t.config.DependsOn {
+			deps, ok := byName[name]
+			if !ok {
+				failed[t] = fmt.Sprintf("unknown dependency %q", name)
+				break
+			}
+			t.deps = append(t.deps, deps...)
+		}
+	}
+
+	// visit marks all tasks which are part of, or depend on, a dependency cycle
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var (
+		marks  = make(map[*task]int, len(tm.tasks))
+		cyclic = make(map[*task]bool)
+		visit  func(t *task) bool
+	)
+	visit = func(t *task) bool {
+		switch marks[t] {
+		case visiting:
+			return true
+		case visited:
+			return cyclic[t]
+		}
+
+		marks[t] = visiting
+		for _, dep := range t.deps {
+			if visit(dep) {
+				cyclic[t] = true
+			}
+		}
+		marks[t] = visited
+		return cyclic[t]
+	}
+	for _, t := range tm.tasks {
+		if visit(t) {
+			if _, exists := failed[t]; !exists {
+				failed[t] = "cyclic dependency"
+			}
+		}
+	}
+
+	for _, t := range tm.tasks {
+		if t.State == api.TaskState_closed {
+			// e.g. headless tasks without commands, which reported their result already
+			continue
+		}
+		if reason, ok := failed[t]; ok {
+			log.WithField("task", t.title).WithField("reason", reason).Error("cannot run task")
+			t.State = api.TaskState_closed
+			t.reportResult(taskFailed(reason))
+			t.setReady(taskFailed(reason))
+			continue
+		}
+		if len(t.deps) > 0 {
+			t.State = api.TaskState_waiting
+		}
+	}
+}
+
+// startAfterDependencies starts the task once all its dependencies are ready.
+// If one of the dependencies does not become ready, the task fails.
+func (tm *tasksManager) startAfterDependencies(ctx context.Context, t *task) {
+	for _, dep := range t.deps {
+		select {
+		case <-ctx.Done():
+			return
+		case <-dep.readyChan:
+		}
+
+		if dep.readiness.Failed() {
+			reason := fmt.Sprintf("dependency %s did not become ready: %s", dep.title, dep.readiness)
+			log.WithField("task", t.title).WithField("reason", reason).Error("cannot run task")
+			t.reportResult(taskFailed(reason))
+			t.setReady(taskFailed(reason))
+			tm.setTaskState(t, api.TaskState_closed)
+			return
+		}
+	}
+
+	tm.setTaskState(t, api.TaskState_opening)
+	tm.startTask(ctx, t)
+}
+
+// taskExited updates the task readiness once the task terminal closed. Tasks without readiness
+// probe are ready once they terminated successfully. Headless tasks only run commands which are
+// expected to terminate, hence they are ready once they terminated successfully, too.
+func (tm *tasksManager) taskExited(t *task, res taskSuccess) {
+	if res.Failed() {
+		t.setReady(taskFailed("task failed: " + string(res)))
+		return
+	}
+	if t.config.Readiness == nil || tm.config.isHeadless() {
+		t.setReady(taskSuccessful)
+	}
+}
+
+// probeReadiness probes the task until it is ready, the readiness timeout expires or
+// readiness was determined otherwise.
+func (tm *tasksManager) probeReadiness(ctx context.Context, t *task) {
+	cfg := t.config.Readiness
+	if cfg.Timeout != nil && *cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*cfg.Timeout)*time.Second)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(readinessProbeInterval)
+	defer ticker.Stop()
+	for {
+		if t.isReadyDecided() {
+			return
+		}
+
+		err := tm.probeOnce(ctx, cfg)
+		if err == nil {
+			log.WithField("task", t.title).Info("task is ready")
+			t.setReady(taskSuccessful)
+			return
+		}
+		log.WithError(err).WithField("task", t.title).Debug("task is not ready yet")
+
+		select {
+		case <-ctx.Done():
+			if cfg.Timeout != nil && *cfg.Timeout > 0 {
+				t.setReady(taskFailed(fmt.Sprintf("task did not become ready within %ds: %v", *cfg.Timeout, err)))
+			}
+			return
+		case <-t.readyChan:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probeOnce runs all configured readiness checks once
+func (tm *tasksManager) probeOnce(ctx context.Context, cfg *TaskReadiness) error {
+	ctx, cancel := context.WithTimeout(ctx, readinessProbeTimeout)
+	defer cancel()
+
+	if cfg.Port != nil {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort("localhost", strconv.Itoa(*cfg.Port)))
+		if err != nil {
+			return err
+		}
+		conn.Close()
+	}
+
+	if cfg.HTTP != nil {
+		path := cfg.HTTP.Path
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://localhost:%d%s", cfg.HTTP.Port, path), nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return xerrors.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+	}
+
+	if cfg.Exec != nil {
+		shell := tm.terminalService.DefaultShell
+		if shell == "" {
+			shell = "/bin/sh"
+		}
+		cmd := exec.CommandContext(ctx, shell, "-c", *cfg.Exec)
+		if tm.terminalService.DefaultCreds != nil {
+			cmd.SysProcAttr = &syscall.SysProcAttr{Credential: tm.terminalService.DefaultCreds}
+		}
+		if tm.terminalService.DefaultWorkdirProvider != nil {
+			cmd.Dir = tm.terminalService.DefaultWorkdirProvider()
+		}
+		if cmd.Dir == "" {
+			cmd.Dir = tm.terminalService.DefaultWorkdir
+		}
+		cmd.Env = tm.terminalService.Env
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			return xerrors.Errorf("%w: %s", err, string(out))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/terminal"
+)
+
+func TestTaskDependencies(t *testing.T) {
+	log.Log.Logger.SetLevel(logrus.FatalLevel)
+	p := func(v string) *string { return &v }
+
+	marker := filepath.Join(t.TempDir(), "marker")
+	tests := []struct {
+		Desc        string
+		GitpodTasks []TaskConfig
+
+		ExpectedReporter testHeadlessTaskProgressReporter
+	}{
+		{
+			Desc: "dependent task starts after its dependency terminated",
+			GitpodTasks: []TaskConfig{
+				{Init: p("test -f " + marker), DependsOn: []string{"setup"}},
+				{Name: p("setup"), Init: p("sleep 0.5 && touch " + marker)},
+			},
+			ExpectedReporter: testHeadlessTaskProgressReporter{Done: true, Success: true},
+		},
+		{
+			Desc: "dependent task fails if its dependency fails",
+			GitpodTasks: []TaskConfig{
+				{Name: p("setup"), Init: &failCommand},
+				{Init: &skipCommand, DependsOn: []string{"setup"}},
+			},
+			ExpectedReporter: testHeadlessTaskProgressReporter{Done: true, Success: false},
+		},
+		{
+			Desc: "unknown dependency",
+			GitpodTasks: []TaskConfig{
+				{Init: &skipCommand, DependsOn: []string{"does-not-exist"}},
+			},
+			ExpectedReporter: testHeadlessTaskProgressReporter{Done: true, Success: false},
+		},
+		{
+			Desc: "cyclic dependency",
+			GitpodTasks: []TaskConfig{
+				{Name: p("a"), Init: &skipCommand, DependsOn: []string{"b"}},
+				{Name: p("b"), Init: &skipCommand, DependsOn: []string{"a"}},
+			},
+			ExpectedReporter: testHeadlessTaskProgressReporter{Done: true, Success: false},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			os.Remove(marker)
+
+			gitpodTasks, err := json.Marshal(test.GitpodTasks)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var (
+				terminalService = terminal.NewMuxTerminalService(terminal.NewMux())
+				contentState    = NewInMemoryContentState("")
+				reporter        = testHeadlessTaskProgressReporter{}
+				taskManager     = newTasksManager(&Config{
+					WorkspaceConfig: WorkspaceConfig{
+						GitpodTasks:    string(gitpodTasks),
+						GitpodHeadless: "true",
+					},
+				}, terminalService, contentState, &reporter, nil, nil)
+			)
+			taskManager.storeLocation = t.TempDir()
+			contentState.MarkContentReady(csapi.WorkspaceInitFromOther)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go taskManager.Run(ctx, &wg, make(chan taskSuccess, 1))
+			wg.Wait()
+			if diff := cmp.Diff(test.ExpectedReporter, reporter); diff != "" {
+				t.Errorf("unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveDependencies(t *testing.T) {
+	p := func(v string) *string { return &v }
+	tm := &tasksManager{}
+	for i, cfg := range []TaskConfig{
+		{Name: p("db")},
+		{Name: p("api"), DependsOn: []string{"db"}},
+		{Name: p("web"), DependsOn: []string{"api", "db"}},
+		{Name: p("a"), DependsOn: []string{"b"}},
+		{Name: p("b"), DependsOn: []string{"a"}},
+		{Name: p("c"), DependsOn: []string{"a"}},
+	} {
+		tm.tasks = append(tm.tasks, &task{
+			TaskStatus:  api.TaskStatus{Id: strconv.Itoa(i), State: api.TaskState_opening},
+			config:      cfg,
+			title:       *cfg.Name,
+			successChan: make(chan taskSuccess, 1),
+			readyChan:   make(chan struct{}),
+		})
+	}
+	tm.resolveDependencies()
+
+	act := make(map[string]api.TaskState)
+	for _, t := range tm.tasks {
+		act[t.title] = t.State
+	}
+	expectation := map[string]api.TaskState{
+		"db":  api.TaskState_opening,
+		"api": api.TaskState_waiting,
+		"web": api.TaskState_waiting,
+		"a":   api.TaskState_closed,
+		"b":   api.TaskState_closed,
+		"c":   api.TaskState_closed,
+	}
+	if diff := cmp.Diff(expectation, act); diff != "" {
+		t.Errorf("unexpected task states (-want +got):\n%s", diff)
+	}
+}
+
+func TestResolveDependenciesOfClosedTask(t *testing.T) {
+	p := func(v string) *string { return &v }
+
+	// headless tasks without commands are closed and report their result before dependencies are resolved
+	closed := &task{
+		TaskStatus:  api.TaskStatus{Id: "0", State: api.TaskState_closed},
+		config:      TaskConfig{Name: p("closed"), DependsOn: []string{"does-not-exist"}},
+		title:       "closed",
+		successChan: make(chan taskSuccess, 1),
+		readyChan:   make(chan struct{}),
+	}
+	closed.successChan <- taskSuccessful
+	closed.setReady(taskSuccessful)
+	tm := &tasksManager{tasks: []*task{closed}}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		tm.resolveDependencies()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("resolveDependencies did not return")
+	}
+
+	if closed.State != api.TaskState_closed {
+		t.Errorf("unexpected task state: %v", closed.State)
+	}
+	if res := <-closed.successChan; res.Failed() {
+		t.Errorf("unexpected task result: %s", res)
+	}
+}
+
+func TestProbeReadiness(t *testing.T) {
+	p := func(v string) *string { return &v }
+	i := func(v int) *int { return &v }
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	openPort := l.Addr().(*net.TCPAddr).Port
+
+	closed, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedPort := closed.Addr().(*net.TCPAddr).Port
+	closed.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	httpPort := srv.Listener.Addr().(*net.TCPAddr).Port
+
+	tests := []struct {
+		Name      string
+		Readiness TaskReadiness
+		Ready     bool
+	}{
+		{Name: "open port", Readiness: TaskReadiness{Port: i(openPort), Timeout: i(1)}, Ready: true},
+		{Name: "closed port", Readiness: TaskReadiness{Port: i(closedPort), Timeout: i(1)}},
+		{Name: "http", Readiness: TaskReadiness{HTTP: &TaskReadinessHTTP{Port: httpPort, Path: "healthz"}, Timeout: i(1)}, Ready: true},
+		{Name: "http not found", Readiness: TaskReadiness{HTTP: &TaskReadinessHTTP{Port: httpPort, Path: "/"}, Timeout: i(1)}},
+		{Name: "exec", Readiness: TaskReadiness{Exec: p("true"), Timeout: i(1)}, Ready: true},
+		{Name: "exec fails", Readiness: TaskReadiness{Exec: p("false"), Timeout: i(1)}},
+		{Name: "all checks must succeed", Readiness: TaskReadiness{Port: i(openPort), Exec: p("false"), Timeout: i(1)}},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			terminalService := terminal.NewMuxTerminalService(terminal.NewMux())
+			terminalService.DefaultWorkdir = t.TempDir()
+			tm := &tasksManager{terminalService: terminalService}
+			tsk := &task{config: TaskConfig{Readiness: &test.Readiness}, readyChan: make(chan struct{})}
+			tm.probeReadiness(context.Background(), tsk)
+
+			select {
+			case <-tsk.readyChan:
+			default:
+				t.Fatal("readiness was not determined")
+			}
+			if ready := !tsk.readiness.Failed(); ready != test.Ready {
+				t.Errorf("unexpected readiness: %v (%s)", ready, tsk.readiness)
+			}
+		})
+	}
+}
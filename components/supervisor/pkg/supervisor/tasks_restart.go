@@ -0,0 +1,182 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+const (
+	// defaultRestartBackoff is the initial delay before a task is restarted if no backoff is configured
+	defaultRestartBackoff = 1 * time.Second
+	// maxRestartBackoff caps the exponential restart backoff
+	maxRestartBackoff = 5 * time.Minute
+	// restartBackoffReset is the time a task must run for its restart backoff to reset
+	restartBackoffReset = 5 * time.Minute
+)
+
+// isSupervised returns true if the task command may be restarted once it exited
+func (t *task) isSupervised() bool {
+	return t.config.Restart.supervised() && t.config.Command != nil
+}
+
+// getRestartCommand produces the command run when a task is restarted. Init commands are not repeated.
+func getRestartCommand(task *task) string {
+	command := composeCommand(composeCommandOptions{
+		commands: []*string{task.config.Before, task.config.Command},
+		format:   "{\n%s\n}",
+		sep:      " && ",
+	})
+	if command != "" && task.isSupervised() {
+		command += "; exit"
+	}
+	return command
+}
+
+// shouldRestart returns true if the task needs restarting according to its restart policy
+func (tm *tasksManager) shouldRestart(t *task, res taskSuccess) bool {
+	if tm.config.isHeadless() || !t.isSupervised() {
+		return false
+	}
+	if t.config.Restart.MaxRetries != nil && int(t.RestartCount) >= *t.config.Restart.MaxRetries {
+		return false
+	}
+	switch t.config.Restart.Policy {
+	case TaskRestartAlways:
+		return true
+	case TaskRestartOnFailure:
+		return res.Failed()
+	default:
+		return false
+	}
+}
+
+// restartBackoff computes the delay before the next restart, doubling it for every restart in a row
+func restartBackoff(cfg *TaskRestart, consecutive int) time.Duration {
+	backoff := defaultRestartBackoff
+	if cfg != nil && cfg.Backoff != nil && *cfg.Backoff >= 0 {
+		backoff = time.Duration(*cfg.Backoff) * time.Second
+	}
+	for i := 0; i < consecutive && backoff < maxRestartBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRestartBackoff {
+		backoff = maxRestartBackoff
+	}
+	return backoff
+}
+
+// restartAfterExit restarts the task if that was requested or its restart policy demands it.
+// Returns false if the task is not restarted.
+func (tm *tasksManager) restartAfterExit(ctx context.Context, t *task, res taskSuccess) bool {
+	tm.mu.Lock()
+	requested := t.restartRequested
+	t.restartRequested = false
+	tm.mu.Unlock()
+
+	if !requested && !tm.shouldRestart(t, res) {
+		return false
+	}
+
+	taskLog := log.WithField("task", t.title).WithField("restartCount", t.RestartCount)
+	if !requested {
+		if time.Since(t.startedAt) >= restartBackoffReset {
+			t.consecutiveRestarts = 0
+		}
+		delay := restartBackoff(t.config.Restart, t.consecutiveRestarts)
+		t.consecutiveRestarts++
+
+		taskLog.WithField("result", res).WithField("delay", delay).Info("restarting task")
+		tm.setTaskState(t, api.TaskState_opening)
+		select {
+		case <-ctx.Done():
+			tm.setTaskState(t, api.TaskState_closed)
+			return true
+		case <-time.After(delay):
+		}
+	} else {
+		taskLog.Info("restarting task on request")
+		tm.setTaskState(t, api.TaskState_opening)
+	}
+
+	tm.updateState(func() bool {
+		t.RestartCount++
+		t.command = getRestartCommand(t)
+		return true
+	})
+	tm.startTask(ctx, t)
+	return true
+}
+
+// Restart restarts a task. Running tasks are terminated first.
+func (tm *tasksManager) Restart(ctx context.Context, id string) error {
+	select {
+	case <-tm.ready:
+	default:
+		return status.Error(codes.Unavailable, "tasks are not ready yet")
+	}
+	if tm.config.isHeadless() {
+		return status.Error(codes.FailedPrecondition, "tasks cannot be restarted in headless workspaces")
+	}
+
+	var t *task
+	for _, candidate := range tm.tasks {
+		if candidate.Id == id {
+			t = candidate
+			break
+		}
+	}
+	if t == nil {
+		return status.Errorf(codes.NotFound, "task %s does not exist", id)
+	}
+
+	command := getRestartCommand(t)
+	var (
+		state api.TaskState
+		alias string
+	)
+	tm.updateState(func() bool {
+		state, alias = t.State, t.Terminal
+		switch {
+		case state == api.TaskState_running:
+			t.restartRequested = true
+			return false
+		case state == api.TaskState_closed && command != "":
+			t.State = api.TaskState_opening
+			t.RestartCount++
+			t.command = command
+			return true
+		default:
+			return false
+		}
+	})
+
+	switch state {
+	case api.TaskState_running:
+		err := tm.terminalService.Mux.CloseTerminal(ctx, alias)
+		if err != nil {
+			tm.mu.Lock()
+			t.restartRequested = false
+			tm.mu.Unlock()
+			return err
+		}
+		return nil
+	case api.TaskState_closed:
+		if command == "" {
+			return status.Errorf(codes.FailedPrecondition, "task %s has no command to restart", id)
+		}
+		go tm.startTask(tm.ctx, t)
+		return nil
+	default:
+		return status.Errorf(codes.FailedPrecondition, "task %s is %s and cannot be restarted", id, state)
+	}
+}
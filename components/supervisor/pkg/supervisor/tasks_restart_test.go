@@ -0,0 +1,170 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/terminal"
+)
+
+func TestShouldRestart(t *testing.T) {
+	p := func(v string) *string { return &v }
+	i := func(v int) *int { return &v }
+	tests := []struct {
+		Desc         string
+		Restart      *TaskRestart
+		Command      *string
+		RestartCount uint32
+		Headless     bool
+		Result       taskSuccess
+		Expectation  bool
+	}{
+		{Desc: "no restart policy", Command: p("server"), Result: taskFailed("exit 1")},
+		{Desc: "never", Restart: &TaskRestart{Policy: TaskRestartNever}, Command: p("server"), Result: taskFailed("exit 1")},
+		{Desc: "on-failure with failure", Restart: &TaskRestart{Policy: TaskRestartOnFailure}, Command: p("server"), Result: taskFailed("exit 1"), Expectation: true},
+		{Desc: "on-failure with success", Restart: &TaskRestart{Policy: TaskRestartOnFailure}, Command: p("server"), Result: taskSuccessful},
+		{Desc: "always with success", Restart: &TaskRestart{Policy: TaskRestartAlways}, Command: p("server"), Result: taskSuccessful, Expectation: true},
+		{Desc: "retries left", Restart: &TaskRestart{Policy: TaskRestartAlways, MaxRetries: i(3)}, Command: p("server"), RestartCount: 2, Expectation: true},
+		{Desc: "retries exhausted", Restart: &TaskRestart{Policy: TaskRestartAlways, MaxRetries: i(3)}, Command: p("server"), RestartCount: 3},
+		{Desc: "no command", Restart: &TaskRestart{Policy: TaskRestartAlways}},
+		{Desc: "headless", Restart: &TaskRestart{Policy: TaskRestartAlways}, Command: p("server"), Headless: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			cfg := &Config{}
+			if test.Headless {
+				cfg.GitpodHeadless = "true"
+			}
+			tm := &tasksManager{config: cfg}
+			tsk := &task{
+				TaskStatus: api.TaskStatus{RestartCount: test.RestartCount},
+				config:     TaskConfig{Command: test.Command, Restart: test.Restart},
+			}
+			if act := tm.shouldRestart(tsk, test.Result); act != test.Expectation {
+				t.Errorf("unexpected result: expected %v, got %v", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestRestartBackoff(t *testing.T) {
+	i := func(v int) *int { return &v }
+	tests := []struct {
+		Desc        string
+		Restart     *TaskRestart
+		Consecutive int
+		Expectation time.Duration
+	}{
+		{Desc: "default", Restart: &TaskRestart{}, Expectation: defaultRestartBackoff},
+		{Desc: "configured", Restart: &TaskRestart{Backoff: i(3)}, Expectation: 3 * time.Second},
+		{Desc: "exponential", Restart: &TaskRestart{Backoff: i(3)}, Consecutive: 2, Expectation: 12 * time.Second},
+		{Desc: "no backoff", Restart: &TaskRestart{Backoff: i(0)}, Consecutive: 5},
+		{Desc: "capped", Restart: &TaskRestart{Backoff: i(10)}, Consecutive: 100, Expectation: maxRestartBackoff},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			if act := restartBackoff(test.Restart, test.Consecutive); act != test.Expectation {
+				t.Errorf("unexpected backoff: expected %s, got %s", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestGetRestartCommand(t *testing.T) {
+	p := func(v string) *string { return &v }
+	tests := []struct {
+		Desc        string
+		Config      TaskConfig
+		Expectation string
+	}{
+		{
+			Desc:        "supervised",
+			Config:      TaskConfig{Before: p("before"), Init: p("init"), Command: p("command"), Restart: &TaskRestart{Policy: TaskRestartAlways}},
+			Expectation: "{\nbefore\n} && {\ncommand\n}; exit",
+		},
+		{
+			Desc:        "unsupervised",
+			Config:      TaskConfig{Init: p("init"), Command: p("command")},
+			Expectation: "{\ncommand\n}",
+		},
+		{
+			Desc:   "init only",
+			Config: TaskConfig{Init: p("init")},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			if act := getRestartCommand(&task{config: test.Config}); act != test.Expectation {
+				t.Errorf("unexpected command: expected %q, got %q", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestTaskRestartPolicy(t *testing.T) {
+	log.Log.Logger.SetLevel(logrus.FatalLevel)
+	p := func(v string) *string { return &v }
+	i := func(v int) *int { return &v }
+
+	runs := filepath.Join(t.TempDir(), "runs")
+	gitpodTasks, err := json.Marshal([]TaskConfig{{
+		Command: p("echo run >> " + runs + "; exit 3"),
+		Restart: &TaskRestart{Policy: TaskRestartOnFailure, MaxRetries: i(2), Backoff: i(0)},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		terminalService = terminal.NewMuxTerminalService(terminal.NewMux())
+		contentState    = NewInMemoryContentState("")
+		taskManager     = newTasksManager(&Config{
+			WorkspaceConfig: WorkspaceConfig{
+				GitpodTasks: string(gitpodTasks),
+			},
+		}, terminalService, contentState, nil, nil, nil)
+	)
+	terminalService.DefaultWorkdir = t.TempDir()
+	taskManager.storeLocation = t.TempDir()
+	contentState.MarkContentReady(csapi.WorkspaceInitFromOther)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go taskManager.Run(ctx, &wg, make(chan taskSuccess, 1))
+	wg.Wait()
+
+	status := taskManager.Status()
+	if len(status) != 1 {
+		t.Fatalf("unexpected number of tasks: %d", len(status))
+	}
+	if status[0].RestartCount != 2 {
+		t.Errorf("unexpected restart count: %d", status[0].RestartCount)
+	}
+	if status[0].LastExitCode != 3 {
+		t.Errorf("unexpected last exit code: %d", status[0].LastExitCode)
+	}
+	fc, err := os.ReadFile(runs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(fc), "run"); n != 3 {
+		t.Errorf("expected the command to run 3 times, got %d", n)
+	}
+}
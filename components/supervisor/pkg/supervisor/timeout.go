@@ -0,0 +1,127 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	gitpod "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/serverapi"
+)
+
+const (
+	// extendTimeoutAction is the notification action which extends the workspace timeout
+	extendTimeoutAction = "Extend Timeout"
+	// extendedTimeout matches the timeout set by `gp timeout extend`
+	extendedTimeout = 180 * time.Minute
+)
+
+type notifier interface {
+	Notify(ctx context.Context, req *api.NotifyRequest) (*api.NotifyResponse, error)
+}
+
+type broadcaster interface {
+	Broadcast(msg []byte)
+}
+
+// watchTimeoutWarnings listens for timeout warnings of the workspace instance and tells the user
+// about them, both through a notification in the IDE and a message in all open terminals.
+func watchTimeoutWarnings(ctx context.Context, gitpodService serverapi.APIInterface, notifications notifier, terminals broadcaster) {
+	updates, err := gitpodService.InstanceUpdates(ctx)
+	if err != nil {
+		log.WithError(err).Error("cannot watch for timeout warnings")
+		return
+	}
+
+	var (
+		lastWarning string
+		cancel      context.CancelFunc = func() {}
+	)
+	defer func() { cancel() }()
+	for inst := range updates {
+		warning := timeoutWarning(inst)
+		if warning == lastWarning {
+			continue
+		}
+		lastWarning = warning
+
+		// the previous warning is obsolete, either because the user became active or the deadline moved
+		cancel()
+		cancel = func() {}
+		if warning == "" {
+			continue
+		}
+
+		timeoutAt, err := time.Parse(time.RFC3339, warning)
+		if err != nil {
+			log.WithError(err).WithField("timeoutWarning", warning).Warn("cannot parse timeout warning")
+			continue
+		}
+
+		warnCtx, cancelWarning := context.WithDeadline(ctx, timeoutAt)
+		cancel = cancelWarning
+		go warnTimeout(warnCtx, gitpodService, notifications, terminals, timeoutAt)
+	}
+}
+
+func timeoutWarning(inst *gitpod.WorkspaceInstance) string {
+	if inst == nil || inst.Status == nil || inst.Status.Conditions == nil {
+		return ""
+	}
+	return inst.Status.Conditions.TimeoutWarning
+}
+
+// warnTimeout shows a timeout warning and extends the timeout if the user asks for it
+func warnTimeout(ctx context.Context, gitpodService serverapi.APIInterface, notifications notifier, terminals broadcaster, timeoutAt time.Time) {
+	minutes := int(math.Ceil(time.Until(timeoutAt).Minutes()))
+	if minutes < 1 {
+		minutes = 1
+	}
+	message := fmt.Sprintf("Your workspace will time out in %d minute(s) due to inactivity.", minutes)
+
+	terminals.Broadcast([]byte(fmt.Sprintf("\r\n\x1b[30;43m Gitpod \x1b[0m %s Run `gp timeout extend` or interact with your IDE to keep it running.\r\n", message)))
+
+	resp, err := notifications.Notify(ctx, &api.NotifyRequest{
+		Level:   api.NotifyRequest_WARNING,
+		Message: message,
+		Actions: []string{extendTimeoutAction},
+	})
+	if err != nil {
+		// the warning was dismissed, became obsolete or the workspace timed out
+		return
+	}
+	if resp.Action != extendTimeoutAction {
+		return
+	}
+
+	// The warning is withdrawn as soon as the heartbeat arrives, hence we must not use its context.
+	extendCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	err = extendTimeout(extendCtx, gitpodService)
+	if err != nil {
+		log.WithError(err).Error("cannot extend workspace timeout")
+		go func() {
+			_, _ = notifications.Notify(context.Background(), &api.NotifyRequest{
+				Level:   api.NotifyRequest_ERROR,
+				Message: fmt.Sprintf("Cannot extend the workspace timeout: %v", err),
+			})
+		}()
+	}
+}
+
+func extendTimeout(ctx context.Context, gitpodService serverapi.APIInterface) error {
+	// A heartbeat resets the inactivity timeout, the extended timeout keeps the workspace running for longer.
+	err := gitpodService.SendHeartBeat(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = gitpodService.SetWorkspaceTimeout(ctx, extendedTimeout)
+	return err
+}
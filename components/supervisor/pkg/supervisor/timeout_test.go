@@ -0,0 +1,172 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	gitpod "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/serverapi"
+)
+
+type fakeTimeoutAPI struct {
+	serverapi.APIInterface
+
+	updates chan *gitpod.WorkspaceInstance
+
+	mu         sync.Mutex
+	heartbeats int
+	timeouts   []time.Duration
+}
+
+func (f *fakeTimeoutAPI) InstanceUpdates(ctx context.Context) (<-chan *gitpod.WorkspaceInstance, error) {
+	return f.updates, nil
+}
+
+func (f *fakeTimeoutAPI) SendHeartBeat(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.heartbeats++
+	return nil
+}
+
+func (f *fakeTimeoutAPI) SetWorkspaceTimeout(ctx context.Context, duration time.Duration) (*gitpod.SetWorkspaceTimeoutResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.timeouts = append(f.timeouts, duration)
+	return &gitpod.SetWorkspaceTimeoutResult{}, nil
+}
+
+func (f *fakeTimeoutAPI) RegisterMetrics(registry *prometheus.Registry) error {
+	return nil
+}
+
+type fakeNotifier struct {
+	action   string
+	requests chan *api.NotifyRequest
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, req *api.NotifyRequest) (*api.NotifyResponse, error) {
+	f.requests <- req
+	if f.action == "" {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &api.NotifyResponse{Action: f.action}, nil
+}
+
+type fakeBroadcaster struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (f *fakeBroadcaster) Broadcast(msg []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.msgs = append(f.msgs, string(msg))
+}
+
+func instanceWithTimeoutWarning(warning string) *gitpod.WorkspaceInstance {
+	return &gitpod.WorkspaceInstance{
+		Status: &gitpod.WorkspaceInstanceStatus{
+			Conditions: &gitpod.WorkspaceInstanceConditions{
+				TimeoutWarning: warning,
+			},
+		},
+	}
+}
+
+func TestWatchTimeoutWarnings(t *testing.T) {
+	tests := []struct {
+		Desc   string
+		Action string
+		// Extended is true if we expect the timeout to be extended
+		Extended bool
+	}{
+		{Desc: "extend timeout", Action: extendTimeoutAction, Extended: true},
+		{Desc: "dismissed", Action: "dismiss"},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			var (
+				srv = &fakeTimeoutAPI{updates: make(chan *gitpod.WorkspaceInstance)}
+				nfy = &fakeNotifier{action: test.Action, requests: make(chan *api.NotifyRequest, 10)}
+				trm = &fakeBroadcaster{}
+			)
+			done := make(chan struct{})
+			go func() {
+				watchTimeoutWarnings(context.Background(), srv, nfy, trm)
+				close(done)
+			}()
+
+			warning := time.Now().Add(5 * time.Minute).UTC().Format(time.RFC3339)
+			srv.updates <- instanceWithTimeoutWarning(warning)
+			// the same warning must be shown only once
+			srv.updates <- instanceWithTimeoutWarning(warning)
+			srv.updates <- &gitpod.WorkspaceInstance{}
+
+			select {
+			case req := <-nfy.requests:
+				if !strings.Contains(req.Message, "5 minute(s)") {
+					t.Errorf("unexpected notification: %s", req.Message)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected a notification")
+			}
+			close(srv.updates)
+			<-done
+
+			// give the action some time to be processed
+			time.Sleep(100 * time.Millisecond)
+
+			if len(nfy.requests) != 0 {
+				t.Errorf("expected a single notification, got %d more", len(nfy.requests))
+			}
+			trm.mu.Lock()
+			if len(trm.msgs) != 1 {
+				t.Errorf("expected a single terminal message, got %v", trm.msgs)
+			}
+			trm.mu.Unlock()
+
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			if extended := len(srv.timeouts) > 0; extended != test.Extended {
+				t.Errorf("unexpected timeout extension: want %v, got %v", test.Extended, srv.timeouts)
+			}
+			if test.Extended && (srv.heartbeats != 1 || srv.timeouts[0] != extendedTimeout) {
+				t.Errorf("unexpected extension: heartbeats %d, timeouts %v", srv.heartbeats, srv.timeouts)
+			}
+		})
+	}
+}
+
+func TestWatchTimeoutWarningsWithdrawn(t *testing.T) {
+	var (
+		srv = &fakeTimeoutAPI{updates: make(chan *gitpod.WorkspaceInstance)}
+		nfy = &fakeNotifier{requests: make(chan *api.NotifyRequest, 10)}
+		trm = &fakeBroadcaster{}
+	)
+	go watchTimeoutWarnings(context.Background(), srv, nfy, trm)
+	defer close(srv.updates)
+
+	srv.updates <- instanceWithTimeoutWarning(time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	<-nfy.requests
+
+	// withdrawing the warning dismisses the pending notification, hence a new warning is shown again
+	srv.updates <- instanceWithTimeoutWarning("")
+	srv.updates <- instanceWithTimeoutWarning(time.Now().Add(2 * time.Hour).UTC().Format(time.RFC3339))
+	select {
+	case <-nfy.requests:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a notification for the new warning")
+	}
+}
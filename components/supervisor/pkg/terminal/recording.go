@@ -0,0 +1,331 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	_pty "github.com/creack/pty"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+const (
+	// DefaultMaxRecordingSize is the size in bytes after which a recording stops
+	DefaultMaxRecordingSize = 64 << 20
+	// DefaultMaxRecordingsSize is the total size in bytes of all recordings beyond which the oldest ones are removed
+	DefaultMaxRecordingsSize = 256 << 20
+	// DefaultMaxRecordingAge is the age after which recordings are removed
+	DefaultMaxRecordingAge = 7 * 24 * time.Hour
+
+	recordingExt = ".cast"
+)
+
+// Recorder writes the output of terminals to disk in asciicast v2 format,
+// see https://github.com/asciinema/asciinema/blob/develop/doc/asciicast-v2.md.
+type Recorder struct {
+	dir          string
+	maxSize      int64
+	maxTotalSize int64
+	maxAge       time.Duration
+
+	mu sync.Mutex
+	// active are the aliases of the terminals currently recorded
+	active map[string]struct{}
+}
+
+// RecorderOption configures a recorder.
+type RecorderOption func(*Recorder)
+
+// WithRecordingsLimit removes the oldest recordings once all recordings together are larger than maxTotalSize bytes,
+// and recordings older than maxAge. Use 0 for DefaultMaxRecordingsSize or DefaultMaxRecordingAge respectively.
+func WithRecordingsLimit(maxTotalSize int64, maxAge time.Duration) RecorderOption {
+	return func(r *Recorder) {
+		if maxTotalSize > 0 {
+			r.maxTotalSize = maxTotalSize
+		}
+		if maxAge > 0 {
+			r.maxAge = maxAge
+		}
+	}
+}
+
+// NewRecorder creates a recorder which stores recordings in dir. The directory is created
+// once the first terminal is recorded. Recordings stop once they reach maxSize bytes.
+// Use 0 for DefaultMaxRecordingSize.
+//
+// Old recordings are removed whenever a terminal starts to be recorded, see WithRecordingsLimit.
+func NewRecorder(dir string, maxSize int64, opts ...RecorderOption) *Recorder {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxRecordingSize
+	}
+	r := &Recorder{
+		dir:          dir,
+		maxSize:      maxSize,
+		maxTotalSize: DefaultMaxRecordingsSize,
+		maxAge:       DefaultMaxRecordingAge,
+		active:       make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Path returns the location of the recording of a terminal
+func (r *Recorder) Path(alias string) (string, error) {
+	// aliases are produced by the mux, checking them prevents path traversal
+	if _, err := uuid.Parse(alias); err != nil {
+		return "", xerrors.Errorf("invalid terminal alias %q", alias)
+	}
+	return filepath.Join(r.dir, alias+recordingExt), nil
+}
+
+// Prune removes recordings which are older than the maximum age, and the oldest recordings until
+// all of them together are no larger than the maximum total size. Active recordings are never removed.
+func (r *Recorder) Prune() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries, err := os.ReadDir(r.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	type castFile struct {
+		Path    string
+		Size    int64
+		ModTime time.Time
+	}
+	var (
+		files []castFile
+		total int64
+	)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, recordingExt) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		total += info.Size()
+		if _, active := r.active[strings.TrimSuffix(name, recordingExt)]; active {
+			continue
+		}
+		files = append(files, castFile{Path: filepath.Join(r.dir, name), Size: info.Size(), ModTime: info.ModTime()})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].ModTime.Before(files[j].ModTime) })
+	for _, f := range files {
+		if total <= r.maxTotalSize && time.Since(f.ModTime) <= r.maxAge {
+			continue
+		}
+		err := os.Remove(f.Path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		total -= f.Size
+	}
+	return nil
+}
+
+func (r *Recorder) setActive(alias string, active bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if active {
+		r.active[alias] = struct{}{}
+	} else {
+		delete(r.active, alias)
+	}
+}
+
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     uint16            `json:"width"`
+	Height    uint16            `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+func (r *Recorder) start(alias string, size _pty.Winsize, title string, shell string) (*recording, error) {
+	fn, err := r.Path(alias)
+	if err != nil {
+		return nil, err
+	}
+	err = os.MkdirAll(r.dir, 0755)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create recording directory: %w", err)
+	}
+	err = r.Prune()
+	if err != nil {
+		log.WithError(err).Warn("cannot remove old terminal recordings")
+	}
+	f, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create recording: %w", err)
+	}
+	r.setActive(alias, true)
+
+	now := time.Now()
+	hdr, err := json.Marshal(castHeader{
+		Version:   2,
+		Width:     size.Cols,
+		Height:    size.Rows,
+		Timestamp: now.Unix(),
+		Title:     title,
+		Env: map[string]string{
+			"SHELL": shell,
+			"TERM":  "xterm-256color",
+		},
+	})
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	res := &recording{
+		f:       f,
+		start:   now,
+		maxSize: r.maxSize,
+		log:     log.WithField("alias", alias),
+		done:    func() { r.setActive(alias, false) },
+	}
+	err = res.writeLine(hdr)
+	if err != nil {
+		res.closeFile()
+		return nil, xerrors.Errorf("cannot write recording header: %w", err)
+	}
+	return res, nil
+}
+
+// recording is the asciicast recording of a single terminal
+type recording struct {
+	mu      sync.Mutex
+	f       *os.File
+	start   time.Time
+	size    int64
+	maxSize int64
+	closed  bool
+	log     *logrus.Entry
+	// done is called once the recording is closed
+	done func()
+
+	// partial holds an incomplete UTF-8 sequence at the end of the last write
+	partial []byte
+}
+
+// Write records terminal output. Errors are logged rather than returned
+// s.t. recording never interferes with the terminal itself.
+func (r *recording) Write(p []byte) (n int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data := p
+	if len(r.partial) > 0 {
+		data = append(r.partial, p...)
+		r.partial = nil
+	}
+	// asciicast events are JSON strings - keep incomplete runes for the next write
+	if cut := incompleteRuneStart(data); cut < len(data) {
+		r.partial = append([]byte{}, data[cut:]...)
+		data = data[:cut]
+	}
+	if len(data) > 0 {
+		r.writeEvent("o", string(data))
+	}
+	return len(p), nil
+}
+
+// Resize records a change of the terminal size
+func (r *recording) Resize(size _pty.Winsize) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.writeEvent("r", fmt.Sprintf("%dx%d", size.Cols, size.Rows))
+}
+
+// Close flushes pending output and closes the recording
+func (r *recording) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	if len(r.partial) > 0 {
+		r.writeEvent("o", string(r.partial))
+		r.partial = nil
+	}
+	return r.closeFile()
+}
+
+// closeFile stops recording. Callers are expected to hold mu.
+func (r *recording) closeFile() error {
+	r.closed = true
+	err := r.f.Close()
+	if r.done != nil {
+		r.done()
+	}
+	return err
+}
+
+// writeEvent writes a single event. Callers are expected to hold mu.
+func (r *recording) writeEvent(code string, data string) {
+	if r.closed {
+		return
+	}
+	line, err := json.Marshal([]interface{}{time.Since(r.start).Seconds(), code, data})
+	if err != nil {
+		r.log.WithError(err).Warn("cannot marshal recording event")
+		return
+	}
+	if r.size+int64(len(line))+1 > r.maxSize {
+		r.log.Warn("terminal recording reached its maximum size - stopping to record")
+		_ = r.closeFile()
+		return
+	}
+	err = r.writeLine(line)
+	if err != nil {
+		r.log.WithError(err).Warn("cannot write terminal recording - stopping to record")
+		_ = r.closeFile()
+	}
+}
+
+func (r *recording) writeLine(line []byte) error {
+	n, err := r.f.Write(append(line, '\n'))
+	r.size += int64(n)
+	return err
+}
+
+// incompleteRuneStart returns the index of a trailing incomplete UTF-8 sequence in p, or len(p) if there is none
+func incompleteRuneStart(p []byte) int {
+	for i := len(p) - 1; i >= 0 && i >= len(p)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(p[i]) {
+			continue
+		}
+		if !utf8.FullRune(p[i:]) {
+			return i
+		}
+		break
+	}
+	return len(p)
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+type testRecordingServer struct {
+	ctx context.Context
+	buf bytes.Buffer
+	grpc.ServerStream
+}
+
+func (s *testRecordingServer) Send(resp *api.GetTerminalRecordingResponse) error {
+	s.buf.Write(resp.Data)
+	return nil
+}
+
+func (s *testRecordingServer) Context() context.Context {
+	return s.ctx
+}
+
+func TestRecording(t *testing.T) {
+	mux := NewMux(WithRecorder(NewRecorder(t.TempDir(), 0)))
+	defer mux.Close(context.Background())
+	srv := NewMuxTerminalService(mux)
+
+	alias, err := mux.Start(exec.Command("sh", "-c", "printf 'hällo'; sleep 0.2; printf ' world'; sleep 0.2"), TermOptions{Title: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp := &testRecordingServer{ctx: ctx}
+	err = srv.GetRecording(&api.GetTerminalRecordingRequest{Alias: alias, Follow: true}, resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scanner := bufio.NewScanner(&resp.buf)
+	if !scanner.Scan() {
+		t.Fatal("recording has no header")
+	}
+	var hdr castHeader
+	err = json.Unmarshal(scanner.Bytes(), &hdr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hdr.Version != 2 || hdr.Width != DEFAULT_COLS || hdr.Height != DEFAULT_ROWS || hdr.Title != "test" {
+		t.Errorf("unexpected header: %+v", hdr)
+	}
+
+	var (
+		output  strings.Builder
+		lastTS  float64
+		nevents int
+	)
+	for scanner.Scan() {
+		var evt []interface{}
+		err = json.Unmarshal(scanner.Bytes(), &evt)
+		if err != nil {
+			t.Fatalf("invalid event %q: %v", scanner.Text(), err)
+		}
+		if len(evt) != 3 {
+			t.Fatalf("invalid event %q", scanner.Text())
+		}
+		ts := evt[0].(float64)
+		if ts < lastTS {
+			t.Errorf("event timestamps are not monotonic: %f < %f", ts, lastTS)
+		}
+		lastTS = ts
+		if evt[1] == "o" {
+			output.WriteString(evt[2].(string))
+			nevents++
+		}
+	}
+	if act := output.String(); act != "hällo world" {
+		t.Errorf("unexpected recorded output: %q", act)
+	}
+	if nevents < 2 {
+		t.Errorf("expected output to be recorded in several events, got %d", nevents)
+	}
+}
+
+func TestGetRecordingErrors(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		Desc        string
+		Mux         *Mux
+		Alias       string
+		Expectation codes.Code
+	}{
+		{Desc: "recording disabled", Mux: NewMux(), Alias: "9e76c6d4-8b4a-4b67-9d1a-6a5a0a3a7b1e", Expectation: codes.FailedPrecondition},
+		{Desc: "invalid alias", Mux: NewMux(WithRecorder(NewRecorder(t.TempDir(), 0))), Alias: "../../etc/passwd", Expectation: codes.InvalidArgument},
+		{Desc: "not found", Mux: NewMux(WithRecorder(NewRecorder(t.TempDir(), 0))), Alias: "9e76c6d4-8b4a-4b67-9d1a-6a5a0a3a7b1e", Expectation: codes.NotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			err := NewMuxTerminalService(test.Mux).GetRecording(&api.GetTerminalRecordingRequest{Alias: test.Alias}, &testRecordingServer{ctx: ctx})
+			if act := status.Code(err); act != test.Expectation {
+				t.Errorf("unexpected status code: expected %v, got %v (%v)", test.Expectation, act, err)
+			}
+		})
+	}
+}
+
+func TestIncompleteRuneStart(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Input       []byte
+		Expectation int
+	}{
+		{Desc: "empty", Input: nil, Expectation: 0},
+		{Desc: "ascii", Input: []byte("hello"), Expectation: 5},
+		{Desc: "complete rune", Input: []byte("hä"), Expectation: 3},
+		{Desc: "incomplete two-byte rune", Input: []byte("h\xc3"), Expectation: 1},
+		{Desc: "incomplete three-byte rune", Input: []byte("h\xe2\x82"), Expectation: 1},
+		{Desc: "invalid continuation", Input: []byte("h\x82"), Expectation: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			if act := incompleteRuneStart(test.Input); act != test.Expectation {
+				t.Errorf("unexpected index: expected %d, got %d", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestRecorderPrune(t *testing.T) {
+	var (
+		dir      = t.TempDir()
+		recorder = NewRecorder(dir, 0, WithRecordingsLimit(20, time.Hour))
+		now      = time.Now()
+	)
+	create := func(alias string, size int, modTime time.Time) string {
+		fn, err := recorder.Path(alias)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(fn, bytes.Repeat([]byte("x"), size), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.Chtimes(fn, modTime, modTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fn
+	}
+	var (
+		expired = create("5c8d1b47-0a4e-4a3b-9a36-0c1f8a1e2b01", 1, now.Add(-2*time.Hour))
+		oldest  = create("5c8d1b47-0a4e-4a3b-9a36-0c1f8a1e2b02", 10, now.Add(-30*time.Minute))
+		older   = create("5c8d1b47-0a4e-4a3b-9a36-0c1f8a1e2b03", 10, now.Add(-20*time.Minute))
+		active  = create("5c8d1b47-0a4e-4a3b-9a36-0c1f8a1e2b04", 10, now.Add(-40*time.Minute))
+		other   = filepath.Join(dir, "notes.txt")
+	)
+	err := os.WriteFile(other, bytes.Repeat([]byte("x"), 100), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder.setActive("5c8d1b47-0a4e-4a3b-9a36-0c1f8a1e2b04", true)
+
+	err = recorder.Prune()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exists := func(fn string) bool {
+		_, err := os.Stat(fn)
+		return err == nil
+	}
+	for fn, expectation := range map[string]bool{
+		expired: false,
+		oldest:  false,
+		older:   true,
+		active:  true,
+		other:   true,
+	} {
+		if act := exists(fn); act != expectation {
+			t.Errorf("%s: expected to exist: %v, got %v", filepath.Base(fn), expectation, act)
+		}
+	}
+}
@@ -320,15 +320,19 @@ func (srv *MuxTerminalService) SetSize(ctx context.Context, req *api.SetTerminal
 		return nil, status.Error(codes.FailedPrecondition, "wrong token or force not set")
 	}
 
-	err := pty.Setsize(term.PTY, &pty.Winsize{
+	size := &pty.Winsize{
 		Cols: uint16(req.Size.Cols),
 		Rows: uint16(req.Size.Rows),
 		X:    uint16(req.Size.WidthPx),
 		Y:    uint16(req.Size.HeightPx),
-	})
+	}
+	err := pty.Setsize(term.PTY, size)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	if term.Stdout.recording != nil {
+		term.Stdout.recording.Resize(*size)
+	}
 
 	return &api.SetTerminalSizeResponse{}, nil
 }
@@ -356,3 +360,69 @@ func (srv *MuxTerminalService) UpdateAnnotations(ctx context.Context, req *api.U
 	term.UpdateAnnotations(req.Changed, req.Deleted)
 	return &api.UpdateTerminalAnnotationsResponse{}, nil
 }
+
+// recordingFollowInterval is the interval in which a followed recording is checked for new output
+const recordingFollowInterval = 200 * time.Millisecond
+
+// GetRecording returns the asciicast recording of a terminal.
+func (srv *MuxTerminalService) GetRecording(req *api.GetTerminalRecordingRequest, resp api.TerminalService_GetRecordingServer) error {
+	recorder := srv.Mux.Recorder()
+	if recorder == nil {
+		return status.Error(codes.FailedPrecondition, "terminal recording is disabled")
+	}
+	fn, err := recorder.Path(req.Alias)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	f, err := os.Open(fn)
+	if os.IsNotExist(err) {
+		return status.Error(codes.NotFound, "recording not found")
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer f.Close()
+
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			serr := resp.Send(&api.GetTerminalRecordingResponse{Data: buf[:n]})
+			if serr != nil {
+				return serr
+			}
+		}
+		if err != nil && err != io.EOF {
+			return status.Error(codes.Internal, err.Error())
+		}
+		if err == nil {
+			continue
+		}
+
+		if !req.Follow {
+			return nil
+		}
+		if _, running := srv.Mux.Get(req.Alias); !running {
+			// the recording is complete once the terminal is gone, read what's left
+			_, err = io.CopyBuffer(recordingSender{resp}, f, buf)
+			return err
+		}
+		select {
+		case <-resp.Context().Done():
+			return status.Error(codes.DeadlineExceeded, resp.Context().Err().Error())
+		case <-time.After(recordingFollowInterval):
+		}
+	}
+}
+
+type recordingSender struct {
+	resp api.TerminalService_GetRecordingServer
+}
+
+func (s recordingSender) Write(p []byte) (n int, err error) {
+	err = s.resp.Send(&api.GetTerminalRecordingResponse{Data: p})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
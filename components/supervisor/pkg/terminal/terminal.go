@@ -38,18 +38,38 @@ const (
 	DEFAULT_ROWS = 24
 )
 
+// MuxOption configures a terminal mux.
+type MuxOption func(*Mux)
+
+// WithRecorder records the output of all terminals started by the mux.
+func WithRecorder(recorder *Recorder) MuxOption {
+	return func(m *Mux) {
+		m.recorder = recorder
+	}
+}
+
 // NewMux creates a new terminal mux.
-func NewMux() *Mux {
-	return &Mux{
+func NewMux(opts ...MuxOption) *Mux {
+	m := &Mux{
 		terms: make(map[string]*Term),
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 // Mux can mux pseudo-terminals.
 type Mux struct {
-	aliases []string
-	terms   map[string]*Term
-	mu      sync.RWMutex
+	aliases  []string
+	terms    map[string]*Term
+	mu       sync.RWMutex
+	recorder *Recorder
+}
+
+// Recorder returns the recorder of this mux, or nil if terminals aren't recorded.
+func (m *Mux) Recorder() *Recorder {
+	return m.recorder
 }
 
 // Get returns a terminal for the given alias.
@@ -72,7 +92,7 @@ func (m *Mux) Start(cmd *exec.Cmd, options TermOptions) (alias string, err error
 	}
 	alias = uid.String()
 
-	term, err := newTerm(alias, cmd, options)
+	term, err := newTerm(alias, cmd, options, m.recorder)
 	if err != nil {
 		return "", err
 	}
@@ -119,6 +139,25 @@ func (m *Mux) CloseTerminal(ctx context.Context, alias string) error {
 	return m.doClose(ctx, alias)
 }
 
+// Broadcast writes a message to the output of all terminals. The message is shown to
+// everyone listening on a terminal, but is not passed to the process running in it.
+func (m *Mux) Broadcast(msg []byte) {
+	m.mu.RLock()
+	terms := make([]*Term, 0, len(m.terms))
+	for _, term := range m.terms {
+		terms = append(terms, term)
+	}
+	m.mu.RUnlock()
+
+	for _, term := range terms {
+		term.mu.RLock()
+		if !term.closed {
+			_, _ = term.Stdout.Write(msg)
+		}
+		term.mu.RUnlock()
+	}
+}
+
 // doClose closes a terminal and ends the process that runs in it.
 // First, the process receives SIGTERM and is given gracePeriod time
 // to stop. If it still runs after that time, it receives SIGKILL.
@@ -155,13 +194,13 @@ func (m *Mux) doClose(ctx context.Context, alias string) error {
 // For now we assume an average of five terminals per workspace, which makes this consume 1MiB of RAM.
 const terminalBacklogSize = 256 << 10
 
-func newTerm(alias string, cmd *exec.Cmd, options TermOptions) (*Term, error) {
+func newTerm(alias string, cmd *exec.Cmd, options TermOptions, recorder *Recorder) (*Term, error) {
 	token, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
 	}
 
-	recorder, err := NewRingBuffer(terminalBacklogSize)
+	backlog, err := NewRingBuffer(terminalBacklogSize)
 	if err != nil {
 		return nil, err
 	}
@@ -244,7 +283,19 @@ func newTerm(alias string, cmd *exec.Cmd, options TermOptions) (*Term, error) {
 	cmd.SysProcAttr.Setsid = true
 	cmd.SysProcAttr.Setctty = true
 
+	var rec *recording
+	if recorder != nil {
+		rec, err = recorder.start(alias, size, options.Title, cmd.Path)
+		if err != nil {
+			log.WithError(err).WithField("alias", alias).Warn("cannot record terminal")
+			rec = nil
+		}
+	}
+
 	if err := cmd.Start(); err != nil {
+		if rec != nil {
+			rec.Close()
+		}
 		pts.Close()
 		pty.Close()
 		return nil, err
@@ -257,7 +308,8 @@ func newTerm(alias string, cmd *exec.Cmd, options TermOptions) (*Term, error) {
 		Stdout: &multiWriter{
 			timeout:   timeout,
 			listener:  make(map[*multiWriterListener]struct{}),
-			recorder:  recorder,
+			recorder:  backlog,
+			recording: rec,
 			logStdout: options.LogToStdout,
 			logLabel:  alias,
 		},
@@ -443,6 +495,8 @@ type multiWriter struct {
 	// ring buffer to record last 256kb of pty output
 	// new listener is initialized with the latest recodring first
 	recorder *RingBuffer
+	// recording persists all pty output, nil if the terminal isn't recorded
+	recording *recording
 
 	logStdout bool
 	logLabel  string
@@ -576,6 +630,9 @@ func (mw *multiWriter) Write(p []byte) (n int, err error) {
 	defer mw.mu.Unlock()
 
 	mw.recorder.Write(p)
+	if mw.recording != nil {
+		_, _ = mw.recording.Write(p)
+	}
 	if mw.logStdout {
 		log.WithFields(logrus.Fields{
 			"terminalOutput": true,
@@ -616,6 +673,12 @@ func (mw *multiWriter) Close() error {
 			err = cerr
 		}
 	}
+	if mw.recording != nil {
+		cerr := mw.recording.Close()
+		if cerr != nil {
+			err = cerr
+		}
+	}
 	return err
 }
 
@@ -379,3 +379,31 @@ func TestWorkDirProvider(t *testing.T) {
 		expectedWorkDir: providedWorkDir,
 	})
 }
+
+func TestBroadcast(t *testing.T) {
+	mux := NewMux()
+	defer mux.Close(context.Background())
+
+	alias, err := mux.Start(exec.Command("sleep", "10"), TermOptions{ReadTimeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	term, ok := mux.Get(alias)
+	if !ok {
+		t.Fatal("no terminal")
+	}
+	listener := term.Stdout.Listen()
+	defer listener.Close()
+
+	msg := "workspace will time out soon"
+	go mux.Broadcast([]byte(msg))
+
+	buf := make([]byte, len(msg))
+	_, err = io.ReadFull(listener, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(msg, string(buf)); diff != "" {
+		t.Errorf("unexpected output (-want +got):\n%s", diff)
+	}
+}
@@ -533,8 +533,10 @@ var ring1Cmd = &cobra.Command{
 				defer t.Stop()
 				for {
 					// We use the ticker to rate-limit the errors from the syscall handler.
-					// We're only handling low-frequency syscalls (e.g. mount), and don't want
-					// the handler to hog the CPU because it fails on its fd.
+					// We're mostly handling low-frequency syscalls (e.g. mount), and don't want
+					// the handler to hog the CPU because it fails on its fd. setxattr is more frequent
+					// (e.g. when extracting archives), but is passed on to the kernel for all attributes
+					// other than the allowed ones.
 					<-t.C
 					err := <-errchan
 					if err == nil {
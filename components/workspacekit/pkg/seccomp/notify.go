@@ -20,6 +20,8 @@ import (
 	"github.com/moby/sys/mountinfo"
 	"golang.org/x/sys/unix"
 	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/workspacekit/pkg/readarg"
@@ -35,15 +37,21 @@ type SyscallHandler interface {
 	Umount(req *libseccomp.ScmpNotifReq) (val uint64, errno int32, flags uint32)
 	Bind(req *libseccomp.ScmpNotifReq) (val uint64, errno int32, flags uint32)
 	Chown(req *libseccomp.ScmpNotifReq) (val uint64, errno int32, flags uint32)
+	Mknod(req *libseccomp.ScmpNotifReq) (val uint64, errno int32, flags uint32)
+	Setxattr(req *libseccomp.ScmpNotifReq) (val uint64, errno int32, flags uint32)
 }
 
 func mapHandler(h SyscallHandler) map[string]syscallHandler {
 	return map[string]syscallHandler{
-		"mount":   h.Mount,
-		"umount":  h.Umount,
-		"umount2": h.Umount,
-		"bind":    h.Bind,
-		"chown":   h.Chown,
+		"mount":     h.Mount,
+		"umount":    h.Umount,
+		"umount2":   h.Umount,
+		"bind":      h.Bind,
+		"chown":     h.Chown,
+		"mknod":     h.Mknod,
+		"mknodat":   h.Mknod,
+		"setxattr":  h.Setxattr,
+		"lsetxattr": h.Setxattr,
 	}
 }
 
@@ -203,6 +211,9 @@ type InWorkspaceHandler struct {
 	WorkspaceId string
 }
 
+// notifIDValid is a variable so that tests can handle synthetic requests
+var notifIDValid = libseccomp.NotifIDValid
+
 // BindEvent describes a process binding to a socket
 type BindEvent struct {
 	PID uint32
@@ -224,7 +235,7 @@ func (h *InWorkspaceHandler) Mount(req *libseccomp.ScmpNotifReq) (val uint64, er
 	}
 	defer memFile.Close()
 
-	err = libseccomp.NotifIDValid(h.FD, req.ID)
+	err = notifIDValid(h.FD, req.ID)
 	if err != nil {
 		log.WithError(err).Error("invalid notify ID", req.ID)
 		return Errno(unix.EPERM)
@@ -469,6 +480,189 @@ func (h *InWorkspaceHandler) Chown(req *libseccomp.ScmpNotifReq) (val uint64, er
 	return 0, 0, libseccomp.NotifRespFlagContinue
 }
 
+// Mknod handles mknod and mknodat syscalls
+func (h *InWorkspaceHandler) Mknod(req *libseccomp.ScmpNotifReq) (val uint64, errno int32, flags uint32) {
+	nme, _ := req.Data.Syscall.GetName()
+	log := log.WithFields(map[string]interface{}{
+		"syscall":            nme,
+		log.WorkspaceIDField: h.WorkspaceId,
+		"pid":                req.Pid,
+		"id":                 req.ID,
+	})
+
+	var (
+		args  = req.Data.Args[:]
+		dirfd = int32(unix.AT_FDCWD)
+	)
+	if nme == "mknodat" {
+		dirfd = int32(args[0])
+		args = args[1:]
+	}
+	var (
+		mode = uint32(args[1])
+		// the kernel takes the device as unsigned int
+		dev   = uint64(uint32(args[2]))
+		major = unix.Major(dev)
+		minor = unix.Minor(dev)
+	)
+	// ws-daemon creates the devices of its allowlist only
+	if mode&unix.S_IFMT != unix.S_IFCHR || !daemonapi.IsMknodAllowed(major, minor) {
+		// let the kernel do the work
+		return 0, 0, libseccomp.NotifRespFlagContinue
+	}
+
+	memFile, err := readarg.OpenMem(req.Pid)
+	if err != nil {
+		log.WithError(err).Error("cannot open mem")
+		return Errno(unix.EPERM)
+	}
+	defer memFile.Close()
+
+	err = notifIDValid(h.FD, req.ID)
+	if err != nil {
+		log.WithError(err).Error("invalid notify ID", req.ID)
+		return Errno(unix.EPERM)
+	}
+
+	pth, err := readarg.ReadString(memFile, int64(args[0]))
+	if err != nil {
+		log.WithField("arg", 0).WithError(err).Error("cannot read argument")
+		return Errno(unix.EFAULT)
+	}
+	if pth == "" {
+		return Errno(unix.ENOENT)
+	}
+	if dirfd != unix.AT_FDCWD && !filepath.IsAbs(pth) {
+		// we cannot resolve paths relative to a file descriptor of the calling process
+		return 0, 0, libseccomp.NotifRespFlagContinue
+	}
+
+	log = log.WithField("path", pth).WithField("device", fmt.Sprintf("%d:%d", major, minor))
+	log.Debug("handling mknod syscall")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	iws, err := h.Daemon(ctx)
+	if err != nil {
+		log.WithError(err).Error("cannot get IWS client to mknod")
+		return Errno(unix.EFAULT)
+	}
+	defer iws.Close()
+
+	_, err = iws.Mknod(ctx, &daemonapi.MknodRequest{
+		Path:  pth,
+		Mode:  mode,
+		Major: major,
+		Minor: minor,
+		Pid:   int64(req.Pid),
+	})
+	if err != nil {
+		log.WithError(err).Error("cannot mknod")
+		return Errno(errnoFromStatus(err))
+	}
+
+	return 0, 0, 0
+}
+
+// xattrSizeMax is the maximum size of an extended attribute value the kernel accepts
+const xattrSizeMax = 65536
+
+// Setxattr handles setxattr and lsetxattr syscalls
+func (h *InWorkspaceHandler) Setxattr(req *libseccomp.ScmpNotifReq) (val uint64, errno int32, flags uint32) {
+	nme, _ := req.Data.Syscall.GetName()
+	log := log.WithFields(map[string]interface{}{
+		"syscall":            nme,
+		log.WorkspaceIDField: h.WorkspaceId,
+		"pid":                req.Pid,
+		"id":                 req.ID,
+	})
+
+	memFile, err := readarg.OpenMem(req.Pid)
+	if err != nil {
+		log.WithError(err).Error("cannot open mem")
+		return Errno(unix.EPERM)
+	}
+	defer memFile.Close()
+
+	err = notifIDValid(h.FD, req.ID)
+	if err != nil {
+		log.WithError(err).Error("invalid notify ID", req.ID)
+		return Errno(unix.EPERM)
+	}
+
+	name, err := readarg.ReadString(memFile, int64(req.Data.Args[1]))
+	if err != nil {
+		log.WithField("arg", 1).WithError(err).Error("cannot read argument")
+		return Errno(unix.EFAULT)
+	}
+	// ws-daemon sets the attributes of its allowlist only
+	if !daemonapi.IsSetXattrAllowed(name) {
+		// let the kernel do the work
+		return 0, 0, libseccomp.NotifRespFlagContinue
+	}
+
+	pth, err := readarg.ReadString(memFile, int64(req.Data.Args[0]))
+	if err != nil {
+		log.WithField("arg", 0).WithError(err).Error("cannot read argument")
+		return Errno(unix.EFAULT)
+	}
+	if pth == "" {
+		return Errno(unix.ENOENT)
+	}
+	size := req.Data.Args[3]
+	if size > xattrSizeMax {
+		return Errno(unix.E2BIG)
+	}
+	value, err := readarg.ReadBytes(memFile, int64(req.Data.Args[2]), int(size))
+	if err != nil {
+		log.WithField("arg", 2).WithError(err).Error("cannot read argument")
+		return Errno(unix.EFAULT)
+	}
+
+	log = log.WithField("path", pth).WithField("name", name)
+	log.Debug("handling setxattr syscall")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	iws, err := h.Daemon(ctx)
+	if err != nil {
+		log.WithError(err).Error("cannot get IWS client to setxattr")
+		return Errno(unix.EFAULT)
+	}
+	defer iws.Close()
+
+	_, err = iws.SetXattr(ctx, &daemonapi.SetXattrRequest{
+		Path:     pth,
+		Name:     name,
+		Value:    value,
+		Flags:    int32(req.Data.Args[4]),
+		NoFollow: nme == "lsetxattr",
+		Pid:      int64(req.Pid),
+	})
+	if err != nil {
+		log.WithError(err).Error("cannot setxattr")
+		return Errno(errnoFromStatus(err))
+	}
+
+	return 0, 0, 0
+}
+
+// errnoFromStatus translates the error of a ws-daemon call to the errno the syscall fails with
+func errnoFromStatus(err error) unix.Errno {
+	switch status.Code(err) {
+	case codes.AlreadyExists:
+		return unix.EEXIST
+	case codes.NotFound:
+		return unix.ENOENT
+	case codes.PermissionDenied:
+		return unix.EPERM
+	case codes.InvalidArgument:
+		return unix.EINVAL
+	default:
+		return unix.EFAULT
+	}
+}
+
 /*
 var nativeEndian binary.ByteOrder
 
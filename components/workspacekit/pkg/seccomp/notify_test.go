@@ -0,0 +1,413 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package seccomp
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"github.com/google/go-cmp/cmp"
+	libseccomp "github.com/seccomp/libseccomp-golang"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonapi "github.com/gitpod-io/gitpod/ws-daemon/api"
+)
+
+func init() {
+	// synthetic requests have no valid notification ID
+	notifIDValid = func(fd libseccomp.ScmpFd, id uint64) error { return nil }
+}
+
+type mknodCall struct {
+	Path  string
+	Mode  uint32
+	Major uint32
+	Minor uint32
+	Pid   int64
+}
+
+type setXattrCall struct {
+	Path     string
+	Name     string
+	Value    []byte
+	Flags    int32
+	NoFollow bool
+	Pid      int64
+}
+
+type fakeIWS struct {
+	daemonapi.InWorkspaceServiceClient
+
+	Err           error
+	MknodCalls    []mknodCall
+	SetXattrCalls []setXattrCall
+}
+
+func (f *fakeIWS) Mknod(ctx context.Context, in *daemonapi.MknodRequest, opts ...grpc.CallOption) (*daemonapi.MknodResponse, error) {
+	f.MknodCalls = append(f.MknodCalls, mknodCall{Path: in.Path, Mode: in.Mode, Major: in.Major, Minor: in.Minor, Pid: in.Pid})
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &daemonapi.MknodResponse{}, nil
+}
+
+func (f *fakeIWS) SetXattr(ctx context.Context, in *daemonapi.SetXattrRequest, opts ...grpc.CallOption) (*daemonapi.SetXattrResponse, error) {
+	f.SetXattrCalls = append(f.SetXattrCalls, setXattrCall{Path: in.Path, Name: in.Name, Value: in.Value, Flags: in.Flags, NoFollow: in.NoFollow, Pid: in.Pid})
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &daemonapi.SetXattrResponse{}, nil
+}
+
+func (f *fakeIWS) Close() error { return nil }
+
+type handlerResult struct {
+	Val   uint64
+	Errno int32
+	Flags uint32
+}
+
+var (
+	resultHandled  = handlerResult{}
+	resultContinue = handlerResult{Flags: libseccomp.NotifRespFlagContinue}
+)
+
+func resultErrno(err unix.Errno) handlerResult {
+	val, errno, flags := Errno(err)
+	return handlerResult{Val: val, Errno: errno, Flags: flags}
+}
+
+// newNotifReq produces a request as if this process had issued the syscall. Pointer arguments refer to memory
+// of this process, which the handler reads from /proc/self/mem.
+func newNotifReq(t *testing.T, syscall string, args ...uint64) *libseccomp.ScmpNotifReq {
+	sc, err := libseccomp.GetSyscallFromName(syscall)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := &libseccomp.ScmpNotifReq{
+		Pid: uint32(os.Getpid()),
+		Data: libseccomp.ScmpNotifData{
+			Syscall: sc,
+			Args:    make([]uint64, 6),
+		},
+	}
+	copy(res.Data.Args, args)
+	return res
+}
+
+func cstring(s string) []byte {
+	return append([]byte(s), 0)
+}
+
+func ptr(b []byte) uint64 {
+	return uint64(uintptr(unsafe.Pointer(&b[0])))
+}
+
+func TestMknod(t *testing.T) {
+	type Expectation struct {
+		Result handlerResult
+		Calls  []mknodCall
+	}
+	tests := []struct {
+		Name        string
+		Syscall     string
+		DirFD       int
+		Path        string
+		Mode        uint32
+		Major       uint32
+		Minor       uint32
+		DaemonErr   error
+		Expectation Expectation
+	}{
+		{
+			Name:    "allowed device",
+			Syscall: "mknod",
+			Path:    "/dev/null",
+			Mode:    unix.S_IFCHR | 0666,
+			Major:   1,
+			Minor:   3,
+			Expectation: Expectation{
+				Result: resultHandled,
+				Calls:  []mknodCall{{Path: "/dev/null", Mode: unix.S_IFCHR | 0666, Major: 1, Minor: 3, Pid: int64(os.Getpid())}},
+			},
+		},
+		{
+			Name:    "mknodat relative to cwd",
+			Syscall: "mknodat",
+			DirFD:   unix.AT_FDCWD,
+			Path:    "fuse",
+			Mode:    unix.S_IFCHR | 0600,
+			Major:   10,
+			Minor:   229,
+			Expectation: Expectation{
+				Result: resultHandled,
+				Calls:  []mknodCall{{Path: "fuse", Mode: unix.S_IFCHR | 0600, Major: 10, Minor: 229, Pid: int64(os.Getpid())}},
+			},
+		},
+		{
+			Name:        "mknodat relative to dirfd",
+			Syscall:     "mknodat",
+			DirFD:       3,
+			Path:        "null",
+			Mode:        unix.S_IFCHR | 0666,
+			Major:       1,
+			Minor:       3,
+			Expectation: Expectation{Result: resultContinue},
+		},
+		{
+			Name:    "mknodat absolute with dirfd",
+			Syscall: "mknodat",
+			DirFD:   3,
+			Path:    "/dev/null",
+			Mode:    unix.S_IFCHR | 0666,
+			Major:   1,
+			Minor:   3,
+			Expectation: Expectation{
+				Result: resultHandled,
+				Calls:  []mknodCall{{Path: "/dev/null", Mode: unix.S_IFCHR | 0666, Major: 1, Minor: 3, Pid: int64(os.Getpid())}},
+			},
+		},
+		{
+			Name:        "ptmx",
+			Syscall:     "mknod",
+			Path:        "/dev/ptmx",
+			Mode:        unix.S_IFCHR | 0666,
+			Major:       5,
+			Minor:       2,
+			Expectation: Expectation{Result: resultContinue},
+		},
+		{
+			Name:        "block device",
+			Syscall:     "mknod",
+			Path:        "/dev/null",
+			Mode:        unix.S_IFBLK | 0666,
+			Major:       1,
+			Minor:       3,
+			Expectation: Expectation{Result: resultContinue},
+		},
+		{
+			Name:        "regular file",
+			Syscall:     "mknod",
+			Path:        "/tmp/foo",
+			Mode:        unix.S_IFREG | 0644,
+			Expectation: Expectation{Result: resultContinue},
+		},
+		{
+			Name:        "fifo",
+			Syscall:     "mknodat",
+			DirFD:       unix.AT_FDCWD,
+			Path:        "/tmp/fifo",
+			Mode:        unix.S_IFIFO | 0644,
+			Expectation: Expectation{Result: resultContinue},
+		},
+		{
+			Name:        "device outside of allowlist",
+			Syscall:     "mknod",
+			Path:        "/dev/kvm",
+			Mode:        unix.S_IFCHR | 0660,
+			Major:       10,
+			Minor:       232,
+			Expectation: Expectation{Result: resultContinue},
+		},
+		{
+			Name:      "file exists",
+			Syscall:   "mknod",
+			Path:      "/dev/null",
+			Mode:      unix.S_IFCHR | 0666,
+			Major:     1,
+			Minor:     3,
+			DaemonErr: status.Error(codes.AlreadyExists, "file exists"),
+			Expectation: Expectation{
+				Result: resultErrno(unix.EEXIST),
+				Calls:  []mknodCall{{Path: "/dev/null", Mode: unix.S_IFCHR | 0666, Major: 1, Minor: 3, Pid: int64(os.Getpid())}},
+			},
+		},
+		{
+			Name:      "denied by daemon",
+			Syscall:   "mknod",
+			Path:      "/dev/net/tun",
+			Mode:      unix.S_IFCHR | 0666,
+			Major:     10,
+			Minor:     200,
+			DaemonErr: status.Error(codes.PermissionDenied, "permission denied"),
+			Expectation: Expectation{
+				Result: resultErrno(unix.EPERM),
+				Calls:  []mknodCall{{Path: "/dev/net/tun", Mode: unix.S_IFCHR | 0666, Major: 10, Minor: 200, Pid: int64(os.Getpid())}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			iws := &fakeIWS{Err: test.DaemonErr}
+			h := &InWorkspaceHandler{
+				Daemon: func(ctx context.Context) (InWorkspaceServiceClient, error) {
+					return iws, nil
+				},
+			}
+
+			pth := cstring(test.Path)
+			args := []uint64{ptr(pth), uint64(test.Mode), unix.Mkdev(test.Major, test.Minor)}
+			if test.Syscall == "mknodat" {
+				args = append([]uint64{uint64(test.DirFD)}, args...)
+			}
+
+			val, errno, flags := h.Mknod(newNotifReq(t, test.Syscall, args...))
+			runtime.KeepAlive(pth)
+
+			act := Expectation{
+				Result: handlerResult{Val: val, Errno: errno, Flags: flags},
+				Calls:  iws.MknodCalls,
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetxattr(t *testing.T) {
+	capValue := []byte{0x01, 0x00, 0x00, 0x02, 0x00, 0x20, 0x00, 0x00, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+	type Expectation struct {
+		Result handlerResult
+		Calls  []setXattrCall
+	}
+	tests := []struct {
+		Name        string
+		Syscall     string
+		Path        string
+		Attr        string
+		Value       []byte
+		Flags       int
+		DaemonErr   error
+		Expectation Expectation
+	}{
+		{
+			Name:    "capability",
+			Syscall: "setxattr",
+			Path:    "/usr/bin/ping",
+			Attr:    "security.capability",
+			Value:   capValue,
+			Expectation: Expectation{
+				Result: resultHandled,
+				Calls:  []setXattrCall{{Path: "/usr/bin/ping", Name: "security.capability", Value: capValue, Pid: int64(os.Getpid())}},
+			},
+		},
+		{
+			Name:    "lsetxattr with flags",
+			Syscall: "lsetxattr",
+			Path:    "ping",
+			Attr:    "security.capability",
+			Value:   capValue,
+			Flags:   unix.XATTR_CREATE,
+			Expectation: Expectation{
+				Result: resultHandled,
+				Calls:  []setXattrCall{{Path: "ping", Name: "security.capability", Value: capValue, Flags: unix.XATTR_CREATE, NoFollow: true, Pid: int64(os.Getpid())}},
+			},
+		},
+		{
+			Name:        "user attribute",
+			Syscall:     "setxattr",
+			Path:        "/workspace/foo",
+			Attr:        "user.foo",
+			Value:       []byte("bar"),
+			Expectation: Expectation{Result: resultContinue},
+		},
+		{
+			Name:        "other security attribute",
+			Syscall:     "setxattr",
+			Path:        "/workspace/foo",
+			Attr:        "security.capabilityfoo",
+			Value:       []byte("bar"),
+			Expectation: Expectation{Result: resultContinue},
+		},
+		{
+			Name:        "selinux label",
+			Syscall:     "setxattr",
+			Path:        "/workspace/foo",
+			Attr:        "security.selinux",
+			Value:       []byte("system_u:object_r:container_file_t:s0"),
+			Expectation: Expectation{Result: resultContinue},
+		},
+		{
+			Name:        "namespace of an allowed attribute",
+			Syscall:     "setxattr",
+			Path:        "/workspace/foo",
+			Attr:        "security",
+			Value:       []byte("bar"),
+			Expectation: Expectation{Result: resultContinue},
+		},
+		{
+			Name:      "file not found",
+			Syscall:   "setxattr",
+			Path:      "/usr/bin/ping",
+			Attr:      "security.capability",
+			Value:     capValue,
+			DaemonErr: status.Error(codes.NotFound, "no such file or directory"),
+			Expectation: Expectation{
+				Result: resultErrno(unix.ENOENT),
+				Calls:  []setXattrCall{{Path: "/usr/bin/ping", Name: "security.capability", Value: capValue, Pid: int64(os.Getpid())}},
+			},
+		},
+		{
+			Name:      "invalid value",
+			Syscall:   "setxattr",
+			Path:      "/usr/bin/ping",
+			Attr:      "security.capability",
+			Value:     []byte("foo"),
+			DaemonErr: status.Error(codes.InvalidArgument, "invalid file capability"),
+			Expectation: Expectation{
+				Result: resultErrno(unix.EINVAL),
+				Calls:  []setXattrCall{{Path: "/usr/bin/ping", Name: "security.capability", Value: []byte("foo"), Pid: int64(os.Getpid())}},
+			},
+		},
+		{
+			Name:      "daemon failure",
+			Syscall:   "setxattr",
+			Path:      "/usr/bin/ping",
+			Attr:      "security.capability",
+			Value:     capValue,
+			DaemonErr: status.Error(codes.Internal, "cannot setxattr"),
+			Expectation: Expectation{
+				Result: resultErrno(unix.EFAULT),
+				Calls:  []setXattrCall{{Path: "/usr/bin/ping", Name: "security.capability", Value: capValue, Pid: int64(os.Getpid())}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			iws := &fakeIWS{Err: test.DaemonErr}
+			h := &InWorkspaceHandler{
+				Daemon: func(ctx context.Context) (InWorkspaceServiceClient, error) {
+					return iws, nil
+				},
+			}
+
+			var (
+				pth  = cstring(test.Path)
+				name = cstring(test.Attr)
+			)
+			val, errno, flags := h.Setxattr(newNotifReq(t, test.Syscall, ptr(pth), ptr(name), ptr(test.Value), uint64(len(test.Value)), uint64(test.Flags)))
+			runtime.KeepAlive(pth)
+			runtime.KeepAlive(name)
+			runtime.KeepAlive(test.Value)
+
+			act := Expectation{
+				Result: handlerResult{Val: val, Errno: errno, Flags: flags},
+				Calls:  iws.SetXattrCalls,
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package api
+
+// The allowlists below are shared by workspacekit, which forwards the syscalls of workspace processes
+// to ws-daemon, and ws-daemon, which refuses all requests outside of them.
+var (
+	// mknodAllowedDevices lists the character devices workspace processes can create using Mknod.
+	// These devices are safe to expose to any process. /dev/ptmx (5:2) is deliberately missing because it
+	// refers to the devpts instance of the node rather than that of the workspace.
+	mknodAllowedDevices = map[[2]uint32]struct{}{
+		{1, 3}:    {}, // null
+		{1, 5}:    {}, // zero
+		{1, 7}:    {}, // full
+		{1, 8}:    {}, // random
+		{1, 9}:    {}, // urandom
+		{5, 0}:    {}, // tty
+		{10, 200}: {}, // net/tun
+		{10, 229}: {}, // fuse
+	}
+
+	// setXattrAllowedNames lists the extended attributes workspace processes can set using SetXattr
+	setXattrAllowedNames = map[string]struct{}{
+		"security.capability": {},
+	}
+)
+
+// IsMknodAllowed returns true if workspace processes can create the character device using Mknod
+func IsMknodAllowed(major, minor uint32) bool {
+	_, ok := mknodAllowedDevices[[2]uint32{major, minor}]
+	return ok
+}
+
+// IsSetXattrAllowed returns true if workspace processes can set the extended attribute using SetXattr.
+// Only the exact names are allowed, not other attributes of their namespace, e.g. security.selinux.
+func IsSetXattrAllowed(name string) bool {
+	_, ok := setXattrAllowedNames[name]
+	return ok
+}
@@ -329,6 +329,26 @@ func (mr *MockInWorkspaceServiceClientMockRecorder) EvacuateCGroup(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvacuateCGroup", reflect.TypeOf((*MockInWorkspaceServiceClient)(nil).EvacuateCGroup), varargs...)
 }
 
+// Mknod mocks base method.
+func (m *MockInWorkspaceServiceClient) Mknod(arg0 context.Context, arg1 *api.MknodRequest, arg2 ...grpc.CallOption) (*api.MknodResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Mknod", varargs...)
+	ret0, _ := ret[0].(*api.MknodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Mknod indicates an expected call of Mknod.
+func (mr *MockInWorkspaceServiceClientMockRecorder) Mknod(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mknod", reflect.TypeOf((*MockInWorkspaceServiceClient)(nil).Mknod), varargs...)
+}
+
 // MountProc mocks base method.
 func (m *MockInWorkspaceServiceClient) MountProc(arg0 context.Context, arg1 *api.MountProcRequest, arg2 ...grpc.CallOption) (*api.MountProcResponse, error) {
 	m.ctrl.T.Helper()
@@ -389,6 +409,26 @@ func (mr *MockInWorkspaceServiceClientMockRecorder) PrepareForUserNS(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareForUserNS", reflect.TypeOf((*MockInWorkspaceServiceClient)(nil).PrepareForUserNS), varargs...)
 }
 
+// SetXattr mocks base method.
+func (m *MockInWorkspaceServiceClient) SetXattr(arg0 context.Context, arg1 *api.SetXattrRequest, arg2 ...grpc.CallOption) (*api.SetXattrResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetXattr", varargs...)
+	ret0, _ := ret[0].(*api.SetXattrResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetXattr indicates an expected call of SetXattr.
+func (mr *MockInWorkspaceServiceClientMockRecorder) SetXattr(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetXattr", reflect.TypeOf((*MockInWorkspaceServiceClient)(nil).SetXattr), varargs...)
+}
+
 // SetupPairVeths mocks base method.
 func (m *MockInWorkspaceServiceClient) SetupPairVeths(arg0 context.Context, arg1 *api.SetupPairVethsRequest, arg2 ...grpc.CallOption) (*api.SetupPairVethsResponse, error) {
 	m.ctrl.T.Helper()
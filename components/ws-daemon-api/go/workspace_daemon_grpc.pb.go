@@ -63,6 +63,14 @@ type InWorkspaceServiceClient interface {
 	SetupPairVeths(ctx context.Context, in *SetupPairVethsRequest, opts ...grpc.CallOption) (*SetupPairVethsResponse, error)
 	// Get information about the workspace
 	WorkspaceInfo(ctx context.Context, in *WorkspaceInfoRequest, opts ...grpc.CallOption) (*WorkspaceInfoResponse, error)
+	// Mknod creates a character device node on behalf of a workspace process.
+	// Only well-known, safe devices can be created. The PID must be in the PID namespace of the workspace container.
+	// The path is relative to the mount namespace and working directory of the PID.
+	Mknod(ctx context.Context, in *MknodRequest, opts ...grpc.CallOption) (*MknodResponse, error)
+	// SetXattr sets an extended attribute on behalf of a workspace process.
+	// Only security.capability is supported. The PID must be in the PID namespace of the workspace container.
+	// The path is relative to the mount namespace and working directory of the PID.
+	SetXattr(ctx context.Context, in *SetXattrRequest, opts ...grpc.CallOption) (*SetXattrResponse, error)
 }
 
 type inWorkspaceServiceClient struct {
@@ -163,6 +171,24 @@ func (c *inWorkspaceServiceClient) WorkspaceInfo(ctx context.Context, in *Worksp
 	return out, nil
 }
 
+func (c *inWorkspaceServiceClient) Mknod(ctx context.Context, in *MknodRequest, opts ...grpc.CallOption) (*MknodResponse, error) {
+	out := new(MknodResponse)
+	err := c.cc.Invoke(ctx, "/iws.InWorkspaceService/Mknod", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inWorkspaceServiceClient) SetXattr(ctx context.Context, in *SetXattrRequest, opts ...grpc.CallOption) (*SetXattrResponse, error) {
+	out := new(SetXattrResponse)
+	err := c.cc.Invoke(ctx, "/iws.InWorkspaceService/SetXattr", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InWorkspaceServiceServer is the server API for InWorkspaceService service.
 // All implementations must embed UnimplementedInWorkspaceServiceServer
 // for forward compatibility
@@ -204,6 +230,14 @@ type InWorkspaceServiceServer interface {
 	SetupPairVeths(context.Context, *SetupPairVethsRequest) (*SetupPairVethsResponse, error)
 	// Get information about the workspace
 	WorkspaceInfo(context.Context, *WorkspaceInfoRequest) (*WorkspaceInfoResponse, error)
+	// Mknod creates a character device node on behalf of a workspace process.
+	// Only well-known, safe devices can be created. The PID must be in the PID namespace of the workspace container.
+	// The path is relative to the mount namespace and working directory of the PID.
+	Mknod(context.Context, *MknodRequest) (*MknodResponse, error)
+	// SetXattr sets an extended attribute on behalf of a workspace process.
+	// Only security.capability is supported. The PID must be in the PID namespace of the workspace container.
+	// The path is relative to the mount namespace and working directory of the PID.
+	SetXattr(context.Context, *SetXattrRequest) (*SetXattrResponse, error)
 	mustEmbedUnimplementedInWorkspaceServiceServer()
 }
 
@@ -241,6 +275,12 @@ func (UnimplementedInWorkspaceServiceServer) SetupPairVeths(context.Context, *Se
 func (UnimplementedInWorkspaceServiceServer) WorkspaceInfo(context.Context, *WorkspaceInfoRequest) (*WorkspaceInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WorkspaceInfo not implemented")
 }
+func (UnimplementedInWorkspaceServiceServer) Mknod(context.Context, *MknodRequest) (*MknodResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Mknod not implemented")
+}
+func (UnimplementedInWorkspaceServiceServer) SetXattr(context.Context, *SetXattrRequest) (*SetXattrResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetXattr not implemented")
+}
 func (UnimplementedInWorkspaceServiceServer) mustEmbedUnimplementedInWorkspaceServiceServer() {}
 
 // UnsafeInWorkspaceServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -434,6 +474,42 @@ func _InWorkspaceService_WorkspaceInfo_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InWorkspaceService_Mknod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MknodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InWorkspaceServiceServer).Mknod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iws.InWorkspaceService/Mknod",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InWorkspaceServiceServer).Mknod(ctx, req.(*MknodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InWorkspaceService_SetXattr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetXattrRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InWorkspaceServiceServer).SetXattr(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iws.InWorkspaceService/SetXattr",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InWorkspaceServiceServer).SetXattr(ctx, req.(*SetXattrRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InWorkspaceService_ServiceDesc is the grpc.ServiceDesc for InWorkspaceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -481,6 +557,14 @@ var InWorkspaceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "WorkspaceInfo",
 			Handler:    _InWorkspaceService_WorkspaceInfo_Handler,
 		},
+		{
+			MethodName: "Mknod",
+			Handler:    _InWorkspaceService_Mknod_Handler,
+		},
+		{
+			MethodName: "SetXattr",
+			Handler:    _InWorkspaceService_SetXattr_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "workspace_daemon.proto",
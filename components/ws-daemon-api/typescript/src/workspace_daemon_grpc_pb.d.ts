@@ -24,6 +24,8 @@ interface IInWorkspaceServiceService extends grpc.ServiceDefinition<grpc.Untyped
     teardown: IInWorkspaceServiceService_ITeardown;
     setupPairVeths: IInWorkspaceServiceService_ISetupPairVeths;
     workspaceInfo: IInWorkspaceServiceService_IWorkspaceInfo;
+    mknod: IInWorkspaceServiceService_IMknod;
+    setXattr: IInWorkspaceServiceService_ISetXattr;
 }
 
 interface IInWorkspaceServiceService_IPrepareForUserNS
@@ -138,6 +140,26 @@ interface IInWorkspaceServiceService_IWorkspaceInfo
     responseSerialize: grpc.serialize<workspace_daemon_pb.WorkspaceInfoResponse>;
     responseDeserialize: grpc.deserialize<workspace_daemon_pb.WorkspaceInfoResponse>;
 }
+interface IInWorkspaceServiceService_IMknod
+    extends grpc.MethodDefinition<workspace_daemon_pb.MknodRequest, workspace_daemon_pb.MknodResponse> {
+    path: "/iws.InWorkspaceService/Mknod";
+    requestStream: false;
+    responseStream: false;
+    requestSerialize: grpc.serialize<workspace_daemon_pb.MknodRequest>;
+    requestDeserialize: grpc.deserialize<workspace_daemon_pb.MknodRequest>;
+    responseSerialize: grpc.serialize<workspace_daemon_pb.MknodResponse>;
+    responseDeserialize: grpc.deserialize<workspace_daemon_pb.MknodResponse>;
+}
+interface IInWorkspaceServiceService_ISetXattr
+    extends grpc.MethodDefinition<workspace_daemon_pb.SetXattrRequest, workspace_daemon_pb.SetXattrResponse> {
+    path: "/iws.InWorkspaceService/SetXattr";
+    requestStream: false;
+    responseStream: false;
+    requestSerialize: grpc.serialize<workspace_daemon_pb.SetXattrRequest>;
+    requestDeserialize: grpc.deserialize<workspace_daemon_pb.SetXattrRequest>;
+    responseSerialize: grpc.serialize<workspace_daemon_pb.SetXattrResponse>;
+    responseDeserialize: grpc.deserialize<workspace_daemon_pb.SetXattrResponse>;
+}
 
 export const InWorkspaceServiceService: IInWorkspaceServiceService;
 
@@ -167,6 +189,8 @@ export interface IInWorkspaceServiceServer extends grpc.UntypedServiceImplementa
         workspace_daemon_pb.WorkspaceInfoRequest,
         workspace_daemon_pb.WorkspaceInfoResponse
     >;
+    mknod: grpc.handleUnaryCall<workspace_daemon_pb.MknodRequest, workspace_daemon_pb.MknodResponse>;
+    setXattr: grpc.handleUnaryCall<workspace_daemon_pb.SetXattrRequest, workspace_daemon_pb.SetXattrResponse>;
 }
 
 export interface IInWorkspaceServiceClient {
@@ -320,6 +344,36 @@ export interface IInWorkspaceServiceClient {
         options: Partial<grpc.CallOptions>,
         callback: (error: grpc.ServiceError | null, response: workspace_daemon_pb.WorkspaceInfoResponse) => void,
     ): grpc.ClientUnaryCall;
+    mknod(
+        request: workspace_daemon_pb.MknodRequest,
+        callback: (error: grpc.ServiceError | null, response: workspace_daemon_pb.MknodResponse) => void,
+    ): grpc.ClientUnaryCall;
+    mknod(
+        request: workspace_daemon_pb.MknodRequest,
+        metadata: grpc.Metadata,
+        callback: (error: grpc.ServiceError | null, response: workspace_daemon_pb.MknodResponse) => void,
+    ): grpc.ClientUnaryCall;
+    mknod(
+        request: workspace_daemon_pb.MknodRequest,
+        metadata: grpc.Metadata,
+        options: Partial<grpc.CallOptions>,
+        callback: (error: grpc.ServiceError | null, response: workspace_daemon_pb.MknodResponse) => void,
+    ): grpc.ClientUnaryCall;
+    setXattr(
+        request: workspace_daemon_pb.SetXattrRequest,
+        callback: (error: grpc.ServiceError | null, response: workspace_daemon_pb.SetXattrResponse) => void,
+    ): grpc.ClientUnaryCall;
+    setXattr(
+        request: workspace_daemon_pb.SetXattrRequest,
+        metadata: grpc.Metadata,
+        callback: (error: grpc.ServiceError | null, response: workspace_daemon_pb.SetXattrResponse) => void,
+    ): grpc.ClientUnaryCall;
+    setXattr(
+        request: workspace_daemon_pb.SetXattrRequest,
+        metadata: grpc.Metadata,
+        options: Partial<grpc.CallOptions>,
+        callback: (error: grpc.ServiceError | null, response: workspace_daemon_pb.SetXattrResponse) => void,
+    ): grpc.ClientUnaryCall;
 }
 
 export class InWorkspaceServiceClient extends grpc.Client implements IInWorkspaceServiceClient {
@@ -474,6 +528,36 @@ export class InWorkspaceServiceClient extends grpc.Client implements IInWorkspac
         options: Partial<grpc.CallOptions>,
         callback: (error: grpc.ServiceError | null, response: workspace_daemon_pb.WorkspaceInfoResponse) => void,
     ): grpc.ClientUnaryCall;
+    public mknod(
+        request: workspace_daemon_pb.MknodRequest,
+        callback: (error: grpc.ServiceError | null, response: workspace_daemon_pb.MknodResponse) => void,
+    ): grpc.ClientUnaryCall;
+    public mknod(
+        request: workspace_daemon_pb.MknodRequest,
+        metadata: grpc.Metadata,
+        callback: (error: grpc.ServiceError | null, response: workspace_daemon_pb.MknodResponse) => void,
+    ): grpc.ClientUnaryCall;
+    public mknod(
+        request: workspace_daemon_pb.MknodRequest,
+        metadata: grpc.Metadata,
+        options: Partial<grpc.CallOptions>,
+        callback: (error: grpc.ServiceError | null, response: workspace_daemon_pb.MknodResponse) => void,
+    ): grpc.ClientUnaryCall;
+    public setXattr(
+        request: workspace_daemon_pb.SetXattrRequest,
+        callback: (error: grpc.ServiceError | null, response: workspace_daemon_pb.SetXattrResponse) => void,
+    ): grpc.ClientUnaryCall;
+    public setXattr(
+        request: workspace_daemon_pb.SetXattrRequest,
+        metadata: grpc.Metadata,
+        callback: (error: grpc.ServiceError | null, response: workspace_daemon_pb.SetXattrResponse) => void,
+    ): grpc.ClientUnaryCall;
+    public setXattr(
+        request: workspace_daemon_pb.SetXattrRequest,
+        metadata: grpc.Metadata,
+        options: Partial<grpc.CallOptions>,
+        callback: (error: grpc.ServiceError | null, response: workspace_daemon_pb.SetXattrResponse) => void,
+    ): grpc.ClientUnaryCall;
 }
 
 interface IWorkspaceInfoServiceService extends grpc.ServiceDefinition<grpc.UntypedServiceImplementation> {
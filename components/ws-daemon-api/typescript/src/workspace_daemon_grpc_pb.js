@@ -32,6 +32,28 @@ function deserialize_iws_EvacuateCGroupResponse(buffer_arg) {
     return workspace_daemon_pb.EvacuateCGroupResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_iws_MknodRequest(arg) {
+    if (!(arg instanceof workspace_daemon_pb.MknodRequest)) {
+        throw new Error("Expected argument of type iws.MknodRequest");
+    }
+    return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_iws_MknodRequest(buffer_arg) {
+    return workspace_daemon_pb.MknodRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_iws_MknodResponse(arg) {
+    if (!(arg instanceof workspace_daemon_pb.MknodResponse)) {
+        throw new Error("Expected argument of type iws.MknodResponse");
+    }
+    return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_iws_MknodResponse(buffer_arg) {
+    return workspace_daemon_pb.MknodResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_iws_MountProcRequest(arg) {
     if (!(arg instanceof workspace_daemon_pb.MountProcRequest)) {
         throw new Error("Expected argument of type iws.MountProcRequest");
@@ -76,6 +98,28 @@ function deserialize_iws_PrepareForUserNSResponse(buffer_arg) {
     return workspace_daemon_pb.PrepareForUserNSResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_iws_SetXattrRequest(arg) {
+    if (!(arg instanceof workspace_daemon_pb.SetXattrRequest)) {
+        throw new Error("Expected argument of type iws.SetXattrRequest");
+    }
+    return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_iws_SetXattrRequest(buffer_arg) {
+    return workspace_daemon_pb.SetXattrRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_iws_SetXattrResponse(arg) {
+    if (!(arg instanceof workspace_daemon_pb.SetXattrResponse)) {
+        throw new Error("Expected argument of type iws.SetXattrResponse");
+    }
+    return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_iws_SetXattrResponse(buffer_arg) {
+    return workspace_daemon_pb.SetXattrResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_iws_SetupPairVethsRequest(arg) {
     if (!(arg instanceof workspace_daemon_pb.SetupPairVethsRequest)) {
         throw new Error("Expected argument of type iws.SetupPairVethsRequest");
@@ -324,6 +368,34 @@ var InWorkspaceServiceService = (exports.InWorkspaceServiceService = {
         responseSerialize: serialize_iws_WorkspaceInfoResponse,
         responseDeserialize: deserialize_iws_WorkspaceInfoResponse,
     },
+    // Mknod creates a character device node on behalf of a workspace process.
+    // Only well-known, safe devices can be created. The PID must be in the PID namespace of the workspace container.
+    // The path is relative to the mount namespace and working directory of the PID.
+    mknod: {
+        path: "/iws.InWorkspaceService/Mknod",
+        requestStream: false,
+        responseStream: false,
+        requestType: workspace_daemon_pb.MknodRequest,
+        responseType: workspace_daemon_pb.MknodResponse,
+        requestSerialize: serialize_iws_MknodRequest,
+        requestDeserialize: deserialize_iws_MknodRequest,
+        responseSerialize: serialize_iws_MknodResponse,
+        responseDeserialize: deserialize_iws_MknodResponse,
+    },
+    // SetXattr sets an extended attribute on behalf of a workspace process.
+    // Only security.capability is supported. The PID must be in the PID namespace of the workspace container.
+    // The path is relative to the mount namespace and working directory of the PID.
+    setXattr: {
+        path: "/iws.InWorkspaceService/SetXattr",
+        requestStream: false,
+        responseStream: false,
+        requestType: workspace_daemon_pb.SetXattrRequest,
+        responseType: workspace_daemon_pb.SetXattrResponse,
+        requestSerialize: serialize_iws_SetXattrRequest,
+        requestDeserialize: deserialize_iws_SetXattrRequest,
+        responseSerialize: serialize_iws_SetXattrResponse,
+        responseDeserialize: deserialize_iws_SetXattrResponse,
+    },
 });
 
 exports.InWorkspaceServiceClient = grpc.makeGenericClientConstructor(InWorkspaceServiceService);
@@ -444,6 +444,105 @@ export namespace Memory {
     };
 }
 
+export class MknodRequest extends jspb.Message {
+    getPath(): string;
+    setPath(value: string): MknodRequest;
+    getMode(): number;
+    setMode(value: number): MknodRequest;
+    getMajor(): number;
+    setMajor(value: number): MknodRequest;
+    getMinor(): number;
+    setMinor(value: number): MknodRequest;
+    getPid(): number;
+    setPid(value: number): MknodRequest;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): MknodRequest.AsObject;
+    static toObject(includeInstance: boolean, msg: MknodRequest): MknodRequest.AsObject;
+    static extensions: { [key: number]: jspb.ExtensionFieldInfo<jspb.Message> };
+    static extensionsBinary: { [key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message> };
+    static serializeBinaryToWriter(message: MknodRequest, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): MknodRequest;
+    static deserializeBinaryFromReader(message: MknodRequest, reader: jspb.BinaryReader): MknodRequest;
+}
+
+export namespace MknodRequest {
+    export type AsObject = {
+        path: string;
+        mode: number;
+        major: number;
+        minor: number;
+        pid: number;
+    };
+}
+
+export class MknodResponse extends jspb.Message {
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): MknodResponse.AsObject;
+    static toObject(includeInstance: boolean, msg: MknodResponse): MknodResponse.AsObject;
+    static extensions: { [key: number]: jspb.ExtensionFieldInfo<jspb.Message> };
+    static extensionsBinary: { [key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message> };
+    static serializeBinaryToWriter(message: MknodResponse, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): MknodResponse;
+    static deserializeBinaryFromReader(message: MknodResponse, reader: jspb.BinaryReader): MknodResponse;
+}
+
+export namespace MknodResponse {
+    export type AsObject = {};
+}
+
+export class SetXattrRequest extends jspb.Message {
+    getPath(): string;
+    setPath(value: string): SetXattrRequest;
+    getName(): string;
+    setName(value: string): SetXattrRequest;
+    getValue(): Uint8Array | string;
+    getValue_asU8(): Uint8Array;
+    getValue_asB64(): string;
+    setValue(value: Uint8Array | string): SetXattrRequest;
+    getFlags(): number;
+    setFlags(value: number): SetXattrRequest;
+    getNoFollow(): boolean;
+    setNoFollow(value: boolean): SetXattrRequest;
+    getPid(): number;
+    setPid(value: number): SetXattrRequest;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): SetXattrRequest.AsObject;
+    static toObject(includeInstance: boolean, msg: SetXattrRequest): SetXattrRequest.AsObject;
+    static extensions: { [key: number]: jspb.ExtensionFieldInfo<jspb.Message> };
+    static extensionsBinary: { [key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message> };
+    static serializeBinaryToWriter(message: SetXattrRequest, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): SetXattrRequest;
+    static deserializeBinaryFromReader(message: SetXattrRequest, reader: jspb.BinaryReader): SetXattrRequest;
+}
+
+export namespace SetXattrRequest {
+    export type AsObject = {
+        path: string;
+        name: string;
+        value: Uint8Array | string;
+        flags: number;
+        noFollow: boolean;
+        pid: number;
+    };
+}
+
+export class SetXattrResponse extends jspb.Message {
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): SetXattrResponse.AsObject;
+    static toObject(includeInstance: boolean, msg: SetXattrResponse): SetXattrResponse.AsObject;
+    static extensions: { [key: number]: jspb.ExtensionFieldInfo<jspb.Message> };
+    static extensionsBinary: { [key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message> };
+    static serializeBinaryToWriter(message: SetXattrResponse, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): SetXattrResponse;
+    static deserializeBinaryFromReader(message: SetXattrResponse, reader: jspb.BinaryReader): SetXattrResponse;
+}
+
+export namespace SetXattrResponse {
+    export type AsObject = {};
+}
+
 export enum FSShiftMethod {
     SHIFTFS = 0,
     FUSE = 1,
@@ -28,11 +28,15 @@ goog.exportSymbol("proto.iws.EvacuateCGroupRequest", null, global);
 goog.exportSymbol("proto.iws.EvacuateCGroupResponse", null, global);
 goog.exportSymbol("proto.iws.FSShiftMethod", null, global);
 goog.exportSymbol("proto.iws.Memory", null, global);
+goog.exportSymbol("proto.iws.MknodRequest", null, global);
+goog.exportSymbol("proto.iws.MknodResponse", null, global);
 goog.exportSymbol("proto.iws.MountProcRequest", null, global);
 goog.exportSymbol("proto.iws.MountProcResponse", null, global);
 goog.exportSymbol("proto.iws.PrepareForUserNSRequest", null, global);
 goog.exportSymbol("proto.iws.PrepareForUserNSResponse", null, global);
 goog.exportSymbol("proto.iws.Resources", null, global);
+goog.exportSymbol("proto.iws.SetXattrRequest", null, global);
+goog.exportSymbol("proto.iws.SetXattrResponse", null, global);
 goog.exportSymbol("proto.iws.SetupPairVethsRequest", null, global);
 goog.exportSymbol("proto.iws.SetupPairVethsResponse", null, global);
 goog.exportSymbol("proto.iws.TeardownRequest", null, global);
@@ -464,6 +468,90 @@ if (goog.DEBUG && !COMPILED) {
      */
     proto.iws.Memory.displayName = "proto.iws.Memory";
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.iws.MknodRequest = function (opt_data) {
+    jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.iws.MknodRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+    /**
+     * @public
+     * @override
+     */
+    proto.iws.MknodRequest.displayName = "proto.iws.MknodRequest";
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.iws.MknodResponse = function (opt_data) {
+    jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.iws.MknodResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+    /**
+     * @public
+     * @override
+     */
+    proto.iws.MknodResponse.displayName = "proto.iws.MknodResponse";
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.iws.SetXattrRequest = function (opt_data) {
+    jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.iws.SetXattrRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+    /**
+     * @public
+     * @override
+     */
+    proto.iws.SetXattrRequest.displayName = "proto.iws.SetXattrRequest";
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.iws.SetXattrResponse = function (opt_data) {
+    jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.iws.SetXattrResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+    /**
+     * @public
+     * @override
+     */
+    proto.iws.SetXattrResponse.displayName = "proto.iws.SetXattrResponse";
+}
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
     /**
@@ -2985,6 +3073,667 @@ proto.iws.Memory.prototype.setLimit = function (value) {
     return jspb.Message.setProto3IntField(this, 2, value);
 };
 
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.iws.MknodRequest.prototype.toObject = function (opt_includeInstance) {
+        return proto.iws.MknodRequest.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.iws.MknodRequest} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.iws.MknodRequest.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {
+                path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+                mode: jspb.Message.getFieldWithDefault(msg, 2, 0),
+                major: jspb.Message.getFieldWithDefault(msg, 3, 0),
+                minor: jspb.Message.getFieldWithDefault(msg, 4, 0),
+                pid: jspb.Message.getFieldWithDefault(msg, 5, 0),
+            };
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.iws.MknodRequest}
+ */
+proto.iws.MknodRequest.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.iws.MknodRequest();
+    return proto.iws.MknodRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.iws.MknodRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.iws.MknodRequest}
+ */
+proto.iws.MknodRequest.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            case 1:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setPath(value);
+                break;
+            case 2:
+                var value = /** @type {number} */ (reader.readUint32());
+                msg.setMode(value);
+                break;
+            case 3:
+                var value = /** @type {number} */ (reader.readUint32());
+                msg.setMajor(value);
+                break;
+            case 4:
+                var value = /** @type {number} */ (reader.readUint32());
+                msg.setMinor(value);
+                break;
+            case 5:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setPid(value);
+                break;
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.iws.MknodRequest.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.iws.MknodRequest.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.iws.MknodRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.MknodRequest.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+    f = message.getPath();
+    if (f.length > 0) {
+        writer.writeString(1, f);
+    }
+    f = message.getMode();
+    if (f !== 0) {
+        writer.writeUint32(2, f);
+    }
+    f = message.getMajor();
+    if (f !== 0) {
+        writer.writeUint32(3, f);
+    }
+    f = message.getMinor();
+    if (f !== 0) {
+        writer.writeUint32(4, f);
+    }
+    f = message.getPid();
+    if (f !== 0) {
+        writer.writeInt64(5, f);
+    }
+};
+
+/**
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.iws.MknodRequest.prototype.getPath = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.iws.MknodRequest} returns this
+ */
+proto.iws.MknodRequest.prototype.setPath = function (value) {
+    return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+/**
+ * optional uint32 mode = 2;
+ * @return {number}
+ */
+proto.iws.MknodRequest.prototype.getMode = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.MknodRequest} returns this
+ */
+proto.iws.MknodRequest.prototype.setMode = function (value) {
+    return jspb.Message.setProto3IntField(this, 2, value);
+};
+
+/**
+ * optional uint32 major = 3;
+ * @return {number}
+ */
+proto.iws.MknodRequest.prototype.getMajor = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.MknodRequest} returns this
+ */
+proto.iws.MknodRequest.prototype.setMajor = function (value) {
+    return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+/**
+ * optional uint32 minor = 4;
+ * @return {number}
+ */
+proto.iws.MknodRequest.prototype.getMinor = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.MknodRequest} returns this
+ */
+proto.iws.MknodRequest.prototype.setMinor = function (value) {
+    return jspb.Message.setProto3IntField(this, 4, value);
+};
+
+/**
+ * optional int64 pid = 5;
+ * @return {number}
+ */
+proto.iws.MknodRequest.prototype.getPid = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.MknodRequest} returns this
+ */
+proto.iws.MknodRequest.prototype.setPid = function (value) {
+    return jspb.Message.setProto3IntField(this, 5, value);
+};
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.iws.MknodResponse.prototype.toObject = function (opt_includeInstance) {
+        return proto.iws.MknodResponse.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.iws.MknodResponse} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.iws.MknodResponse.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {};
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.iws.MknodResponse}
+ */
+proto.iws.MknodResponse.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.iws.MknodResponse();
+    return proto.iws.MknodResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.iws.MknodResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.iws.MknodResponse}
+ */
+proto.iws.MknodResponse.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.iws.MknodResponse.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.iws.MknodResponse.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.iws.MknodResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.MknodResponse.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+};
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.iws.SetXattrRequest.prototype.toObject = function (opt_includeInstance) {
+        return proto.iws.SetXattrRequest.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.iws.SetXattrRequest} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.iws.SetXattrRequest.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {
+                path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+                name: jspb.Message.getFieldWithDefault(msg, 2, ""),
+                value: msg.getValue_asB64(),
+                flags: jspb.Message.getFieldWithDefault(msg, 4, 0),
+                noFollow: jspb.Message.getBooleanFieldWithDefault(msg, 5, false),
+                pid: jspb.Message.getFieldWithDefault(msg, 6, 0),
+            };
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.iws.SetXattrRequest}
+ */
+proto.iws.SetXattrRequest.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.iws.SetXattrRequest();
+    return proto.iws.SetXattrRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.iws.SetXattrRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.iws.SetXattrRequest}
+ */
+proto.iws.SetXattrRequest.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            case 1:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setPath(value);
+                break;
+            case 2:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setName(value);
+                break;
+            case 3:
+                var value = /** @type {!Uint8Array} */ (reader.readBytes());
+                msg.setValue(value);
+                break;
+            case 4:
+                var value = /** @type {number} */ (reader.readInt32());
+                msg.setFlags(value);
+                break;
+            case 5:
+                var value = /** @type {boolean} */ (reader.readBool());
+                msg.setNoFollow(value);
+                break;
+            case 6:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setPid(value);
+                break;
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.iws.SetXattrRequest.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.iws.SetXattrRequest.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.iws.SetXattrRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.SetXattrRequest.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+    f = message.getPath();
+    if (f.length > 0) {
+        writer.writeString(1, f);
+    }
+    f = message.getName();
+    if (f.length > 0) {
+        writer.writeString(2, f);
+    }
+    f = message.getValue_asU8();
+    if (f.length > 0) {
+        writer.writeBytes(3, f);
+    }
+    f = message.getFlags();
+    if (f !== 0) {
+        writer.writeInt32(4, f);
+    }
+    f = message.getNoFollow();
+    if (f) {
+        writer.writeBool(5, f);
+    }
+    f = message.getPid();
+    if (f !== 0) {
+        writer.writeInt64(6, f);
+    }
+};
+
+/**
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.iws.SetXattrRequest.prototype.getPath = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.iws.SetXattrRequest} returns this
+ */
+proto.iws.SetXattrRequest.prototype.setPath = function (value) {
+    return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+/**
+ * optional string name = 2;
+ * @return {string}
+ */
+proto.iws.SetXattrRequest.prototype.getName = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.iws.SetXattrRequest} returns this
+ */
+proto.iws.SetXattrRequest.prototype.setName = function (value) {
+    return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+/**
+ * optional bytes value = 3;
+ * @return {!(string|Uint8Array)}
+ */
+proto.iws.SetXattrRequest.prototype.getValue = function () {
+    return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+/**
+ * optional bytes value = 3;
+ * This is a type-conversion wrapper around `getValue()`
+ * @return {string}
+ */
+proto.iws.SetXattrRequest.prototype.getValue_asB64 = function () {
+    return /** @type {string} */ (jspb.Message.bytesAsB64(this.getValue()));
+};
+
+/**
+ * optional bytes value = 3;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getValue()`
+ * @return {!Uint8Array}
+ */
+proto.iws.SetXattrRequest.prototype.getValue_asU8 = function () {
+    return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(this.getValue()));
+};
+
+/**
+ * @param {!(string|Uint8Array)} value
+ * @return {!proto.iws.SetXattrRequest} returns this
+ */
+proto.iws.SetXattrRequest.prototype.setValue = function (value) {
+    return jspb.Message.setProto3BytesField(this, 3, value);
+};
+
+/**
+ * optional int32 flags = 4;
+ * @return {number}
+ */
+proto.iws.SetXattrRequest.prototype.getFlags = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.SetXattrRequest} returns this
+ */
+proto.iws.SetXattrRequest.prototype.setFlags = function (value) {
+    return jspb.Message.setProto3IntField(this, 4, value);
+};
+
+/**
+ * optional bool no_follow = 5;
+ * @return {boolean}
+ */
+proto.iws.SetXattrRequest.prototype.getNoFollow = function () {
+    return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 5, false));
+};
+
+/**
+ * @param {boolean} value
+ * @return {!proto.iws.SetXattrRequest} returns this
+ */
+proto.iws.SetXattrRequest.prototype.setNoFollow = function (value) {
+    return jspb.Message.setProto3BooleanField(this, 5, value);
+};
+
+/**
+ * optional int64 pid = 6;
+ * @return {number}
+ */
+proto.iws.SetXattrRequest.prototype.getPid = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.SetXattrRequest} returns this
+ */
+proto.iws.SetXattrRequest.prototype.setPid = function (value) {
+    return jspb.Message.setProto3IntField(this, 6, value);
+};
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.iws.SetXattrResponse.prototype.toObject = function (opt_includeInstance) {
+        return proto.iws.SetXattrResponse.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.iws.SetXattrResponse} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.iws.SetXattrResponse.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {};
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.iws.SetXattrResponse}
+ */
+proto.iws.SetXattrResponse.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.iws.SetXattrResponse();
+    return proto.iws.SetXattrResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.iws.SetXattrResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.iws.SetXattrResponse}
+ */
+proto.iws.SetXattrResponse.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.iws.SetXattrResponse.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.iws.SetXattrResponse.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.iws.SetXattrResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.SetXattrResponse.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+};
+
 /**
  * @enum {number}
  */
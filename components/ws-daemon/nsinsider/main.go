@@ -5,6 +5,7 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -12,6 +13,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 	"time"
 	"unsafe"
@@ -212,6 +215,139 @@ func main() {
 					return unix.Unmount(c.String("target"), 0)
 				},
 			},
+			{
+				Name:  "mknod",
+				Usage: "creates a character device on behalf of a workspace user",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "path",
+						Required: true,
+					},
+					&cli.UintFlag{
+						Name:     "mode",
+						Required: true,
+					},
+					&cli.UintFlag{
+						Name:     "major",
+						Required: true,
+					},
+					&cli.UintFlag{
+						Name:     "minor",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "uid",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "gid",
+						Required: true,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					// The filesystem IDs are per thread. We become the workspace user so that the kernel
+					// checks the path against their permissions and the node is created with their ownership.
+					// CAP_MKNOD is not affected by the change of the filesystem IDs.
+					runtime.LockOSThread()
+					err := becomeFSUser(c.Int("uid"), c.Int("gid"))
+					if err != nil {
+						return err
+					}
+					unix.Umask(0)
+
+					mode := uint32(c.Uint("mode"))&07777 | unix.S_IFCHR
+					err = unix.Mknod(c.String("path"), mode, int(unix.Mkdev(uint32(c.Uint("major")), uint32(c.Uint("minor")))))
+					return exitWithErrno(err)
+				},
+			},
+			{
+				Name:  "setxattr",
+				Usage: "sets an extended attribute on behalf of a workspace user",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "path",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "name",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "value",
+						Usage:    "hex encoded value of the attribute",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name: "flags",
+					},
+					&cli.BoolFlag{
+						Name: "no-follow",
+					},
+					&cli.IntFlag{
+						Name:     "uid",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "gid",
+						Required: true,
+					},
+					&cli.StringSliceFlag{
+						Name:     "mapped-uid-range",
+						Usage:    "host UID range (start:size) which is mapped into the workspace",
+						Required: true,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					value, err := hex.DecodeString(c.String("value"))
+					if err != nil {
+						return cli.Exit(err, int(unix.EINVAL))
+					}
+					ranges, err := parseUIDRanges(c.StringSlice("mapped-uid-range"))
+					if err != nil {
+						return cli.Exit(err, int(unix.EINVAL))
+					}
+
+					runtime.LockOSThread()
+					err = becomeFSUser(c.Int("uid"), c.Int("gid"))
+					if err != nil {
+						return err
+					}
+
+					// The file is opened using O_PATH, which neither requires read permission nor opens devices or FIFOs.
+					flags := unix.O_PATH | unix.O_CLOEXEC
+					if c.Bool("no-follow") {
+						flags |= unix.O_NOFOLLOW
+					}
+					fd, err := unix.Open(c.String("path"), flags, 0)
+					if err != nil {
+						return exitWithErrno(err)
+					}
+					defer unix.Close(fd)
+
+					// We operate on the file descriptor from here on so that the file cannot be replaced
+					// between the ownership check and setting the attribute.
+					var stat unix.Stat_t
+					err = unix.Fstat(fd, &stat)
+					if err != nil {
+						return exitWithErrno(err)
+					}
+					if stat.Mode&unix.S_IFMT == unix.S_IFLNK {
+						// security.capability cannot be set on symlinks
+						return cli.Exit(fmt.Sprintf("%s is a symlink", c.String("path")), int(unix.EPERM))
+					}
+					if stat.Mode&unix.S_IFMT != unix.S_IFREG {
+						return cli.Exit(fmt.Sprintf("%s is not a regular file", c.String("path")), int(unix.EINVAL))
+					}
+					if !ranges.contains(stat.Uid) {
+						return cli.Exit(fmt.Sprintf("%s is not owned by a workspace user", c.String("path")), int(unix.EPERM))
+					}
+
+					// fsetxattr does not support O_PATH file descriptors, hence we set the attribute through
+					// /proc/self/fd, which refers to the file of the descriptor.
+					err = unix.Setxattr(fmt.Sprintf("/proc/self/fd/%d", fd), c.String("name"), value, c.Int("flags"))
+					return exitWithErrno(err)
+				},
+			},
 			{
 				Name:  "prepare-dev",
 				Usage: "prepares a workspaces /dev directory",
@@ -680,6 +816,72 @@ const (
 	flagAtRecursive = 0x8000
 )
 
+// becomeFSUser changes the filesystem IDs of the current thread, which drops the filesystem related capabilities.
+// Callers must lock the OS thread beforehand.
+func becomeFSUser(uid, gid int) error {
+	err := unix.Setgroups(nil)
+	if err != nil {
+		return xerrors.Errorf("cannot drop supplementary groups: %w", err)
+	}
+	err = unix.Setfsgid(gid)
+	if err != nil {
+		return xerrors.Errorf("cannot set fsgid: %w", err)
+	}
+	err = unix.Setfsuid(uid)
+	if err != nil {
+		return xerrors.Errorf("cannot set fsuid: %w", err)
+	}
+	return nil
+}
+
+// exitWithErrno makes nsinsider exit with the errno of err as exit code, so that ws-daemon can pass it on
+// to the workspace.
+func exitWithErrno(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errno, ok := err.(unix.Errno); ok {
+		return cli.Exit(err, int(errno))
+	}
+	return err
+}
+
+type uidRange struct {
+	Start uint32
+	Size  uint32
+}
+
+type uidRanges []uidRange
+
+func parseUIDRanges(specs []string) (uidRanges, error) {
+	var res uidRanges
+	for _, spec := range specs {
+		segs := strings.Split(spec, ":")
+		if len(segs) != 2 {
+			return nil, xerrors.Errorf("invalid UID range %q: expected start:size", spec)
+		}
+		start, err := strconv.ParseUint(segs[0], 10, 32)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid UID range %q: %w", spec, err)
+		}
+		size, err := strconv.ParseUint(segs[1], 10, 32)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid UID range %q: %w", spec, err)
+		}
+		res = append(res, uidRange{Start: uint32(start), Size: uint32(size)})
+	}
+	return res, nil
+}
+
+func (r uidRanges) contains(uid uint32) bool {
+	for _, rng := range r {
+		if uid >= rng.Start && uint64(uid) < uint64(rng.Start)+uint64(rng.Size) {
+			return true
+		}
+	}
+	return false
+}
+
 func processWorkspaceCIDR(networkCIDR string) (net.IP, net.IP, *net.IPNet, error) {
 	netIP, mask, err := net.ParseCIDR(networkCIDR)
 	if err != nil {
@@ -0,0 +1,276 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+	"github.com/gitpod-io/gitpod/content-service/pkg/chunked"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+// UploadChunkedBackup splits the backup tar file into content-defined, compressed chunks and uploads all chunks
+// which do not exist in remote storage yet. Once all chunks are uploaded, the manifest listing them is uploaded
+// and the chunks which are no longer referenced are deleted.
+func UploadChunkedBackup(ctx context.Context, rs storage.DirectAccess, ps storage.PresignedAccess, owner string, tarFile string, tmpdir string, attempts int, log *logrus.Entry) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "UploadChunkedBackup")
+	defer tracing.FinishSpan(span, &err)
+
+	existing := make(map[string]struct{})
+	objs, err := rs.ListObjects(ctx, rs.BackupObject(chunked.ChunkPrefix))
+	if err != nil {
+		return xerrors.Errorf("cannot list existing chunks: %w", err)
+	}
+	for _, obj := range objs {
+		existing[obj] = struct{}{}
+	}
+
+	f, err := os.Open(tarFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var uploaded int
+	mf, err := chunked.Write(ctx, f, tmpdir,
+		func(chunk chunked.Chunk) bool {
+			_, exists := existing[rs.BackupObject(chunk.Name())]
+			return exists
+		},
+		func(ctx context.Context, chunk chunked.Chunk, compressedPath string) error {
+			err := retryIfErr(ctx, attempts, log.WithField("op", "upload chunk").WithField("chunk", chunk.Digest), func(ctx context.Context) (err error) {
+				_, _, err = rs.Upload(ctx, compressedPath, chunk.Name(), storage.WithContentType(chunked.MediaTypeChunk))
+				return
+			})
+			if err != nil {
+				return err
+			}
+
+			// the same content can occur several times within a backup
+			existing[rs.BackupObject(chunk.Name())] = struct{}{}
+			uploaded++
+			return nil
+		},
+	)
+	if err != nil {
+		return xerrors.Errorf("cannot upload chunks: %w", err)
+	}
+	span.LogKV("chunks", len(mf.Chunks), "uploaded", uploaded)
+	log.WithField("chunks", len(mf.Chunks)).WithField("uploaded", uploaded).WithField("size", mf.Size).Debug("uploaded backup chunks")
+
+	tmpmf, err := os.CreateTemp(tmpdir, "chunked-mf-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpmf.Name())
+	err = json.NewEncoder(tmpmf).Encode(mf)
+	tmpmf.Close()
+	if err != nil {
+		return err
+	}
+
+	err = retryIfErr(ctx, attempts, log.WithField("op", "upload chunked manifest"), func(ctx context.Context) (err error) {
+		_, _, err = rs.Upload(ctx, tmpmf.Name(), storage.DefaultChunkedBackup, storage.WithContentType(chunked.MediaTypeManifest), storage.WithoutEncryption())
+		return
+	})
+	if err != nil {
+		return xerrors.Errorf("cannot upload chunked backup manifest: %w", err)
+	}
+
+	// The manifest we just uploaded replaced the previous one, hence chunks it does not reference
+	// are garbage. A failure to delete them does not fail the backup - we'll try again next time.
+	referenced := make(map[string]struct{}, len(mf.Chunks))
+	for _, c := range mf.Chunks {
+		referenced[rs.BackupObject(c.Name())] = struct{}{}
+	}
+	var garbage []string
+	for obj := range existing {
+		if _, ok := referenced[obj]; !ok {
+			garbage = append(garbage, obj)
+		}
+	}
+	if len(garbage) > 0 {
+		err = deleteChunks(ctx, ps, rs.Bucket(owner), garbage)
+		if err != nil {
+			log.WithError(err).Warn("cannot delete unreferenced backup chunks")
+		} else {
+			log.WithField("deleted", len(garbage)).Debug("deleted unreferenced backup chunks")
+		}
+	}
+
+	return nil
+}
+
+func deleteChunks(ctx context.Context, ps storage.PresignedAccess, bucket string, objs []string) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(chunkedStorageConcurrency)
+	for _, obj := range objs {
+		obj := obj
+		eg.Go(func() error {
+			return ps.DeleteObject(ctx, bucket, &storage.DeleteObjectQuery{Name: obj})
+		})
+	}
+	return eg.Wait()
+}
+
+// chunkedStorageConcurrency is the number of storage requests made in parallel when signing or deleting chunks
+const chunkedStorageConcurrency = 16
+
+// collectChunkedBackup adds the chunked backup manifest and all chunks it references to the remote content.
+// If the full backup in rc is newer than the chunked one, e.g. because chunked backups were disabled in the
+// meantime, the chunked backup is ignored.
+func collectChunkedBackup(ctx context.Context, rs storage.DirectAccess, ps storage.PresignedAccess, workspaceOwner string, rc map[string]storage.DownloadInfo) error {
+	bucket := rs.Bucket(workspaceOwner)
+	info, err := ps.SignDownload(ctx, bucket, rs.BackupObject(storage.DefaultChunkedBackup), &storage.SignedURLOptions{})
+	if err == storage.ErrNotFound {
+		// no chunked backup found - that's fine
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if full, ok := rc[storage.DefaultBackup]; ok && full.LastModified.After(info.LastModified) {
+		return nil
+	}
+
+	mf, err := fetchChunkedManifest(ctx, info.URL)
+	if err != nil {
+		return err
+	}
+
+	// the same content can occur several times within a backup, but we sign every chunk only once
+	var (
+		unique []chunked.Chunk
+		seen   = make(map[string]struct{}, len(mf.Chunks))
+	)
+	for _, c := range mf.Chunks {
+		if _, exists := seen[c.Name()]; exists {
+			continue
+		}
+		seen[c.Name()] = struct{}{}
+		unique = append(unique, c)
+	}
+
+	var (
+		chunks   = make(map[string]storage.DownloadInfo, len(unique))
+		chunksMu sync.Mutex
+	)
+	eg, egctx := errgroup.WithContext(ctx)
+	eg.SetLimit(chunkedStorageConcurrency)
+	for _, c := range unique {
+		c := c
+		eg.Go(func() error {
+			ci, err := ps.SignDownload(egctx, bucket, rs.BackupObject(c.Name()), &storage.SignedURLOptions{})
+			if err == storage.ErrNotFound {
+				return xerrors.Errorf("chunked backup is incomplete: chunk %s not found", c.Digest)
+			}
+			if err != nil {
+				return xerrors.Errorf("cannot find chunk %s: %w", c.Digest, err)
+			}
+
+			chunksMu.Lock()
+			chunks[c.Name()] = *ci
+			chunksMu.Unlock()
+			return nil
+		})
+	}
+	err = eg.Wait()
+	if err != nil {
+		return err
+	}
+
+	rc[storage.DefaultChunkedBackup] = *info
+	for name, ci := range chunks {
+		rc[name] = ci
+	}
+	return nil
+}
+
+// DownloadChunked restores a chunked backup from the remote content
+func (rs *remoteContentStorage) DownloadChunked(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (exists bool, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "remoteContentStorage.DownloadChunked")
+	span.SetTag("destination", destination)
+	span.SetTag("name", name)
+	defer tracing.FinishSpan(span, &err)
+
+	info, exists := rs.RemoteContent[name]
+	if !exists {
+		return false, nil
+	}
+
+	mf, err := fetchChunkedManifest(ctx, info.URL)
+	if err != nil {
+		return true, err
+	}
+	span.LogKV("chunks", len(mf.Chunks), "size", mf.Size)
+
+	rd := chunked.NewReader(ctx, mf, func(ctx context.Context, chunk chunked.Chunk) (io.ReadCloser, error) {
+		ci, exists := rs.RemoteContent[chunk.Name()]
+		if !exists {
+			return nil, xerrors.Errorf("chunk %s is not available", chunk.Digest)
+		}
+		body, err := fetchURL(ctx, ci.URL)
+		if err != nil {
+			return nil, err
+		}
+		rd, err := storage.DecryptIfEncrypted(ctx, body)
+		if err != nil {
+			body.Close()
+			return nil, err
+		}
+		return struct {
+			io.Reader
+			io.Closer
+		}{rd, body}, nil
+	}, chunked.DefaultConcurrency)
+	defer rd.Close()
+
+	err = archive.ExtractTarbal(ctx, rd, destination, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
+	if err != nil {
+		return true, xerrors.Errorf("tar %s: %s", destination, err.Error())
+	}
+
+	return true, nil
+}
+
+func fetchChunkedManifest(ctx context.Context, url string) (*chunked.Manifest, error) {
+	rc, err := fetchURL(ctx, url)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot download chunked backup manifest: %w", err)
+	}
+	defer rc.Close()
+
+	return chunked.ParseManifest(rc)
+}
+
+func fetchURL(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("non-OK status code: %v", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
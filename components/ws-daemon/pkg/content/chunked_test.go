@@ -0,0 +1,157 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/gitpod-io/gitpod/content-service/pkg/chunked"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+type fakeDirectAccess struct {
+	storage.DirectAccess
+}
+
+func (fakeDirectAccess) Bucket(owner string) string      { return "bucket-" + owner }
+func (fakeDirectAccess) BackupObject(name string) string { return "workspaces/ws/" + name }
+
+type fakePresignedAccess struct {
+	storage.PresignedAccess
+
+	Objects map[string]storage.DownloadInfo
+
+	mu     sync.Mutex
+	Signed []string
+}
+
+func (f *fakePresignedAccess) SignDownload(ctx context.Context, bucket, obj string, options *storage.SignedURLOptions) (*storage.DownloadInfo, error) {
+	f.mu.Lock()
+	f.Signed = append(f.Signed, obj)
+	f.mu.Unlock()
+
+	info, ok := f.Objects[obj]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return &info, nil
+}
+
+func TestCollectChunkedBackup(t *testing.T) {
+	var (
+		chunkA = chunked.Chunk{Digest: digest.FromString("a"), Size: 1}
+		chunkB = chunked.Chunk{Digest: digest.FromString("b"), Size: 1}
+		mf     = chunked.Manifest{MediaType: chunked.MediaTypeManifest, Chunks: []chunked.Chunk{chunkA, chunkB, chunkA}}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(mf)
+	}))
+	defer srv.Close()
+
+	var (
+		older = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		newer = older.Add(time.Hour)
+		rs    = fakeDirectAccess{}
+	)
+	objects := func(manifestTime time.Time) map[string]storage.DownloadInfo {
+		return map[string]storage.DownloadInfo{
+			rs.BackupObject(storage.DefaultChunkedBackup): {URL: srv.URL, LastModified: manifestTime},
+			rs.BackupObject(chunkA.Name()):                {URL: "chunk-a"},
+			rs.BackupObject(chunkB.Name()):                {URL: "chunk-b"},
+		}
+	}
+
+	type Expectation struct {
+		Content []string
+		Signed  []string
+		Error   bool
+	}
+	tests := []struct {
+		Name        string
+		Objects     map[string]storage.DownloadInfo
+		Full        *storage.DownloadInfo
+		Expectation Expectation
+	}{
+		{
+			Name:    "no chunked backup",
+			Objects: map[string]storage.DownloadInfo{},
+			Expectation: Expectation{
+				Signed: []string{rs.BackupObject(storage.DefaultChunkedBackup)},
+			},
+		},
+		{
+			Name:    "chunked backup only",
+			Objects: objects(newer),
+			Expectation: Expectation{
+				Content: []string{storage.DefaultChunkedBackup, chunkA.Name(), chunkB.Name()},
+				Signed:  []string{rs.BackupObject(chunkA.Name()), rs.BackupObject(chunkB.Name()), rs.BackupObject(storage.DefaultChunkedBackup)},
+			},
+		},
+		{
+			Name:    "chunked backup is newer",
+			Objects: objects(newer),
+			Full:    &storage.DownloadInfo{LastModified: older},
+			Expectation: Expectation{
+				Content: []string{storage.DefaultBackup, storage.DefaultChunkedBackup, chunkA.Name(), chunkB.Name()},
+				Signed:  []string{rs.BackupObject(chunkA.Name()), rs.BackupObject(chunkB.Name()), rs.BackupObject(storage.DefaultChunkedBackup)},
+			},
+		},
+		{
+			Name:    "full backup is newer",
+			Objects: objects(older),
+			Full:    &storage.DownloadInfo{LastModified: newer},
+			Expectation: Expectation{
+				Content: []string{storage.DefaultBackup},
+				Signed:  []string{rs.BackupObject(storage.DefaultChunkedBackup)},
+			},
+		},
+		{
+			Name: "missing chunk",
+			Objects: map[string]storage.DownloadInfo{
+				rs.BackupObject(storage.DefaultChunkedBackup): {URL: srv.URL},
+				rs.BackupObject(chunkA.Name()):                {URL: "chunk-a"},
+			},
+			Expectation: Expectation{Error: true},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ps := &fakePresignedAccess{Objects: test.Objects}
+			rc := make(map[string]storage.DownloadInfo)
+			if test.Full != nil {
+				rc[storage.DefaultBackup] = *test.Full
+			}
+
+			var act Expectation
+			err := collectChunkedBackup(context.Background(), rs, ps, "owner", rc)
+			if err != nil {
+				act.Error = true
+			} else {
+				for name := range rc {
+					act.Content = append(act.Content, name)
+				}
+				act.Signed = ps.Signed
+			}
+			sort.Strings(act.Content)
+			sort.Strings(act.Signed)
+			sort.Strings(test.Expectation.Content)
+			sort.Strings(test.Expectation.Signed)
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -52,6 +52,12 @@ type BackupConfig struct {
 
 	// Period is the time between regular workspace backups
 	Period util.Duration `json:"period"`
+
+	// Chunked enables the chunked backup format, where backups are split into compressed,
+	// content-addressed chunks and only chunks which changed since the last backup are uploaded.
+	// Restoring a workspace prefers a chunked backup over a legacy full.tar backup, hence this
+	// should not be disabled again once enabled.
+	Chunked bool `json:"chunked,omitempty"`
 }
 
 type UserNamespacesConfig struct {
@@ -9,7 +9,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -28,7 +31,9 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/common-go/tracing"
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	cntntcfg "github.com/gitpod-io/gitpod/content-service/api/config"
 	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+	"github.com/gitpod-io/gitpod/content-service/pkg/encryption"
 	wsinit "github.com/gitpod-io/gitpod/content-service/pkg/initializer"
 	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 )
@@ -46,6 +51,9 @@ type RunInitializerOpts struct {
 	GID uint32
 
 	OWI OWI
+
+	// Encryption configures the keys used to decrypt encrypted backups
+	Encryption *cntntcfg.EncryptionConfig
 }
 
 type OWI struct {
@@ -58,6 +66,9 @@ func (o OWI) Fields() map[string]interface{} {
 	return log.OWI(o.Owner, o.WorkspaceID, o.InstanceID)
 }
 
+// encryptionHeaderReadSize is the amount of remote content fetched to read its encryption header
+const encryptionHeaderReadSize = 4096
+
 // errors to be tested with errors.Is
 var (
 	// cannot find snapshot
@@ -76,18 +87,12 @@ func CollectRemoteContent(ctx context.Context, rs storage.DirectAccess, ps stora
 		rc[storage.DefaultBackup] = *backup
 	}
 
-	si := initializer.GetSnapshot()
-	pi := initializer.GetPrebuild()
-	if ci := initializer.GetComposite(); ci != nil {
-		for _, c := range ci.Initializer {
-			if c.GetSnapshot() != nil {
-				si = c.GetSnapshot()
-			}
-			if c.GetPrebuild() != nil {
-				pi = c.GetPrebuild()
-			}
-		}
+	err = collectChunkedBackup(ctx, rs, ps, workspaceOwner, rc)
+	if err != nil {
+		return nil, err
 	}
+
+	si, pi := snapshotInitializers(initializer)
 	if si != nil {
 		bkt, obj, err := storage.ParseSnapshotName(si.Snapshot)
 		if err != nil {
@@ -121,6 +126,82 @@ func CollectRemoteContent(ctx context.Context, rs storage.DirectAccess, ps stora
 	return rc, nil
 }
 
+// snapshotInitializers returns the snapshot and prebuild initializer of a workspace initializer, if it has any
+func snapshotInitializers(initializer *csapi.WorkspaceInitializer) (si *csapi.SnapshotInitializer, pi *csapi.PrebuildInitializer) {
+	si = initializer.GetSnapshot()
+	pi = initializer.GetPrebuild()
+	if ci := initializer.GetComposite(); ci != nil {
+		for _, c := range ci.Initializer {
+			if c.GetSnapshot() != nil {
+				si = c.GetSnapshot()
+			}
+			if c.GetPrebuild() != nil {
+				pi = c.GetPrebuild()
+			}
+		}
+	}
+	return si, pi
+}
+
+// initializerDataKeys returns the data keys needed to decrypt the content restored by the initializer, indexed by owner.
+// Snapshots and prebuilds may have been taken by someone other than the workspace owner, whose data keys are needed
+// in addition to those of the owner. We only pass along the data keys of those owners, as the workspace user can read them.
+func initializerDataKeys(ctx context.Context, kr *encryption.KeyRing, owner string, initializer *csapi.WorkspaceInitializer, remoteContent map[string]storage.DownloadInfo) map[string]map[string][]byte {
+	res := map[string]map[string][]byte{
+		owner: kr.OwnerKeys(owner),
+	}
+
+	var snapshots []string
+	si, pi := snapshotInitializers(initializer)
+	if si != nil {
+		snapshots = append(snapshots, si.Snapshot)
+	}
+	if pi != nil && pi.Prebuild != nil {
+		snapshots = append(snapshots, pi.Prebuild.Snapshot)
+	}
+	for _, name := range snapshots {
+		info, ok := remoteContent[name]
+		if !ok {
+			continue
+		}
+		hdr, err := readEncryptionHeader(ctx, info.URL)
+		if errors.Is(err, encryption.ErrNotEncrypted) {
+			continue
+		}
+		if err != nil {
+			// the initializer fails to decrypt the snapshot in this case, and reports the error
+			log.WithError(err).WithField("snapshot", name).Warn("cannot read encryption header of snapshot")
+			continue
+		}
+		if _, ok := res[hdr.Owner]; !ok {
+			res[hdr.Owner] = kr.OwnerKeys(hdr.Owner)
+		}
+	}
+	return res
+}
+
+// readEncryptionHeader reads the encryption header of remote content, without downloading all of it
+func readEncryptionHeader(ctx context.Context, url string) (*encryption.Header, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", encryptionHeaderReadSize-1))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return nil, xerrors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return encryption.ReadHeader(io.LimitReader(resp.Body, encryptionHeaderReadSize))
+}
+
 // RunInitializer runs a content initializer in a user, PID and mount namespace to isolate it from ws-daemon
 func RunInitializer(ctx context.Context, destination string, initializer *csapi.WorkspaceInitializer, remoteContent map[string]storage.DownloadInfo, opts RunInitializerOpts) (err error) {
 	//nolint:ineffassign,staticcheck
@@ -167,11 +248,25 @@ func RunInitializer(ctx context.Context, destination string, initializer *csapi.
 		UID:           int(opts.UID),
 		OWI:           opts.OWI.Fields(),
 	}
+	if opts.Encryption != nil {
+		// The initializer cannot read the key files itself, and content.json is readable by the workspace user.
+		// Hence we only pass along the data keys of the owners whose content is restored.
+		kr, err := encryption.LoadKeyRing(opts.Encryption)
+		if err != nil {
+			return xerrors.Errorf("cannot load encryption keys: %w", err)
+		}
+		msg.DataKeys = initializerDataKeys(ctx, kr, opts.OWI.Owner, initializer, remoteContent)
+	}
 	fc, err := json.MarshalIndent(msg, "", "  ")
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(filepath.Join(tmpdir, "rootfs", "content.json"), fc, 0644)
+	contentFN := filepath.Join(tmpdir, "rootfs", "content.json")
+	err = os.WriteFile(contentFN, fc, 0600)
+	if err != nil {
+		return err
+	}
+	err = os.Chown(contentFN, int(opts.UID), int(opts.GID))
 	if err != nil {
 		return err
 	}
@@ -326,7 +421,14 @@ func RunInitializerChild() (err error) {
 		return err
 	}
 
-	rs := &remoteContentStorage{RemoteContent: initmsg.RemoteContent}
+	var rs storage.DirectDownloader = &remoteContentStorage{RemoteContent: initmsg.RemoteContent}
+	if len(initmsg.DataKeys) > 0 {
+		kr, err := encryption.NewOwnerKeyRing(initmsg.DataKeys)
+		if err != nil {
+			return err
+		}
+		rs = storage.WithDecryption(rs, kr)
+	}
 
 	dst := initmsg.Destination
 	initializer, err := wsinit.NewFromRequest(ctx, dst, rs, &req, wsinit.NewFromRequestOpts{ForceGitpodUserForGit: false})
@@ -421,7 +523,12 @@ func (rs *remoteContentStorage) Download(ctx context.Context, destination string
 	defer os.Remove(tempFile.Name())
 	defer tempFile.Close()
 
-	err = archive.ExtractTarbal(ctx, tempFile, destination, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
+	content, err := storage.DecryptIfEncrypted(ctx, tempFile)
+	if err != nil {
+		return true, xerrors.Errorf("cannot decrypt %s: %w", name, err)
+	}
+
+	err = archive.ExtractTarbal(ctx, content, destination, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
 	if err != nil {
 		return true, xerrors.Errorf("tar %s: %s", destination, err.Error())
 	}
@@ -483,4 +590,7 @@ type msgInitContent struct {
 
 	TraceInfo string
 	OWI       map[string]interface{}
+
+	// DataKeys are the data keys for encrypted content, indexed by owner and key-encryption key ID
+	DataKeys map[string]map[string][]byte
 }
@@ -0,0 +1,155 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package content
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	cntntcfg "github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/encryption"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+func TestInitializerDataKeysRestoreSnapshotOfAnotherOwner(t *testing.T) {
+	ctx := context.Background()
+
+	key := make([]byte, encryption.KeySize)
+	_, _ = rand.Read(key)
+	keyFile := filepath.Join(t.TempDir(), "kek")
+	err := os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &cntntcfg.StorageConfig{
+		Stage: cntntcfg.StageDevStaging,
+		Kind:  cntntcfg.FileStorage,
+		FileConfig: &cntntcfg.FileConfig{
+			Path:       t.TempDir(),
+			SigningKey: "secret",
+		},
+		Encryption: &cntntcfg.EncryptionConfig{KeyFile: keyFile},
+	}
+
+	cfg.FileConfig.URL = "http://localhost/storage"
+	pattern, handler, err := storage.NewFileStorageHandler(cfg.FileConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(pattern, handler)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	cfg.FileConfig.URL = srv.URL + "/storage"
+
+	// the snapshot is taken by the owner of the original workspace
+	author, err := storage.NewDirectAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = author.Init(ctx, "author", "workspace-a", "instance-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join(t.TempDir(), "snapshot.tar")
+	f, err := os.Create(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw := tar.NewWriter(f)
+	_ = tw.WriteHeader(&tar.Header{Name: "hello.txt", Mode: 0644, Size: 11, Typeflag: tar.TypeReg})
+	_, _ = tw.Write([]byte("hello world"))
+	_ = tw.Close()
+	f.Close()
+	_, _, err = author.Upload(ctx, src, "snapshot.tar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := author.Qualify("snapshot.tar")
+
+	// and restored in a workspace of someone else
+	rs, err := storage.NewDirectAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rs.Init(ctx, "other", "workspace-b", "instance-b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps, err := storage.NewPresignedAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	initializer := &csapi.WorkspaceInitializer{
+		Spec: &csapi.WorkspaceInitializer_Snapshot{
+			Snapshot: &csapi.SnapshotInitializer{Snapshot: snapshot},
+		},
+	}
+	remoteContent, err := CollectRemoteContent(ctx, rs, ps, "other", initializer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, ok := remoteContent[snapshot]
+	if !ok {
+		t.Fatal("snapshot is not part of the remote content")
+	}
+
+	kr, err := encryption.LoadKeyRing(cfg.Encryption)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypt := func(dataKeys map[string]map[string][]byte) (string, error) {
+		okr, err := encryption.NewOwnerKeyRing(dataKeys)
+		if err != nil {
+			return "", err
+		}
+		resp, err := http.Get(info.URL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		r, err := okr.NewReader(resp.Body)
+		if err != nil {
+			return "", err
+		}
+		tr := tar.NewReader(r)
+		_, err = tr.Next()
+		if err != nil {
+			return "", err
+		}
+		c, err := io.ReadAll(tr)
+		return string(c), err
+	}
+
+	_, err = decrypt(map[string]map[string][]byte{"other": kr.OwnerKeys("other")})
+	if !errors.Is(err, encryption.ErrUnknownKey) {
+		t.Errorf("expected the snapshot not to be readable with the keys of the workspace owner only, got %v", err)
+	}
+
+	dataKeys := initializerDataKeys(ctx, kr, "other", initializer, remoteContent)
+	if _, ok := dataKeys["other"]; !ok {
+		t.Error("data keys of the workspace owner are missing")
+	}
+	if len(dataKeys) != 2 {
+		t.Errorf("expected data keys of the workspace and snapshot owner only, got %d owners", len(dataKeys))
+	}
+	content, err := decrypt(dataKeys)
+	if err != nil {
+		t.Fatalf("cannot restore snapshot of another owner: %v", err)
+	}
+	if content != "hello world" {
+		t.Errorf("unexpected content: %q", content)
+	}
+}
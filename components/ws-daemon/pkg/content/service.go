@@ -291,6 +291,7 @@ func (s *WorkspaceService) InitWorkspace(ctx context.Context, req *api.InitWorks
 				WorkspaceID: req.Metadata.MetaId,
 				InstanceID:  req.Id,
 			},
+			Encryption: s.config.Storage.Encryption,
 		}
 
 		err = RunInitializer(ctx, workspace.Location, req.Initializer, remoteContent, opts)
@@ -609,6 +610,19 @@ func (s *WorkspaceService) uploadWorkspaceContent(ctx context.Context, sess *ses
 		}
 	}()
 
+	if s.config.Backup.Chunked && !sess.FullWorkspaceBackup && backupName == storage.DefaultBackup {
+		var ps storage.PresignedAccess
+		ps, err = storage.NewPresignedAccess(&s.config.Storage)
+		if err != nil {
+			return xerrors.Errorf("no presigned storage available: %w", err)
+		}
+		err = UploadChunkedBackup(ctx, rs, ps, sess.Owner, tmpf.Name(), s.config.TmpDir, s.config.Backup.Attempts, log.WithFields(sess.OWI()))
+		if err != nil {
+			return xerrors.Errorf("cannot upload workspace content: %w", err)
+		}
+		return nil
+	}
+
 	var (
 		layerBucket string
 		layerObject string
@@ -623,6 +637,8 @@ func (s *WorkspaceService) uploadWorkspaceContent(ctx context.Context, sess *ses
 					storage.ObjectAnnotationUncompressedDigest: tmpfDigest.String(),
 					storage.ObjectAnnotationOCIContentType:     csapi.MediaTypeUncompressedLayer,
 				}),
+				// FWB layers are served as image layers and must match their digest
+				storage.WithoutEncryption(),
 			}
 		}
 
@@ -679,7 +695,7 @@ func (s *WorkspaceService) uploadWorkspaceContent(ctx context.Context, sess *ses
 		// Upload new manifest without opts as don't want to overwrite the layer trail with the manifest.
 		// We have to make sure we use the right content type s.t. we can identify this as manifest later on,
 		// e.g. when distinguishing between legacy snapshots and new manifests.
-		_, _, err = rs.Upload(ctx, tmpmf.Name(), mfName, storage.WithContentType(csapi.ContentTypeManifest))
+		_, _, err = rs.Upload(ctx, tmpmf.Name(), mfName, storage.WithContentType(csapi.ContentTypeManifest), storage.WithoutEncryption())
 		if err != nil {
 			return err
 		}
@@ -9,11 +9,13 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -56,11 +58,14 @@ func snapshotEventFilter(nodeName string) predicate.Predicate {
 	return predicate.Funcs{
 		CreateFunc: func(e event.CreateEvent) bool {
 			if ss, ok := e.Object.(*workspacev1.Snapshot); ok {
-				return ss.Spec.NodeName == nodeName
+				return ss.Spec.NodeName == nodeName || isSnapshotDeleted(ss)
 			}
 			return false
 		},
 		UpdateFunc: func(ue event.UpdateEvent) bool {
+			if ss, ok := ue.ObjectNew.(*workspacev1.Snapshot); ok {
+				return isSnapshotDeleted(ss)
+			}
 			return false
 		},
 		DeleteFunc: func(de event.DeleteEvent) bool {
@@ -69,6 +74,12 @@ func snapshotEventFilter(nodeName string) predicate.Predicate {
 	}
 }
 
+// isSnapshotDeleted returns true if the snapshot was deleted and its content still needs to be removed.
+// Any node may remove the content, as the node which took the snapshot might be gone by now.
+func isSnapshotDeleted(ss *workspacev1.Snapshot) bool {
+	return !ss.DeletionTimestamp.IsZero() && controllerutil.ContainsFinalizer(ss, workspacev1.SnapshotFinalizerName)
+}
+
 //+kubebuilder:rbac:groups=workspace.gitpod.io,resources=snapshots,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=workspace.gitpod.io,resources=snapshots/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=workspace.gitpod.io,resources=snapshots/finalizers,verbs=update
@@ -85,10 +96,22 @@ func (ssc *SnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if !snapshot.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, ssc.deleteSnapshotContent(ctx, &snapshot)
+	}
+
 	if snapshot.Status.Completed {
 		return ctrl.Result{}, nil
 	}
 
+	// make sure we get to delete the content once the snapshot is deleted
+	if !controllerutil.ContainsFinalizer(&snapshot, workspacev1.SnapshotFinalizerName) {
+		controllerutil.AddFinalizer(&snapshot, workspacev1.SnapshotFinalizerName)
+		if err := ssc.Client.Update(ctx, &snapshot); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	snapshotURL, snapshotName, snapshotErr := ssc.operations.SnapshotIDs(ctx, snapshot.Spec.WorkspaceID)
 	if snapshotErr != nil {
 		return ctrl.Result{}, snapshotErr
@@ -136,6 +159,34 @@ func (ssc *SnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{}, err
 }
 
+// deleteSnapshotContent removes the content of a deleted snapshot from remote storage and releases the snapshot
+func (ssc *SnapshotReconciler) deleteSnapshotContent(ctx context.Context, snapshot *workspacev1.Snapshot) error {
+	if !controllerutil.ContainsFinalizer(snapshot, workspacev1.SnapshotFinalizerName) {
+		return nil
+	}
+
+	if snapshot.Status.URL != "" {
+		err := ssc.operations.DeleteSnapshot(ctx, snapshot.Status.URL)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "could not delete snapshot content", "workspace", snapshot.Spec.WorkspaceID)
+			return err
+		}
+	}
+
+	return retry.RetryOnConflict(retryParams, func() error {
+		err := ssc.Client.Get(ctx, types.NamespacedName{Namespace: snapshot.Namespace, Name: snapshot.Name}, snapshot)
+		if err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !controllerutil.ContainsFinalizer(snapshot, workspacev1.SnapshotFinalizerName) {
+			return nil
+		}
+
+		controllerutil.RemoveFinalizer(snapshot, workspacev1.SnapshotFinalizerName)
+		return client.IgnoreNotFound(ssc.Client.Update(ctx, snapshot))
+	})
+}
+
 func (ssc *SnapshotReconciler) emitEvent(s *workspacev1.Snapshot, failure error) {
 	eventType := corev1.EventTypeNormal
 	reason := "Succeeded"
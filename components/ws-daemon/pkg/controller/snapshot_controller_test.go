@@ -0,0 +1,83 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+func TestSnapshotContentDeletion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := workspacev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		ctx      = context.Background()
+		name     = types.NamespacedName{Namespace: "default", Name: "snapshot"}
+		snapshot = &workspacev1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{Namespace: name.Namespace, Name: name.Name},
+			Spec:       workspacev1.SnapshotSpec{NodeName: nodeName, WorkspaceID: "ws"},
+		}
+		clnt = fake.NewClientBuilder().WithScheme(scheme).WithObjects(snapshot).Build()
+		ops  = &fakeWorkspaceOperations{}
+		ssc  = NewSnapshotController(clnt, record.NewFakeRecorder(10), nodeName, 1, ops)
+	)
+
+	_, err := ssc.Reconcile(ctx, ctrl.Request{NamespacedName: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = clnt.Get(ctx, name, snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !snapshot.Status.Completed || snapshot.Status.URL == "" {
+		t.Fatalf("snapshot was not taken: %+v", snapshot.Status)
+	}
+	if !controllerutil.ContainsFinalizer(snapshot, workspacev1.SnapshotFinalizerName) {
+		t.Fatal("snapshot has no finalizer")
+	}
+
+	// deletion must be handled by any node, the one which took the snapshot might be gone
+	deleted := snapshot.DeepCopy()
+	err = clnt.Delete(ctx, snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = clnt.Get(ctx, name, deleted)
+	if err != nil {
+		t.Fatalf("snapshot was removed before its content: %v", err)
+	}
+	if !isSnapshotDeleted(deleted) {
+		t.Error("deleted snapshot is not recognised as such")
+	}
+
+	ssc = NewSnapshotController(clnt, record.NewFakeRecorder(10), "another-node", 1, ops)
+	_, err = ssc.Reconcile(ctx, ctrl.Request{NamespacedName: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{deleted.Status.URL}, ops.deletedSnapshots); diff != "" {
+		t.Errorf("unexpected deleted snapshots (-want +got):\n%s", diff)
+	}
+	err = clnt.Get(ctx, name, deleted)
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected snapshot to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package controller
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+// These tests use Ginkgo (BDD-style Go testing framework). Refer to
+// http://onsi.github.io/ginkgo/ to learn more about Ginkgo.
+
+const (
+	timeout          = time.Second * 20
+	interval         = time.Millisecond * 250
+	nodeName         = "ws-daemon-test-node"
+	secretsNamespace = "workspace-secrets"
+)
+
+var (
+	k8sClient client.Client
+	testEnv   *envtest.Environment
+	ctx       context.Context
+	cancel    context.CancelFunc
+	ops       *fakeWorkspaceOperations
+)
+
+func TestAPIs(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Controller Suite")
+}
+
+var _ = BeforeSuite(func() {
+	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
+
+	By("bootstrapping test environment")
+	testEnv = &envtest.Environment{
+		ControlPlaneStartTimeout: 1 * time.Minute,
+		ControlPlaneStopTimeout:  1 * time.Minute,
+		CRDDirectoryPaths:        []string{filepath.Join("..", "..", "..", "ws-manager-mk2", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing:    true,
+	}
+
+	cfg, err := testEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(cfg).NotTo(BeNil())
+
+	err = workspacev1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(k8sClient).NotTo(BeNil())
+
+	k8sManager, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme: scheme.Scheme,
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	ops = &fakeWorkspaceOperations{}
+	wsController, err := NewWorkspaceController(k8sManager.GetClient(), k8sManager.GetEventRecorderFor("workspace"), nodeName, secretsNamespace, 1, ops, prometheus.NewRegistry())
+	Expect(err).ToNot(HaveOccurred())
+	Expect(wsController.SetupWithManager(k8sManager)).To(Succeed())
+
+	ctx, cancel = context.WithCancel(context.Background())
+	Expect(k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: secretsNamespace}})).To(Succeed())
+
+	go func() {
+		defer GinkgoRecover()
+		err = k8sManager.Start(ctx)
+		Expect(err).ToNot(HaveOccurred(), "failed to run manager")
+	}()
+})
+
+var _ = AfterSuite(func() {
+	cancel()
+	By("tearing down the test environment")
+	err := testEnv.Stop()
+	Expect(err).NotTo(HaveOccurred())
+})
+
+// fakeWorkspaceOperations records the operations the controller performs instead of acting on workspace content
+type fakeWorkspaceOperations struct {
+	mu        sync.Mutex
+	backups   []BackupOptions
+	backupErr error
+
+	deletedSnapshots []string
+}
+
+var _ WorkspaceOperations = &fakeWorkspaceOperations{}
+
+func (f *fakeWorkspaceOperations) InitWorkspace(ctx context.Context, options InitOptions) (string, error) {
+	return "", nil
+}
+
+func (f *fakeWorkspaceOperations) BackupWorkspace(ctx context.Context, opts BackupOptions) (*csapi.GitStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.backups = append(f.backups, opts)
+	if f.backupErr != nil {
+		return nil, f.backupErr
+	}
+	return &csapi.GitStatus{Branch: "main"}, nil
+}
+
+func (f *fakeWorkspaceOperations) DeleteWorkspace(ctx context.Context, instanceID string) error {
+	return nil
+}
+
+func (f *fakeWorkspaceOperations) SnapshotIDs(ctx context.Context, instanceID string) (snapshotUrl, snapshotName string, err error) {
+	return "gs://gitpod-test/snapshot.tar", "snapshot.tar", nil
+}
+
+func (f *fakeWorkspaceOperations) BackupURL(ctx context.Context, instanceID, backupName string) (string, error) {
+	return "gs://gitpod-test/workspaces/" + instanceID + "/" + backupName, nil
+}
+
+func (f *fakeWorkspaceOperations) Snapshot(ctx context.Context, instanceID, snapshotName string) (err error) {
+	return nil
+}
+
+func (f *fakeWorkspaceOperations) DeleteSnapshot(ctx context.Context, snapshotURL string) (err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.deletedSnapshots = append(f.deletedSnapshots, snapshotURL)
+	return nil
+}
+
+// Backups returns the backups taken of a workspace
+func (f *fakeWorkspaceOperations) Backups(instanceID string) []BackupOptions {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var res []BackupOptions
+	for _, b := range f.backups {
+		if b.Meta.InstanceID == instanceID {
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+func (f *fakeWorkspaceOperations) SetBackupError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.backupErr = err
+}
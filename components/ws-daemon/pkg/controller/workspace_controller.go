@@ -132,6 +132,13 @@ func (wsc *WorkspaceController) Reconcile(ctx context.Context, req ctrl.Request)
 		return result, err
 	}
 
+	if workspace.Status.Phase == workspacev1.WorkspacePhaseRunning &&
+		wsk8s.ConditionPresentAndTrue(workspace.Status.Conditions, string(workspacev1.WorkspaceConditionBackupRequested)) {
+
+		result, err = wsc.handleWorkspaceBackup(ctx, &workspace, req)
+		return result, err
+	}
+
 	if workspace.Status.Phase == workspacev1.WorkspacePhaseStopping {
 
 		result, err = wsc.handleWorkspaceStop(ctx, &workspace, req)
@@ -315,6 +322,74 @@ func (wsc *WorkspaceController) handleWorkspaceStop(ctx context.Context, ws *wor
 	return ctrl.Result{}, err
 }
 
+// handleWorkspaceBackup backs up a running workspace on request. In contrast to the backup when the workspace stops,
+// the workspace content stays in place.
+func (wsc *WorkspaceController) handleWorkspaceBackup(ctx context.Context, ws *workspacev1.Workspace, req ctrl.Request) (result ctrl.Result, err error) {
+	log := log.FromContext(ctx)
+	span, ctx := opentracing.StartSpanFromContext(ctx, "handleWorkspaceBackup")
+	defer tracing.FinishSpan(span, &err)
+
+	if c := wsk8s.GetCondition(ws.Status.Conditions, string(workspacev1.WorkspaceConditionContentReady)); c == nil || c.Status == metav1.ConditionFalse {
+		return ctrl.Result{}, wsc.completeBackupRequest(ctx, ws, req, nil, "", fmt.Errorf("workspace content is not ready"))
+	}
+
+	if wsc.latestWorkspace(ctx, ws) != nil {
+		return ctrl.Result{Requeue: true}, nil
+	}
+	if !wsk8s.ConditionPresentAndTrue(ws.Status.Conditions, string(workspacev1.WorkspaceConditionBackupRequested)) {
+		// someone else has handled the request already
+		return ctrl.Result{}, nil
+	}
+
+	var (
+		backupName = storage.DefaultBackup
+		gitStatus  *csapi.GitStatus
+	)
+	backupURL, backupErr := wsc.operations.BackupURL(ctx, ws.Name, backupName)
+	if backupErr == nil {
+		gitStatus, backupErr = wsc.operations.BackupWorkspace(ctx, BackupOptions{
+			Meta: WorkspaceMeta{
+				Owner:       ws.Spec.Ownership.Owner,
+				WorkspaceID: ws.Spec.Ownership.WorkspaceID,
+				InstanceID:  ws.Name,
+			},
+			WorkspaceLocation: ws.Spec.WorkspaceLocation,
+			SnapshotName:      backupName,
+			UpdateGitStatus:   ws.Spec.Type == workspacev1.WorkspaceTypeRegular,
+		})
+	}
+	if backupErr != nil {
+		log.Error(backupErr, "failed to backup workspace on request", "name", ws.Name)
+	}
+
+	return ctrl.Result{}, wsc.completeBackupRequest(ctx, ws, req, toWorkspaceGitStatus(gitStatus), backupURL, backupErr)
+}
+
+// completeBackupRequest reports the outcome of a backup request through the BackupRequested condition
+func (wsc *WorkspaceController) completeBackupRequest(ctx context.Context, ws *workspacev1.Workspace, req ctrl.Request, gitStatus *workspacev1.GitStatus, backupURL string, backupErr error) error {
+	err := retry.RetryOnConflict(retryParams, func() error {
+		if err := wsc.Get(ctx, req.NamespacedName, ws); err != nil {
+			return err
+		}
+
+		if gitStatus != nil {
+			ws.Status.GitStatus = gitStatus
+		}
+		if backupErr != nil {
+			ws.Status.SetCondition(workspacev1.NewWorkspaceConditionBackupRequested(metav1.ConditionFalse, workspacev1.ReasonBackupFailed, backupErr.Error()))
+		} else {
+			ws.Status.SetCondition(workspacev1.NewWorkspaceConditionBackupRequested(metav1.ConditionFalse, workspacev1.ReasonBackupComplete, backupURL))
+		}
+
+		return wsc.Status().Update(ctx, ws)
+	})
+
+	if backupErr != nil {
+		wsc.emitEvent(ws, "Backup", fmt.Errorf("failed to backup workspace: %w", backupErr))
+	}
+	return err
+}
+
 func (wsc *WorkspaceController) prepareInitializer(ctx context.Context, ws *workspacev1.Workspace) (*csapi.WorkspaceInitializer, error) {
 	var init csapi.WorkspaceInitializer
 	err := proto.Unmarshal(ws.Spec.Initializer, &init)
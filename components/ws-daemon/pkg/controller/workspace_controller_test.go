@@ -0,0 +1,168 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package controller
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	wsk8s "github.com/gitpod-io/gitpod/common-go/kubernetes"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+var _ = Describe("WorkspaceController", func() {
+	Context("with backup requests", func() {
+		It("should backup a running workspace", func() {
+			ws := createRunningWorkspace(uuid.NewString(), true)
+			requestBackup(ws)
+
+			cond := expectBackupRequestHandled(ws, workspacev1.ReasonBackupComplete)
+			Expect(cond.Message).To(Equal(fmt.Sprintf("gs://gitpod-test/workspaces/%s/%s", ws.Name, storage.DefaultBackup)))
+			Expect(ws.Status.GitStatus).ToNot(BeNil())
+			Expect(ws.Status.GitStatus.Branch).To(Equal("main"))
+
+			backups := ops.Backups(ws.Name)
+			Expect(backups).To(HaveLen(1))
+			Expect(backups[0].SnapshotName).To(Equal(storage.DefaultBackup))
+			Expect(backups[0].UpdateGitStatus).To(BeTrue())
+			Expect(backups[0].BackupLogs).To(BeFalse())
+
+			By("not taking another backup until requested again")
+			Consistently(func() []BackupOptions {
+				return ops.Backups(ws.Name)
+			}, "2s", interval).Should(HaveLen(1))
+
+			requestBackup(ws)
+			expectBackupRequestHandled(ws, workspacev1.ReasonBackupComplete)
+			Expect(ops.Backups(ws.Name)).To(HaveLen(2))
+		})
+
+		It("should report failed backups", func() {
+			ops.SetBackupError(fmt.Errorf("remote storage unavailable"))
+			defer ops.SetBackupError(nil)
+
+			ws := createRunningWorkspace(uuid.NewString(), true)
+			requestBackup(ws)
+
+			cond := expectBackupRequestHandled(ws, workspacev1.ReasonBackupFailed)
+			Expect(cond.Message).To(ContainSubstring("remote storage unavailable"))
+		})
+
+		It("should not backup workspaces whose content is not ready", func() {
+			ws := createRunningWorkspace(uuid.NewString(), false)
+			requestBackup(ws)
+
+			cond := expectBackupRequestHandled(ws, workspacev1.ReasonBackupFailed)
+			Expect(cond.Message).To(ContainSubstring("content is not ready"))
+			Expect(ops.Backups(ws.Name)).To(BeEmpty())
+		})
+
+		It("should ignore workspaces on other nodes", func() {
+			ws := createRunningWorkspace(uuid.NewString(), true)
+			updateWorkspaceStatus(ws, func(ws *workspacev1.Workspace) {
+				ws.Status.Runtime.NodeName = "another-node"
+			})
+			requestBackup(ws)
+
+			Consistently(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: ws.Name, Namespace: ws.Namespace}, ws)).To(Succeed())
+				g.Expect(wsk8s.ConditionPresentAndTrue(ws.Status.Conditions, string(workspacev1.WorkspaceConditionBackupRequested))).To(BeTrue())
+			}, "2s", interval).Should(Succeed())
+			Expect(ops.Backups(ws.Name)).To(BeEmpty())
+		})
+	})
+})
+
+func createRunningWorkspace(name string, contentReady bool) *workspacev1.Workspace {
+	GinkgoHelper()
+
+	ws := &workspacev1.Workspace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "workspace.gitpod.io/v1",
+			Kind:       "Workspace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: workspacev1.WorkspaceSpec{
+			Ownership: workspacev1.Ownership{
+				Owner:       "foobar",
+				WorkspaceID: "cool-workspace",
+			},
+			Type:  workspacev1.WorkspaceTypeRegular,
+			Class: "default",
+			Image: workspacev1.WorkspaceImages{
+				Workspace: workspacev1.WorkspaceImage{
+					Ref: pointer.String("alpine:latest"),
+				},
+				IDE: workspacev1.IDEImages{
+					Refs: []string{},
+				},
+			},
+			Ports:             []workspacev1.PortSpec{},
+			WorkspaceLocation: "/workspace",
+			Admission: workspacev1.AdmissionSpec{
+				Level: workspacev1.AdmissionLevelEveryone,
+			},
+		},
+	}
+
+	By("creating workspace")
+	Expect(k8sClient.Create(ctx, ws)).To(Succeed())
+
+	updateWorkspaceStatus(ws, func(ws *workspacev1.Workspace) {
+		ws.Status.Phase = workspacev1.WorkspacePhaseRunning
+		ws.Status.Runtime = &workspacev1.WorkspaceRuntimeStatus{NodeName: nodeName}
+		if contentReady {
+			ws.Status.SetCondition(workspacev1.NewWorkspaceConditionContentReady(metav1.ConditionTrue, workspacev1.ReasonInitializationSuccess, ""))
+		}
+	})
+	return ws
+}
+
+func requestBackup(ws *workspacev1.Workspace) {
+	GinkgoHelper()
+
+	By("requesting a backup")
+	updateWorkspaceStatus(ws, func(ws *workspacev1.Workspace) {
+		ws.Status.SetCondition(workspacev1.NewWorkspaceConditionBackupRequested(metav1.ConditionTrue, workspacev1.ReasonBackupRequested, ""))
+	})
+}
+
+func updateWorkspaceStatus(ws *workspacev1.Workspace, update func(ws *workspacev1.Workspace)) {
+	GinkgoHelper()
+
+	Eventually(func() error {
+		err := k8sClient.Get(ctx, types.NamespacedName{Name: ws.Name, Namespace: ws.Namespace}, ws)
+		if err != nil {
+			return err
+		}
+		update(ws)
+		return k8sClient.Status().Update(ctx, ws)
+	}, timeout, interval).Should(Succeed())
+}
+
+func expectBackupRequestHandled(ws *workspacev1.Workspace, reason string) *metav1.Condition {
+	GinkgoHelper()
+
+	By(fmt.Sprintf("controller handling the backup request with %s", reason))
+	var cond *metav1.Condition
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: ws.Name, Namespace: ws.Namespace}, ws)).To(Succeed())
+		cond = wsk8s.GetCondition(ws.Status.Conditions, string(workspacev1.WorkspaceConditionBackupRequested))
+		g.Expect(cond).ToNot(BeNil())
+		g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(cond.Reason).To(Equal(reason))
+	}, timeout, interval).Should(Succeed())
+	return cond
+}
@@ -37,8 +37,12 @@ type WorkspaceOperations interface {
 	DeleteWorkspace(ctx context.Context, instanceID string) error
 	// SnapshotIDs generates the name and url for a snapshot
 	SnapshotIDs(ctx context.Context, instanceID string) (snapshotUrl, snapshotName string, err error)
+	// BackupURL returns the url of a backup of the workspace
+	BackupURL(ctx context.Context, instanceID, backupName string) (string, error)
 	// Snapshot takes a snapshot of the workspace
 	Snapshot(ctx context.Context, instanceID, snapshotName string) (err error)
+	// DeleteSnapshot deletes the content of a snapshot from remote storage
+	DeleteSnapshot(ctx context.Context, snapshotURL string) (err error)
 }
 
 type DefaultWorkspaceOperations struct {
@@ -131,6 +135,7 @@ func (wso *DefaultWorkspaceOperations) InitWorkspace(ctx context.Context, option
 			WorkspaceID: options.Meta.WorkspaceID,
 			InstanceID:  options.Meta.InstanceID,
 		},
+		Encryption: wso.config.Storage.Encryption,
 	}
 
 	err = ensureCleanSlate(ws.Location)
@@ -255,6 +260,28 @@ func (wso *DefaultWorkspaceOperations) SnapshotIDs(ctx context.Context, instance
 	return rs.Qualify(snapshotName), snapshotName, nil
 }
 
+func (wso *DefaultWorkspaceOperations) BackupURL(ctx context.Context, instanceID, backupName string) (string, error) {
+	sess, err := wso.provider.Get(ctx, instanceID)
+	if err != nil {
+		return "", fmt.Errorf("cannot find workspace %s during BackupURL: %w", instanceID, err)
+	}
+
+	rs, ok := sess.NonPersistentAttrs[session.AttrRemoteStorage].(storage.DirectAccess)
+	if rs == nil || !ok {
+		return "", fmt.Errorf("no remote storage configured")
+	}
+
+	if wso.isChunkedBackup(sess, backupName) {
+		backupName = storage.DefaultChunkedBackup
+	}
+	return rs.Qualify(backupName), nil
+}
+
+// isChunkedBackup returns true if a backup is uploaded as chunked backup rather than a single archive
+func (wso *DefaultWorkspaceOperations) isChunkedBackup(sess *session.Workspace, backupName string) bool {
+	return wso.config.Backup.Chunked && !sess.FullWorkspaceBackup && backupName == storage.DefaultBackup
+}
+
 func (wso *DefaultWorkspaceOperations) Snapshot(ctx context.Context, workspaceID, snapshotName string) (err error) {
 	//nolint:ineffassign
 	span, ctx := opentracing.StartSpanFromContext(ctx, "TakeSnapshot")
@@ -451,6 +478,19 @@ func (wso *DefaultWorkspaceOperations) uploadWorkspaceContent(ctx context.Contex
 		return xerrors.Errorf("cannot create archive: %w", err)
 	}
 
+	if wso.isChunkedBackup(sess, backupName) {
+		var ps storage.PresignedAccess
+		ps, err = storage.NewPresignedAccess(&wso.config.Storage)
+		if err != nil {
+			return xerrors.Errorf("no presigned storage available: %w", err)
+		}
+		err = content.UploadChunkedBackup(ctx, rs, ps, sess.Owner, tmpf.Name(), wso.config.TmpDir, wso.config.Backup.Attempts, glog.WithFields(sess.OWI()))
+		if err != nil {
+			return xerrors.Errorf("cannot upload workspace content: %w", err)
+		}
+		return nil
+	}
+
 	err = retryIfErr(ctx, wso.config.Backup.Attempts, glog.WithFields(sess.OWI()).WithField("op", "upload layer"), func(ctx context.Context) (err error) {
 		_, _, err = rs.Upload(ctx, tmpf.Name(), backupName, opts...)
 		if err != nil {
@@ -466,6 +506,30 @@ func (wso *DefaultWorkspaceOperations) uploadWorkspaceContent(ctx context.Contex
 	return nil
 }
 
+func (wso *DefaultWorkspaceOperations) DeleteSnapshot(ctx context.Context, snapshotURL string) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DeleteSnapshot")
+	span.SetTag("snapshot", snapshotURL)
+	defer tracing.FinishSpan(span, &err)
+
+	bkt, obj, err := storage.ParseSnapshotName(snapshotURL)
+	if err != nil {
+		// e.g. snapshots taken without remote storage, there is nothing we could delete
+		glog.WithError(err).WithField("snapshot", snapshotURL).Warn("cannot delete snapshot content")
+		return nil
+	}
+
+	ps, err := storage.NewPresignedAccess(&wso.config.Storage)
+	if err != nil {
+		return xerrors.Errorf("no presigned storage available: %w", err)
+	}
+	err = ps.DeleteObject(ctx, bkt, &storage.DeleteObjectQuery{Name: obj})
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
 func retryIfErr(ctx context.Context, attempts int, log *logrus.Entry, op func(ctx context.Context) error) (err error) {
 	//nolint:ineffassign
 	span, ctx := opentracing.StartSpanFromContext(ctx, "retryIfErr")
@@ -5,7 +5,10 @@
 package iws
 
 import (
+	"bufio"
 	"context"
+	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -955,6 +958,342 @@ func (wbs *InWorkspaceServiceServer) WorkspaceInfo(ctx context.Context, req *api
 	}, nil
 }
 
+// Mknod creates a character device on behalf of a workspace process
+func (wbs *InWorkspaceServiceServer) Mknod(ctx context.Context, req *api.MknodRequest) (resp *api.MknodResponse, err error) {
+	var (
+		reqPID  = req.Pid
+		procPID uint64
+	)
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		log.WithError(err).WithField("procPID", procPID).WithField("reqPID", reqPID).WithField("path", req.Path).WithFields(wbs.Session.OWI()).Error("cannot mknod")
+		if _, ok := status.FromError(err); !ok {
+			err = status.Error(codes.Internal, "cannot mknod")
+		}
+	}()
+
+	if req.Path == "" {
+		return nil, status.Error(codes.InvalidArgument, "path is required")
+	}
+	if req.Mode&unix.S_IFMT != unix.S_IFCHR {
+		return nil, status.Error(codes.PermissionDenied, "only character devices can be created")
+	}
+	if !api.IsMknodAllowed(req.Major, req.Minor) {
+		return nil, status.Errorf(codes.PermissionDenied, "device %d:%d is not allowed", req.Major, req.Minor)
+	}
+
+	procPID, err = wbs.findWorkspacePID(ctx, req.Pid)
+	if err != nil {
+		return nil, err
+	}
+	proc, err := readProcCreds(filepath.Join(wbs.Uidmapper.Config.ProcLocation, strconv.FormatUint(procPID, 10), "status"))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read credentials of PID %d: %w", procPID, err)
+	}
+
+	mode := req.Mode &^ unix.S_IFMT &^ proc.Umask & 07777
+	err = nsi.Nsinsider(wbs.Session.InstanceID, int(procPID), func(c *exec.Cmd) {
+		c.Args = append(c.Args, "mknod",
+			"--path", req.Path,
+			"--mode", strconv.FormatUint(uint64(mode), 10),
+			"--major", strconv.FormatUint(uint64(req.Major), 10),
+			"--minor", strconv.FormatUint(uint64(req.Minor), 10),
+			"--uid", strconv.FormatUint(uint64(proc.FSUID), 10),
+			"--gid", strconv.FormatUint(uint64(proc.FSGID), 10),
+		)
+	}, nsi.EnterMountNS(true))
+	if err != nil {
+		return nil, statusFromNsinsider(err, "cannot mknod")
+	}
+
+	return &api.MknodResponse{}, nil
+}
+
+const (
+	// capSetfcap is the bit of CAP_SETFCAP in a capability set
+	capSetfcap = 31
+
+	vfsCapRevisionMask = 0xFF000000
+	vfsCapRevision2    = 0x02000000
+	vfsCapRevision3    = 0x03000000
+	vfsCapV2Size       = 20
+	vfsCapV3Size       = 24
+)
+
+// SetXattr sets security.capability on behalf of a workspace process
+func (wbs *InWorkspaceServiceServer) SetXattr(ctx context.Context, req *api.SetXattrRequest) (resp *api.SetXattrResponse, err error) {
+	var (
+		reqPID  = req.Pid
+		procPID uint64
+	)
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		log.WithError(err).WithField("procPID", procPID).WithField("reqPID", reqPID).WithField("path", req.Path).WithField("name", req.Name).WithFields(wbs.Session.OWI()).Error("cannot setxattr")
+		if _, ok := status.FromError(err); !ok {
+			err = status.Error(codes.Internal, "cannot setxattr")
+		}
+	}()
+
+	if req.Path == "" {
+		return nil, status.Error(codes.InvalidArgument, "path is required")
+	}
+	if !api.IsSetXattrAllowed(req.Name) {
+		return nil, status.Errorf(codes.PermissionDenied, "extended attribute %s is not allowed", req.Name)
+	}
+	if req.Flags&^(unix.XATTR_CREATE|unix.XATTR_REPLACE) != 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid flags %#x", req.Flags)
+	}
+
+	procPID, err = wbs.findWorkspacePID(ctx, req.Pid)
+	if err != nil {
+		return nil, err
+	}
+	procDir := filepath.Join(wbs.Uidmapper.Config.ProcLocation, strconv.FormatUint(procPID, 10))
+	proc, err := readProcCreds(filepath.Join(procDir, "status"))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read credentials of PID %d: %w", procPID, err)
+	}
+	if proc.CapEff&(1<<capSetfcap) == 0 {
+		return nil, status.Error(codes.PermissionDenied, "CAP_SETFCAP is required")
+	}
+	uidMap, err := readIDMap(filepath.Join(procDir, "uid_map"))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read UID map of PID %d: %w", procPID, err)
+	}
+	value, err := toHostVFSCap(req.Value, uidMap)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = nsi.Nsinsider(wbs.Session.InstanceID, int(procPID), func(c *exec.Cmd) {
+		c.Args = append(c.Args, "setxattr",
+			"--path", req.Path,
+			"--name", req.Name,
+			"--value", hex.EncodeToString(value),
+			"--flags", strconv.Itoa(int(req.Flags)),
+			"--uid", strconv.FormatUint(uint64(proc.FSUID), 10),
+			"--gid", strconv.FormatUint(uint64(proc.FSGID), 10),
+		)
+		if req.NoFollow {
+			c.Args = append(c.Args, "--no-follow")
+		}
+		for _, m := range uidMap {
+			c.Args = append(c.Args, "--mapped-uid-range", fmt.Sprintf("%d:%d", m.HostID, m.Size))
+		}
+	}, nsi.EnterMountNS(true))
+	if err != nil {
+		return nil, statusFromNsinsider(err, "cannot setxattr")
+	}
+
+	return &api.SetXattrResponse{}, nil
+}
+
+// findWorkspacePID translates an in-workspace PID to the root PID namespace
+func (wbs *InWorkspaceServiceServer) findWorkspacePID(ctx context.Context, pid int64) (uint64, error) {
+	rt := wbs.Uidmapper.Runtime
+	if rt == nil {
+		return 0, status.Errorf(codes.FailedPrecondition, "not connected to container runtime")
+	}
+	wscontainerID, err := rt.WaitForContainer(ctx, wbs.Session.InstanceID)
+	if err != nil {
+		return 0, xerrors.Errorf("cannot find workspace container")
+	}
+
+	containerPID, err := rt.ContainerPID(ctx, wscontainerID)
+	if err != nil {
+		return 0, xerrors.Errorf("cannot find container PID for containerID %v: %w", wscontainerID, err)
+	}
+
+	procPID, err := wbs.Uidmapper.findHostPID(containerPID, uint64(pid))
+	if err != nil {
+		return 0, xerrors.Errorf("cannot map in-container PID %d (container PID: %d): %w", pid, containerPID, err)
+	}
+	return procPID, nil
+}
+
+// statusFromNsinsider turns the errno nsinsider exited with into a gRPC status
+func statusFromNsinsider(err error, msg string) error {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return err
+	}
+
+	switch syscall.Errno(exitErr.ExitCode()) {
+	case syscall.EEXIST:
+		return status.Errorf(codes.AlreadyExists, "%s: file exists", msg)
+	case syscall.ENOENT, syscall.ENOTDIR, syscall.ENODATA:
+		return status.Errorf(codes.NotFound, "%s: no such file or directory", msg)
+	case syscall.EPERM, syscall.EACCES:
+		return status.Errorf(codes.PermissionDenied, "%s: permission denied", msg)
+	case syscall.EINVAL:
+		return status.Errorf(codes.InvalidArgument, "%s: invalid argument", msg)
+	default:
+		return err
+	}
+}
+
+type procCreds struct {
+	FSUID  uint32
+	FSGID  uint32
+	Umask  uint32
+	CapEff uint64
+}
+
+// readProcCreds reads the filesystem IDs, umask and effective capabilities from /proc/<pid>/status
+func readProcCreds(fn string) (*procCreds, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		res   procCreds
+		found int
+	)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		segs := strings.Fields(scanner.Text())
+		if len(segs) < 2 {
+			continue
+		}
+
+		var (
+			fld  = segs[1]
+			base = 10
+			dst  interface{}
+		)
+		switch segs[0] {
+		case "Uid:", "Gid:":
+			// Real, effective, saved set and filesystem ID
+			if len(segs) != 5 {
+				return nil, xerrors.Errorf("cannot parse %s in %s", segs[0], fn)
+			}
+			fld = segs[4]
+			if segs[0] == "Uid:" {
+				dst = &res.FSUID
+			} else {
+				dst = &res.FSGID
+			}
+		case "Umask:":
+			base = 8
+			dst = &res.Umask
+		case "CapEff:":
+			base = 16
+			dst = &res.CapEff
+		default:
+			continue
+		}
+
+		switch d := dst.(type) {
+		case *uint32:
+			v, err := strconv.ParseUint(fld, base, 32)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot parse %s in %s: %w", segs[0], fn, err)
+			}
+			*d = uint32(v)
+		case *uint64:
+			v, err := strconv.ParseUint(fld, base, 64)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot parse %s in %s: %w", segs[0], fn, err)
+			}
+			*d = v
+		}
+		found++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if found != 4 {
+		return nil, xerrors.Errorf("%s is incomplete", fn)
+	}
+
+	return &res, nil
+}
+
+type idMapping struct {
+	ContainerID uint32
+	HostID      uint32
+	Size        uint32
+}
+
+// readIDMap reads /proc/<pid>/uid_map or /proc/<pid>/gid_map
+func readIDMap(fn string) ([]idMapping, error) {
+	fc, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []idMapping
+	for _, line := range strings.Split(strings.TrimSpace(string(fc)), "\n") {
+		segs := strings.Fields(line)
+		if len(segs) != 3 {
+			return nil, xerrors.Errorf("cannot parse ID mapping %q", line)
+		}
+		var vals [3]uint32
+		for i, seg := range segs {
+			v, err := strconv.ParseUint(seg, 10, 32)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot parse ID mapping %q: %w", line, err)
+			}
+			vals[i] = uint32(v)
+		}
+		res = append(res, idMapping{ContainerID: vals[0], HostID: vals[1], Size: vals[2]})
+	}
+	return res, nil
+}
+
+func toHostID(mapping []idMapping, id uint32) (uint32, bool) {
+	for _, m := range mapping {
+		if id >= m.ContainerID && uint64(id) < uint64(m.ContainerID)+uint64(m.Size) {
+			return m.HostID + (id - m.ContainerID), true
+		}
+	}
+	return 0, false
+}
+
+// toHostVFSCap converts a security.capability value written in the workspace user namespace to a
+// namespaced (v3) file capability for the host. This is what the kernel does when a process in a user
+// namespace sets the attribute itself: the capabilities are only effective for the root user of the workspace.
+func toHostVFSCap(value []byte, uidMap []idMapping) ([]byte, error) {
+	if len(value) < 4 {
+		return nil, xerrors.Errorf("invalid file capability: too short")
+	}
+
+	var rootID uint32
+	magic := binary.LittleEndian.Uint32(value)
+	switch magic & vfsCapRevisionMask {
+	case vfsCapRevision2:
+		if len(value) != vfsCapV2Size {
+			return nil, xerrors.Errorf("invalid v2 file capability: expected %d bytes, got %d", vfsCapV2Size, len(value))
+		}
+	case vfsCapRevision3:
+		if len(value) != vfsCapV3Size {
+			return nil, xerrors.Errorf("invalid v3 file capability: expected %d bytes, got %d", vfsCapV3Size, len(value))
+		}
+		rootID = binary.LittleEndian.Uint32(value[vfsCapV2Size:])
+	default:
+		return nil, xerrors.Errorf("unsupported file capability revision %#x", magic&vfsCapRevisionMask)
+	}
+
+	hostRootID, ok := toHostID(uidMap, rootID)
+	if !ok {
+		return nil, xerrors.Errorf("root ID %d is not mapped in the workspace", rootID)
+	}
+
+	res := make([]byte, vfsCapV3Size)
+	copy(res, value[:vfsCapV2Size])
+	binary.LittleEndian.PutUint32(res, magic&^vfsCapRevisionMask|vfsCapRevision3)
+	binary.LittleEndian.PutUint32(res[vfsCapV2Size:], hostRootID)
+	return res, nil
+}
+
 func getWorkspaceResourceInfo(mountPoint, cgroupPath string) (*api.Resources, error) {
 	cpu, err := getCpuResourceInfoV2(mountPoint, cgroupPath)
 	if err != nil {
@@ -0,0 +1,84 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package iws
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestToHostVFSCap(t *testing.T) {
+	uidMap := []idMapping{
+		{ContainerID: 0, HostID: 100000, Size: 1},
+		{ContainerID: 1, HostID: 100001, Size: 65534},
+	}
+	tests := []struct {
+		Name        string
+		Value       string
+		Expectation string
+		Error       bool
+	}{
+		{
+			Name: "v2 cap_net_raw+ep",
+			// magic_etc, permitted, inheritable, permitted (high), inheritable (high)
+			Value:       "01000002" + "00200000" + "00000000" + "00000000" + "00000000",
+			Expectation: "01000003" + "00200000" + "00000000" + "00000000" + "00000000" + "a0860100",
+		},
+		{
+			Name:        "v3 with workspace root",
+			Value:       "00000003" + "00200000" + "00000000" + "00000000" + "00000000" + "00000000",
+			Expectation: "00000003" + "00200000" + "00000000" + "00000000" + "00000000" + "a0860100",
+		},
+		{
+			Name:        "v3 with other root",
+			Value:       "00000003" + "00200000" + "00000000" + "00000000" + "00000000" + "e8030000",
+			Expectation: "00000003" + "00200000" + "00000000" + "00000000" + "00000000" + "888a0100",
+		},
+		{
+			Name:  "v3 with unmapped root",
+			Value: "00000003" + "00200000" + "00000000" + "00000000" + "00000000" + "ffffffff",
+			Error: true,
+		},
+		{
+			Name:  "v2 too short",
+			Value: "01000002" + "00200000",
+			Error: true,
+		},
+		{
+			Name:  "v1",
+			Value: "01000001" + "00200000" + "00000000",
+			Error: true,
+		},
+		{
+			Name:  "empty",
+			Value: "",
+			Error: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			value, err := hex.DecodeString(test.Value)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			act, err := toHostVFSCap(value, uidMap)
+			if test.Error {
+				if err == nil {
+					t.Errorf("expected error, got %x", act)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, hex.EncodeToString(act)); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -109,13 +109,13 @@ func Nsinsider(instanceID string, targetPid int, mod func(*exec.Cmd), opts ...ns
 	if err != nil {
 		out, oErr := cmd.CombinedOutput()
 		if oErr != nil {
-			return fmt.Errorf("run nsinsider (%v) \n%v\n output error: %v",
+			return fmt.Errorf("run nsinsider (%v) \n%w\n output error: %v",
 				cmd.Args,
 				err,
 				oErr,
 			)
 		}
-		return fmt.Errorf("run nsinsider (%v) failed: %q\n%v",
+		return fmt.Errorf("run nsinsider (%v) failed: %q\n%w",
 			cmd.Args,
 			string(out),
 			err,
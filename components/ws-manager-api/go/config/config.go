@@ -133,6 +133,8 @@ type Configuration struct {
 	TimeoutMaxConcurrentReconciles int `json:"timeoutMaxConcurrentReconciles,omitempty"`
 	// ExperimentalMode controls if experimental features are enabled
 	ExperimentalMode bool `json:"experimentalMode"`
+	// Quotas limit the number of workspaces owners and organisations can run concurrently
+	Quotas []WorkspaceQuota `json:"quotas,omitempty"`
 }
 
 type WorkspaceClass struct {
@@ -164,6 +166,54 @@ type WorkspaceTimeoutConfiguration struct {
 	Stopping util.Duration `json:"stopping"`
 	// Interrupted is the time a workspace may be interrupted (since it last saw activity or since it was created if it never saw any)
 	Interrupted util.Duration `json:"interrupted"`
+	// Warning is the time before a running workspace times out at which users are warned about the upcoming timeout.
+	// Zero disables timeout warnings.
+	Warning util.Duration `json:"warning,omitempty"`
+}
+
+// QuotaScope determines whom a workspace quota applies to
+type QuotaScope string
+
+const (
+	// QuotaScopeOwner limits the workspaces of each owner
+	QuotaScopeOwner QuotaScope = "owner"
+	// QuotaScopeOrganization limits the workspaces of each organisation, i.e. the team label of a workspace
+	QuotaScopeOrganization QuotaScope = "organization"
+)
+
+// WorkspaceQuota limits the number of workspaces which run concurrently per owner or organisation
+type WorkspaceQuota struct {
+	// Name identifies the quota in errors and metrics
+	Name string `json:"name"`
+	// Scope determines whether the quota applies per owner or per organisation
+	Scope QuotaScope `json:"scope"`
+	// Classes restricts the quota to workspaces of these classes. An empty list applies the quota to all classes.
+	Classes []string `json:"classes,omitempty"`
+	// MaxRunning is the number of workspaces an owner or organisation may run concurrently
+	MaxRunning int `json:"maxRunning"`
+	// Overrides replaces MaxRunning for individual owners or organisations, keyed by their ID
+	Overrides map[string]int `json:"overrides,omitempty"`
+}
+
+// Limit returns the number of workspaces the owner or organisation may run concurrently
+func (q *WorkspaceQuota) Limit(subject string) int {
+	if l, ok := q.Overrides[subject]; ok {
+		return l
+	}
+	return q.MaxRunning
+}
+
+// AppliesToClass returns true if the quota limits workspaces of the given class
+func (q *WorkspaceQuota) AppliesToClass(class string) bool {
+	if len(q.Classes) == 0 {
+		return true
+	}
+	for _, c := range q.Classes {
+		if c == class {
+			return true
+		}
+	}
+	return false
 }
 
 // InitProbeConfiguration configures the behaviour of the workspace ready probe
@@ -259,6 +309,34 @@ func (c *Configuration) Validate() error {
 		}
 	}
 
+	quotas := make(map[string]struct{}, len(c.Quotas))
+	for _, q := range c.Quotas {
+		if errs := validation.IsValidLabelValue(q.Name); q.Name == "" || len(errs) > 0 {
+			return xerrors.Errorf("quota name \"%s\" is invalid: %v", q.Name, errs)
+		}
+		if _, exists := quotas[q.Name]; exists {
+			return xerrors.Errorf("quota %s is defined more than once", q.Name)
+		}
+		quotas[q.Name] = struct{}{}
+
+		if q.Scope != QuotaScopeOwner && q.Scope != QuotaScopeOrganization {
+			return xerrors.Errorf("quota %s: unsupported scope \"%s\"", q.Name, q.Scope)
+		}
+		if q.MaxRunning < 0 {
+			return xerrors.Errorf("quota %s: maxRunning must not be negative", q.Name)
+		}
+		for subject, l := range q.Overrides {
+			if l < 0 {
+				return xerrors.Errorf("quota %s: override for %s must not be negative", q.Name, subject)
+			}
+		}
+		for _, class := range q.Classes {
+			if _, ok := c.WorkspaceClasses[class]; !ok {
+				return xerrors.Errorf("quota %s: unknown workspace class \"%s\"", q.Name, class)
+			}
+		}
+	}
+
 	return err
 }
 
@@ -65,6 +65,39 @@ func TestValidate(t *testing.T) {
 			}),
 			Expectation: `workspace class name "not/a/valid/name" is invalid: [a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')]`,
 		},
+		{
+			Name: "valid quotas",
+			Cfg: fromValidConfig(func(c *Configuration) {
+				c.Quotas = []WorkspaceQuota{
+					{Name: "org", Scope: QuotaScopeOrganization, MaxRunning: 20},
+					{Name: "user-default", Scope: QuotaScopeOwner, Classes: []string{DefaultWorkspaceClass}, MaxRunning: 4, Overrides: map[string]int{"foo": 8}},
+				}
+			}),
+		},
+		{
+			Name: "duplicate quota",
+			Cfg: fromValidConfig(func(c *Configuration) {
+				c.Quotas = []WorkspaceQuota{
+					{Name: "org", Scope: QuotaScopeOrganization, MaxRunning: 20},
+					{Name: "org", Scope: QuotaScopeOwner, MaxRunning: 4},
+				}
+			}),
+			Expectation: `quota org is defined more than once`,
+		},
+		{
+			Name: "invalid quota scope",
+			Cfg: fromValidConfig(func(c *Configuration) {
+				c.Quotas = []WorkspaceQuota{{Name: "org", Scope: "project", MaxRunning: 20}}
+			}),
+			Expectation: `quota org: unsupported scope "project"`,
+		},
+		{
+			Name: "quota for unknown class",
+			Cfg: fromValidConfig(func(c *Configuration) {
+				c.Quotas = []WorkspaceQuota{{Name: "large", Scope: QuotaScopeOwner, Classes: []string{"large"}, MaxRunning: 1}}
+			}),
+			Expectation: `quota large: unknown workspace class "large"`,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
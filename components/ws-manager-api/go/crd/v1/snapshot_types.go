@@ -8,6 +8,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// SnapshotFinalizerName is the finalizer ws-daemon uses to delete the content of a snapshot from remote storage
+// before the snapshot object is removed.
+const SnapshotFinalizerName = "gitpod.io/snapshot-content"
+
 // SnapshotSpec defines the desired state of the snapshot
 type SnapshotSpec struct {
 	// +kubebuilder:validation:Required
@@ -5,6 +5,8 @@
 package v1
 
 import (
+	"time"
+
 	wsk8s "github.com/gitpod-io/gitpod/common-go/kubernetes"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,6 +22,23 @@ const (
 	// ReasonInitializationFailure is a Reason for the WorkspaceConditionContentReady condition,
 	// indicating that content init failed. The condition's message will contain the failure details.
 	ReasonInitializationFailure = "InitializationFailure"
+
+	// ReasonBackupRequested is a Reason for the WorkspaceConditionBackupRequested condition,
+	// indicating that a backup of the running workspace was requested and has not been taken yet.
+	ReasonBackupRequested = "BackupRequested"
+	// ReasonBackupComplete is a Reason for the WorkspaceConditionBackupRequested condition,
+	// indicating that the requested backup was taken. The condition's message will contain the backup URL.
+	ReasonBackupComplete = "BackupComplete"
+	// ReasonBackupFailed is a Reason for the WorkspaceConditionBackupRequested condition,
+	// indicating that the requested backup failed. The condition's message will contain the failure details.
+	ReasonBackupFailed = "BackupFailed"
+
+	// ReasonTimeoutImminent is a Reason for the WorkspaceConditionTimeoutWarning condition,
+	// indicating that the workspace is about to time out.
+	ReasonTimeoutImminent = "TimeoutImminent"
+	// ReasonTimeoutAverted is a Reason for the WorkspaceConditionTimeoutWarning condition,
+	// indicating that the workspace is no longer about to time out, e.g. because of user activity.
+	ReasonTimeoutAverted = "TimeoutAverted"
 )
 
 // WorkspaceSpec defines the desired state of Workspace
@@ -149,13 +168,18 @@ type WorkspaceStatus struct {
 
 	// +kubebuilder:validation:Optional
 	Runtime *WorkspaceRuntimeStatus `json:"runtime,omitempty"`
+
+	// LastActivity is the last user activity persisted for this workspace. Activity is flushed
+	// periodically, hence this can lag behind the actual last activity by up to the flush interval.
+	// +kubebuilder:validation:Optional
+	LastActivity *metav1.Time `json:"lastActivity,omitempty"`
 }
 
 func (s *WorkspaceStatus) SetCondition(cond metav1.Condition) {
 	s.Conditions = wsk8s.AddUniqueCondition(s.Conditions, cond)
 }
 
-// +kubebuilder:validation:Enum=Deployed;Failed;Timeout;FirstUserActivity;Closed;HeadlessTaskFailed;StoppedByRequest;Aborted;ContentReady;EverReady;BackupComplete;BackupFailure
+// +kubebuilder:validation:Enum=Deployed;Failed;Timeout;FirstUserActivity;Closed;HeadlessTaskFailed;StoppedByRequest;Aborted;ContentReady;EverReady;BackupComplete;BackupFailure;BackupRequested;TimeoutWarning
 type WorkspaceCondition string
 
 const (
@@ -200,6 +224,14 @@ const (
 
 	// Refresh is used to ensure that we operate on the latest version of the workspace
 	WorkspaceConditionRefresh WorkspaceCondition = "Refresh"
+
+	// BackupRequested is true while a backup of a running workspace has been requested, but not taken yet.
+	// Once ws-daemon handled the request the condition is false and its reason indicates the outcome.
+	WorkspaceConditionBackupRequested WorkspaceCondition = "BackupRequested"
+
+	// TimeoutWarning is true shortly before a running workspace times out. The condition message
+	// contains the time the workspace is expected to time out at in RFC3339 format.
+	WorkspaceConditionTimeoutWarning WorkspaceCondition = "TimeoutWarning"
 )
 
 func NewWorkspaceConditionDeployed() metav1.Condition {
@@ -303,6 +335,30 @@ func NewWorkspaceConditionBackupFailure(message string) metav1.Condition {
 	}
 }
 
+func NewWorkspaceConditionBackupRequested(status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(WorkspaceConditionBackupRequested),
+		LastTransitionTime: metav1.Now(),
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+func NewWorkspaceConditionTimeoutWarning(status metav1.ConditionStatus, reason string, timeoutAt time.Time) metav1.Condition {
+	var message string
+	if status == metav1.ConditionTrue {
+		message = timeoutAt.UTC().Format(time.RFC3339)
+	}
+	return metav1.Condition{
+		Type:               string(WorkspaceConditionTimeoutWarning),
+		LastTransitionTime: metav1.Now(),
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
 func NewWorkspaceConditionRefresh() metav1.Condition {
 	return metav1.Condition{
 		Type:               string(WorkspaceConditionRefresh),
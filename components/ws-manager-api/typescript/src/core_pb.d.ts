@@ -863,6 +863,11 @@ export class WorkspaceConditions extends jspb.Message {
     getAborted(): WorkspaceConditionBool;
     setAborted(value: WorkspaceConditionBool): WorkspaceConditions;
 
+    hasTimeoutWarning(): boolean;
+    clearTimeoutWarning(): void;
+    getTimeoutWarning(): google_protobuf_timestamp_pb.Timestamp | undefined;
+    setTimeoutWarning(value?: google_protobuf_timestamp_pb.Timestamp): WorkspaceConditions;
+
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): WorkspaceConditions.AsObject;
     static toObject(includeInstance: boolean, msg: WorkspaceConditions): WorkspaceConditions.AsObject;
@@ -887,6 +892,7 @@ export namespace WorkspaceConditions {
         stoppedByRequest: WorkspaceConditionBool,
         volumeSnapshot?: VolumeSnapshotInfo.AsObject,
         aborted: WorkspaceConditionBool,
+        timeoutWarning?: google_protobuf_timestamp_pb.Timestamp.AsObject,
     }
 }
 
@@ -1199,6 +1205,10 @@ export namespace SSHPublicKeys {
 }
 
 export class DescribeClusterRequest extends jspb.Message {
+    getOwner(): string;
+    setOwner(value: string): DescribeClusterRequest;
+    getTeam(): string;
+    setTeam(value: string): DescribeClusterRequest;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): DescribeClusterRequest.AsObject;
@@ -1212,6 +1222,8 @@ export class DescribeClusterRequest extends jspb.Message {
 
 export namespace DescribeClusterRequest {
     export type AsObject = {
+        owner: string,
+        team: string,
     }
 }
 
@@ -1220,6 +1232,10 @@ export class DescribeClusterResponse extends jspb.Message {
     getWorkspaceclassesList(): Array<WorkspaceClass>;
     setWorkspaceclassesList(value: Array<WorkspaceClass>): DescribeClusterResponse;
     addWorkspaceclasses(value?: WorkspaceClass, index?: number): WorkspaceClass;
+    clearQuotasList(): void;
+    getQuotasList(): Array<WorkspaceQuotaStatus>;
+    setQuotasList(value: Array<WorkspaceQuotaStatus>): DescribeClusterResponse;
+    addQuotas(value?: WorkspaceQuotaStatus, index?: number): WorkspaceQuotaStatus;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): DescribeClusterResponse.AsObject;
@@ -1234,6 +1250,47 @@ export class DescribeClusterResponse extends jspb.Message {
 export namespace DescribeClusterResponse {
     export type AsObject = {
         workspaceclassesList: Array<WorkspaceClass.AsObject>,
+        quotasList: Array<WorkspaceQuotaStatus.AsObject>,
+    }
+}
+
+export class WorkspaceQuotaStatus extends jspb.Message {
+    getName(): string;
+    setName(value: string): WorkspaceQuotaStatus;
+    getScope(): string;
+    setScope(value: string): WorkspaceQuotaStatus;
+    getSubject(): string;
+    setSubject(value: string): WorkspaceQuotaStatus;
+    clearWorkspaceClassesList(): void;
+    getWorkspaceClassesList(): Array<string>;
+    setWorkspaceClassesList(value: Array<string>): WorkspaceQuotaStatus;
+    addWorkspaceClasses(value: string, index?: number): string;
+    getLimit(): number;
+    setLimit(value: number): WorkspaceQuotaStatus;
+    getUsed(): number;
+    setUsed(value: number): WorkspaceQuotaStatus;
+    getRemaining(): number;
+    setRemaining(value: number): WorkspaceQuotaStatus;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): WorkspaceQuotaStatus.AsObject;
+    static toObject(includeInstance: boolean, msg: WorkspaceQuotaStatus): WorkspaceQuotaStatus.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: WorkspaceQuotaStatus, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): WorkspaceQuotaStatus;
+    static deserializeBinaryFromReader(message: WorkspaceQuotaStatus, reader: jspb.BinaryReader): WorkspaceQuotaStatus;
+}
+
+export namespace WorkspaceQuotaStatus {
+    export type AsObject = {
+        name: string,
+        scope: string,
+        subject: string,
+        workspaceClassesList: Array<string>,
+        limit: number,
+        used: number,
+        remaining: number,
     }
 }
 
@@ -74,6 +74,7 @@ goog.exportSymbol('proto.wsman.WorkspaceConditions', null, global);
 goog.exportSymbol('proto.wsman.WorkspaceFeatureFlag', null, global);
 goog.exportSymbol('proto.wsman.WorkspaceMetadata', null, global);
 goog.exportSymbol('proto.wsman.WorkspacePhase', null, global);
+goog.exportSymbol('proto.wsman.WorkspaceQuotaStatus', null, global);
 goog.exportSymbol('proto.wsman.WorkspaceRuntimeInfo', null, global);
 goog.exportSymbol('proto.wsman.WorkspaceSpec', null, global);
 goog.exportSymbol('proto.wsman.WorkspaceStatus', null, global);
@@ -1002,6 +1003,27 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.wsman.DescribeClusterResponse.displayName = 'proto.wsman.DescribeClusterResponse';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.wsman.WorkspaceQuotaStatus = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.wsman.WorkspaceQuotaStatus.repeatedFields_, null);
+};
+goog.inherits(proto.wsman.WorkspaceQuotaStatus, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.wsman.WorkspaceQuotaStatus.displayName = 'proto.wsman.WorkspaceQuotaStatus';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -6867,7 +6889,8 @@ proto.wsman.WorkspaceConditions.toObject = function(includeInstance, msg) {
     headlessTaskFailed: jspb.Message.getFieldWithDefault(msg, 10, ""),
     stoppedByRequest: jspb.Message.getFieldWithDefault(msg, 11, 0),
     volumeSnapshot: (f = msg.getVolumeSnapshot()) && proto.wsman.VolumeSnapshotInfo.toObject(includeInstance, f),
-    aborted: jspb.Message.getFieldWithDefault(msg, 13, 0)
+    aborted: jspb.Message.getFieldWithDefault(msg, 13, 0),
+    timeoutWarning: (f = msg.getTimeoutWarning()) && google_protobuf_timestamp_pb.Timestamp.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -6954,6 +6977,11 @@ proto.wsman.WorkspaceConditions.deserializeBinaryFromReader = function(msg, read
       var value = /** @type {!proto.wsman.WorkspaceConditionBool} */ (reader.readEnum());
       msg.setAborted(value);
       break;
+    case 14:
+      var value = new google_protobuf_timestamp_pb.Timestamp;
+      reader.readMessage(value,google_protobuf_timestamp_pb.Timestamp.deserializeBinaryFromReader);
+      msg.setTimeoutWarning(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -7069,6 +7097,14 @@ proto.wsman.WorkspaceConditions.serializeBinaryToWriter = function(message, writ
       f
     );
   }
+  f = message.getTimeoutWarning();
+  if (f != null) {
+    writer.writeMessage(
+      14,
+      f,
+      google_protobuf_timestamp_pb.Timestamp.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -7326,6 +7362,43 @@ proto.wsman.WorkspaceConditions.prototype.setAborted = function(value) {
 };
 
 
+/**
+ * optional google.protobuf.Timestamp timeout_warning = 14;
+ * @return {?proto.google.protobuf.Timestamp}
+ */
+proto.wsman.WorkspaceConditions.prototype.getTimeoutWarning = function() {
+  return /** @type{?proto.google.protobuf.Timestamp} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_timestamp_pb.Timestamp, 14));
+};
+
+
+/**
+ * @param {?proto.google.protobuf.Timestamp|undefined} value
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+*/
+proto.wsman.WorkspaceConditions.prototype.setTimeoutWarning = function(value) {
+  return jspb.Message.setWrapperField(this, 14, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.clearTimeoutWarning = function() {
+  return this.setTimeoutWarning(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.wsman.WorkspaceConditions.prototype.hasTimeoutWarning = function() {
+  return jspb.Message.getField(this, 14) != null;
+};
+
+
 
 
 
@@ -9724,7 +9797,8 @@ proto.wsman.DescribeClusterRequest.prototype.toObject = function(opt_includeInst
  */
 proto.wsman.DescribeClusterRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-
+    owner: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    team: jspb.Message.getFieldWithDefault(msg, 2, "")
   };
 
   if (includeInstance) {
@@ -9761,6 +9835,14 @@ proto.wsman.DescribeClusterRequest.deserializeBinaryFromReader = function(msg, r
     }
     var field = reader.getFieldNumber();
     switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setOwner(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setTeam(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -9790,6 +9872,56 @@ proto.wsman.DescribeClusterRequest.prototype.serializeBinary = function() {
  */
 proto.wsman.DescribeClusterRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
+  f = message.getOwner();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getTeam();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string owner = 1;
+ * @return {string}
+ */
+proto.wsman.DescribeClusterRequest.prototype.getOwner = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.DescribeClusterRequest} returns this
+ */
+proto.wsman.DescribeClusterRequest.prototype.setOwner = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string team = 2;
+ * @return {string}
+ */
+proto.wsman.DescribeClusterRequest.prototype.getTeam = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.DescribeClusterRequest} returns this
+ */
+proto.wsman.DescribeClusterRequest.prototype.setTeam = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
 };
 
 
@@ -9799,7 +9931,7 @@ proto.wsman.DescribeClusterRequest.serializeBinaryToWriter = function(message, w
  * @private {!Array<number>}
  * @const
  */
-proto.wsman.DescribeClusterResponse.repeatedFields_ = [1];
+proto.wsman.DescribeClusterResponse.repeatedFields_ = [1,2];
 
 
 
@@ -9833,7 +9965,9 @@ proto.wsman.DescribeClusterResponse.prototype.toObject = function(opt_includeIns
 proto.wsman.DescribeClusterResponse.toObject = function(includeInstance, msg) {
   var f, obj = {
     workspaceclassesList: jspb.Message.toObjectList(msg.getWorkspaceclassesList(),
-    proto.wsman.WorkspaceClass.toObject, includeInstance)
+    proto.wsman.WorkspaceClass.toObject, includeInstance),
+    quotasList: jspb.Message.toObjectList(msg.getQuotasList(),
+    proto.wsman.WorkspaceQuotaStatus.toObject, includeInstance)
   };
 
   if (includeInstance) {
@@ -9875,6 +10009,11 @@ proto.wsman.DescribeClusterResponse.deserializeBinaryFromReader = function(msg,
       reader.readMessage(value,proto.wsman.WorkspaceClass.deserializeBinaryFromReader);
       msg.addWorkspaceclasses(value);
       break;
+    case 2:
+      var value = new proto.wsman.WorkspaceQuotaStatus;
+      reader.readMessage(value,proto.wsman.WorkspaceQuotaStatus.deserializeBinaryFromReader);
+      msg.addQuotas(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -9912,6 +10051,14 @@ proto.wsman.DescribeClusterResponse.serializeBinaryToWriter = function(message,
       proto.wsman.WorkspaceClass.serializeBinaryToWriter
     );
   }
+  f = message.getQuotasList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      2,
+      f,
+      proto.wsman.WorkspaceQuotaStatus.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -9953,6 +10100,380 @@ proto.wsman.DescribeClusterResponse.prototype.clearWorkspaceclassesList = functi
 };
 
 
+/**
+ * repeated WorkspaceQuotaStatus quotas = 2;
+ * @return {!Array<!proto.wsman.WorkspaceQuotaStatus>}
+ */
+proto.wsman.DescribeClusterResponse.prototype.getQuotasList = function() {
+  return /** @type{!Array<!proto.wsman.WorkspaceQuotaStatus>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.wsman.WorkspaceQuotaStatus, 2));
+};
+
+
+/**
+ * @param {!Array<!proto.wsman.WorkspaceQuotaStatus>} value
+ * @return {!proto.wsman.DescribeClusterResponse} returns this
+*/
+proto.wsman.DescribeClusterResponse.prototype.setQuotasList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 2, value);
+};
+
+
+/**
+ * @param {!proto.wsman.WorkspaceQuotaStatus=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.WorkspaceQuotaStatus}
+ */
+proto.wsman.DescribeClusterResponse.prototype.addQuotas = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 2, opt_value, proto.wsman.WorkspaceQuotaStatus, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.DescribeClusterResponse} returns this
+ */
+proto.wsman.DescribeClusterResponse.prototype.clearQuotasList = function() {
+  return this.setQuotasList([]);
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.wsman.WorkspaceQuotaStatus.repeatedFields_ = [4];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.toObject = function(opt_includeInstance) {
+  return proto.wsman.WorkspaceQuotaStatus.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.wsman.WorkspaceQuotaStatus} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.wsman.WorkspaceQuotaStatus.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    name: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    scope: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    subject: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    workspaceClassesList: (f = jspb.Message.getRepeatedField(msg, 4)) == null ? undefined : f,
+    limit: jspb.Message.getFieldWithDefault(msg, 5, 0),
+    used: jspb.Message.getFieldWithDefault(msg, 6, 0),
+    remaining: jspb.Message.getFieldWithDefault(msg, 7, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.wsman.WorkspaceQuotaStatus}
+ */
+proto.wsman.WorkspaceQuotaStatus.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.wsman.WorkspaceQuotaStatus;
+  return proto.wsman.WorkspaceQuotaStatus.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.wsman.WorkspaceQuotaStatus} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.wsman.WorkspaceQuotaStatus}
+ */
+proto.wsman.WorkspaceQuotaStatus.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setName(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setScope(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setSubject(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addWorkspaceClasses(value);
+      break;
+    case 5:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setLimit(value);
+      break;
+    case 6:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setUsed(value);
+      break;
+    case 7:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setRemaining(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.wsman.WorkspaceQuotaStatus.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.wsman.WorkspaceQuotaStatus} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.wsman.WorkspaceQuotaStatus.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getName();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getScope();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getSubject();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getWorkspaceClassesList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      4,
+      f
+    );
+  }
+  f = message.getLimit();
+  if (f !== 0) {
+    writer.writeInt32(
+      5,
+      f
+    );
+  }
+  f = message.getUsed();
+  if (f !== 0) {
+    writer.writeInt32(
+      6,
+      f
+    );
+  }
+  f = message.getRemaining();
+  if (f !== 0) {
+    writer.writeInt32(
+      7,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string name = 1;
+ * @return {string}
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceQuotaStatus} returns this
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.setName = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string scope = 2;
+ * @return {string}
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.getScope = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceQuotaStatus} returns this
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.setScope = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string subject = 3;
+ * @return {string}
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.getSubject = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceQuotaStatus} returns this
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.setSubject = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * repeated string workspace_classes = 4;
+ * @return {!Array<string>}
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.getWorkspaceClassesList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 4));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.wsman.WorkspaceQuotaStatus} returns this
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.setWorkspaceClassesList = function(value) {
+  return jspb.Message.setField(this, 4, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.WorkspaceQuotaStatus} returns this
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.addWorkspaceClasses = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 4, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.WorkspaceQuotaStatus} returns this
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.clearWorkspaceClassesList = function() {
+  return this.setWorkspaceClassesList([]);
+};
+
+
+/**
+ * optional int32 limit = 5;
+ * @return {number}
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.getLimit = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.wsman.WorkspaceQuotaStatus} returns this
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.setLimit = function(value) {
+  return jspb.Message.setProto3IntField(this, 5, value);
+};
+
+
+/**
+ * optional int32 used = 6;
+ * @return {number}
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.getUsed = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.wsman.WorkspaceQuotaStatus} returns this
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.setUsed = function(value) {
+  return jspb.Message.setProto3IntField(this, 6, value);
+};
+
+
+/**
+ * optional int32 remaining = 7;
+ * @return {number}
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.getRemaining = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 7, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.wsman.WorkspaceQuotaStatus} returns this
+ */
+proto.wsman.WorkspaceQuotaStatus.prototype.setRemaining = function(value) {
+  return jspb.Message.setProto3IntField(this, 7, value);
+};
+
+
 
 
 
@@ -248,7 +248,7 @@ export class WorkspaceManagerBridge implements Disposable {
 
             if (!instance.status.conditions.firstUserActivity && status.conditions.firstUserActivity) {
                 // Only report this when it's observed the first time
-                const firstUserActivity = mapFirstUserActivity(rawStatus.getConditions()!.getFirstUserActivity())!;
+                const firstUserActivity = mapTimestamp(rawStatus.getConditions()!.getFirstUserActivity())!;
                 this.prometheusExporter.observeFirstUserActivity(instance, firstUserActivity);
             }
 
@@ -270,9 +270,10 @@ export class WorkspaceManagerBridge implements Disposable {
             instance.status.conditions.pullingImages = toBool(status.conditions.pullingImages!);
             instance.status.conditions.deployed = toBool(status.conditions.deployed);
             instance.status.conditions.timeout = status.conditions.timeout;
-            instance.status.conditions.firstUserActivity = mapFirstUserActivity(
+            instance.status.conditions.firstUserActivity = mapTimestamp(
                 rawStatus.getConditions()!.getFirstUserActivity(),
             );
+            instance.status.conditions.timeoutWarning = mapTimestamp(rawStatus.getConditions()!.getTimeoutWarning());
             instance.status.conditions.headlessTaskFailed = status.conditions.headlessTaskFailed;
             instance.status.conditions.stoppedByRequest = toBool(status.conditions.stoppedByRequest);
             instance.status.message = status.message;
@@ -386,12 +387,12 @@ export class WorkspaceManagerBridge implements Disposable {
     }
 }
 
-const mapFirstUserActivity = (firstUserActivity: Timestamp | undefined): string | undefined => {
-    if (!firstUserActivity) {
+const mapTimestamp = (timestamp: Timestamp | undefined): string | undefined => {
+    if (!timestamp) {
         return undefined;
     }
 
-    return firstUserActivity.toDate().toISOString();
+    return timestamp.toDate().toISOString();
 };
 
 const mapPortVisibility = (visibility: WsManPortVisibility | undefined): PortVisibility | undefined => {
@@ -11,6 +11,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
@@ -26,7 +27,7 @@ import (
 	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
 )
 
-func NewTimeoutReconciler(c client.Client, recorder record.EventRecorder, cfg config.Configuration, activity *wsactivity.WorkspaceActivity) (*TimeoutReconciler, error) {
+func NewTimeoutReconciler(c client.Client, recorder record.EventRecorder, cfg config.Configuration, activity wsactivity.Store) (*TimeoutReconciler, error) {
 	if cfg.HeartbeatInterval == 0 {
 		return nil, fmt.Errorf("invalid heartbeat interval, must not be 0")
 	}
@@ -53,7 +54,7 @@ type TimeoutReconciler struct {
 	client.Client
 
 	Config            config.Configuration
-	activity          *wsactivity.WorkspaceActivity
+	activity          wsactivity.Store
 	reconcileInterval time.Duration
 	ctrlStartTime     time.Time
 	recorder          record.EventRecorder
@@ -92,10 +93,14 @@ func (r *TimeoutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		result.RequeueAfter = r.reconcileInterval
 	}()
 
-	timedout := r.isWorkspaceTimedOut(&workspace)
+	timedout, timeoutAt := r.isWorkspaceTimedOut(&workspace, time.Now())
 	if timedout == "" {
-		// Hasn't timed out.
-		return ctrl.Result{}, nil
+		// Hasn't timed out, but might do so soon.
+		err = r.updateTimeoutWarning(ctx, &workspace, timeoutAt)
+		if err != nil {
+			log.Error(err, "Failed to update workspace status with TimeoutWarning condition")
+		}
+		return ctrl.Result{}, err
 	}
 
 	// Workspace timed out, set Timeout condition.
@@ -117,6 +122,48 @@ func (r *TimeoutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	return ctrl.Result{}, nil
 }
 
+// updateTimeoutWarning sets the TimeoutWarning condition once a running workspace is about to time out,
+// and clears it again if the timeout was averted, e.g. because of user activity or a longer timeout.
+func (r *TimeoutReconciler) updateTimeoutWarning(ctx context.Context, ws *workspacev1.Workspace, timeoutAt time.Time) error {
+	warning := time.Duration(r.Config.Timeouts.Warning)
+	if warning == 0 || ws.Status.Phase != workspacev1.WorkspacePhaseRunning || ws.IsHeadless() {
+		return nil
+	}
+
+	current := wsk8s.GetCondition(ws.Status.Conditions, string(workspacev1.WorkspaceConditionTimeoutWarning))
+	isWarned := current != nil && current.Status == metav1.ConditionTrue
+
+	var cond metav1.Condition
+	if !timeoutAt.IsZero() && time.Until(timeoutAt) <= warning {
+		cond = workspacev1.NewWorkspaceConditionTimeoutWarning(metav1.ConditionTrue, workspacev1.ReasonTimeoutImminent, timeoutAt)
+		if isWarned && current.Message == cond.Message {
+			return nil
+		}
+	} else if isWarned {
+		cond = workspacev1.NewWorkspaceConditionTimeoutWarning(metav1.ConditionFalse, workspacev1.ReasonTimeoutAverted, timeoutAt)
+	} else {
+		return nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		err := r.Get(ctx, types.NamespacedName{Name: ws.Name, Namespace: ws.Namespace}, ws)
+		if err != nil {
+			return err
+		}
+
+		ws.Status.SetCondition(cond)
+		return r.Status().Update(ctx, ws)
+	})
+	if err != nil {
+		return err
+	}
+
+	if cond.Status == metav1.ConditionTrue {
+		r.recorder.Event(ws, corev1.EventTypeNormal, "TimeoutWarning", fmt.Sprintf("workspace will time out at %s", cond.Message))
+	}
+	return nil
+}
+
 type timeoutActivity string
 
 const (
@@ -135,13 +182,17 @@ const (
 
 // isWorkspaceTimedOut determines if a workspace is timed out based on the manager configuration and state the pod is in.
 // This function does NOT use the Timeout condition, but rather is used to set that condition in the first place.
-func (r *TimeoutReconciler) isWorkspaceTimedOut(ws *workspacev1.Workspace) (reason string) {
+// If the workspace has not timed out yet, timeoutAt is the time it will time out at unless its state changes, or zero if it never will.
+func (r *TimeoutReconciler) isWorkspaceTimedOut(ws *workspacev1.Workspace, now time.Time) (reason string, timeoutAt time.Time) {
 	timeouts := r.Config.Timeouts
 	phase := ws.Status.Phase
 
 	decide := func(start time.Time, timeout util.Duration, activity timeoutActivity) string {
 		td := time.Duration(timeout)
-		inactivity := time.Since(start)
+		if deadline := start.Add(td); timeoutAt.IsZero() || deadline.Before(timeoutAt) {
+			timeoutAt = deadline
+		}
+		inactivity := now.Sub(start)
 		if inactivity < td {
 			return ""
 		}
@@ -150,15 +201,17 @@ func (r *TimeoutReconciler) isWorkspaceTimedOut(ws *workspacev1.Workspace) (reas
 	}
 
 	start := ws.ObjectMeta.CreationTimestamp.Time
-	lastActivity := r.activity.GetLastActivity(ws.Name)
+	lastActivity := r.activity.GetLastActivity(ws)
 	isClosed := wsk8s.ConditionPresentAndTrue(ws.Status.Conditions, string(workspacev1.WorkspaceConditionClosed))
 
 	switch phase {
 	case workspacev1.WorkspacePhasePending:
-		return decide(start, timeouts.Initialization, activityInit)
+		reason = decide(start, timeouts.Initialization, activityInit)
+		return reason, timeoutAt
 
 	case workspacev1.WorkspacePhaseInitializing:
-		return decide(start, timeouts.TotalStartup, activityStartup)
+		reason = decide(start, timeouts.TotalStartup, activityStartup)
+		return reason, timeoutAt
 
 	case workspacev1.WorkspacePhaseCreating:
 		activity := activityCreatingContainers
@@ -166,12 +219,13 @@ func (r *TimeoutReconciler) isWorkspaceTimedOut(ws *workspacev1.Workspace) (reas
 		// if status.Conditions.PullingImages == api.WorkspaceConditionBool_TRUE {
 		// 	activity = activityPullingImages
 		// }
-		return decide(start, timeouts.TotalStartup, activity)
+		reason = decide(start, timeouts.TotalStartup, activity)
+		return reason, timeoutAt
 
 	case workspacev1.WorkspacePhaseRunning:
 		// First check is always for the max lifetime
 		if msg := decide(start, timeouts.MaxLifetime, activityMaxLifetime); msg != "" {
-			return msg
+			return msg, timeoutAt
 		}
 
 		timeout := timeouts.RegularWorkspace
@@ -184,25 +238,22 @@ func (r *TimeoutReconciler) isWorkspaceTimedOut(ws *workspacev1.Workspace) (reas
 			lastActivity = &start
 			activity = activityRunningHeadless
 		} else if lastActivity == nil {
-			// The workspace is up and running, but the user has never produced any activity, OR the controller
-			// has restarted and not yet received a heartbeat for this workspace (since heartbeats are stored
-			// in-memory and reset on restart).
-			// First check whether the controller has restarted during this workspace's lifetime.
-			// If it has, use the FirstUserActivity condition to determine whether there had already been any user activity
-			// before the controller restart.
-			// If the controller started before the workspace, then the user hasn't produced any activity yet.
+			// The workspace is up and running, but the user has never produced any activity. Activity is persisted
+			// on the workspace status, starting with the first activity, so it survives controller restarts.
+			// Workspaces started before activity was persisted only carry the FirstUserActivity condition though.
+			// If the controller restarted during the lifetime of such a workspace, its last activity has been lost.
 			if r.ctrlStartTime.After(start) && wsk8s.ConditionPresentAndTrue(ws.Status.Conditions, string(workspacev1.WorkspaceConditionFirstUserActivity)) {
-				// The controller restarted during this workspace's lifetime, and the workspace has had activity before the restart,
-				// so the last activity has been lost on restart. Therefore, "reset" the timeout and measure only since the controller startup time.
+				// The workspace has had activity before the restart, so "reset" the timeout and measure only since the controller startup time.
 				start = r.ctrlStartTime
 			} else {
 				// This workspace hasn't had any user activity yet (also not before a potential controller restart).
 				// So check for a startup timeout, and measure since workspace creation time.
 				timeout = timeouts.TotalStartup
 			}
-			return decide(start, timeout, activityNone)
+			reason = decide(start, timeout, activityNone)
+			return reason, timeoutAt
 		} else if isClosed {
-			reason := func() string {
+			reason = func() string {
 				afterClosed := timeouts.AfterClose
 				if customClosedTimeout := ws.Spec.Timeout.ClosedTimeout; customClosedTimeout != nil {
 					afterClosed = util.Duration(customClosedTimeout.Duration)
@@ -213,26 +264,29 @@ func (r *TimeoutReconciler) isWorkspaceTimedOut(ws *workspacev1.Workspace) (reas
 				return decide(*lastActivity, afterClosed, activityClosed)
 			}()
 			if reason != "" {
-				return reason
+				return reason, timeoutAt
 			}
 		}
-		return decide(*lastActivity, timeout, activity)
+		reason = decide(*lastActivity, timeout, activity)
+		return reason, timeoutAt
 
 	case workspacev1.WorkspacePhaseStopping:
 		if isWorkspaceBeingDeleted(ws) && !wsk8s.ConditionPresentAndTrue(ws.Status.Conditions, string(workspacev1.WorkspaceConditionBackupComplete)) {
 			// Beware: we apply the ContentFinalization timeout only to workspaces which are currently being deleted.
 			//         We basically don't expect a workspace to be in content finalization before it's been deleted.
-			return decide(ws.DeletionTimestamp.Time, timeouts.ContentFinalization, activityBackup)
+			reason = decide(ws.DeletionTimestamp.Time, timeouts.ContentFinalization, activityBackup)
+			return reason, timeoutAt
 		} else if !isWorkspaceBeingDeleted(ws) {
 			// workspaces that have not been deleted have never timed out
-			return ""
+			return "", timeoutAt
 		} else {
-			return decide(ws.DeletionTimestamp.Time, timeouts.Stopping, activityStopping)
+			reason = decide(ws.DeletionTimestamp.Time, timeouts.Stopping, activityStopping)
+			return reason, timeoutAt
 		}
 
 	default:
 		// The only other phases we can be in is stopped which is pointless to time out
-		return ""
+		return "", timeoutAt
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	wsk8s "github.com/gitpod-io/gitpod/common-go/kubernetes"
+	"github.com/gitpod-io/gitpod/common-go/util"
 	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/activity"
 	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
 	"github.com/google/uuid"
@@ -32,11 +33,12 @@ var _ = Describe("TimeoutController", func() {
 			fakeClient client.Client
 		)
 		BeforeEach(func() {
-			var err error
 			// Use a fake client instead of the envtest's k8s client, such that we can add objects
 			// with custom CreationTimestamps and check timeout logic.
 			fakeClient = fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).Build()
-			r, err = NewTimeoutReconciler(fakeClient, record.NewFakeRecorder(100), conf, &activity.WorkspaceActivity{})
+			store, err := activity.NewPersistentActivity(fakeClient, "default", time.Minute, activity.DefaultFlushQPS)
+			Expect(err).ToNot(HaveOccurred())
+			r, err = NewTimeoutReconciler(fakeClient, record.NewFakeRecorder(100), conf, store)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -58,7 +60,7 @@ var _ = Describe("TimeoutController", func() {
 				Expect(fakeClient.Create(ctx, ws)).To(Succeed())
 
 				if tc.lastActivityAgo != nil {
-					r.activity.Store(ws.Name, now.Add(-*tc.lastActivityAgo))
+					r.activity.MarkActive(ws.Name, now.Add(-*tc.lastActivityAgo))
 				}
 
 				updateObjWithRetries(fakeClient, ws, false, func(ws *workspacev1.Workspace) {
@@ -176,6 +178,45 @@ var _ = Describe("TimeoutController", func() {
 				controllerRestart: now,
 				expectTimeout:     true,
 			}),
+			Entry("shouldn't timeout with persisted activity after controller restart", testCase{
+				phase: workspacev1.WorkspacePhaseRunning,
+				updateStatus: func(ws *workspacev1.Workspace) {
+					ws.Status.Conditions = wsk8s.AddUniqueCondition(ws.Status.Conditions, metav1.Condition{
+						Type:               string(workspacev1.WorkspaceConditionFirstUserActivity),
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now.Add(-5 * time.Hour)),
+					})
+					ws.Status.LastActivity = &metav1.Time{Time: now.Add(-1 * time.Minute)}
+				},
+				age:               5 * time.Hour,
+				lastActivityAgo:   nil, // Activity recorded before the restart has been lost.
+				controllerRestart: now,
+				expectTimeout:     false,
+			}),
+			Entry("should timeout with stale persisted activity after controller restart", testCase{
+				phase: workspacev1.WorkspacePhaseRunning,
+				updateStatus: func(ws *workspacev1.Workspace) {
+					ws.Status.Conditions = wsk8s.AddUniqueCondition(ws.Status.Conditions, metav1.Condition{
+						Type:               string(workspacev1.WorkspaceConditionFirstUserActivity),
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now.Add(-5 * time.Hour)),
+					})
+					ws.Status.LastActivity = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+				},
+				age:               5 * time.Hour,
+				lastActivityAgo:   nil,
+				controllerRestart: now,
+				expectTimeout:     true,
+			}),
+			Entry("should use the latest of local and persisted activity", testCase{
+				phase: workspacev1.WorkspacePhaseRunning,
+				updateStatus: func(ws *workspacev1.Workspace) {
+					ws.Status.LastActivity = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+				},
+				age:             5 * time.Hour,
+				lastActivityAgo: pointer.Duration(1 * time.Minute),
+				expectTimeout:   false,
+			}),
 			Entry("should timeout eventually with no user activity after controller restart", testCase{
 				phase: workspacev1.WorkspacePhaseRunning,
 				updateStatus: func(ws *workspacev1.Workspace) {
@@ -193,6 +234,89 @@ var _ = Describe("TimeoutController", func() {
 		)
 	})
 
+	Context("timeout warnings", func() {
+		var (
+			conf       = newTestConfig()
+			r          *TimeoutReconciler
+			fakeClient client.Client
+		)
+		conf.Timeouts.Warning = util.Duration(5 * time.Minute)
+
+		BeforeEach(func() {
+			fakeClient = fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).Build()
+			store, err := activity.NewPersistentActivity(fakeClient, "default", time.Minute, activity.DefaultFlushQPS)
+			Expect(err).ToNot(HaveOccurred())
+			r, err = NewTimeoutReconciler(fakeClient, record.NewFakeRecorder(100), conf, store)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		createRunningWorkspace := func(update func(ws *workspacev1.Workspace)) *workspacev1.Workspace {
+			ws := newWorkspace(uuid.NewString(), "default")
+			ws.CreationTimestamp = metav1.NewTime(time.Now().Add(-5 * time.Hour))
+			if update != nil {
+				update(ws)
+			}
+			Expect(fakeClient.Create(ctx, ws)).To(Succeed())
+			updateObjWithRetries(fakeClient, ws, true, func(ws *workspacev1.Workspace) {
+				ws.Status.Phase = workspacev1.WorkspacePhaseRunning
+			})
+			return ws
+		}
+		reconcileWorkspace := func(ws *workspacev1.Workspace) *metav1.Condition {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: ws.Name, Namespace: ws.Namespace}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: ws.Name, Namespace: ws.Namespace}, ws)).To(Succeed())
+			return wsk8s.GetCondition(ws.Status.Conditions, string(workspacev1.WorkspaceConditionTimeoutWarning))
+		}
+
+		It("should warn before a workspace times out", func() {
+			ws := createRunningWorkspace(nil)
+			lastActivity := time.Now().Add(-time.Duration(conf.Timeouts.RegularWorkspace) + 2*time.Minute)
+			r.activity.MarkActive(ws.Name, lastActivity)
+
+			cond := reconcileWorkspace(ws)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal(workspacev1.ReasonTimeoutImminent))
+			Expect(cond.Message).To(Equal(lastActivity.Add(time.Duration(conf.Timeouts.RegularWorkspace)).UTC().Format(time.RFC3339)))
+			Expect(wsk8s.GetCondition(ws.Status.Conditions, string(workspacev1.WorkspaceConditionTimeout))).To(BeNil())
+
+			By("clearing the warning on user activity")
+			r.activity.MarkActive(ws.Name, time.Now())
+			cond = reconcileWorkspace(ws)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal(workspacev1.ReasonTimeoutAverted))
+		})
+
+		It("should not warn active workspaces", func() {
+			ws := createRunningWorkspace(nil)
+			r.activity.MarkActive(ws.Name, time.Now().Add(-time.Minute))
+
+			Expect(reconcileWorkspace(ws)).To(BeNil())
+		})
+
+		It("should not warn headless workspaces", func() {
+			ws := createRunningWorkspace(func(ws *workspacev1.Workspace) {
+				ws.Spec.Type = workspacev1.WorkspaceTypePrebuild
+				ws.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Duration(conf.Timeouts.HeadlessWorkspace) + time.Minute))
+			})
+
+			Expect(reconcileWorkspace(ws)).To(BeNil())
+		})
+
+		It("should warn before the max lifetime is reached", func() {
+			ws := createRunningWorkspace(func(ws *workspacev1.Workspace) {
+				ws.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Duration(conf.Timeouts.MaxLifetime) + time.Minute))
+			})
+			r.activity.MarkActive(ws.Name, time.Now())
+
+			cond := reconcileWorkspace(ws)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		})
+	})
+
 	Context("reconciliation", func() {
 		var r *TimeoutReconciler
 		BeforeEach(func() {
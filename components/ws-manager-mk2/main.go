@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -46,6 +47,7 @@ import (
 	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/activity"
 	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/maintenance"
 	imgproxy "github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/proxy"
+	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/quota"
 	"github.com/gitpod-io/gitpod/ws-manager-mk2/service"
 	//+kubebuilder:scaffold:imports
 )
@@ -72,12 +74,14 @@ func main() {
 	var configFN string
 	var jsonLog bool
 	var verbose bool
+	var enableWebhooks bool
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&configFN, "config", "", "Path to the config file")
 	flag.BoolVar(&jsonLog, "json-log", true, "produce JSON log output on verbose level")
 	flag.BoolVar(&verbose, "verbose", false, "Enable verbose logging")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false, "Enable the admission webhooks. Requires the webhook configuration and certificates to be installed.")
 	flag.Parse()
 
 	log.Init(ServiceName, Version, jsonLog, verbose)
@@ -147,14 +151,26 @@ func main() {
 		os.Exit(1)
 	}
 
-	activity := &activity.WorkspaceActivity{}
+	activity, err := activity.NewPersistentActivity(mgr.GetClient(), cfg.Manager.Namespace, time.Duration(cfg.Manager.HeartbeatInterval), activity.DefaultFlushQPS)
+	if err != nil {
+		setupLog.Error(err, "unable to create activity store")
+		os.Exit(1)
+	}
+	if err = mgr.Add(activity); err != nil {
+		setupLog.Error(err, "unable to add activity store to manager")
+		os.Exit(1)
+	}
+
 	timeoutReconciler, err := controllers.NewTimeoutReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("workspace"), cfg.Manager, activity)
 	if err != nil {
 		setupLog.Error(err, "unable to create timeout controller", "controller", "Timeout")
 		os.Exit(1)
 	}
 
-	wsmanService, err := setupGRPCService(cfg, mgr.GetClient(), activity, maintenanceReconciler)
+	quotas := quota.NewChecker(mgr.GetClient(), cfg.Manager.Namespace, cfg.Manager.Quotas)
+	metrics.Registry.MustRegister(quotas)
+
+	wsmanService, err := setupGRPCService(cfg, mgr.GetClient(), activity, maintenanceReconciler, quotas)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager service")
 		os.Exit(1)
@@ -174,10 +190,12 @@ func main() {
 		os.Exit(1)
 	}
 
-	// if err = (&workspacev1.Workspace{}).SetupWebhookWithManager(mgr); err != nil {
-	// 	setupLog.Error(err, "unable to create webhook", "webhook", "Workspace")
-	// 	os.Exit(1)
-	// }
+	if enableWebhooks {
+		if err = (&quota.Validator{Checker: quotas}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Workspace")
+			os.Exit(1)
+		}
+	}
 
 	//+kubebuilder:scaffold:builder
 
@@ -197,7 +215,7 @@ func main() {
 	}
 }
 
-func setupGRPCService(cfg *config.ServiceConfiguration, k8s client.Client, activity *activity.WorkspaceActivity, maintenance maintenance.Maintenance) (*service.WorkspaceManagerServer, error) {
+func setupGRPCService(cfg *config.ServiceConfiguration, k8s client.Client, activity activity.Store, maintenance maintenance.Maintenance, quotas *quota.Checker) (*service.WorkspaceManagerServer, error) {
 	// TODO(cw): remove use of common-go/log
 
 	if len(cfg.RPCServer.RateLimits) > 0 {
@@ -253,7 +271,7 @@ func setupGRPCService(cfg *config.ServiceConfiguration, k8s client.Client, activ
 		imgbldr.RegisterImageBuilderServer(grpcServer, imgproxy.ImageBuilder{D: imgbldr.NewImageBuilderClient(conn)})
 	}
 
-	srv := service.NewWorkspaceManagerServer(k8s, &cfg.Manager, metrics.Registry, activity, maintenance)
+	srv := service.NewWorkspaceManagerServer(k8s, &cfg.Manager, metrics.Registry, activity, maintenance, quotas)
 
 	grpc_prometheus.Register(grpcServer)
 	wsmanapi.RegisterWorkspaceManagerServer(grpcServer, srv)
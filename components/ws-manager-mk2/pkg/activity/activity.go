@@ -7,8 +7,18 @@ package activity
 import (
 	"sync"
 	"time"
+
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
 )
 
+// Store tracks the last user activity per workspace
+type Store interface {
+	// MarkActive records user activity for a workspace
+	MarkActive(workspaceId string, lastActivity time.Time)
+	// GetLastActivity returns the last user activity of a workspace, or nil if there was none
+	GetLastActivity(ws *workspacev1.Workspace) *time.Time
+}
+
 // WorkspaceActivity is used to track the last user activity per workspace. This is
 // stored in memory instead of on the Workspace resource to limit load on the k8s API,
 // as this value will update often for each workspace.
@@ -16,14 +26,25 @@ type WorkspaceActivity struct {
 	m sync.Map
 }
 
-func (w *WorkspaceActivity) Store(workspaceId string, lastActivity time.Time) {
+var _ Store = &WorkspaceActivity{}
+
+func (w *WorkspaceActivity) MarkActive(workspaceId string, lastActivity time.Time) {
 	w.m.Store(workspaceId, &lastActivity)
 }
 
-func (w *WorkspaceActivity) GetLastActivity(workspaceId string) *time.Time {
+func (w *WorkspaceActivity) GetLastActivity(ws *workspacev1.Workspace) *time.Time {
+	return w.get(ws.Name)
+}
+
+func (w *WorkspaceActivity) get(workspaceId string) *time.Time {
 	lastActivity, ok := w.m.Load(workspaceId)
 	if ok {
 		return lastActivity.(*time.Time)
 	}
 	return nil
 }
+
+// Forget removes the activity of a workspace
+func (w *WorkspaceActivity) Forget(workspaceId string) {
+	w.m.Delete(workspaceId)
+}
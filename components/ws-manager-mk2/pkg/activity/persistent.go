@@ -0,0 +1,189 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package activity
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+const (
+	// DefaultFlushQPS is the default number of workspace status updates per second a flush may produce
+	DefaultFlushQPS = 20
+	// finalFlushTimeout bounds the flush we attempt when shutting down
+	finalFlushTimeout = 5 * time.Second
+)
+
+// PersistentActivity keeps the last user activity in memory and periodically flushes it to the
+// LastActivity field of the workspace status. This way activity survives controller restarts and
+// leader failover, and is shared between replicas serving MarkActive requests.
+//
+// Flushes are batched per interval, i.e. a workspace is updated at most once per flush regardless
+// of how many heartbeats it received, and rate-limited so that flushing many workspaces at once
+// does not overload the API server.
+type PersistentActivity struct {
+	Client    client.Client
+	Namespace string
+
+	interval time.Duration
+	limiter  *rate.Limiter
+	mem      WorkspaceActivity
+
+	mu    sync.Mutex
+	dirty map[string]time.Time
+}
+
+var (
+	_ Store                          = &PersistentActivity{}
+	_ manager.Runnable               = &PersistentActivity{}
+	_ manager.LeaderElectionRunnable = &PersistentActivity{}
+)
+
+// NewPersistentActivity creates a new activity store which flushes activity every interval at a rate of at most qps updates per second
+func NewPersistentActivity(c client.Client, namespace string, interval time.Duration, qps float64) (*PersistentActivity, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid flush interval, must be > 0")
+	}
+	if qps <= 0 {
+		return nil, fmt.Errorf("invalid flush rate, must be > 0")
+	}
+
+	return &PersistentActivity{
+		Client:    c,
+		Namespace: namespace,
+		interval:  interval,
+		limiter:   rate.NewLimiter(rate.Limit(qps), 1),
+		dirty:     make(map[string]time.Time),
+	}, nil
+}
+
+// MarkActive records user activity. The activity is persisted with the next flush.
+func (p *PersistentActivity) MarkActive(workspaceId string, lastActivity time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if prev := p.mem.get(workspaceId); prev != nil && prev.After(lastActivity) {
+		return
+	}
+	p.mem.MarkActive(workspaceId, lastActivity)
+	p.dirty[workspaceId] = lastActivity
+}
+
+// GetLastActivity returns the later of the activity recorded by this replica and the persisted activity
+func (p *PersistentActivity) GetLastActivity(ws *workspacev1.Workspace) *time.Time {
+	local := p.mem.GetLastActivity(ws)
+	if ws.Status.LastActivity == nil {
+		return local
+	}
+
+	persisted := ws.Status.LastActivity.Time
+	if local != nil && local.After(persisted) {
+		return local
+	}
+	return &persisted
+}
+
+// Start flushes the activity periodically until the context is canceled
+func (p *PersistentActivity) Start(ctx context.Context) error {
+	log := log.FromContext(ctx).WithName("activity")
+
+	t := time.NewTicker(p.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			err := p.Flush(ctx)
+			if err != nil {
+				log.Error(err, "cannot flush workspace activity")
+			}
+		case <-ctx.Done():
+			// Make a last attempt to persist activity so that it isn't lost during rollouts.
+			flushCtx, cancel := context.WithTimeout(context.Background(), finalFlushTimeout)
+			err := p.Flush(flushCtx)
+			cancel()
+			if err != nil {
+				log.Error(err, "cannot flush workspace activity on shutdown")
+			}
+			return nil
+		}
+	}
+}
+
+// NeedLeaderElection returns false because every replica serving MarkActive requests must flush its activity
+func (p *PersistentActivity) NeedLeaderElection() bool {
+	return false
+}
+
+// Flush persists all activity recorded since the last flush
+func (p *PersistentActivity) Flush(ctx context.Context) error {
+	p.mu.Lock()
+	batch := p.dirty
+	p.dirty = make(map[string]time.Time, len(batch))
+	p.mu.Unlock()
+
+	var (
+		failed  int
+		lastErr error
+	)
+	for id, lastActivity := range batch {
+		err := p.limiter.Wait(ctx)
+		if err == nil {
+			err = p.persist(ctx, id, lastActivity)
+		}
+		if apierrors.IsNotFound(err) {
+			p.mem.Forget(id)
+			continue
+		}
+		if err != nil {
+			failed++
+			lastErr = err
+			p.retry(id, lastActivity)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("cannot persist activity of %d workspaces: %w", failed, lastErr)
+	}
+	return nil
+}
+
+func (p *PersistentActivity) persist(ctx context.Context, workspaceId string, lastActivity time.Time) error {
+	var ws workspacev1.Workspace
+	err := p.Client.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: workspaceId}, &ws)
+	if err != nil {
+		return err
+	}
+	if ws.Status.LastActivity != nil && !ws.Status.LastActivity.Time.Before(lastActivity.Truncate(time.Second)) {
+		// Another replica has persisted the same or later activity already.
+		return nil
+	}
+
+	// A merge patch only touches the activity, hence we don't conflict with other status updates.
+	patch := client.MergeFrom(ws.DeepCopy())
+	ws.Status.LastActivity = &metav1.Time{Time: lastActivity}
+	return p.Client.Status().Patch(ctx, &ws, patch)
+}
+
+// retry re-queues activity which failed to persist, unless newer activity was recorded in the meantime
+func (p *PersistentActivity) retry(workspaceId string, lastActivity time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, newer := p.dirty[workspaceId]; newer {
+		return
+	}
+	p.dirty[workspaceId] = lastActivity
+}
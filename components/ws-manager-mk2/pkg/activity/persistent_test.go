@@ -0,0 +1,175 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package activity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+func TestPersistentActivity(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ago := func(d time.Duration) *metav1.Time {
+		return &metav1.Time{Time: now.Add(-d)}
+	}
+
+	tests := []struct {
+		Name      string
+		Persisted *metav1.Time
+		Activity  []time.Time
+		// Expectation is the persisted activity after the flush
+		Expectation *metav1.Time
+	}{
+		{
+			Name:        "no activity",
+			Expectation: nil,
+		},
+		{
+			Name:        "first activity",
+			Activity:    []time.Time{now.Add(-time.Minute)},
+			Expectation: ago(time.Minute),
+		},
+		{
+			Name:        "latest activity",
+			Persisted:   ago(time.Hour),
+			Activity:    []time.Time{now.Add(-2 * time.Minute), now.Add(-time.Minute)},
+			Expectation: ago(time.Minute),
+		},
+		{
+			Name:        "out of order activity",
+			Activity:    []time.Time{now.Add(-time.Minute), now.Add(-2 * time.Minute)},
+			Expectation: ago(time.Minute),
+		},
+		{
+			Name:        "newer activity persisted by another replica",
+			Persisted:   ago(time.Second),
+			Activity:    []time.Time{now.Add(-time.Minute)},
+			Expectation: ago(time.Second),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx := context.Background()
+			ws := newWorkspace("ws-1")
+			ws.Status.LastActivity = test.Persisted
+			clnt := newFakeClient(t, ws)
+
+			store, err := NewPersistentActivity(clnt, "default", time.Minute, 100)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, a := range test.Activity {
+				store.MarkActive(ws.Name, a)
+			}
+			err = store.Flush(ctx)
+			if err != nil {
+				t.Fatalf("unexpected flush error: %v", err)
+			}
+
+			var act workspacev1.Workspace
+			err = clnt.Get(ctx, types.NamespacedName{Namespace: "default", Name: ws.Name}, &act)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalTime(act.Status.LastActivity, test.Expectation) {
+				t.Errorf("unexpected persisted activity: want %v, got %v", test.Expectation, act.Status.LastActivity)
+			}
+
+			// a new replica must see the persisted activity
+			fresh, _ := NewPersistentActivity(clnt, "default", time.Minute, 100)
+			last := fresh.GetLastActivity(&act)
+			if test.Expectation == nil {
+				if last != nil {
+					t.Errorf("expected no last activity, got %v", last)
+				}
+			} else if last == nil || !last.Equal(test.Expectation.Time) {
+				t.Errorf("unexpected last activity: want %v, got %v", test.Expectation.Time, last)
+			}
+		})
+	}
+}
+
+func TestPersistentActivityDeletedWorkspace(t *testing.T) {
+	ctx := context.Background()
+	clnt := newFakeClient(t)
+
+	store, err := NewPersistentActivity(clnt, "default", time.Minute, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.MarkActive("gone", time.Now())
+
+	err = store.Flush(ctx)
+	if err != nil {
+		t.Fatalf("deleted workspaces must not fail the flush: %v", err)
+	}
+	if len(store.dirty) != 0 {
+		t.Errorf("expected no pending activity, got %v", store.dirty)
+	}
+	if act := store.GetLastActivity(newWorkspace("gone")); act != nil {
+		t.Errorf("expected activity of deleted workspace to be forgotten, got %v", act)
+	}
+}
+
+func TestPersistentActivityLocalActivity(t *testing.T) {
+	store, err := NewPersistentActivity(newFakeClient(t), "default", time.Minute, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	ws := newWorkspace("ws-1")
+	ws.Status.LastActivity = &metav1.Time{Time: now.Add(-time.Hour)}
+	store.MarkActive(ws.Name, now)
+
+	act := store.GetLastActivity(ws)
+	if act == nil || !act.Equal(now) {
+		t.Errorf("expected local activity to take precedence over older persisted activity: want %v, got %v", now, act)
+	}
+}
+
+func TestNewPersistentActivity(t *testing.T) {
+	if _, err := NewPersistentActivity(nil, "default", 0, 1); err == nil {
+		t.Error("expected error for zero flush interval")
+	}
+	if _, err := NewPersistentActivity(nil, "default", time.Minute, 0); err == nil {
+		t.Error("expected error for zero flush rate")
+	}
+}
+
+func newWorkspace(name string) *workspacev1.Workspace {
+	return &workspacev1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+	}
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	err := workspacev1.AddToScheme(scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func equalTime(a, b *metav1.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(b)
+}
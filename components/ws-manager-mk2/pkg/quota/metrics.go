@@ -0,0 +1,88 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package quota
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "gitpod"
+	metricsSubsystem = "ws_manager_mk2"
+
+	// collectTimeout bounds the time we spend listing workspaces when metrics are scraped
+	collectTimeout = 5 * time.Second
+)
+
+type metrics struct {
+	checker *Checker
+
+	usage             *prometheus.Desc
+	limit             *prometheus.Desc
+	rejectionsCounter *prometheus.CounterVec
+}
+
+func newMetrics(c *Checker) *metrics {
+	return &metrics{
+		checker: c,
+		usage: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "workspace_quota_usage"),
+			"Current number of workspaces counting towards a quota per owner or organisation",
+			[]string{"quota", "scope", "subject"},
+			nil,
+		),
+		limit: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "workspace_quota_limit"),
+			"Number of workspaces an owner or organisation may run under a quota",
+			[]string{"quota", "scope", "subject"},
+			nil,
+		),
+		rejectionsCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "workspace_quota_rejections_total",
+			Help:      "total number of workspace starts rejected because of a quota",
+		}, []string{"quota"}),
+	}
+}
+
+func (m *metrics) recordRejection(quota string) {
+	m.rejectionsCounter.WithLabelValues(quota).Inc()
+}
+
+// Describe implements Collector.
+func (c *Checker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.metrics.usage
+	ch <- c.metrics.limit
+	c.metrics.rejectionsCounter.Describe(ch)
+}
+
+// Collect implements Collector. Usage is reported for all owners and organisations which currently run workspaces.
+func (c *Checker) Collect(ch chan<- prometheus.Metric) {
+	c.metrics.rejectionsCounter.Collect(ch)
+
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	status, err := c.Status(ctx, "", "")
+	if err != nil {
+		return
+	}
+	for _, s := range status {
+		labels := []string{s.Quota.Name, string(s.Quota.Scope), s.Subject}
+
+		metric, err := prometheus.NewConstMetric(c.metrics.usage, prometheus.GaugeValue, float64(s.Used), labels...)
+		if err == nil {
+			ch <- metric
+		}
+		metric, err = prometheus.NewConstMetric(c.metrics.limit, prometheus.GaugeValue, float64(s.Limit), labels...)
+		if err == nil {
+			ch <- metric
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wsk8s "github.com/gitpod-io/gitpod/common-go/kubernetes"
+	"github.com/gitpod-io/gitpod/ws-manager/api/config"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+// ExceededError is returned when starting a workspace would exceed a quota
+type ExceededError struct {
+	Quota   string
+	Scope   config.QuotaScope
+	Subject string
+	Limit   int
+	Used    int
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("workspace quota %s exceeded: %s %s runs %d of %d allowed workspaces", e.Quota, e.Scope, e.Subject, e.Used, e.Limit)
+}
+
+// Status is the usage of a quota by a single owner or organisation
+type Status struct {
+	Quota   *config.WorkspaceQuota
+	Subject string
+	Limit   int
+	Used    int
+}
+
+// Remaining returns the number of workspaces the subject can still start
+func (s Status) Remaining() int {
+	if s.Used >= s.Limit {
+		return 0
+	}
+	return s.Limit - s.Used
+}
+
+// Checker evaluates the workspace quotas against the workspaces in a namespace.
+//
+// Quotas are evaluated against the workspaces known at the time of the check. Workspaces started
+// concurrently may hence exceed a quota by the number of concurrent starts.
+type Checker struct {
+	Client    client.Client
+	Namespace string
+	Quotas    []config.WorkspaceQuota
+
+	metrics *metrics
+}
+
+// NewChecker creates a new quota checker
+func NewChecker(c client.Client, namespace string, quotas []config.WorkspaceQuota) *Checker {
+	res := &Checker{
+		Client:    c,
+		Namespace: namespace,
+		Quotas:    quotas,
+	}
+	res.metrics = newMetrics(res)
+	return res
+}
+
+// Check returns an *ExceededError if running the workspace in addition to the
+// workspaces which run already would exceed any of the quotas.
+func (c *Checker) Check(ctx context.Context, ws *workspacev1.Workspace) error {
+	for i := range c.Quotas {
+		q := &c.Quotas[i]
+		if !q.AppliesToClass(ws.Spec.Class) {
+			continue
+		}
+		subject := ws.Labels[subjectLabel(q.Scope)]
+		if subject == "" {
+			continue
+		}
+
+		var workspaces workspacev1.WorkspaceList
+		err := c.Client.List(ctx, &workspaces,
+			client.InNamespace(c.Namespace),
+			client.MatchingLabels{subjectLabel(q.Scope): subject},
+		)
+		if err != nil {
+			return fmt.Errorf("cannot list workspaces: %w", err)
+		}
+
+		used := usage(q, workspaces.Items, ws.Name)[subject]
+		limit := q.Limit(subject)
+		if used >= limit {
+			c.metrics.recordRejection(q.Name)
+			return &ExceededError{
+				Quota:   q.Name,
+				Scope:   q.Scope,
+				Subject: subject,
+				Limit:   limit,
+				Used:    used,
+			}
+		}
+	}
+	return nil
+}
+
+// Status reports the usage of all quotas which apply to the owner or team. If both are empty,
+// the usage of all owners and teams which currently run workspaces is reported.
+func (c *Checker) Status(ctx context.Context, owner, team string) ([]Status, error) {
+	if len(c.Quotas) == 0 {
+		return nil, nil
+	}
+
+	var workspaces workspacev1.WorkspaceList
+	err := c.Client.List(ctx, &workspaces, client.InNamespace(c.Namespace))
+	if err != nil {
+		return nil, fmt.Errorf("cannot list workspaces: %w", err)
+	}
+
+	var res []Status
+	for i := range c.Quotas {
+		q := &c.Quotas[i]
+		used := usage(q, workspaces.Items, "")
+
+		var subjects []string
+		switch {
+		case owner == "" && team == "":
+			for s := range used {
+				subjects = append(subjects, s)
+			}
+			sort.Strings(subjects)
+		case q.Scope == config.QuotaScopeOwner && owner != "":
+			subjects = []string{owner}
+		case q.Scope == config.QuotaScopeOrganization && team != "":
+			subjects = []string{team}
+		}
+
+		for _, s := range subjects {
+			res = append(res, Status{
+				Quota:   q,
+				Subject: s,
+				Limit:   q.Limit(s),
+				Used:    used[s],
+			})
+		}
+	}
+	return res, nil
+}
+
+// usage counts the running workspaces per subject the quota applies to, ignoring the workspace named exclude
+func usage(q *config.WorkspaceQuota, workspaces []workspacev1.Workspace, exclude string) map[string]int {
+	res := make(map[string]int)
+	for _, ws := range workspaces {
+		if ws.Name == exclude || ws.Status.Phase == workspacev1.WorkspacePhaseStopped {
+			continue
+		}
+		if !q.AppliesToClass(ws.Spec.Class) {
+			continue
+		}
+		subject := ws.Labels[subjectLabel(q.Scope)]
+		if subject == "" {
+			continue
+		}
+		res[subject]++
+	}
+	return res
+}
+
+func subjectLabel(scope config.QuotaScope) string {
+	if scope == config.QuotaScopeOrganization {
+		return wsk8s.TeamLabel
+	}
+	return wsk8s.OwnerLabel
+}
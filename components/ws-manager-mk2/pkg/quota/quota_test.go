@@ -0,0 +1,228 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wsk8s "github.com/gitpod-io/gitpod/common-go/kubernetes"
+	"github.com/gitpod-io/gitpod/ws-manager/api/config"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+var testQuotas = []config.WorkspaceQuota{
+	{Name: "org", Scope: config.QuotaScopeOrganization, MaxRunning: 2, Overrides: map[string]int{"big-org": 3}},
+	{Name: "user-large", Scope: config.QuotaScopeOwner, Classes: []string{"large"}, MaxRunning: 1},
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Running     []*workspacev1.Workspace
+		Workspace   *workspacev1.Workspace
+		Expectation *ExceededError
+	}{
+		{
+			Name:      "no workspaces",
+			Workspace: newWorkspace("ws", "user", "org-1", "default", ""),
+		},
+		{
+			Name: "within organisation quota",
+			Running: []*workspacev1.Workspace{
+				newWorkspace("ws-1", "user", "org-1", "default", workspacev1.WorkspacePhaseRunning),
+				newWorkspace("ws-2", "other-user", "org-2", "default", workspacev1.WorkspacePhaseRunning),
+			},
+			Workspace: newWorkspace("ws", "user", "org-1", "default", ""),
+		},
+		{
+			Name: "organisation quota exceeded",
+			Running: []*workspacev1.Workspace{
+				newWorkspace("ws-1", "user", "org-1", "default", workspacev1.WorkspacePhaseRunning),
+				newWorkspace("ws-2", "other-user", "org-1", "large", workspacev1.WorkspacePhasePending),
+			},
+			Workspace:   newWorkspace("ws", "user", "org-1", "default", ""),
+			Expectation: &ExceededError{Quota: "org", Scope: config.QuotaScopeOrganization, Subject: "org-1", Limit: 2, Used: 2},
+		},
+		{
+			Name: "stopped workspaces don't count",
+			Running: []*workspacev1.Workspace{
+				newWorkspace("ws-1", "user", "org-1", "default", workspacev1.WorkspacePhaseRunning),
+				newWorkspace("ws-2", "user", "org-1", "default", workspacev1.WorkspacePhaseStopped),
+			},
+			Workspace: newWorkspace("ws", "user", "org-1", "default", ""),
+		},
+		{
+			Name: "organisation override",
+			Running: []*workspacev1.Workspace{
+				newWorkspace("ws-1", "user", "big-org", "default", workspacev1.WorkspacePhaseRunning),
+				newWorkspace("ws-2", "user", "big-org", "default", workspacev1.WorkspacePhaseRunning),
+			},
+			Workspace: newWorkspace("ws", "user", "big-org", "default", ""),
+		},
+		{
+			Name: "workspaces without organisation",
+			Running: []*workspacev1.Workspace{
+				newWorkspace("ws-1", "user", "", "default", workspacev1.WorkspacePhaseRunning),
+				newWorkspace("ws-2", "user", "", "default", workspacev1.WorkspacePhaseRunning),
+			},
+			Workspace: newWorkspace("ws", "user", "", "default", ""),
+		},
+		{
+			Name: "class quota exceeded",
+			Running: []*workspacev1.Workspace{
+				newWorkspace("ws-1", "user", "", "large", workspacev1.WorkspacePhaseRunning),
+			},
+			Workspace:   newWorkspace("ws", "user", "", "large", ""),
+			Expectation: &ExceededError{Quota: "user-large", Scope: config.QuotaScopeOwner, Subject: "user", Limit: 1, Used: 1},
+		},
+		{
+			Name: "class quota does not apply to other classes",
+			Running: []*workspacev1.Workspace{
+				newWorkspace("ws-1", "user", "", "large", workspacev1.WorkspacePhaseRunning),
+			},
+			Workspace: newWorkspace("ws", "user", "", "default", ""),
+		},
+		{
+			Name: "workspace does not count against itself",
+			Running: []*workspacev1.Workspace{
+				newWorkspace("ws", "user", "", "large", workspacev1.WorkspacePhaseRunning),
+			},
+			Workspace: newWorkspace("ws", "user", "", "large", ""),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			checker := NewChecker(newFakeClient(t, test.Running...), "default", testQuotas)
+
+			err := checker.Check(context.Background(), test.Workspace)
+
+			var act *ExceededError
+			if err != nil && !errors.As(err, &act) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	running := []*workspacev1.Workspace{
+		newWorkspace("ws-1", "user-1", "org-1", "large", workspacev1.WorkspacePhaseRunning),
+		newWorkspace("ws-2", "user-2", "org-1", "default", workspacev1.WorkspacePhaseRunning),
+		newWorkspace("ws-3", "user-2", "big-org", "default", workspacev1.WorkspacePhaseRunning),
+	}
+	type status struct {
+		Quota, Subject         string
+		Limit, Used, Remaining int
+	}
+
+	tests := []struct {
+		Name        string
+		Owner, Team string
+		Expectation []status
+	}{
+		{
+			Name: "all subjects",
+			Expectation: []status{
+				{Quota: "org", Subject: "big-org", Limit: 3, Used: 1, Remaining: 2},
+				{Quota: "org", Subject: "org-1", Limit: 2, Used: 2, Remaining: 0},
+				{Quota: "user-large", Subject: "user-1", Limit: 1, Used: 1, Remaining: 0},
+			},
+		},
+		{
+			Name:  "owner and team",
+			Owner: "user-2",
+			Team:  "big-org",
+			Expectation: []status{
+				{Quota: "org", Subject: "big-org", Limit: 3, Used: 1, Remaining: 2},
+				{Quota: "user-large", Subject: "user-2", Limit: 1, Used: 0, Remaining: 1},
+			},
+		},
+		{
+			Name:  "owner only",
+			Owner: "user-1",
+			Expectation: []status{
+				{Quota: "user-large", Subject: "user-1", Limit: 1, Used: 1, Remaining: 0},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			checker := NewChecker(newFakeClient(t, running...), "default", testQuotas)
+
+			res, err := checker.Status(context.Background(), test.Owner, test.Team)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var act []status
+			for _, s := range res {
+				act = append(act, status{Quota: s.Quota.Name, Subject: s.Subject, Limit: s.Limit, Used: s.Used, Remaining: s.Remaining()})
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected status (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidator(t *testing.T) {
+	running := newWorkspace("ws-1", "user", "", "large", workspacev1.WorkspacePhaseRunning)
+	v := &Validator{Checker: NewChecker(newFakeClient(t, running), "default", testQuotas)}
+
+	err := v.ValidateCreate(context.Background(), newWorkspace("ws", "user", "", "large", ""))
+	if err == nil {
+		t.Error("expected creation of workspace exceeding a quota to be rejected")
+	}
+	err = v.ValidateUpdate(context.Background(), running, running)
+	if err != nil {
+		t.Errorf("expected update to be admitted: %v", err)
+	}
+}
+
+func newWorkspace(name, owner, team, class string, phase workspacev1.WorkspacePhase) *workspacev1.Workspace {
+	return &workspacev1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				wsk8s.OwnerLabel: owner,
+				wsk8s.TeamLabel:  team,
+			},
+		},
+		Spec: workspacev1.WorkspaceSpec{
+			Class: class,
+		},
+		Status: workspacev1.WorkspaceStatus{
+			Phase: phase,
+		},
+	}
+}
+
+func newFakeClient(t *testing.T, workspaces ...*workspacev1.Workspace) client.Client {
+	scheme := runtime.NewScheme()
+	err := workspacev1.AddToScheme(scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objs := make([]client.Object, 0, len(workspaces))
+	for _, ws := range workspaces {
+		objs = append(objs, ws.DeepCopy())
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
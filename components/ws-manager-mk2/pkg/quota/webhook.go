@@ -0,0 +1,64 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package quota
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+// Validator rejects the creation of workspaces which would exceed a quota. This catches workspaces
+// which are created without going through StartWorkspace, e.g. by other controllers or kubectl.
+//
+// The validator replaces the webhook of the workspace type, hence it runs the validation of the type itself first.
+type Validator struct {
+	Checker *Checker
+}
+
+var _ admission.CustomValidator = &Validator{}
+
+// SetupWebhookWithManager registers the validating webhook for workspaces
+func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&workspacev1.Workspace{}).
+		WithValidator(v).
+		Complete()
+}
+
+// ValidateCreate implements admission.CustomValidator
+func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	ws, ok := obj.(*workspacev1.Workspace)
+	if !ok {
+		return fmt.Errorf("expected a workspace but got %T", obj)
+	}
+	if err := ws.ValidateCreate(); err != nil {
+		return err
+	}
+	return v.Checker.Check(ctx, ws)
+}
+
+// ValidateUpdate implements admission.CustomValidator. Quotas limit starting workspaces, hence they don't apply to updates.
+func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	ws, ok := newObj.(*workspacev1.Workspace)
+	if !ok {
+		return fmt.Errorf("expected a workspace but got %T", newObj)
+	}
+	return ws.ValidateUpdate(oldObj)
+}
+
+// ValidateDelete implements admission.CustomValidator
+func (v *Validator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	ws, ok := obj.(*workspacev1.Workspace)
+	if !ok {
+		return fmt.Errorf("expected a workspace but got %T", obj)
+	}
+	return ws.ValidateDelete()
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/util"
 	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/activity"
 	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/maintenance"
+	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/quota"
 	"github.com/gitpod-io/gitpod/ws-manager/api"
 	wsmanapi "github.com/gitpod-io/gitpod/ws-manager/api"
 	"github.com/gitpod-io/gitpod/ws-manager/api/config"
@@ -56,7 +57,7 @@ const (
 	stopWorkspaceImmediatelyGracePeriod = 1 * time.Second
 )
 
-func NewWorkspaceManagerServer(clnt client.Client, cfg *config.Configuration, reg prometheus.Registerer, activity *activity.WorkspaceActivity, maintenance maintenance.Maintenance) *WorkspaceManagerServer {
+func NewWorkspaceManagerServer(clnt client.Client, cfg *config.Configuration, reg prometheus.Registerer, activity activity.Store, maintenance maintenance.Maintenance, quotas *quota.Checker) *WorkspaceManagerServer {
 	metrics := newWorkspaceMetrics()
 	reg.MustRegister(metrics)
 
@@ -66,6 +67,7 @@ func NewWorkspaceManagerServer(clnt client.Client, cfg *config.Configuration, re
 		metrics:     metrics,
 		activity:    activity,
 		maintenance: maintenance,
+		quotas:      quotas,
 		subs: subscriptions{
 			subscribers: make(map[string]chan *wsmanapi.SubscribeResponse),
 		},
@@ -76,8 +78,9 @@ type WorkspaceManagerServer struct {
 	Client      client.Client
 	Config      *config.Configuration
 	metrics     *workspaceMetrics
-	activity    *activity.WorkspaceActivity
+	activity    activity.Store
 	maintenance maintenance.Maintenance
+	quotas      *quota.Checker
 
 	subs subscriptions
 	wsmanapi.UnimplementedWorkspaceManagerServer
@@ -228,6 +231,8 @@ func (wsm *WorkspaceManagerServer) StartWorkspace(ctx context.Context, req *wsma
 			Labels: map[string]string{
 				wsk8s.WorkspaceIDLabel: req.Metadata.MetaId,
 				wsk8s.OwnerLabel:       req.Metadata.Owner,
+				wsk8s.ProjectLabel:     req.Metadata.GetProject(),
+				wsk8s.TeamLabel:        req.Metadata.GetTeam(),
 			},
 		},
 		Spec: workspacev1.WorkspaceSpec{
@@ -265,6 +270,16 @@ func (wsm *WorkspaceManagerServer) StartWorkspace(ctx context.Context, req *wsma
 	}
 	controllerutil.AddFinalizer(&ws, workspacev1.GitpodFinalizerName)
 
+	err = wsm.quotas.Check(ctx, &ws)
+	var exceeded *quota.ExceededError
+	if xerrors.As(err, &exceeded) {
+		log.WithFields(owi).WithField("quota", exceeded.Quota).Info("workspace start rejected by quota")
+		return nil, status.Error(codes.ResourceExhausted, exceeded.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "cannot check workspace quotas: %v", err)
+	}
+
 	err = wsm.createWorkspaceSecret(ctx, &ws, envSecretName, wsm.Config.Namespace, envData)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create env secret for workspace %s: %w", req.Id, err)
@@ -424,7 +439,7 @@ func (wsm *WorkspaceManagerServer) DescribeWorkspace(ctx context.Context, req *w
 		Status: wsm.extractWorkspaceStatus(&ws),
 	}
 
-	lastActivity := wsm.activity.GetLastActivity(req.Id)
+	lastActivity := wsm.activity.GetLastActivity(&ws)
 	if lastActivity != nil {
 		result.LastActivity = lastActivity.UTC().Format(time.RFC3339Nano)
 	}
@@ -469,10 +484,10 @@ func (wsm *WorkspaceManagerServer) MarkActive(ctx context.Context, req *wsmanapi
 		return &wsmanapi.MarkActiveResponse{}, nil
 	}
 
-	// We do not keep the last activity in the workspace resource to limit the load we're placing
-	// on the K8S master in check. Thus, this state lives locally in a map.
+	// We do not update the workspace resource on every call to keep the load we're placing on the
+	// K8S master in check. Instead, the activity store persists the last activity periodically.
 	now := time.Now().UTC()
-	wsm.activity.Store(req.Id, now)
+	wsm.activity.MarkActive(req.Id, now)
 
 	// We do however maintain the the "closed" flag as condition on the workspace. This flag should not change
 	// very often and provides a better UX if it persists across ws-manager restarts.
@@ -500,6 +515,8 @@ func (wsm *WorkspaceManagerServer) MarkActive(ctx context.Context, req *wsmanapi
 	if firstUserActivity == nil {
 		err := wsm.modifyWorkspace(ctx, req.Id, true, func(ws *workspacev1.Workspace) error {
 			ws.Status.SetCondition(workspacev1.NewWorkspaceConditionFirstUserActivity("MarkActiveRequest"))
+			// Persist the first activity right away, so that it's never lost on restarts.
+			ws.Status.LastActivity = &metav1.Time{Time: now}
 			return nil
 		})
 		if err != nil {
@@ -668,6 +685,103 @@ func (wsm *WorkspaceManagerServer) TakeSnapshot(ctx context.Context, req *wsmana
 	}, nil
 }
 
+// conditionTimeTolerance accounts for condition timestamps being stored with second precision only
+const conditionTimeTolerance = time.Second
+
+// BackupWorkspace requests a backup of a running workspace from ws-daemon and waits for the backup to complete
+func (wsm *WorkspaceManagerServer) BackupWorkspace(ctx context.Context, req *wsmanapi.BackupWorkspaceRequest) (res *wsmanapi.BackupWorkspaceResponse, err error) {
+	span, ctx := tracing.FromContext(ctx, "BackupWorkspace")
+	tracing.ApplyOWI(span, log.OWI("", "", req.Id))
+	defer tracing.FinishSpan(span, &err)
+
+	var requested time.Time
+	err = wsm.modifyWorkspace(ctx, req.Id, true, func(ws *workspacev1.Workspace) error {
+		if ws.Status.Phase != workspacev1.WorkspacePhaseRunning {
+			return status.Errorf(codes.FailedPrecondition, "backups can only be taken of running workspaces, not %s workspaces", ws.Status.Phase)
+		}
+
+		// join a pending backup request rather than requesting another backup
+		if c := wsk8s.GetCondition(ws.Status.Conditions, string(workspacev1.WorkspaceConditionBackupRequested)); c != nil && c.Status == metav1.ConditionTrue {
+			requested = c.LastTransitionTime.Time
+			return nil
+		}
+
+		cond := workspacev1.NewWorkspaceConditionBackupRequested(metav1.ConditionTrue, workspacev1.ReasonBackupRequested, "")
+		requested = cond.LastTransitionTime.Time
+		ws.Status.SetCondition(cond)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		url     string
+		timeout = time.Duration(wsm.Config.Timeouts.ContentFinalization)
+	)
+	err = wait.PollWithContext(ctx, 500*time.Millisecond, timeout, func(c context.Context) (done bool, err error) {
+		var ws workspacev1.Workspace
+		err = wsm.Client.Get(c, types.NamespacedName{Namespace: wsm.Config.Namespace, Name: req.Id}, &ws)
+		if errors.IsNotFound(err) {
+			return false, status.Errorf(codes.NotFound, "workspace %s not found", req.Id)
+		}
+		if err != nil {
+			return false, nil
+		}
+
+		cond := wsk8s.GetCondition(ws.Status.Conditions, string(workspacev1.WorkspaceConditionBackupRequested))
+		if cond == nil || cond.Status != metav1.ConditionFalse || cond.LastTransitionTime.Time.Before(requested.Add(-conditionTimeTolerance)) {
+			return false, nil
+		}
+		if cond.Reason == workspacev1.ReasonBackupFailed {
+			return false, status.Errorf(codes.DataLoss, "backup failed: %s", cond.Message)
+		}
+
+		url = cond.Message
+		return true, nil
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.DeadlineExceeded, "cannot wait for backup: %v", err)
+	}
+
+	return &wsmanapi.BackupWorkspaceResponse{Url: url}, nil
+}
+
+// DeleteVolumeSnapshot deletes a snapshot. ws-manager-mk2 does not use volume snapshots, but stores snapshots in
+// remote storage and tracks them using Snapshot objects. ws-daemon deletes the content of a snapshot from remote
+// storage before its Snapshot object is removed.
+func (wsm *WorkspaceManagerServer) DeleteVolumeSnapshot(ctx context.Context, req *wsmanapi.DeleteVolumeSnapshotRequest) (res *wsmanapi.DeleteVolumeSnapshotResponse, err error) {
+	span, ctx := tracing.FromContext(ctx, "DeleteVolumeSnapshot")
+	tracing.LogRequestSafe(span, req)
+	defer tracing.FinishSpan(span, &err)
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	res = &wsmanapi.DeleteVolumeSnapshotResponse{}
+	snapshot := &workspacev1.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: wsm.Config.Namespace,
+			Name:      req.Id,
+		},
+	}
+	err = wsm.Client.Delete(ctx, snapshot)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, status.Errorf(codes.Internal, "cannot delete snapshot %s: %v", req.Id, err)
+	}
+	res.WasDeleted = err == nil
+
+	if !res.WasDeleted && !req.SoftDelete && req.VolumeHandle != "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "snapshot %s does not exist and cannot be restored from a volume handle", req.Id)
+	}
+
+	return res, nil
+}
+
 func (wsm *WorkspaceManagerServer) ControlAdmission(ctx context.Context, req *wsmanapi.ControlAdmissionRequest) (*wsmanapi.ControlAdmissionResponse, error) {
 	err := wsm.modifyWorkspace(ctx, req.Id, false, func(ws *workspacev1.Workspace) error {
 		switch req.Level {
@@ -704,7 +818,7 @@ func (wsm *WorkspaceManagerServer) UpdateSSHKey(ctx context.Context, req *wsmana
 }
 
 func (wsm *WorkspaceManagerServer) DescribeCluster(ctx context.Context, req *wsmanapi.DescribeClusterRequest) (*wsmanapi.DescribeClusterResponse, error) {
-	span, _ := tracing.FromContext(ctx, "DescribeCluster")
+	span, ctx := tracing.FromContext(ctx, "DescribeCluster")
 	defer tracing.FinishSpan(span, nil)
 
 	classes := make([]*wsmanapi.WorkspaceClass, len(wsm.Config.WorkspaceClasses))
@@ -718,8 +832,26 @@ func (wsm *WorkspaceManagerServer) DescribeCluster(ctx context.Context, req *wsm
 		i += 1
 	}
 
+	quotaStatus, err := wsm.quotas.Status(ctx, req.Owner, req.Team)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "cannot determine quota usage: %v", err)
+	}
+	quotas := make([]*wsmanapi.WorkspaceQuotaStatus, 0, len(quotaStatus))
+	for _, q := range quotaStatus {
+		quotas = append(quotas, &wsmanapi.WorkspaceQuotaStatus{
+			Name:             q.Quota.Name,
+			Scope:            string(q.Quota.Scope),
+			Subject:          q.Subject,
+			WorkspaceClasses: q.Quota.Classes,
+			Limit:            int32(q.Limit),
+			Used:             int32(q.Used),
+			Remaining:        int32(q.Remaining()),
+		})
+	}
+
 	return &wsmanapi.DescribeClusterResponse{
 		WorkspaceClasses: classes,
+		Quotas:           quotas,
 	}, nil
 }
 
@@ -968,6 +1100,13 @@ func (wsm *WorkspaceManagerServer) extractWorkspaceStatus(ws *workspacev1.Worksp
 		}
 	}
 
+	var timeoutWarning *timestamppb.Timestamp
+	if msg := getConditionMessageIfTrue(ws.Status.Conditions, string(workspacev1.WorkspaceConditionTimeoutWarning)); msg != "" {
+		if t, err := time.Parse(time.RFC3339, msg); err == nil {
+			timeoutWarning = timestamppb.New(t)
+		}
+	}
+
 	var runtime *wsmanapi.WorkspaceRuntimeInfo
 	if rt := ws.Status.Runtime; rt != nil {
 		runtime = &wsmanapi.WorkspaceRuntimeInfo{
@@ -1018,6 +1157,7 @@ func (wsm *WorkspaceManagerServer) extractWorkspaceStatus(ws *workspacev1.Worksp
 			StoppedByRequest:    convertCondition(ws.Status.Conditions, string(workspacev1.WorkspaceConditionStoppedByRequest)),
 			FinalBackupComplete: convertCondition(ws.Status.Conditions, string(workspacev1.WorkspaceConditionBackupComplete)),
 			Aborted:             convertCondition(ws.Status.Conditions, string(workspacev1.WorkspaceConditionAborted)),
+			TimeoutWarning:      timeoutWarning,
 		},
 		Runtime: runtime,
 		Auth: &wsmanapi.WorkspaceAuthentication{
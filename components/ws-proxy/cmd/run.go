@@ -160,6 +160,13 @@ var runCmd = &cobra.Command{
 			}
 			if len(signers) > 0 {
 				server := sshproxy.New(signers, infoprov, heartbeat)
+				if cfg.SSHGatewayPolicy != nil {
+					policy, err := sshproxy.NewRulePolicy(*cfg.SSHGatewayPolicy)
+					if err != nil {
+						log.WithError(err).Fatal("cannot create SSH gateway policy")
+					}
+					server.Policy = policy
+				}
 				l, err := net.Listen("tcp", ":2200")
 				if err != nil {
 					panic(err)
@@ -169,7 +176,12 @@ var runCmd = &cobra.Command{
 			}
 		}
 
-		go proxy.NewWorkspaceProxy(cfg.Ingress, cfg.Proxy, proxy.HostBasedRouter(cfg.Ingress.Header, cfg.Proxy.GitpodInstallation.WorkspaceHostSuffix, cfg.Proxy.GitpodInstallation.WorkspaceHostSuffixRegex), infoprov, signers).MustServe()
+		workspaceRouter := proxy.HostBasedRouter(cfg.Ingress.Header, cfg.Proxy.GitpodInstallation.WorkspaceHostSuffix, cfg.Proxy.GitpodInstallation.WorkspaceHostSuffixRegex)
+		if cfg.Ingress.Routing == proxy.PathBasedRouting {
+			log.WithField("workspaceHost", cfg.Ingress.WorkspaceHost).Warn("path-based routing is enabled: all workspaces and their ports share a single origin and are not isolated from each other")
+			workspaceRouter = proxy.PathBasedRouter(cfg.Ingress.Header, cfg.Ingress.WorkspaceHost)
+		}
+		go proxy.NewWorkspaceProxy(cfg.Ingress, cfg.Proxy, workspaceRouter, infoprov, signers).MustServe()
 		log.Infof("started proxying on %s", cfg.Ingress.HTTPAddress)
 
 		log.Info("🚪 ws-proxy is up and running")
@@ -11,6 +11,7 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/gitpod-io/gitpod/ws-proxy/pkg/proxy"
+	"github.com/gitpod-io/gitpod/ws-proxy/pkg/sshproxy"
 )
 
 // Config configures this service.
@@ -23,6 +24,7 @@ type Config struct {
 	Namespace          string                       `json:"namespace"`
 	WorkspaceManager   *WorkspaceManagerConn        `json:"wsManager"`
 	EnableWorkspaceCRD bool                         `json:"enableWorkspaceCRD"`
+	SSHGatewayPolicy   *sshproxy.PolicyConfig       `json:"sshGatewayPolicy,omitempty"`
 }
 
 type WorkspaceManagerConn struct {
@@ -44,6 +46,10 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.SSHGatewayPolicy.Validate(); err != nil {
+		return xerrors.Errorf("invalid SSH gateway policy: %w", err)
+	}
+
 	return nil
 }
 
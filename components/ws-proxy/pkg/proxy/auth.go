@@ -83,35 +83,47 @@ func WorkspaceAuthHandler(domain string, info WorkspaceInfoProvider) mux.Middlew
 				// port seems to be private - subject it to the same access policy as the workspace itself
 			}
 
-			tkn := req.Header.Get("x-gitpod-owner-token")
-			if tkn == "" {
+			var tkns []string
+			if tkn := req.Header.Get("x-gitpod-owner-token"); tkn != "" {
+				tkns = []string{tkn}
+			} else {
+				// With path-based routing, owner cookies are scoped to the workspace path. Browsers send all cookies
+				// whose path matches, hence there can be several cookies of the same name, e.g. a stale one.
 				cn := fmt.Sprintf("%s%s_owner_", cookiePrefix, ws.InstanceID)
-				c, err := req.Cookie(cn)
-				if err != nil {
+				for _, c := range readCookies(req.Header, cn) {
+					tkns = append(tkns, c.Value)
+				}
+				if len(tkns) == 0 {
 					log.WithField("cookieName", cn).Debug("no owner cookie present")
 					resp.WriteHeader(http.StatusUnauthorized)
 
 					return
 				}
-
-				tkn = c.Value
 			}
-			tkn, err := url.QueryUnescape(tkn)
-			if err != nil {
-				log.WithError(err).Warn("cannot decode owner token")
-				resp.WriteHeader(http.StatusBadRequest)
 
-				return
+			var decodeErr error
+			for _, tkn := range tkns {
+				tkn, err := url.QueryUnescape(tkn)
+				if err != nil {
+					decodeErr = err
+					continue
+				}
+				if tkn == ws.Auth.OwnerToken {
+					h.ServeHTTP(resp, req)
+
+					return
+				}
 			}
 
-			if tkn != ws.Auth.OwnerToken {
-				log.Warn("owner token mismatch")
-				resp.WriteHeader(http.StatusForbidden)
+			if decodeErr != nil && len(tkns) == 1 {
+				log.WithError(decodeErr).Warn("cannot decode owner token")
+				resp.WriteHeader(http.StatusBadRequest)
 
 				return
 			}
 
-			h.ServeHTTP(resp, req)
+			log.Warn("owner token mismatch")
+			resp.WriteHeader(http.StatusForbidden)
 		})
 	}
 }
@@ -68,6 +68,8 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 		Name        string
 		Infos       map[string]*WorkspaceInfo
 		OwnerCookie string
+		// StaleCookie is an owner cookie of the same name sent before OwnerCookie, e.g. one scoped to another path
+		StaleCookie string
 		WorkspaceID string
 		Port        string
 		Expected    testResult
@@ -126,6 +128,39 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 				StatusCode:    http.StatusOK,
 			},
 		},
+		{
+			Name:        "correct credentials with stale cookie",
+			Infos:       ownerOnlyInfos,
+			WorkspaceID: workspaceID,
+			StaleCookie: "some-old-token",
+			OwnerCookie: ownerToken,
+			Expected: testResult{
+				HandlerCalled: true,
+				StatusCode:    http.StatusOK,
+			},
+		},
+		{
+			Name:        "correct credentials with broken stale cookie",
+			Infos:       ownerOnlyInfos,
+			WorkspaceID: workspaceID,
+			StaleCookie: "%^? is invalid encoding ",
+			OwnerCookie: ownerToken,
+			Expected: testResult{
+				HandlerCalled: true,
+				StatusCode:    http.StatusOK,
+			},
+		},
+		{
+			Name:        "wrong credentials with stale cookie",
+			Infos:       ownerOnlyInfos,
+			WorkspaceID: workspaceID,
+			StaleCookie: "some-old-token",
+			OwnerCookie: "this is the wrong value",
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusForbidden,
+			},
+		},
 		{
 			Name:        "admit everyone without cookie",
 			Infos:       admitEveryoneInfos,
@@ -230,6 +265,9 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 
 			rr := httptest.NewRecorder()
 			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://%s/", domain), nil)
+			if test.StaleCookie != "" {
+				setOwnerTokenCookie(req, instanceID, test.StaleCookie)
+			}
 			if test.OwnerCookie != "" {
 				setOwnerTokenCookie(req, instanceID, test.OwnerCookie)
 			}
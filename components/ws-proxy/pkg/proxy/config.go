@@ -50,11 +50,28 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// IngressRouting determines how requests are routed to workspaces.
+type IngressRouting string
+
+const (
+	// HostBasedRouting routes workspaces and their ports using a host name each, e.g. <workspaceID>.ws.gitpod.io
+	HostBasedRouting IngressRouting = "host"
+	// PathBasedRouting routes workspaces and their ports using a path prefix below a single host, e.g. ws.gitpod.io/<workspaceID>/.
+	// Warning: all workspaces and their ports share a single origin, i.e. they are not isolated from each other by the browser.
+	// Path-based routing must be enabled explicitly and is only suitable for installations whose users trust each other.
+	PathBasedRouting IngressRouting = "path"
+)
+
 // HostBasedIngressConfig configures the host-based ingress.
 type HostBasedIngressConfig struct {
 	HTTPAddress  string `json:"httpAddress"`
 	HTTPSAddress string `json:"httpsAddress"`
 	Header       string `json:"header"`
+
+	// Routing defaults to host-based routing. See PathBasedRouting before opting into path-based routing.
+	Routing IngressRouting `json:"routing,omitempty"`
+	// WorkspaceHost is the host workspaces are served from when using path-based routing
+	WorkspaceHost string `json:"workspaceHost,omitempty"`
 }
 
 // Validate validates this config.
@@ -62,11 +79,19 @@ func (c *HostBasedIngressConfig) Validate() error {
 	if c == nil {
 		return xerrors.Errorf("host based ingress config is mandatory")
 	}
-	return validation.ValidateStruct(c,
+	err := validation.ValidateStruct(c,
 		validation.Field(&c.HTTPAddress, validation.Required),
 		validation.Field(&c.HTTPSAddress, validation.Required),
 		validation.Field(&c.Header, validation.Required),
+		validation.Field(&c.Routing, validation.In(HostBasedRouting, PathBasedRouting)),
 	)
+	if err != nil {
+		return err
+	}
+	if c.Routing == PathBasedRouting && c.WorkspaceHost == "" {
+		return xerrors.Errorf("workspaceHost is required for path-based routing")
+	}
+	return nil
 }
 
 // WorkspacePodConfig contains config around the workspace pod.
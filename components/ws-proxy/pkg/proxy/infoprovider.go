@@ -67,8 +67,9 @@ type WorkspaceInfo struct {
 	Auth      *wsapi.WorkspaceAuthentication
 	StartedAt time.Time
 
-	OwnerUserId   string
-	SSHPublicKeys []string
+	OwnerUserId    string
+	OrganizationId string
+	SSHPublicKeys  []string
 }
 
 // RemoteWorkspaceInfoProvider provides (cached) infos about running workspaces that it queries from ws-manager.
@@ -169,6 +170,7 @@ func mapPodToWorkspaceInfo(pod *corev1.Pod) *WorkspaceInfo {
 		Auth:            &wsapi.WorkspaceAuthentication{Admission: admission, OwnerToken: ownerToken},
 		StartedAt:       pod.CreationTimestamp.Time,
 		OwnerUserId:     pod.Labels[kubernetes.OwnerLabel],
+		OrganizationId:  pod.Labels[kubernetes.TeamLabel],
 		SSHPublicKeys:   extractUserSSHPublicKeys(pod),
 	}
 }
@@ -291,6 +293,8 @@ func (r *CRDWorkspaceInfoProvider) Reconcile(ctx context.Context, req ctrl.Reque
 		Ports:           ports,
 		Auth:            &wsapi.WorkspaceAuthentication{Admission: admission, OwnerToken: ws.Status.OwnerToken},
 		StartedAt:       ws.CreationTimestamp.Time,
+		OwnerUserId:     ws.Spec.Ownership.Owner,
+		OrganizationId:  ws.Spec.Ownership.Team,
 		SSHPublicKeys:   ws.Spec.SshPublicKeys,
 	}
 
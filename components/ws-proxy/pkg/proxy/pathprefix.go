@@ -0,0 +1,143 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package proxy
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/xerrors"
+)
+
+// forwardedPrefixHeader tells workspace servers under which path prefix they are served
+const forwardedPrefixHeader = "X-Forwarded-Prefix"
+
+// workspacePathPrefixHandler makes content served below a workspace path prefix behave as if it was served from the root.
+// Upstream servers learn about the prefix through the X-Forwarded-Prefix header, while redirects and cookies they
+// produce are rewritten to stay within the prefix.
+func workspacePathPrefixHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		prefix := mux.Vars(req)[workspacePathPrefixIdentifier]
+		if prefix == "" {
+			h.ServeHTTP(resp, req)
+			return
+		}
+
+		req.Header.Set(forwardedPrefixHeader, prefix)
+		h.ServeHTTP(&pathPrefixResponseWriter{
+			ResponseWriter: resp,
+			prefix:         prefix,
+			host:           req.Host,
+		}, req)
+	})
+}
+
+type pathPrefixResponseWriter struct {
+	http.ResponseWriter
+	prefix      string
+	host        string
+	wroteHeader bool
+}
+
+func (w *pathPrefixResponseWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		rewritePathPrefixHeaders(w.Header(), w.prefix, w.host)
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *pathPrefixResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *pathPrefixResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack is required for websocket connections
+func (w *pathPrefixResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, xerrors.Errorf("response writer does not support hijacking")
+	}
+	return h.Hijack()
+}
+
+func (w *pathPrefixResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// rewritePathPrefixHeaders places redirects and cookies below the path prefix
+func rewritePathPrefixHeaders(header http.Header, prefix, host string) {
+	if loc := header.Get("Location"); loc != "" {
+		header.Set("Location", addPathPrefixToLocation(loc, prefix, host))
+	}
+	if cookies := header.Values("Set-Cookie"); len(cookies) > 0 {
+		header.Del("Set-Cookie")
+		for _, c := range cookies {
+			header.Add("Set-Cookie", addPathPrefixToCookie(c, prefix))
+		}
+	}
+}
+
+// addPathPrefixToLocation adds the prefix to redirects pointing to the same host
+func addPathPrefixToLocation(location, prefix, host string) string {
+	u, err := url.Parse(location)
+	if err != nil {
+		return location
+	}
+	if u.Host != "" && u.Host != host {
+		return location
+	}
+	if u.Host == "" && !strings.HasPrefix(u.Path, "/") {
+		// relative redirects resolve within the prefix already
+		return location
+	}
+	if u.Path == prefix || strings.HasPrefix(u.Path, prefix+"/") {
+		return location
+	}
+	u.Path = prefix + u.Path
+	if u.RawPath != "" {
+		u.RawPath = prefix + u.RawPath
+	}
+	return u.String()
+}
+
+// addPathPrefixToCookie scopes a Set-Cookie header value to the prefix
+func addPathPrefixToCookie(cookie, prefix string) string {
+	var (
+		parts   = strings.Split(cookie, ";")
+		hasPath bool
+	)
+	for i, part := range parts {
+		attr := strings.TrimSpace(part)
+		if i == 0 || len(attr) < 5 || !strings.EqualFold(attr[:5], "path=") {
+			continue
+		}
+		hasPath = true
+		path := attr[5:]
+		if !strings.HasPrefix(path, "/") {
+			path = "/"
+		}
+		if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			path = prefix + path
+		}
+		parts[i] = " Path=" + path
+	}
+	if !hasPath {
+		parts = append(parts, " Path="+prefix+"/")
+	}
+	return strings.Join(parts, ";")
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+func TestAddPathPrefixToLocation(t *testing.T) {
+	const (
+		prefix = "/amaranth-smelt-9ba20cc1/ports/3000"
+		host   = "ws.gitpod.dev"
+	)
+	tests := []struct {
+		Name     string
+		Location string
+		Expected string
+	}{
+		{Name: "root relative", Location: "/login", Expected: prefix + "/login"},
+		{Name: "root relative with query", Location: "/login?next=%2Fhome", Expected: prefix + "/login?next=%2Fhome"},
+		{Name: "same host", Location: "https://ws.gitpod.dev/login", Expected: "https://ws.gitpod.dev" + prefix + "/login"},
+		{Name: "other host", Location: "https://gitpod.io/login", Expected: "https://gitpod.io/login"},
+		{Name: "relative", Location: "login", Expected: "login"},
+		{Name: "already prefixed", Location: prefix + "/login", Expected: prefix + "/login"},
+		{Name: "prefix root", Location: prefix, Expected: prefix},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := addPathPrefixToLocation(test.Location, prefix, host)
+			if act != test.Expected {
+				t.Errorf("unexpected location: expected %q, got %q", test.Expected, act)
+			}
+		})
+	}
+}
+
+func TestAddPathPrefixToCookie(t *testing.T) {
+	const prefix = "/amaranth-smelt-9ba20cc1"
+	tests := []struct {
+		Name     string
+		Cookie   string
+		Expected string
+	}{
+		{Name: "no path", Cookie: "session=abc; HttpOnly", Expected: "session=abc; HttpOnly; Path=" + prefix + "/"},
+		{Name: "root path", Cookie: "session=abc; Path=/; Secure", Expected: "session=abc; Path=" + prefix + "/; Secure"},
+		{Name: "sub path", Cookie: "session=abc; path=/api", Expected: "session=abc; Path=" + prefix + "/api"},
+		{Name: "already prefixed", Cookie: "session=abc; Path=" + prefix + "/api", Expected: "session=abc; Path=" + prefix + "/api"},
+		{Name: "invalid path", Cookie: "session=abc; Path=api", Expected: "session=abc; Path=" + prefix + "/"},
+		{Name: "path in value", Cookie: "path=/foo", Expected: "path=/foo; Path=" + prefix + "/"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := addPathPrefixToCookie(test.Cookie, prefix)
+			if act != test.Expected {
+				t.Errorf("unexpected cookie: expected %q, got %q", test.Expected, act)
+			}
+		})
+	}
+}
+
+func TestWorkspacePathPrefixHandler(t *testing.T) {
+	const prefix = "/amaranth-smelt-9ba20cc1"
+	type Expectation struct {
+		ForwardedPrefix string
+		Location        string
+		Cookies         []string
+	}
+
+	var act Expectation
+	handler := workspacePathPrefixHandler(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		act.ForwardedPrefix = req.Header.Get(forwardedPrefixHeader)
+		resp.Header().Add("Set-Cookie", "a=b")
+		resp.Header().Add("Set-Cookie", "c=d; Path=/foo")
+		http.Redirect(resp, req, "/login", http.StatusFound)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://ws.gitpod.dev/", nil)
+	req = mux.SetURLVars(req, map[string]string{workspacePathPrefixIdentifier: prefix})
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	act.Location = rr.Header().Get("Location")
+	act.Cookies = rr.Header().Values("Set-Cookie")
+	expected := Expectation{
+		ForwardedPrefix: prefix,
+		Location:        prefix + "/login",
+		Cookies:         []string{"a=b; Path=" + prefix + "/", "c=d; Path=" + prefix + "/foo"},
+	}
+	if diff := cmp.Diff(expected, act); diff != "" {
+		t.Errorf("unexpected response (-want +got):\n%s", diff)
+	}
+}
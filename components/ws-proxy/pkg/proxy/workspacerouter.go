@@ -5,6 +5,7 @@
 package proxy
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"path/filepath"
@@ -57,15 +58,7 @@ func HostBasedRouter(header, wsHostSuffix string, wsHostSuffixRegex string) Work
 		setupAcmeRouter(r)
 
 		var (
-			getHostHeader = func(req *http.Request) string {
-				host := req.Header.Get(header)
-				// if we don't get host from special header, fallback to use req.Host
-				if header == "Host" || host == "" {
-					parts := strings.Split(req.Host, ":")
-					return parts[0]
-				}
-				return host
-			}
+			getHostHeader = hostHeaderFrom(header)
 			foreignRouter = r.MatcherFunc(matchForeignHostHeader(wsHostSuffix, getHostHeader)).Subrouter()
 			portRouter    = r.MatcherFunc(matchWorkspaceHostHeader(wsHostSuffix, getHostHeader, true)).Subrouter()
 			ideRouter     = r.MatcherFunc(matchWorkspaceHostHeader(allClusterWsHostSuffixRegex, getHostHeader, false)).Subrouter()
@@ -82,6 +75,19 @@ func HostBasedRouter(header, wsHostSuffix string, wsHostSuffixRegex string) Work
 
 type hostHeaderProvider func(req *http.Request) string
 
+// hostHeaderFrom provides the host a request was sent to from the given header.
+func hostHeaderFrom(header string) hostHeaderProvider {
+	return func(req *http.Request) string {
+		host := req.Header.Get(header)
+		// if we don't get host from special header, fallback to use req.Host
+		if header == "Host" || host == "" {
+			parts := strings.Split(req.Host, ":")
+			return parts[0]
+		}
+		return host
+	}
+}
+
 func matchWorkspaceHostHeader(wsHostSuffix string, headerProvider hostHeaderProvider, matchPort bool) mux.MatcherFunc {
 	var regexPrefix string
 	if matchPort {
@@ -225,6 +231,143 @@ func matchForeignHostHeader(wsHostSuffix string, headerProvider hostHeaderProvid
 	}
 }
 
+// PathBasedRouter is a WorkspaceRouter that serves all workspaces from a single host, which needs neither wildcard DNS
+// nor wildcard certificates. The IDE is served from /{workspaceID}/ and exposed ports from /{workspaceID}/ports/{port}/.
+// The path prefix is stripped before requests are forwarded and re-added to redirects and cookies in responses.
+// If host is empty requests to any host are routed.
+//
+// Warning: all workspaces and their ports share a single origin in this mode, i.e. the browser does not isolate them
+// from each other. Any page served from an exposed port can read the cookies and storage of every other workspace
+// served by this host. Only use this mode if all workspaces served from host belong to mutually trusting users.
+func PathBasedRouter(header, host string) WorkspaceRouter {
+	return func(r *mux.Router, wsInfoProvider WorkspaceInfoProvider) (*mux.Router, *mux.Router, *mux.Router) {
+		// make sure acme router is the first handler setup to make sure it has a chance to catch acme challenge
+		setupAcmeRouter(r)
+
+		getHostHeader := hostHeaderFrom(header)
+
+		// the IDE expects to be served from a directory, i.e. /{workspaceID} has to become /{workspaceID}/
+		r.MatcherFunc(matchWorkspacePathRoot(host, getHostHeader)).HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			target := *req.URL
+			target.Path += "/"
+			target.RawPath = ""
+			http.Redirect(w, req, target.RequestURI(), http.StatusMovedPermanently)
+		})
+
+		var (
+			portRouter = workspacePathRouter(r.MatcherFunc(matchWorkspacePath(host, getHostHeader, true)))
+			ideRouter  = workspacePathRouter(r.MatcherFunc(matchWorkspacePath(host, getHostHeader, false)))
+			// there is no foreign origin with path-based routing
+			foreignRouter = r.MatcherFunc(func(*http.Request, *mux.RouteMatch) bool { return false }).Subrouter()
+		)
+
+		r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			log.Debugf("no match for path %s, host: %s", req.URL.Path, getHostHeader(req))
+			w.WriteHeader(http.StatusNotFound)
+		})
+		return ideRouter, portRouter, foreignRouter
+	}
+}
+
+const workspacePortsPathSegment = "/ports/"
+
+var (
+	workspacePathRegex     = regexp.MustCompile("^/" + debugWorkspaceRegex + workspaceIDRegex + "(/|$)")
+	workspacePortPathRegex = regexp.MustCompile("^/" + debugWorkspaceRegex + workspaceIDRegex + workspacePortsPathSegment + "(?P<" + workspacePortIdentifier + ">[0-9]+)(/|$)")
+)
+
+func matchWorkspacePathRoot(host string, headerProvider hostHeaderProvider) mux.MatcherFunc {
+	return func(req *http.Request, m *mux.RouteMatch) bool {
+		if host != "" && headerProvider(req) != host {
+			return false
+		}
+		if matches := workspacePortPathRegex.FindStringSubmatch(req.URL.Path); matches != nil {
+			return !strings.HasSuffix(matches[0], "/")
+		}
+		matches := workspacePathRegex.FindStringSubmatch(req.URL.Path)
+		return matches != nil && !strings.HasSuffix(matches[0], "/")
+	}
+}
+
+// matchWorkspacePath matches requests to /{workspaceID}/ or, if matchPort is true, /{workspaceID}/ports/{port}/.
+func matchWorkspacePath(host string, headerProvider hostHeaderProvider, matchPort bool) mux.MatcherFunc {
+	regex := workspacePathRegex
+	if matchPort {
+		regex = workspacePortPathRegex
+	}
+	return func(req *http.Request, m *mux.RouteMatch) bool {
+		if host != "" && headerProvider(req) != host {
+			return false
+		}
+
+		matches := regex.FindStringSubmatch(req.URL.Path)
+		if matches == nil || !strings.HasSuffix(matches[0], "/") {
+			return false
+		}
+		var (
+			prefix         = strings.TrimSuffix(matches[0], "/")
+			workspaceID    = matches[regex.SubexpIndex(workspaceIDIdentifier)]
+			debugWorkspace = matches[regex.SubexpIndex(debugWorkspaceIdentifier)]
+			workspacePort  string
+		)
+		if matchPort {
+			workspacePort = matches[regex.SubexpIndex(workspacePortIdentifier)]
+		}
+		if workspaceID == "" {
+			return false
+		}
+
+		if m.Vars == nil {
+			m.Vars = make(map[string]string)
+		}
+		m.Vars[workspaceIDIdentifier] = workspaceID
+		m.Vars[workspacePathPrefixIdentifier] = prefix
+		if workspacePort != "" {
+			m.Vars[workspacePortIdentifier] = workspacePort
+		}
+		if debugWorkspace != "" {
+			m.Vars[debugWorkspaceIdentifier] = "true"
+		}
+		return true
+	}
+}
+
+type workspacePathVarsContextKey struct{}
+
+// workspacePathRouter produces a router which serves the requests matched by route with the workspace path prefix stripped.
+// Matchers must not modify requests, hence the prefix is stripped by the route's handler, which dispatches the
+// request to a router of its own.
+func workspacePathRouter(route *mux.Route) *mux.Router {
+	router := mux.NewRouter()
+	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	route.Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		vars := mux.Vars(req)
+		req = req.Clone(context.WithValue(req.Context(), workspacePathVarsContextKey{}, vars))
+		req.URL.Path = strings.TrimPrefix(req.URL.Path, vars[workspacePathPrefixIdentifier])
+		req.URL.RawPath = ""
+		router.ServeHTTP(w, req)
+	}))
+
+	// the router replaces the route variables of the request, hence we restore the ones matched by route
+	res := router.MatcherFunc(func(req *http.Request, m *mux.RouteMatch) bool {
+		vars, ok := req.Context().Value(workspacePathVarsContextKey{}).(map[string]string)
+		if !ok {
+			return false
+		}
+		if m.Vars == nil {
+			m.Vars = make(map[string]string, len(vars))
+		}
+		for k, v := range vars {
+			m.Vars[k] = v
+		}
+		return true
+	}).Subrouter()
+	res.Use(workspacePathPrefixHandler)
+	return res
+}
+
 func getWorkspaceCoords(req *http.Request) WorkspaceCoords {
 	vars := mux.Vars(req)
 	return WorkspaceCoords{
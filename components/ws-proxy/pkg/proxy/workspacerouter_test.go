@@ -94,6 +94,111 @@ func TestWorkspaceRouter(t *testing.T) {
 				URL:            "http://1234-debug-amaranth-smelt-9ba20cc1.ws.gitpod.dev/",
 			},
 		},
+		{
+			Name: "path-based workspace access",
+			URL:  "http://ws.gitpod.dev/amaranth-smelt-9ba20cc1/",
+			Headers: map[string]string{
+				forwardedHostnameHeader: "ws.gitpod.dev",
+			},
+			Router: PathBasedRouter(forwardedHostnameHeader, "ws.gitpod.dev"),
+			Expected: Expectation{
+				WorkspaceID: "amaranth-smelt-9ba20cc1",
+				Status:      http.StatusOK,
+				URL:         "http://ws.gitpod.dev/",
+			},
+		},
+		{
+			Name: "path-based workspace access with path",
+			URL:  "http://ws.gitpod.dev/amaranth-smelt-9ba20cc1/services",
+			Headers: map[string]string{
+				forwardedHostnameHeader: "ws.gitpod.dev",
+			},
+			Router: PathBasedRouter(forwardedHostnameHeader, "ws.gitpod.dev"),
+			Expected: Expectation{
+				WorkspaceID: "amaranth-smelt-9ba20cc1",
+				Status:      http.StatusOK,
+				URL:         "http://ws.gitpod.dev/services",
+			},
+		},
+		{
+			Name: "path-based debug workspace access",
+			URL:  "http://ws.gitpod.dev/debug-amaranth-smelt-9ba20cc1/",
+			Headers: map[string]string{
+				forwardedHostnameHeader: "ws.gitpod.dev",
+			},
+			Router: PathBasedRouter(forwardedHostnameHeader, "ws.gitpod.dev"),
+			Expected: Expectation{
+				DebugWorkspace: "true",
+				WorkspaceID:    "amaranth-smelt-9ba20cc1",
+				Status:         http.StatusOK,
+				URL:            "http://ws.gitpod.dev/",
+			},
+		},
+		{
+			Name: "path-based port access",
+			URL:  "http://ws.gitpod.dev/amaranth-smelt-9ba20cc1/ports/1234/",
+			Headers: map[string]string{
+				forwardedHostnameHeader: "ws.gitpod.dev",
+			},
+			Router: PathBasedRouter(forwardedHostnameHeader, "ws.gitpod.dev"),
+			Expected: Expectation{
+				WorkspaceID:   "amaranth-smelt-9ba20cc1",
+				WorkspacePort: "1234",
+				Status:        http.StatusOK,
+				URL:           "http://ws.gitpod.dev/",
+			},
+		},
+		{
+			Name: "path-based debug port access",
+			URL:  "http://ws.gitpod.dev/debug-amaranth-smelt-9ba20cc1/ports/1234/",
+			Headers: map[string]string{
+				forwardedHostnameHeader: "ws.gitpod.dev",
+			},
+			Router: PathBasedRouter(forwardedHostnameHeader, "ws.gitpod.dev"),
+			Expected: Expectation{
+				DebugWorkspace: "true",
+				WorkspaceID:    "amaranth-smelt-9ba20cc1",
+				WorkspacePort:  "1234",
+				Status:         http.StatusOK,
+				URL:            "http://ws.gitpod.dev/",
+			},
+		},
+		{
+			Name: "path-based workspace root redirect",
+			URL:  "http://ws.gitpod.dev/amaranth-smelt-9ba20cc1",
+			Headers: map[string]string{
+				forwardedHostnameHeader: "ws.gitpod.dev",
+			},
+			Router: PathBasedRouter(forwardedHostnameHeader, "ws.gitpod.dev"),
+			Expected: Expectation{
+				Status:             http.StatusMovedPermanently,
+				AdditionalHitCount: -1,
+			},
+		},
+		{
+			Name: "path-based port root redirect",
+			URL:  "http://ws.gitpod.dev/amaranth-smelt-9ba20cc1/ports/1234",
+			Headers: map[string]string{
+				forwardedHostnameHeader: "ws.gitpod.dev",
+			},
+			Router: PathBasedRouter(forwardedHostnameHeader, "ws.gitpod.dev"),
+			Expected: Expectation{
+				Status:             http.StatusMovedPermanently,
+				AdditionalHitCount: -1,
+			},
+		},
+		{
+			Name: "path-based foreign host",
+			URL:  "http://foo.gitpod.dev/amaranth-smelt-9ba20cc1/",
+			Headers: map[string]string{
+				forwardedHostnameHeader: "foo.gitpod.dev",
+			},
+			Router: PathBasedRouter(forwardedHostnameHeader, "ws.gitpod.dev"),
+			Expected: Expectation{
+				Status:             http.StatusNotFound,
+				AdditionalHitCount: -1,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,72 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package sshproxy
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// AuditEvent records a forwarding decision of the SSH gateway
+type AuditEvent struct {
+	Time      time.Time
+	SessionID string
+	// RemoteAddr is the address the client connected from
+	RemoteAddr string
+
+	UserID         string
+	WorkspaceID    string
+	InstanceID     string
+	OrganizationID string
+
+	Kind ForwardingKind
+	// Address is the forwarded host:port, empty for agent forwarding
+	Address string
+	Allowed bool
+}
+
+// Auditor receives audit events of the SSH gateway
+type Auditor interface {
+	Audit(evt AuditEvent)
+}
+
+// LogAuditor writes audit events to the log
+type LogAuditor struct{}
+
+// Audit logs the audit event
+func (LogAuditor) Audit(evt AuditEvent) {
+	log.WithFields(log.OWI(evt.UserID, evt.WorkspaceID, evt.InstanceID)).WithFields(logrus.Fields{
+		"sessionId":      evt.SessionID,
+		"remoteAddr":     evt.RemoteAddr,
+		"organizationId": evt.OrganizationID,
+		"kind":           evt.Kind,
+		"address":        evt.Address,
+		"allowed":        evt.Allowed,
+		"time":           evt.Time,
+	}).Info("ssh gateway forwarding")
+}
+
+// newAuditEvent produces an audit event of a forwarding within a session
+func newAuditEvent(session *Session, fwd Forwarding, allowed bool) AuditEvent {
+	evt := AuditEvent{
+		Time:           time.Now(),
+		UserID:         session.OwnerUserId,
+		WorkspaceID:    session.WorkspaceID,
+		InstanceID:     session.InstanceID,
+		OrganizationID: session.OrganizationID,
+		Kind:           fwd.Kind,
+		Address:        fwd.Address(),
+		Allowed:        allowed,
+	}
+	if session.Conn != nil {
+		evt.SessionID = hex.EncodeToString(session.Conn.SessionID())
+		evt.RemoteAddr = session.Conn.RemoteAddr().String()
+	}
+	return evt
+}
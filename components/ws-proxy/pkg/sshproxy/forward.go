@@ -39,6 +39,12 @@ func (s *Server) ChannelForward(ctx context.Context, session *Session, targetCon
 
 	go func() {
 		for req := range originReqs {
+			if fwd, ok := channelRequestForwarding(req.Type); ok && !s.checkForwarding(session, fwd) {
+				if req.WantReply {
+					_ = req.Reply(false, nil)
+				}
+				continue
+			}
 			switch req.Type {
 			case "pty-req", "shell":
 				log.WithFields(log.OWI("", session.WorkspaceID, session.InstanceID)).Debugf("forwarding %s request", req.Type)
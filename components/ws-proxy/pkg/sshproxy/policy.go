@@ -0,0 +1,241 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package sshproxy
+
+import (
+	"path"
+	"strconv"
+
+	"github.com/gitpod-io/golang-crypto/ssh"
+	"golang.org/x/xerrors"
+)
+
+// ForwardingKind is the kind of forwarding a client asks for
+type ForwardingKind string
+
+const (
+	// ForwardingDirectTCPIP is local port forwarding (ssh -L), i.e. the client opens connections from within the workspace
+	ForwardingDirectTCPIP ForwardingKind = "direct-tcpip"
+	// ForwardingTCPIPForward is remote port forwarding (ssh -R), i.e. the workspace opens connections to the client
+	ForwardingTCPIPForward ForwardingKind = "tcpip-forward"
+	// ForwardingAgent is SSH agent forwarding (ssh -A)
+	ForwardingAgent ForwardingKind = "agent"
+)
+
+// Forwarding describes a single forwarding a client asks for
+type Forwarding struct {
+	Kind ForwardingKind
+	// Host and Port are the forwarded address. They are empty for agent forwarding.
+	Host string
+	Port uint32
+}
+
+// Address returns the forwarded host:port
+func (f Forwarding) Address() string {
+	if f.Host == "" && f.Port == 0 {
+		return ""
+	}
+	return f.Host + ":" + strconv.FormatUint(uint64(f.Port), 10)
+}
+
+// Policy decides which forwardings are permitted for a session
+type Policy interface {
+	// Allow returns true if the session may establish the forwarding
+	Allow(session *Session, fwd Forwarding) bool
+}
+
+// AllowAllPolicy permits all forwardings
+type AllowAllPolicy struct{}
+
+// Allow returns always true
+func (AllowAllPolicy) Allow(session *Session, fwd Forwarding) bool { return true }
+
+// PolicyAction is the outcome of a policy rule
+type PolicyAction string
+
+const (
+	PolicyActionAllow PolicyAction = "allow"
+	PolicyActionDeny  PolicyAction = "deny"
+)
+
+// PolicyConfig configures a RulePolicy
+type PolicyConfig struct {
+	// Rules are evaluated in order, the first matching rule decides
+	Rules []PolicyRule `json:"rules,omitempty"`
+	// DefaultAction applies if no rule matches. Defaults to allow.
+	DefaultAction PolicyAction `json:"defaultAction,omitempty"`
+}
+
+// PolicyRule matches forwardings. Empty fields match everything.
+type PolicyRule struct {
+	Action          PolicyAction     `json:"action"`
+	Kinds           []ForwardingKind `json:"kinds,omitempty"`
+	OrganizationIDs []string         `json:"organizationIds,omitempty"`
+	WorkspaceIDs    []string         `json:"workspaceIds,omitempty"`
+	// Hosts are shell patterns as understood by path.Match, e.g. "*.internal"
+	Hosts []string `json:"hosts,omitempty"`
+	Ports []uint32 `json:"ports,omitempty"`
+}
+
+// Validate validates the policy configuration
+func (c *PolicyConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	switch c.DefaultAction {
+	case "", PolicyActionAllow, PolicyActionDeny:
+	default:
+		return xerrors.Errorf("invalid default action %q", c.DefaultAction)
+	}
+	for i, r := range c.Rules {
+		switch r.Action {
+		case PolicyActionAllow, PolicyActionDeny:
+		default:
+			return xerrors.Errorf("rule %d: invalid action %q", i, r.Action)
+		}
+		for _, k := range r.Kinds {
+			switch k {
+			case ForwardingDirectTCPIP, ForwardingTCPIPForward, ForwardingAgent:
+			default:
+				return xerrors.Errorf("rule %d: invalid kind %q", i, k)
+			}
+		}
+		for _, h := range r.Hosts {
+			if _, err := path.Match(h, ""); err != nil {
+				return xerrors.Errorf("rule %d: invalid host pattern %q: %w", i, h, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RulePolicy is a Policy based on a list of rules
+type RulePolicy struct {
+	cfg PolicyConfig
+}
+
+// NewRulePolicy creates a new rule based policy
+func NewRulePolicy(cfg PolicyConfig) (*RulePolicy, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &RulePolicy{cfg: cfg}, nil
+}
+
+// Allow evaluates the rules of the policy
+func (p *RulePolicy) Allow(session *Session, fwd Forwarding) bool {
+	for _, r := range p.cfg.Rules {
+		if r.matches(session, fwd) {
+			return r.Action == PolicyActionAllow
+		}
+	}
+	return p.cfg.DefaultAction != PolicyActionDeny
+}
+
+func (r *PolicyRule) matches(session *Session, fwd Forwarding) bool {
+	if len(r.Kinds) > 0 && !containsKind(r.Kinds, fwd.Kind) {
+		return false
+	}
+	if len(r.OrganizationIDs) > 0 && !containsString(r.OrganizationIDs, session.OrganizationID) {
+		return false
+	}
+	if len(r.WorkspaceIDs) > 0 && !containsString(r.WorkspaceIDs, session.WorkspaceID) {
+		return false
+	}
+	if len(r.Hosts) > 0 {
+		var matched bool
+		for _, h := range r.Hosts {
+			if ok, _ := path.Match(h, fwd.Host); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(r.Ports) > 0 {
+		var matched bool
+		for _, p := range r.Ports {
+			if p == fwd.Port {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func containsKind(kinds []ForwardingKind, k ForwardingKind) bool {
+	for _, e := range kinds {
+		if e == k {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// channelForwarding returns the forwarding a channel represents, or false if the channel is not subject to the policy
+func channelForwarding(channelType string, extraData []byte) (Forwarding, bool) {
+	switch channelType {
+	case "direct-tcpip":
+		// RFC 4254 7.2
+		var msg struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		_ = ssh.Unmarshal(extraData, &msg)
+		return Forwarding{Kind: ForwardingDirectTCPIP, Host: msg.Host, Port: msg.Port}, true
+	case "forwarded-tcpip":
+		// RFC 4254 7.2
+		var msg struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		_ = ssh.Unmarshal(extraData, &msg)
+		return Forwarding{Kind: ForwardingTCPIPForward, Host: msg.Host, Port: msg.Port}, true
+	case "auth-agent@openssh.com":
+		return Forwarding{Kind: ForwardingAgent}, true
+	}
+	return Forwarding{}, false
+}
+
+// globalRequestForwarding returns the forwarding a global request asks for, or false if the request is not subject to the policy
+func globalRequestForwarding(requestType string, payload []byte) (Forwarding, bool) {
+	if requestType != "tcpip-forward" {
+		return Forwarding{}, false
+	}
+	// RFC 4254 7.1
+	var msg struct {
+		Host string
+		Port uint32
+	}
+	_ = ssh.Unmarshal(payload, &msg)
+	return Forwarding{Kind: ForwardingTCPIPForward, Host: msg.Host, Port: msg.Port}, true
+}
+
+// channelRequestForwarding returns the forwarding a channel request asks for, or false if the request is not subject to the policy
+func channelRequestForwarding(requestType string) (Forwarding, bool) {
+	if requestType != "auth-agent-req@openssh.com" {
+		return Forwarding{}, false
+	}
+	return Forwarding{Kind: ForwardingAgent}, true
+}
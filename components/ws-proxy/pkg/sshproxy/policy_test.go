@@ -0,0 +1,164 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package sshproxy
+
+import (
+	"testing"
+
+	"github.com/gitpod-io/golang-crypto/ssh"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRulePolicy(t *testing.T) {
+	session := &Session{WorkspaceID: "amaranth-smelt-9ba20cc1", OrganizationID: "org-1"}
+	tests := []struct {
+		Name        string
+		Config      PolicyConfig
+		Forwarding  Forwarding
+		Expectation bool
+	}{
+		{
+			Name:        "no rules",
+			Forwarding:  Forwarding{Kind: ForwardingDirectTCPIP, Host: "localhost", Port: 3000},
+			Expectation: true,
+		},
+		{
+			Name:       "deny by default",
+			Config:     PolicyConfig{DefaultAction: PolicyActionDeny},
+			Forwarding: Forwarding{Kind: ForwardingDirectTCPIP, Host: "localhost", Port: 3000},
+		},
+		{
+			Name: "deny agent forwarding",
+			Config: PolicyConfig{Rules: []PolicyRule{
+				{Action: PolicyActionDeny, Kinds: []ForwardingKind{ForwardingAgent}},
+			}},
+			Forwarding: Forwarding{Kind: ForwardingAgent},
+		},
+		{
+			Name: "deny agent forwarding does not affect ports",
+			Config: PolicyConfig{Rules: []PolicyRule{
+				{Action: PolicyActionDeny, Kinds: []ForwardingKind{ForwardingAgent}},
+			}},
+			Forwarding:  Forwarding{Kind: ForwardingDirectTCPIP, Host: "localhost", Port: 3000},
+			Expectation: true,
+		},
+		{
+			Name: "first matching rule wins",
+			Config: PolicyConfig{Rules: []PolicyRule{
+				{Action: PolicyActionAllow, Hosts: []string{"localhost"}, Ports: []uint32{3000}},
+				{Action: PolicyActionDeny, Kinds: []ForwardingKind{ForwardingDirectTCPIP}},
+			}},
+			Forwarding:  Forwarding{Kind: ForwardingDirectTCPIP, Host: "localhost", Port: 3000},
+			Expectation: true,
+		},
+		{
+			Name: "port mismatch",
+			Config: PolicyConfig{Rules: []PolicyRule{
+				{Action: PolicyActionAllow, Hosts: []string{"localhost"}, Ports: []uint32{3000}},
+				{Action: PolicyActionDeny, Kinds: []ForwardingKind{ForwardingDirectTCPIP}},
+			}},
+			Forwarding: Forwarding{Kind: ForwardingDirectTCPIP, Host: "localhost", Port: 22},
+		},
+		{
+			Name: "host pattern",
+			Config: PolicyConfig{Rules: []PolicyRule{
+				{Action: PolicyActionDeny, Hosts: []string{"*.internal"}},
+			}},
+			Forwarding: Forwarding{Kind: ForwardingDirectTCPIP, Host: "db.internal", Port: 5432},
+		},
+		{
+			Name: "other organization",
+			Config: PolicyConfig{Rules: []PolicyRule{
+				{Action: PolicyActionDeny, OrganizationIDs: []string{"org-2"}},
+			}},
+			Forwarding:  Forwarding{Kind: ForwardingTCPIPForward, Host: "0.0.0.0", Port: 8080},
+			Expectation: true,
+		},
+		{
+			Name: "organization and workspace",
+			Config: PolicyConfig{Rules: []PolicyRule{
+				{Action: PolicyActionDeny, OrganizationIDs: []string{"org-1"}, WorkspaceIDs: []string{"amaranth-smelt-9ba20cc1"}},
+			}},
+			Forwarding: Forwarding{Kind: ForwardingTCPIPForward, Host: "0.0.0.0", Port: 8080},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			p, err := NewRulePolicy(test.Config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if act := p.Allow(session, test.Forwarding); act != test.Expectation {
+				t.Errorf("unexpected decision: expected %v, got %v", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestPolicyConfigValidate(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Config  *PolicyConfig
+		Invalid bool
+	}{
+		{Name: "nil"},
+		{Name: "valid", Config: &PolicyConfig{DefaultAction: PolicyActionDeny, Rules: []PolicyRule{{Action: PolicyActionAllow, Kinds: []ForwardingKind{ForwardingAgent}}}}},
+		{Name: "invalid default action", Config: &PolicyConfig{DefaultAction: "maybe"}, Invalid: true},
+		{Name: "missing action", Config: &PolicyConfig{Rules: []PolicyRule{{}}}, Invalid: true},
+		{Name: "invalid kind", Config: &PolicyConfig{Rules: []PolicyRule{{Action: PolicyActionDeny, Kinds: []ForwardingKind{"x11"}}}}, Invalid: true},
+		{Name: "invalid host pattern", Config: &PolicyConfig{Rules: []PolicyRule{{Action: PolicyActionDeny, Hosts: []string{"["}}}}, Invalid: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := test.Config.Validate()
+			if act := err != nil; act != test.Invalid {
+				t.Errorf("unexpected validation result: %v", err)
+			}
+		})
+	}
+}
+
+func TestChannelForwarding(t *testing.T) {
+	type result struct {
+		Forwarding Forwarding
+		OK         bool
+	}
+	tests := []struct {
+		Name        string
+		ChannelType string
+		ExtraData   []byte
+		Expectation result
+	}{
+		{
+			Name:        "direct-tcpip",
+			ChannelType: "direct-tcpip",
+			ExtraData: ssh.Marshal(struct {
+				Host       string
+				Port       uint32
+				OriginHost string
+				OriginPort uint32
+			}{"localhost", 3000, "127.0.0.1", 52000}),
+			Expectation: result{Forwarding: Forwarding{Kind: ForwardingDirectTCPIP, Host: "localhost", Port: 3000}, OK: true},
+		},
+		{
+			Name:        "agent",
+			ChannelType: "auth-agent@openssh.com",
+			Expectation: result{Forwarding: Forwarding{Kind: ForwardingAgent}, OK: true},
+		},
+		{
+			Name:        "session",
+			ChannelType: "session",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var act result
+			act.Forwarding, act.OK = channelForwarding(test.ChannelType, test.ExtraData)
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -40,6 +40,11 @@ var (
 		Name: "gitpod_ws_proxy_ssh_attempt_total",
 		Help: "Total number of SSH attempt",
 	}, []string{"status", "error_type"})
+
+	SSHForwardingTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitpod_ws_proxy_ssh_forwarding_total",
+		Help: "Total number of SSH port and agent forwarding attempts",
+	}, []string{"kind", "decision"})
 )
 
 var (
@@ -83,9 +88,10 @@ func NewSSHErrorWithReject(shortName string, description string) SSHError {
 type Session struct {
 	Conn *ssh.ServerConn
 
-	WorkspaceID string
-	InstanceID  string
-	OwnerUserId string
+	WorkspaceID    string
+	InstanceID     string
+	OwnerUserId    string
+	OrganizationID string
 
 	PublicKey           ssh.PublicKey
 	WorkspacePrivateKey ssh.Signer
@@ -93,6 +99,10 @@ type Session struct {
 
 type Server struct {
 	Heartbeater Heartbeat
+	// Policy decides which port and agent forwardings are permitted
+	Policy Policy
+	// Auditor receives an event for every port and agent forwarding attempt
+	Auditor Auditor
 
 	sshConfig             *ssh.ServerConfig
 	workspaceInfoProvider proxy.WorkspaceInfoProvider
@@ -102,6 +112,7 @@ func init() {
 	metrics.Registry.MustRegister(
 		SSHConnectionCount,
 		SSHAttemptTotal,
+		SSHForwardingTotal,
 	)
 }
 
@@ -111,6 +122,8 @@ func New(signers []ssh.Signer, workspaceInfoProvider proxy.WorkspaceInfoProvider
 	server := &Server{
 		workspaceInfoProvider: workspaceInfoProvider,
 		Heartbeater:           &noHeartbeat{},
+		Policy:                AllowAllPolicy{},
+		Auditor:               LogAuditor{},
 	}
 	if heartbeat != nil {
 		server.Heartbeater = heartbeat
@@ -220,6 +233,32 @@ func ReportSSHAttemptMetrics(err error) {
 	SSHAttemptTotal.WithLabelValues("failed", errorType).Inc()
 }
 
+func ReportSSHForwardingMetrics(kind ForwardingKind, allowed bool) {
+	decision := string(PolicyActionAllow)
+	if !allowed {
+		decision = string(PolicyActionDeny)
+	}
+	SSHForwardingTotal.WithLabelValues(string(kind), decision).Inc()
+}
+
+// checkForwarding applies the policy to a forwarding and records the decision
+func (s *Server) checkForwarding(session *Session, fwd Forwarding) bool {
+	allowed := s.Policy.Allow(session, fwd)
+	ReportSSHForwardingMetrics(fwd.Kind, allowed)
+	s.Auditor.Audit(newAuditEvent(session, fwd, allowed))
+	return allowed
+}
+
+// rejectChannel rejects channels which the policy does not permit. It returns true if the channel was rejected.
+func (s *Server) rejectChannel(session *Session, newChannel ssh.NewChannel) bool {
+	fwd, ok := channelForwarding(newChannel.ChannelType(), newChannel.ExtraData())
+	if !ok || s.checkForwarding(session, fwd) {
+		return false
+	}
+	_ = newChannel.Reject(ssh.Prohibited, "forwarding is not permitted by policy")
+	return true
+}
+
 func (s *Server) RequestForward(reqs <-chan *ssh.Request, targetConn ssh.Conn) {
 	for req := range reqs {
 		result, payload, err := targetConn.SendRequest(req.Type, req.WantReply, req.Payload)
@@ -269,6 +308,7 @@ func (s *Server) HandleConn(c net.Conn) {
 		WorkspaceID:         workspaceId,
 		InstanceID:          wsInfo.InstanceID,
 		OwnerUserId:         wsInfo.OwnerUserId,
+		OrganizationID:      wsInfo.OrganizationId,
 		WorkspacePrivateKey: key,
 	}
 	sshPort := "23001"
@@ -308,8 +348,12 @@ func (s *Server) HandleConn(c net.Conn) {
 	SSHConnectionCount.Inc()
 	ReportSSHAttemptMetrics(nil)
 
-	forwardRequests := func(reqs <-chan *ssh.Request, targetConn ssh.Conn) {
+	forwardRequests := func(reqs <-chan *ssh.Request, targetConn ssh.Conn, checkPolicy bool) {
 		for req := range reqs {
+			if fwd, ok := globalRequestForwarding(req.Type, req.Payload); checkPolicy && ok && !s.checkForwarding(session, fwd) {
+				_ = req.Reply(false, nil)
+				continue
+			}
 			result, payload, err := targetConn.SendRequest(req.Type, req.WantReply, req.Payload)
 			if err != nil {
 				continue
@@ -318,18 +362,24 @@ func (s *Server) HandleConn(c net.Conn) {
 		}
 	}
 	// client -> workspace global request forward
-	go forwardRequests(clientReqs, workspaceConn)
+	go forwardRequests(clientReqs, workspaceConn, true)
 	// workspce -> client global request forward
-	go forwardRequests(workspaceReqs, clientConn)
+	go forwardRequests(workspaceReqs, clientConn, false)
 
 	go func() {
 		for newChannel := range workspaceChans {
+			if s.rejectChannel(session, newChannel) {
+				continue
+			}
 			go s.ChannelForward(ctx, session, clientConn, newChannel)
 		}
 	}()
 
 	go func() {
 		for newChannel := range clientChans {
+			if s.rejectChannel(session, newChannel) {
+				continue
+			}
 			go s.ChannelForward(ctx, session, workspaceConn, newChannel)
 		}
 	}()
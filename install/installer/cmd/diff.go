@@ -0,0 +1,120 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	"github.com/gitpod-io/gitpod/installer/pkg/diff"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var diffOpts struct {
+	Kube                   kubeConfig
+	ConfigFN               string
+	Namespace              string
+	Output                 string
+	ValidateConfigDisabled bool
+	UseExperimentalConfig  bool
+	FailOnDestructive      bool
+}
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compares the rendered Kubernetes manifests with the objects in the cluster",
+	Long: `Compares the rendered Kubernetes manifests with the objects in the cluster
+
+Objects of the current installation which are no longer rendered are reported as deleted.
+Changes which cannot be applied in place or which lose data, such as changes to immutable
+fields or the deletion of CustomResourceDefinitions, are marked as destructive.`,
+	Example: `  # Review the changes of an upgrade.
+  gitpod-installer diff --config config.yaml --namespace gitpod
+
+  # Fail a pipeline if the changes are destructive.
+  gitpod-installer diff --config config.yaml --output json --fail-on-destructive`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if diffOpts.Output != "text" && diffOpts.Output != "json" {
+			return fmt.Errorf("unsupported output format %q, must be one of text, json", diffOpts.Output)
+		}
+		if err := checkKubeConfig(&diffOpts.Kube); err != nil {
+			return err
+		}
+
+		renderOpts.ConfigFN = diffOpts.ConfigFN
+		renderOpts.Namespace = diffOpts.Namespace
+		renderOpts.ValidateConfigDisabled = diffOpts.ValidateConfigDisabled
+		renderOpts.UseExperimentalConfig = diffOpts.UseExperimentalConfig
+		manifests, err := renderFn()
+		if err != nil {
+			return err
+		}
+		rendered, err := diff.ParseObjects(manifests...)
+		if err != nil {
+			return err
+		}
+
+		clientcfg := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			&clientcmd.ClientConfigLoadingRules{ExplicitPath: diffOpts.Kube.Config},
+			&clientcmd.ConfigOverrides{},
+		)
+		res, err := clientcfg.ClientConfig()
+		if err != nil {
+			return err
+		}
+		cluster, err := diff.NewCluster(res, diffOpts.Namespace)
+		if err != nil {
+			return err
+		}
+
+		result, err := diff.Run(context.Background(), cluster, rendered)
+		if err != nil {
+			return err
+		}
+
+		switch diffOpts.Output {
+		case "json":
+			jsonOut, err := common.ToJSONString(result)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s\n", string(jsonOut))
+		default:
+			if err := result.WriteText(os.Stdout); err != nil {
+				return err
+			}
+		}
+
+		if diffOpts.FailOnDestructive && result.Summary.Destructive > 0 {
+			fmt.Fprintf(os.Stderr, "%d destructive changes\n", result.Summary.Destructive)
+			os.Exit(1)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	dir, err := os.Getwd()
+	if err != nil {
+		log.WithError(err).Fatal("Failed to get working directory")
+	}
+
+	diffCmd.PersistentFlags().StringVar(&diffOpts.Kube.Config, "kubeconfig", "", "path to the kubeconfig file")
+	diffCmd.PersistentFlags().StringVarP(&diffOpts.ConfigFN, "config", "c", getEnvvar("GITPOD_INSTALLER_CONFIG", filepath.Join(dir, "gitpod.config.yaml")), "path to the config file, use - for stdin")
+	diffCmd.PersistentFlags().StringVarP(&diffOpts.Namespace, "namespace", "n", getEnvvar("NAMESPACE", "default"), "namespace to deploy to")
+	diffCmd.Flags().StringVarP(&diffOpts.Output, "output", "o", "text", "output format, one of text, json")
+	diffCmd.Flags().BoolVar(&diffOpts.ValidateConfigDisabled, "no-validation", false, "if set, the config will not be validated before running")
+	diffCmd.Flags().BoolVar(&diffOpts.UseExperimentalConfig, "use-experimental-config", false, "enable the use of experimental config that is prone to be changed")
+	diffCmd.Flags().BoolVar(&diffOpts.FailOnDestructive, "fail-on-destructive", false, "exit with a non-zero code if any change is destructive")
+}
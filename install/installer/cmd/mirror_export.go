@@ -0,0 +1,86 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	dockerconfig "github.com/docker/cli/cli/config"
+	"github.com/gitpod-io/gitpod/installer/pkg/mirror"
+	"github.com/spf13/cobra"
+)
+
+var mirrorExportOpts struct {
+	ConfigFN          string
+	Output            string
+	ExcludeThirdParty bool
+	Insecure          bool
+}
+
+// mirrorExportCmd represents the mirror export command
+var mirrorExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports the images used into a bundle for air-gapped installations",
+	Long: `Exports the images used into a bundle for air-gapped installations
+
+The images listed by "mirror list" are pulled and written into a tarball
+in the OCI image layout. Layers shared between images are stored once.
+The bundle can then be transferred into the disconnected environment
+and pushed into its registry with "mirror import".
+
+Registry credentials are read from the docker config.`,
+	Example: `
+  gitpod-installer mirror export --config config.yaml --output gitpod-images.tar`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if mirrorExportOpts.ConfigFN == "" {
+			return fmt.Errorf("config is a required flag")
+		}
+		if mirrorExportOpts.Output == "" {
+			return fmt.Errorf("output is a required flag")
+		}
+
+		_, cfgVersion, cfg, err := loadConfig(mirrorExportOpts.ConfigFN)
+		if err != nil {
+			return err
+		}
+		repository := strings.TrimRight(cfg.Repository, "/")
+
+		list, err := generateMirrorList(cfgVersion, cfg, mirrorExportOpts.ExcludeThirdParty)
+		if err != nil {
+			return err
+		}
+		imgs := make([]mirror.Image, 0, len(list))
+		for _, img := range list {
+			imgs = append(imgs, mirror.Image{Original: img.Original, Target: img.Target})
+		}
+
+		f, err := os.Create(mirrorExportOpts.Output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		resolver := mirror.NewResolver(dockerconfig.LoadDefaultConfigFile(os.Stderr), mirrorExportOpts.Insecure)
+		err = mirror.Export(context.Background(), resolver, imgs, repository, f)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "exported %d images to %s\n", len(imgs), mirrorExportOpts.Output)
+		return f.Close()
+	},
+}
+
+func init() {
+	mirrorCmd.AddCommand(mirrorExportCmd)
+
+	mirrorExportCmd.Flags().BoolVar(&mirrorExportOpts.ExcludeThirdParty, "exclude-third-party", false, "exclude non-Gitpod images")
+	mirrorExportCmd.Flags().StringVarP(&mirrorExportOpts.ConfigFN, "config", "c", os.Getenv("GITPOD_INSTALLER_CONFIG"), "path to the config file")
+	mirrorExportCmd.Flags().StringVarP(&mirrorExportOpts.Output, "output", "o", "", "path to write the bundle to")
+	mirrorExportCmd.Flags().BoolVar(&mirrorExportOpts.Insecure, "insecure", false, "access registries via plain HTTP")
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	dockerconfig "github.com/docker/cli/cli/config"
+	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	"github.com/gitpod-io/gitpod/installer/pkg/mirror"
+	"github.com/spf13/cobra"
+)
+
+var mirrorImportOpts struct {
+	Input      string
+	Repository string
+	Insecure   bool
+}
+
+// mirrorImportCmd represents the mirror import command
+var mirrorImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Pushes the images of a bundle into a registry",
+	Long: `Pushes the images of a bundle into a registry
+
+The bundle must have been written by "mirror export". The images are
+pushed to the targets listed by "mirror list" for the config the bundle
+was exported with, unless another repository is given.
+
+Registry credentials are read from the docker config.`,
+	Example: `
+  gitpod-installer mirror import --input gitpod-images.tar
+
+  # Push into another repository than the one in the config
+  gitpod-installer mirror import --input gitpod-images.tar --repository registry.example.com/gitpod`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if mirrorImportOpts.Input == "" {
+			return fmt.Errorf("input is a required flag")
+		}
+
+		f, err := os.Open(mirrorImportOpts.Input)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		resolver := mirror.NewResolver(dockerconfig.LoadDefaultConfigFile(os.Stderr), mirrorImportOpts.Insecure)
+		imgs, err := mirror.Import(context.Background(), resolver, f, mirrorImportOpts.Repository)
+		if err != nil {
+			return err
+		}
+
+		fc, err := common.ToJSONString(imgs)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(fc))
+
+		return nil
+	},
+}
+
+func init() {
+	mirrorCmd.AddCommand(mirrorImportCmd)
+
+	mirrorImportCmd.Flags().StringVarP(&mirrorImportOpts.Input, "input", "i", "", "path to the bundle")
+	mirrorImportCmd.Flags().StringVar(&mirrorImportOpts.Repository, "repository", "", "repository to push the images to, defaults to the repository of the config the bundle was exported with")
+	mirrorImportCmd.Flags().BoolVar(&mirrorImportOpts.Insecure, "insecure", false, "access registries via plain HTTP")
+}
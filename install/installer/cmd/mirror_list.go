@@ -63,7 +63,7 @@ image to the "target" repo`,
 			return err
 		}
 
-		images, err := generateMirrorList(cfgVersion, cfg)
+		images, err := generateMirrorList(cfgVersion, cfg, mirrorListOpts.ExcludeThirdParty)
 		if err != nil {
 			return err
 		}
@@ -181,7 +181,7 @@ func renderAllKubernetesObject(cfgVersion string, cfg *configv1.Config) ([]strin
 	return k8s, nil
 }
 
-func generateMirrorList(cfgVersion string, cfg *configv1.Config) ([]mirrorListRepo, error) {
+func generateMirrorList(cfgVersion string, cfg *configv1.Config, excludeThirdParty bool) ([]mirrorListRepo, error) {
 	// Throw error if set to the default Gitpod repository
 	if cfg.Repository == common.GitpodContainerRegistry {
 		return nil, fmt.Errorf("cannot mirror images to repository %s", common.GitpodContainerRegistry)
@@ -224,7 +224,7 @@ func generateMirrorList(cfgVersion string, cfg *configv1.Config) ([]mirrorListRe
 		if strings.Contains(img, cfg.Repository) {
 			// This is the Gitpod registry
 			target = strings.Replace(target, cfg.Repository, targetRepo, 1)
-		} else if !mirrorListOpts.ExcludeThirdParty {
+		} else if !excludeThirdParty {
 			// Amend third-party images - remove the first part
 			thirdPartyImg := strings.Join(strings.Split(img, "/")[1:], "/")
 			target = fmt.Sprintf("%s/%s", targetRepo, thirdPartyImg)
@@ -109,6 +109,7 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 		PProfAddr:          common.LocalhostAddressFromPort(baseserver.BuiltinDebugPort),
 		PrometheusAddr:     common.LocalhostPrometheusAddr(),
 		ReadinessProbeAddr: fmt.Sprintf(":%v", ReadinessPort),
+		AdminAddr:          fmt.Sprintf(":%v", AdminPort),
 	}
 
 	fc, err := common.ToJSONString(bscfg)
@@ -15,4 +15,7 @@ const (
 	ServicePortName = "service"
 	MaxSizeBytes    = 1024 * 1024 * 1024 // 1 Gibibyte
 	ReadinessPort   = 8086
+	// AdminPort serves the unauthenticated admin API, which is only reachable through a port-forward
+	AdminPort     = 8087
+	AdminPortName = "admin"
 )
@@ -111,6 +111,9 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 							Ports: []corev1.ContainerPort{{
 								Name:          ServicePortName,
 								ContainerPort: ContainerPort,
+							}, {
+								Name:          AdminPortName,
+								ContainerPort: AdminPort,
 							}},
 							Resources: common.ResourceRequirements(ctx, Component, Component, corev1.ResourceRequirements{
 								Requests: corev1.ResourceList{
@@ -5,11 +5,13 @@
 package blobserve
 
 import (
+	"github.com/gitpod-io/gitpod/common-go/baseserver"
 	"github.com/gitpod-io/gitpod/installer/pkg/common"
 
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func networkpolicy(ctx *common.RenderContext) ([]runtime.Object, error) {
@@ -25,7 +27,14 @@ func networkpolicy(ctx *common.RenderContext) ([]runtime.Object, error) {
 		Spec: networkingv1.NetworkPolicySpec{
 			PodSelector: metav1.LabelSelector{MatchLabels: labels},
 			PolicyTypes: []networkingv1.PolicyType{"Ingress"},
-			Ingress:     []networkingv1.NetworkPolicyIngressRule{{}},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				// The admin API is unauthenticated, hence its port is not listed and can only be reached through a port-forward.
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: common.TCPProtocol, Port: &intstr.IntOrString{IntVal: ContainerPort}},
+					{Protocol: common.TCPProtocol, Port: &intstr.IntOrString{IntVal: ReadinessPort}},
+					{Protocol: common.TCPProtocol, Port: &intstr.IntOrString{IntVal: baseserver.BuiltinMetricsPort}},
+				},
+			}},
 		},
 	}}, nil
 }
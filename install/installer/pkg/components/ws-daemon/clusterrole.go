@@ -82,6 +82,9 @@ func clusterrole(ctx *common.RenderContext) ([]runtime.Object, error) {
 						"get",
 						"list",
 						"watch",
+						// ws-daemon maintains the finalizer which deletes the snapshot content
+						"patch",
+						"update",
 					},
 				},
 				rbacv1.PolicyRule{
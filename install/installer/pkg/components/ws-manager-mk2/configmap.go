@@ -83,6 +83,7 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 
 	var schedulerName string
 	var experimentalMode bool
+	var quotas []config.WorkspaceQuota
 	gitpodHostURL := "https://" + ctx.Config.Domain
 	workspaceClusterHost := fmt.Sprintf("ws%s.%s", installationShortNameSuffix, ctx.Config.Domain)
 	workspaceURLTemplate := fmt.Sprintf("https://{{ .Prefix }}.ws%s.%s", installationShortNameSuffix, ctx.Config.Domain)
@@ -147,6 +148,7 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 			workspacePortURLTemplate = ucfg.Workspace.WorkspacePortURLTemplate
 		}
 		rateLimits = ucfg.Workspace.WSManagerRateLimits
+		quotas = ucfg.Workspace.WorkspaceQuotas
 
 		if ucfg.Workspace.UseWsmanagerMk2 {
 			hostWorkingArea = wsdaemon.HostWorkingAreaMk2
@@ -217,6 +219,7 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 				ContentFinalization: util.Duration(1 * time.Hour),
 				Stopping:            util.Duration(1 * time.Hour),
 				Interrupted:         util.Duration(5 * time.Minute),
+				Warning:             util.Duration(5 * time.Minute),
 			},
 			//EventTraceLog:                "", // todo(sje): make conditional based on config
 			ReconnectionInterval:             util.Duration(30 * time.Second),
@@ -224,6 +227,7 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 			WorkspaceMaxConcurrentReconciles: 25,
 			TimeoutMaxConcurrentReconciles:   15,
 			ExperimentalMode:                 experimentalMode,
+			Quotas:                           quotas,
 		},
 		Content: struct {
 			Storage storageconfig.StorageConfig `json:"storage"`
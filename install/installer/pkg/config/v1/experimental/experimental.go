@@ -17,6 +17,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/grpc"
 	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/cpulimit"
+	wsmancfg "github.com/gitpod-io/gitpod/ws-manager/api/config"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -98,6 +99,9 @@ type WorkspaceConfig struct {
 
 	WSManagerRateLimits map[string]grpc.RateLimit `json:"wsManagerRateLimits,omitempty"`
 
+	// WorkspaceQuotas limit the number of workspaces owners and organisations can run concurrently. Only supported by ws-manager-mk2.
+	WorkspaceQuotas []wsmancfg.WorkspaceQuota `json:"workspaceQuotas,omitempty"`
+
 	RegistryFacade struct {
 		IPFSCache struct {
 			Enabled  bool   `json:"enabled"`
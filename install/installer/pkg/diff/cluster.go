@@ -0,0 +1,122 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package diff
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+// installationConfigMap is the ConfigMap in which the installer records the objects of an installation
+const installationConfigMap = "gitpod-app"
+
+type kubernetesCluster struct {
+	client    dynamic.Interface
+	mapper    meta.RESTMapper
+	namespace string
+}
+
+// NewCluster returns the cluster of the given config, in which Gitpod is installed into namespace.
+func NewCluster(config *rest.Config, namespace string) (Cluster, error) {
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kubernetesCluster{
+		client:    client,
+		mapper:    restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+		namespace: namespace,
+	}, nil
+}
+
+func (c *kubernetesCluster) Get(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// The kind is not known to the cluster, e.g. because its CustomResourceDefinition is not installed yet
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var resource dynamic.ResourceInterface = c.client.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace := obj.GetNamespace()
+		if namespace == "" {
+			namespace = c.namespace
+		}
+		resource = c.client.Resource(mapping.Resource).Namespace(namespace)
+	}
+
+	live, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return live, nil
+}
+
+func (c *kubernetesCluster) InstalledObjects(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	cfgMap, err := c.client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).
+		Namespace(c.namespace).
+		Get(ctx, installationConfigMap, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		// Gitpod is not installed yet
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := unstructured.NestedString(cfgMap.Object, "data", "app.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s config map: %w", installationConfigMap, err)
+	}
+
+	return ParseObjects(data)
+}
+
+// ParseObjects parses multi-document YAML into objects.
+func ParseObjects(documents ...string) ([]*unstructured.Unstructured, error) {
+	runtimeObjs, err := common.YamlToRuntimeObject(documents)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*unstructured.Unstructured, 0, len(runtimeObjs))
+	for _, o := range runtimeObjs {
+		if o.Kind == "" {
+			continue
+		}
+
+		var content map[string]interface{}
+		if err := yaml.Unmarshal([]byte(o.Content), &content); err != nil {
+			return nil, fmt.Errorf("cannot parse %s %s: %w", o.Kind, o.Metadata.Name, err)
+		}
+		result = append(result, &unstructured.Unstructured{Object: content})
+	}
+	return result, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package diff
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type rule struct {
+	// prefix matches a field path and all paths below it
+	prefix string
+	reason string
+}
+
+// destructiveFields are the fields whose change cannot be applied in place. Changing them either fails, because the
+// field is immutable, or requires the object to be recreated.
+var destructiveFields = map[schema.GroupKind][]rule{
+	{Group: "apps", Kind: "StatefulSet"}: {
+		{prefix: "spec.volumeClaimTemplates", reason: "volumeClaimTemplates are immutable, the StatefulSet must be recreated and existing volumes are not resized"},
+		{prefix: "spec.selector", reason: "selector is immutable"},
+		{prefix: "spec.serviceName", reason: "serviceName is immutable"},
+		{prefix: "spec.podManagementPolicy", reason: "podManagementPolicy is immutable"},
+		{prefix: "spec.template.spec.volumes", reason: "volume changes restart all pods and may detach data"},
+	},
+	{Group: "apps", Kind: "Deployment"}: {
+		{prefix: "spec.selector", reason: "selector is immutable"},
+	},
+	{Group: "apps", Kind: "DaemonSet"}: {
+		{prefix: "spec.selector", reason: "selector is immutable"},
+	},
+	{Group: "batch", Kind: "Job"}: {
+		{prefix: "spec.selector", reason: "selector is immutable"},
+		{prefix: "spec.template", reason: "template is immutable"},
+	},
+	{Kind: "Service"}: {
+		{prefix: "spec.clusterIP", reason: "clusterIP is immutable"},
+	},
+	{Kind: "PersistentVolumeClaim"}: {
+		{prefix: "spec.storageClassName", reason: "storageClassName is immutable"},
+		{prefix: "spec.accessModes", reason: "accessModes are immutable"},
+		{prefix: "spec.volumeName", reason: "volumeName is immutable"},
+		{prefix: "spec.selector", reason: "selector is immutable"},
+	},
+	{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"}: {
+		{prefix: "roleRef", reason: "roleRef is immutable"},
+	},
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}: {
+		{prefix: "roleRef", reason: "roleRef is immutable"},
+	},
+	{Group: "storage.k8s.io", Kind: "StorageClass"}: {
+		{prefix: "provisioner", reason: "provisioner is immutable"},
+		{prefix: "parameters", reason: "parameters are immutable"},
+		{prefix: "reclaimPolicy", reason: "reclaimPolicy is immutable"},
+		{prefix: "volumeBindingMode", reason: "volumeBindingMode is immutable"},
+	},
+}
+
+// destructiveDeletions are the kinds whose deletion loses data.
+var destructiveDeletions = map[schema.GroupKind]string{
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}: "deleting a CustomResourceDefinition deletes all its custom resources",
+	{Kind: "PersistentVolumeClaim"}: "deleting a PersistentVolumeClaim may delete its volume",
+	{Kind: "Namespace"}:             "deleting a Namespace deletes all objects in it",
+}
+
+func classify(desired, live *unstructured.Unstructured, changes []FieldChange) []FieldChange {
+	rules := destructiveFields[desired.GroupVersionKind().GroupKind()]
+
+	immutable, _, _ := unstructured.NestedBool(live.Object, "immutable")
+	switch desired.GetKind() {
+	case "ConfigMap", "Secret":
+		if immutable {
+			rules = append(rules,
+				rule{prefix: "data", reason: "data of an immutable " + desired.GetKind() + " cannot be changed"},
+				rule{prefix: "binaryData", reason: "data of an immutable " + desired.GetKind() + " cannot be changed"},
+				rule{prefix: "stringData", reason: "data of an immutable " + desired.GetKind() + " cannot be changed"},
+			)
+		}
+	}
+
+	for i, change := range changes {
+		for _, r := range rules {
+			if hasPathPrefix(change.Path, r.prefix) {
+				changes[i].Destructive = true
+				changes[i].Reason = r.reason
+				break
+			}
+		}
+	}
+	return changes
+}
+
+func hasPathPrefix(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	if len(path) == len(prefix) {
+		return true
+	}
+	next := path[len(prefix)]
+	return next == '.' || next == '['
+}
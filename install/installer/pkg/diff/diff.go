@@ -0,0 +1,424 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type ChangeType string
+
+const (
+	ChangeCreate    ChangeType = "create"
+	ChangeUpdate    ChangeType = "update"
+	ChangeDelete    ChangeType = "delete"
+	ChangeUnchanged ChangeType = "unchanged"
+)
+
+const (
+	lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+	redacted              = "(redacted)"
+)
+
+// FieldChange is the change of a single field. Old is unset for added fields, New is unset for removed fields.
+type FieldChange struct {
+	Path        string      `json:"path"`
+	Old         interface{} `json:"old,omitempty"`
+	New         interface{} `json:"new,omitempty"`
+	Destructive bool        `json:"destructive,omitempty"`
+	Reason      string      `json:"reason,omitempty"`
+}
+
+type ObjectDiff struct {
+	APIVersion  string        `json:"apiVersion"`
+	Kind        string        `json:"kind"`
+	Namespace   string        `json:"namespace,omitempty"`
+	Name        string        `json:"name"`
+	Change      ChangeType    `json:"change"`
+	Destructive bool          `json:"destructive"`
+	Reason      string        `json:"reason,omitempty"`
+	Fields      []FieldChange `json:"fields,omitempty"`
+}
+
+type Summary struct {
+	Create      int `json:"create"`
+	Update      int `json:"update"`
+	Delete      int `json:"delete"`
+	Unchanged   int `json:"unchanged"`
+	Destructive int `json:"destructive"`
+}
+
+// Result is the diff of a rendered installation against a cluster. Unchanged objects are only counted.
+type Result struct {
+	Objects []ObjectDiff `json:"objects"`
+	Summary Summary      `json:"summary"`
+}
+
+// Cluster provides the live state of the objects of an installation.
+type Cluster interface {
+	// Get returns the live object corresponding to obj, or nil if it does not exist.
+	Get(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	// InstalledObjects returns the objects of the current installation, as recorded by the installer on the cluster.
+	InstalledObjects(ctx context.Context) ([]*unstructured.Unstructured, error)
+}
+
+// Run diffs the rendered objects against the cluster. Objects of the current installation which are no longer rendered are reported as deleted.
+func Run(ctx context.Context, cluster Cluster, rendered []*unstructured.Unstructured) (*Result, error) {
+	result := &Result{Objects: []ObjectDiff{}}
+	renderedKeys := make(map[string]struct{}, len(rendered))
+
+	for _, desired := range rendered {
+		renderedKeys[key(desired)] = struct{}{}
+
+		live, err := cluster.Get(ctx, desired)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get %s: %w", describe(desired), err)
+		}
+
+		result.add(Compare(desired, live))
+	}
+
+	installed, err := cluster.InstalledObjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get installed objects: %w", err)
+	}
+	for _, obj := range installed {
+		if _, ok := renderedKeys[key(obj)]; ok {
+			continue
+		}
+
+		live, err := cluster.Get(ctx, obj)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get %s: %w", describe(obj), err)
+		}
+		if live == nil {
+			continue
+		}
+
+		result.add(Deleted(live))
+	}
+
+	return result, nil
+}
+
+func (r *Result) add(d ObjectDiff) {
+	switch d.Change {
+	case ChangeCreate:
+		r.Summary.Create++
+	case ChangeUpdate:
+		r.Summary.Update++
+	case ChangeDelete:
+		r.Summary.Delete++
+	case ChangeUnchanged:
+		r.Summary.Unchanged++
+		return
+	}
+	if d.Destructive {
+		r.Summary.Destructive++
+	}
+	r.Objects = append(r.Objects, d)
+}
+
+// Compare diffs the desired state of an object against its live state, which is nil if the object does not exist.
+//
+// Only fields of the desired object are compared, because the live object contains fields defaulted by the API server.
+// Fields which were applied previously, according to the last-applied-configuration annotation, and are no longer
+// desired are reported as removed.
+func Compare(desired, live *unstructured.Unstructured) ObjectDiff {
+	result := newObjectDiff(desired)
+	if live == nil {
+		result.Change = ChangeCreate
+		return result
+	}
+
+	desiredContent := normalize(desired.Object)
+	liveContent := normalize(live.Object)
+	lastApplied := lastAppliedConfiguration(live)
+
+	var changes []FieldChange
+	for _, field := range []string{"labels", "annotations"} {
+		changes = append(changes, compareValues("metadata."+field,
+			nested(desiredContent, "metadata", field),
+			nested(liveContent, "metadata", field),
+			nested(lastApplied, "metadata", field),
+		)...)
+	}
+
+	var fields []string
+	for field := range desiredContent {
+		fields = append(fields, field)
+	}
+	for field := range lastApplied {
+		if _, ok := desiredContent[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		switch field {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+		changes = append(changes, compareValues(field, desiredContent[field], liveContent[field], lastApplied[field])...)
+	}
+
+	if len(changes) == 0 {
+		result.Change = ChangeUnchanged
+		return result
+	}
+
+	result.Change = ChangeUpdate
+	if desired.GetKind() == "Secret" {
+		changes = redactSecretData(changes)
+	}
+	result.Fields = classify(desired, live, changes)
+	for _, change := range result.Fields {
+		if change.Destructive {
+			result.Destructive = true
+		}
+	}
+
+	return result
+}
+
+// Deleted returns the diff of an object which is no longer part of the installation.
+func Deleted(live *unstructured.Unstructured) ObjectDiff {
+	result := newObjectDiff(live)
+	result.Change = ChangeDelete
+	if reason, ok := destructiveDeletions[live.GroupVersionKind().GroupKind()]; ok {
+		result.Destructive = true
+		result.Reason = reason
+	}
+	return result
+}
+
+func newObjectDiff(obj *unstructured.Unstructured) ObjectDiff {
+	return ObjectDiff{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
+
+func compareValues(path string, desired, live, lastApplied interface{}) []FieldChange {
+	if desired == nil {
+		// Only fields we applied before are removed, the others are owned by the API server or other controllers.
+		if lastApplied != nil && live != nil {
+			return []FieldChange{{Path: path, Old: live}}
+		}
+		return nil
+	}
+
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok && live != nil {
+			return []FieldChange{{Path: path, Old: live, New: desired}}
+		}
+		la, _ := lastApplied.(map[string]interface{})
+
+		keys := make([]string, 0, len(d))
+		for k := range d {
+			keys = append(keys, k)
+		}
+		for k := range la {
+			if _, ok := d[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		var changes []FieldChange
+		for _, k := range keys {
+			changes = append(changes, compareValues(path+"."+k, d[k], l[k], la[k])...)
+		}
+		return changes
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok {
+			return []FieldChange{{Path: path, Old: live, New: desired}}
+		}
+		la, _ := lastApplied.([]interface{})
+		if named(d) && named(l) {
+			return compareNamedLists(path, d, l, la)
+		}
+
+		var changes []FieldChange
+		for i := 0; i < len(d) || i < len(l); i++ {
+			elementPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(l):
+				changes = append(changes, FieldChange{Path: elementPath, New: d[i]})
+			case i >= len(d):
+				// Lists are replaced as a whole when applied.
+				changes = append(changes, FieldChange{Path: elementPath, Old: l[i]})
+			default:
+				var lastAppliedElement interface{}
+				if i < len(la) {
+					lastAppliedElement = la[i]
+				}
+				changes = append(changes, compareValues(elementPath, d[i], l[i], lastAppliedElement)...)
+			}
+		}
+		return changes
+	default:
+		if live == nil {
+			return []FieldChange{{Path: path, New: desired}}
+		}
+		if equalScalars(desired, live) {
+			return nil
+		}
+		return []FieldChange{{Path: path, Old: live, New: desired}}
+	}
+}
+
+// compareNamedLists matches the elements of lists like containers, env vars or volumes by name rather than position.
+func compareNamedLists(path string, desired, live, lastApplied []interface{}) []FieldChange {
+	byName := func(list []interface{}) map[string]interface{} {
+		result := make(map[string]interface{}, len(list))
+		for _, e := range list {
+			if m, ok := e.(map[string]interface{}); ok {
+				result[fmt.Sprint(m["name"])] = e
+			}
+		}
+		return result
+	}
+	liveByName, lastAppliedByName := byName(live), byName(lastApplied)
+
+	var changes []FieldChange
+	seen := make(map[string]struct{}, len(desired))
+	for _, e := range desired {
+		name := fmt.Sprint(e.(map[string]interface{})["name"])
+		seen[name] = struct{}{}
+
+		elementPath := fmt.Sprintf("%s[name=%s]", path, name)
+		l, ok := liveByName[name]
+		if !ok {
+			changes = append(changes, FieldChange{Path: elementPath, New: e})
+			continue
+		}
+		changes = append(changes, compareValues(elementPath, e, l, lastAppliedByName[name])...)
+	}
+	for _, e := range live {
+		name := fmt.Sprint(e.(map[string]interface{})["name"])
+		if _, ok := seen[name]; !ok {
+			changes = append(changes, FieldChange{Path: fmt.Sprintf("%s[name=%s]", path, name), Old: e})
+		}
+	}
+	return changes
+}
+
+func named(list []interface{}) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, e := range list {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := m["name"].(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// equalScalars compares scalars, treating quantities the API server normalizes, e.g. 1024Mi and 1Gi, as equal.
+func equalScalars(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+
+	as, aok := a.(string)
+	bs, bok := b.(string)
+	if !aok || !bok {
+		return false
+	}
+	aq, err := resource.ParseQuantity(as)
+	if err != nil {
+		return false
+	}
+	bq, err := resource.ParseQuantity(bs)
+	if err != nil {
+		return false
+	}
+	return aq.Cmp(bq) == 0
+}
+
+func redactSecretData(changes []FieldChange) []FieldChange {
+	for i, change := range changes {
+		if strings.HasPrefix(change.Path, "data.") || strings.HasPrefix(change.Path, "stringData.") {
+			if change.Old != nil {
+				changes[i].Old = redacted
+			}
+			if change.New != nil {
+				changes[i].New = redacted
+			}
+		}
+	}
+	return changes
+}
+
+// normalize converts the content of an object to plain JSON types, such that rendered and live objects compare equal.
+func normalize(obj map[string]interface{}) map[string]interface{} {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return obj
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return obj
+	}
+	return result
+}
+
+func lastAppliedConfiguration(live *unstructured.Unstructured) map[string]interface{} {
+	raw, ok := live.GetAnnotations()[lastAppliedAnnotation]
+	if !ok {
+		return nil
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		return nil
+	}
+	return result
+}
+
+func nested(obj map[string]interface{}, fields ...string) interface{} {
+	var current interface{} = obj
+	for _, field := range fields {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[field]
+	}
+	if m, ok := current.(map[string]interface{}); ok && fields[len(fields)-1] == "annotations" {
+		// The annotation is maintained by kubectl, it is not part of the desired state.
+		delete(m, lastAppliedAnnotation)
+	}
+	return current
+}
+
+func key(obj *unstructured.Unstructured) string {
+	return strings.Join([]string{obj.GroupVersionKind().Group, obj.GetKind(), obj.GetNamespace(), obj.GetName()}, "/")
+}
+
+func describe(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", obj.GetKind(), obj.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
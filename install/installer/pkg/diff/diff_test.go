@@ -0,0 +1,200 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package diff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Desired     string
+		Live        string
+		Change      ChangeType
+		Fields      []FieldChange
+		Destructive bool
+	}{
+		{
+			Name:    "create",
+			Desired: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  a: b\n",
+			Change:  ChangeCreate,
+		},
+		{
+			Name:    "server defaulted fields are ignored",
+			Desired: "apiVersion: v1\nkind: Service\nmetadata:\n  name: svc\nspec:\n  ports:\n  - name: http\n    port: 80\n",
+			Live:    "apiVersion: v1\nkind: Service\nmetadata:\n  name: svc\n  uid: abc\n  resourceVersion: \"1\"\nspec:\n  clusterIP: 10.0.0.1\n  ports:\n  - name: http\n    port: 80\n    protocol: TCP\nstatus: {}\n",
+			Change:  ChangeUnchanged,
+		},
+		{
+			Name:    "quantities are compared semantically",
+			Desired: "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: d\nspec:\n  template:\n    spec:\n      containers:\n      - name: c\n        resources:\n          requests:\n            memory: 1024Mi\n",
+			Live:    "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: d\nspec:\n  template:\n    spec:\n      containers:\n      - name: c\n        resources:\n          requests:\n            memory: 1Gi\n",
+			Change:  ChangeUnchanged,
+		},
+		{
+			Name:    "containers are matched by name",
+			Desired: "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: d\nspec:\n  template:\n    spec:\n      containers:\n      - name: b\n        image: b:2\n      - name: a\n        image: a:1\n",
+			Live:    "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: d\nspec:\n  template:\n    spec:\n      containers:\n      - name: a\n        image: a:1\n      - name: b\n        image: b:1\n",
+			Change:  ChangeUpdate,
+			Fields: []FieldChange{
+				{Path: "spec.template.spec.containers[name=b].image", Old: "b:1", New: "b:2"},
+			},
+		},
+		{
+			Name:    "removed fields are detected from the last applied configuration",
+			Desired: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n  labels:\n    app: gitpod\ndata:\n  a: b\n",
+			Live:    "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n  labels:\n    app: gitpod\n    component: old\n  annotations:\n    kubectl.kubernetes.io/last-applied-configuration: '{\"metadata\":{\"labels\":{\"app\":\"gitpod\",\"component\":\"old\"}},\"data\":{\"a\":\"b\",\"c\":\"d\"}}'\ndata:\n  a: b\n  c: d\n  e: f\n",
+			Change:  ChangeUpdate,
+			Fields: []FieldChange{
+				{Path: "metadata.labels.component", Old: "old"},
+				{Path: "data.c", Old: "d"},
+			},
+		},
+		{
+			Name:    "statefulset volume claim templates",
+			Desired: "apiVersion: apps/v1\nkind: StatefulSet\nmetadata:\n  name: db\nspec:\n  replicas: 1\n  volumeClaimTemplates:\n  - metadata:\n      name: data\n    spec:\n      resources:\n        requests:\n          storage: 20Gi\n",
+			Live:    "apiVersion: apps/v1\nkind: StatefulSet\nmetadata:\n  name: db\nspec:\n  replicas: 1\n  volumeClaimTemplates:\n  - metadata:\n      name: data\n    spec:\n      resources:\n        requests:\n          storage: 8Gi\n",
+			Change:  ChangeUpdate,
+			Fields: []FieldChange{
+				{
+					Path:        "spec.volumeClaimTemplates[0].spec.resources.requests.storage",
+					Old:         "8Gi",
+					New:         "20Gi",
+					Destructive: true,
+					Reason:      "volumeClaimTemplates are immutable, the StatefulSet must be recreated and existing volumes are not resized",
+				},
+			},
+			Destructive: true,
+		},
+		{
+			Name:    "deployment selector",
+			Desired: "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: d\nspec:\n  replicas: 2\n  selector:\n    matchLabels:\n      component: new\n",
+			Live:    "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: d\nspec:\n  replicas: 1\n  selector:\n    matchLabels:\n      component: old\n",
+			Change:  ChangeUpdate,
+			Fields: []FieldChange{
+				{Path: "spec.replicas", Old: float64(1), New: float64(2)},
+				{Path: "spec.selector.matchLabels.component", Old: "old", New: "new", Destructive: true, Reason: "selector is immutable"},
+			},
+			Destructive: true,
+		},
+		{
+			Name:    "immutable secret data is redacted",
+			Desired: "apiVersion: v1\nkind: Secret\nmetadata:\n  name: s\ndata:\n  password: bmV3\n",
+			Live:    "apiVersion: v1\nkind: Secret\nmetadata:\n  name: s\nimmutable: true\ndata:\n  password: b2xk\n",
+			Change:  ChangeUpdate,
+			Fields: []FieldChange{
+				{Path: "data.password", Old: redacted, New: redacted, Destructive: true, Reason: "data of an immutable Secret cannot be changed"},
+			},
+			Destructive: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			desired := parse(t, test.Desired)
+			var live *unstructured.Unstructured
+			if test.Live != "" {
+				live = parse(t, test.Live)
+			}
+
+			result := Compare(desired, live)
+			require.Equal(t, test.Change, result.Change)
+			require.Equal(t, test.Fields, result.Fields)
+			require.Equal(t, test.Destructive, result.Destructive)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	crd := parse(t, "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: things.gitpod.io\n")
+	cm := parse(t, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n  namespace: gitpod\ndata:\n  a: b\n")
+	removed := parse(t, "apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: gone\n  namespace: gitpod\n")
+	svc := parse(t, "apiVersion: v1\nkind: Service\nmetadata:\n  name: svc\n  namespace: gitpod\n")
+
+	cluster := &fakeCluster{
+		objects:   []*unstructured.Unstructured{crd, cm, svc},
+		installed: []*unstructured.Unstructured{crd, cm, removed},
+	}
+
+	result, err := Run(context.Background(), cluster, []*unstructured.Unstructured{
+		cm,
+		parse(t, "apiVersion: v1\nkind: Service\nmetadata:\n  name: svc\n  namespace: gitpod\nspec:\n  clusterIP: 10.0.0.2\n"),
+		parse(t, "apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: new\n  namespace: gitpod\n"),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, Summary{Create: 1, Update: 1, Delete: 1, Unchanged: 1, Destructive: 2}, result.Summary)
+	require.Len(t, result.Objects, 3)
+	require.Equal(t, ChangeUpdate, result.Objects[0].Change)
+	require.Equal(t, ChangeCreate, result.Objects[1].Change)
+	// The service account is no longer installed, but does not exist in the cluster anymore either.
+	require.Equal(t, ObjectDiff{
+		APIVersion:  "apiextensions.k8s.io/v1",
+		Kind:        "CustomResourceDefinition",
+		Name:        "things.gitpod.io",
+		Change:      ChangeDelete,
+		Destructive: true,
+		Reason:      "deleting a CustomResourceDefinition deletes all its custom resources",
+	}, result.Objects[2])
+
+	// The JSON output is read by review gates
+	b, err := json.Marshal(result)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"summary":{"create":1,"update":1,"delete":1,"unchanged":1,"destructive":2}`)
+
+	var text bytes.Buffer
+	require.NoError(t, result.WriteText(&text))
+	require.Equal(t, `~ Service gitpod/svc [DESTRUCTIVE]
+    + spec.clusterIP: "10.0.0.2"
+      ! clusterIP is immutable
++ ServiceAccount gitpod/new
+- CustomResourceDefinition things.gitpod.io [DESTRUCTIVE]
+    ! deleting a CustomResourceDefinition deletes all its custom resources
+
+1 to create, 1 to update, 1 to delete, 1 unchanged, 2 destructive
+`, text.String())
+}
+
+func TestParseObjects(t *testing.T) {
+	objs, err := ParseObjects("---\n# v1/ConfigMap a\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n", "---\n\n---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: b\n")
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+	require.Equal(t, "ConfigMap", objs[0].GetKind())
+	require.Equal(t, "b", objs[1].GetName())
+}
+
+type fakeCluster struct {
+	objects   []*unstructured.Unstructured
+	installed []*unstructured.Unstructured
+}
+
+func (c *fakeCluster) Get(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	for _, o := range c.objects {
+		if key(o) == key(obj) {
+			return o, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *fakeCluster) InstalledObjects(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	return c.installed, nil
+}
+
+func parse(t *testing.T, manifest string) *unstructured.Unstructured {
+	t.Helper()
+
+	objs, err := ParseObjects(manifest)
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	return objs[0]
+}
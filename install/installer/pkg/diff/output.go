@@ -0,0 +1,77 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+var changeSymbols = map[ChangeType]string{
+	ChangeCreate: "+",
+	ChangeUpdate: "~",
+	ChangeDelete: "-",
+}
+
+// WriteText writes a human readable form of the result.
+func (r *Result) WriteText(w io.Writer) error {
+	p := &printer{w: w}
+	for _, obj := range r.Objects {
+		name := obj.Name
+		if obj.Namespace != "" {
+			name = obj.Namespace + "/" + name
+		}
+		p.printf("%s %s %s", changeSymbols[obj.Change], obj.Kind, name)
+		if obj.Destructive {
+			p.printf(" [DESTRUCTIVE]")
+		}
+		p.printf("\n")
+		if obj.Reason != "" {
+			p.printf("    ! %s\n", obj.Reason)
+		}
+
+		for _, f := range obj.Fields {
+			switch {
+			case f.Old == nil:
+				p.printf("    + %s: %s\n", f.Path, format(f.New))
+			case f.New == nil:
+				p.printf("    - %s: %s\n", f.Path, format(f.Old))
+			default:
+				p.printf("    ~ %s: %s -> %s\n", f.Path, format(f.Old), format(f.New))
+			}
+			if f.Destructive {
+				p.printf("      ! %s\n", f.Reason)
+			}
+		}
+	}
+
+	p.printf("\n%d to create, %d to update, %d to delete, %d unchanged, %d destructive\n",
+		r.Summary.Create, r.Summary.Update, r.Summary.Delete, r.Summary.Unchanged, r.Summary.Destructive)
+	return p.err
+}
+
+type printer struct {
+	w   io.Writer
+	err error
+}
+
+func (p *printer) printf(format string, args ...interface{}) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.w, format, args...)
+}
+
+func format(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
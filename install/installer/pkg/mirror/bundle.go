@@ -0,0 +1,183 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/content/local"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/images/archive"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/docker/cli/cli/config/configfile"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// AnnotationTarget is the reference an image of a bundle is pushed to on import
+	AnnotationTarget = "io.gitpod.installer.mirror.target"
+	// AnnotationRepository is the repository the target of an image of a bundle is in
+	AnnotationRepository = "io.gitpod.installer.mirror.repository"
+)
+
+// Image is an image to mirror from its original reference to the target reference
+type Image struct {
+	Original string `json:"original"`
+	Target   string `json:"target"`
+}
+
+// NewResolver returns a resolver which authenticates using the docker config. Registries on localhost are accessed
+// via plain HTTP, as are all registries if insecure is set.
+func NewResolver(cfg *configfile.ConfigFile, insecure bool) remotes.Resolver {
+	plainHTTP := docker.MatchLocalhost
+	if insecure {
+		plainHTTP = docker.MatchAllHosts
+	}
+
+	return docker.NewResolver(docker.ResolverOptions{
+		Hosts: docker.ConfigureDefaultRegistries(
+			docker.WithAuthorizer(docker.NewDockerAuthorizer(docker.WithAuthCreds(func(host string) (user, pass string, err error) {
+				if cfg == nil {
+					return
+				}
+				auth, err := cfg.GetAuthConfig(host)
+				if err != nil {
+					return
+				}
+				user = auth.Username
+				pass = auth.Password
+				return
+			}))),
+			docker.WithPlainHTTP(plainHTTP),
+		),
+	})
+}
+
+// Export pulls the images, including all their platforms, and writes them as OCI image layout tarball to w.
+// Blobs shared between images are written once.
+func Export(ctx context.Context, resolver remotes.Resolver, imgs []Image, repository string, w io.Writer) error {
+	store, done, err := newTempStore()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	opts := []archive.ExportOpt{archive.WithSkipDockerManifest()}
+	for _, img := range imgs {
+		name, desc, err := resolver.Resolve(ctx, img.Original)
+		if err != nil {
+			return fmt.Errorf("cannot resolve %s: %w", img.Original, err)
+		}
+		fetcher, err := resolver.Fetcher(ctx, name)
+		if err != nil {
+			return fmt.Errorf("cannot fetch %s: %w", img.Original, err)
+		}
+
+		// Content which is already in the store, e.g. layers shared with previous images, is not fetched again
+		err = images.Dispatch(ctx, images.Handlers(remotes.FetchHandler(store, fetcher), images.ChildrenHandler(store)), nil, desc)
+		if err != nil {
+			return fmt.Errorf("cannot fetch %s: %w", img.Original, err)
+		}
+
+		desc.Annotations = map[string]string{
+			AnnotationTarget:     img.Target,
+			AnnotationRepository: repository,
+		}
+		opts = append(opts, archive.WithManifest(desc, img.Original))
+	}
+
+	err = archive.Export(ctx, store, w, opts...)
+	if err != nil {
+		return fmt.Errorf("cannot write bundle: %w", err)
+	}
+	return nil
+}
+
+// Import reads a bundle written by Export and pushes its images to their targets. If repository is set, the images
+// are pushed to that repository instead of the one the bundle was exported for.
+func Import(ctx context.Context, resolver remotes.Resolver, r io.Reader, repository string) ([]Image, error) {
+	store, done, err := newTempStore()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	indexDesc, err := archive.ImportIndex(ctx, store, r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read bundle: %w", err)
+	}
+	p, err := content.ReadBlob(ctx, store, indexDesc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read bundle index: %w", err)
+	}
+	var index ociv1.Index
+	err = json.Unmarshal(p, &index)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read bundle index: %w", err)
+	}
+
+	result := make([]Image, 0, len(index.Manifests))
+	for _, desc := range index.Manifests {
+		img := Image{
+			Original: desc.Annotations[images.AnnotationImageName],
+			Target:   desc.Annotations[AnnotationTarget],
+		}
+		if img.Target == "" {
+			return nil, fmt.Errorf("image %s has no target, the bundle was not exported by the installer", img.Original)
+		}
+		if repository != "" {
+			img.Target = retarget(img.Target, desc.Annotations[AnnotationRepository], repository)
+		}
+
+		desc.Annotations = nil
+		pusher, err := resolver.Pusher(ctx, img.Target)
+		if err != nil {
+			return nil, fmt.Errorf("cannot push %s: %w", img.Target, err)
+		}
+		err = remotes.PushContent(ctx, pusher, desc, store, nil, platforms.All, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot push %s: %w", img.Target, err)
+		}
+
+		result = append(result, img)
+	}
+
+	return result, nil
+}
+
+// retarget moves the target from its repository to another one, e.g. registry.example.com/gitpod/server:tag
+// to mirror.example.com/server:tag for the repositories registry.example.com/gitpod and mirror.example.com.
+func retarget(target, from, to string) string {
+	from = strings.TrimRight(from, "/")
+	to = strings.TrimRight(to, "/")
+	if from != "" && strings.HasPrefix(target, from+"/") {
+		return to + strings.TrimPrefix(target, from)
+	}
+	// Bundles without a recorded repository only have the registry host in common
+	return to + "/" + strings.Join(strings.Split(target, "/")[1:], "/")
+}
+
+func newTempStore() (content.Store, func(), error) {
+	dir, err := os.MkdirTemp("", "gitpod-mirror-")
+	if err != nil {
+		return nil, nil, err
+	}
+	done := func() { os.RemoveAll(dir) }
+
+	store, err := local.NewStore(dir)
+	if err != nil {
+		done()
+		return nil, nil, err
+	}
+	return store, done, nil
+}
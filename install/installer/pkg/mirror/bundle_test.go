@@ -0,0 +1,255 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package mirror
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImport(t *testing.T) {
+	src := newTestRegistry(t)
+	dst := newTestRegistry(t)
+
+	sharedLayer := src.addBlob([]byte("shared layer"))
+	server := src.addImage("gitpod/server", "v1", sharedLayer, src.addBlob([]byte("server layer")))
+	proxy := src.addImage("gitpod/proxy", "v1", sharedLayer)
+
+	imgs := []Image{
+		{Original: src.host + "/gitpod/server:v1", Target: "registry.example.com/gitpod/server:v1"},
+		{Original: src.host + "/gitpod/proxy:v1", Target: "registry.example.com/gitpod/proxy:v1"},
+	}
+
+	var bundle bytes.Buffer
+	err := Export(context.Background(), NewResolver(nil, false), imgs, "registry.example.com/gitpod", &bundle)
+	require.NoError(t, err)
+
+	entries := tarEntries(t, bundle.Bytes())
+	require.Equal(t, 1, entries["oci-layout"])
+	require.Equal(t, 1, entries["index.json"])
+	require.Equal(t, 1, entries["blobs/sha256/"+sharedLayer.Encoded()], "shared layers are written once")
+	require.Equal(t, 1, entries["blobs/sha256/"+server.Encoded()])
+	require.Equal(t, 1, entries["blobs/sha256/"+proxy.Encoded()])
+
+	imported, err := Import(context.Background(), NewResolver(nil, false), bytes.NewReader(bundle.Bytes()), dst.host+"/mirror")
+	require.NoError(t, err)
+	require.Equal(t, []Image{
+		{Original: src.host + "/gitpod/server:v1", Target: dst.host + "/mirror/server:v1"},
+		{Original: src.host + "/gitpod/proxy:v1", Target: dst.host + "/mirror/proxy:v1"},
+	}, imported)
+
+	require.Equal(t, server, dst.tag("mirror/server", "v1"))
+	require.Equal(t, proxy, dst.tag("mirror/proxy", "v1"))
+	require.True(t, dst.hasBlob(sharedLayer))
+}
+
+func TestRetarget(t *testing.T) {
+	tests := []struct {
+		Target   string
+		From     string
+		To       string
+		Expected string
+	}{
+		{Target: "registry.example.com/gitpod/server:v1", From: "registry.example.com/gitpod", To: "mirror.example.com", Expected: "mirror.example.com/server:v1"},
+		{Target: "registry.example.com/gitpod/library/redis:7", From: "registry.example.com/gitpod/", To: "mirror.example.com/a/", Expected: "mirror.example.com/a/library/redis:7"},
+		{Target: "registry.example.com/server:v1", To: "mirror.example.com", Expected: "mirror.example.com/server:v1"},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.Expected, retarget(test.Target, test.From, test.To), test.Target)
+	}
+}
+
+func tarEntries(t *testing.T, b []byte) map[string]int {
+	t.Helper()
+
+	result := make(map[string]int)
+	tr := tar.NewReader(bytes.NewReader(b))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return result
+		}
+		require.NoError(t, err)
+		result[hdr.Name]++
+	}
+}
+
+var (
+	uploadPath   = regexp.MustCompile(`^/v2/(.+)/blobs/uploads/(.*)$`)
+	manifestPath = regexp.MustCompile(`^/v2/(.+)/manifests/(.+)$`)
+	blobPath     = regexp.MustCompile(`^/v2/(.+)/blobs/(.+)$`)
+)
+
+type manifest struct {
+	mediaType string
+	content   []byte
+}
+
+// testRegistry is an in-memory stand-in for a registry implementing the parts of the distribution API used
+// when pulling and pushing images.
+type testRegistry struct {
+	t    *testing.T
+	host string
+
+	mu        sync.Mutex
+	blobs     map[digest.Digest][]byte
+	manifests map[digest.Digest]manifest
+	tags      map[string]digest.Digest
+}
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	reg := &testRegistry{
+		t:         t,
+		blobs:     make(map[digest.Digest][]byte),
+		manifests: make(map[digest.Digest]manifest),
+		tags:      make(map[string]digest.Digest),
+	}
+
+	srv := httptest.NewServer(reg)
+	t.Cleanup(srv.Close)
+	reg.host = strings.TrimPrefix(srv.URL, "http://")
+
+	return reg
+}
+
+func (r *testRegistry) addBlob(content []byte) digest.Digest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	dgst := digest.FromBytes(content)
+	r.blobs[dgst] = content
+	return dgst
+}
+
+func (r *testRegistry) addImage(name, tag string, layers ...digest.Digest) digest.Digest {
+	config := r.addBlob([]byte(fmt.Sprintf(`{"architecture":"amd64","os":"linux","config":{"Labels":{"name":%q}}}`, name)))
+
+	mf := ociv1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ociv1.MediaTypeImageManifest,
+		Config:    ociv1.Descriptor{MediaType: ociv1.MediaTypeImageConfig, Digest: config, Size: int64(len(r.blobs[config]))},
+	}
+	for _, l := range layers {
+		mf.Layers = append(mf.Layers, ociv1.Descriptor{MediaType: ociv1.MediaTypeImageLayerGzip, Digest: l, Size: int64(len(r.blobs[l]))})
+	}
+	content, err := json.Marshal(mf)
+	require.NoError(r.t, err)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	dgst := digest.FromBytes(content)
+	r.manifests[dgst] = manifest{mediaType: ociv1.MediaTypeImageManifest, content: content}
+	r.tags[name+":"+tag] = dgst
+	return dgst
+}
+
+func (r *testRegistry) tag(name, tag string) digest.Digest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.tags[name+":"+tag]
+}
+
+func (r *testRegistry) hasBlob(dgst digest.Digest) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.blobs[dgst]
+	return ok
+}
+
+func (r *testRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path := req.URL.Path
+	switch {
+	case path == "/v2/":
+		w.WriteHeader(http.StatusOK)
+	case uploadPath.MatchString(path):
+		m := uploadPath.FindStringSubmatch(path)
+		switch req.Method {
+		case http.MethodPost:
+			w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/upload-%d", m[1], len(r.blobs)))
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodPut:
+			content, err := io.ReadAll(req.Body)
+			require.NoError(r.t, err)
+			dgst := digest.FromBytes(content)
+			if dgst.String() != req.URL.Query().Get("digest") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			r.blobs[dgst] = content
+			w.Header().Set("Docker-Content-Digest", dgst.String())
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	case manifestPath.MatchString(path):
+		m := manifestPath.FindStringSubmatch(path)
+		name, ref := m[1], m[2]
+		switch req.Method {
+		case http.MethodHead, http.MethodGet:
+			dgst, err := digest.Parse(ref)
+			if err != nil {
+				dgst = r.tags[name+":"+ref]
+			}
+			mf, ok := r.manifests[dgst]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", mf.mediaType)
+			w.Header().Set("Docker-Content-Digest", dgst.String())
+			w.Header().Set("Content-Length", fmt.Sprint(len(mf.content)))
+			if req.Method == http.MethodGet {
+				_, _ = w.Write(mf.content)
+			}
+		case http.MethodPut:
+			content, err := io.ReadAll(req.Body)
+			require.NoError(r.t, err)
+			dgst := digest.FromBytes(content)
+			r.manifests[dgst] = manifest{mediaType: req.Header.Get("Content-Type"), content: content}
+			if _, err := digest.Parse(ref); err != nil {
+				r.tags[name+":"+ref] = dgst
+			}
+			w.Header().Set("Docker-Content-Digest", dgst.String())
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	case blobPath.MatchString(path):
+		m := blobPath.FindStringSubmatch(path)
+		content, ok := r.blobs[digest.Digest(m[2])]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+		w.Header().Set("Docker-Content-Digest", m[2])
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(content)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}